@@ -4,12 +4,21 @@
 package medley
 
 import (
+	"errors"
+	"fmt"
 	"hash"
-	"unsafe"
+	"hash/fnv"
+	"slices"
+	"strings"
 
 	"github.com/spaolacci/murmur3"
 )
 
+var (
+	// ErrUnknownAlgorithm indicates that no hash algorithm was registered under a given name.
+	ErrUnknownAlgorithm = errors.New("unknown hash algorithm")
+)
+
 // Algorithm represents a hash algorithm which medley can use to implement
 // service location.
 type Algorithm struct {
@@ -47,7 +56,7 @@ func (alg Algorithm) Sum64Bytes(v []byte) uint64 {
 // New64 is used to create a Hash64 and write the string's bytes.
 func (alg Algorithm) Sum64String(v string) uint64 {
 	return alg.Sum64Bytes(
-		unsafe.Slice(unsafe.StringData(v), len(v)),
+		stringToBytes(v),
 	)
 }
 
@@ -60,3 +69,51 @@ func DefaultAlgorithm() Algorithm {
 		Sum64: murmur3.Sum64,
 	}
 }
+
+const (
+	// AlgorithmMurmur3 is the name of the murmur3 algorithm returned by DefaultAlgorithm.
+	AlgorithmMurmur3 = "murmur3"
+
+	// AlgorithmFNV is the name of the 64-bit FNV-1 algorithm.
+	AlgorithmFNV = "fnv"
+
+	// AlgorithmFNV1a is the name of the 64-bit FNV-1a algorithm.
+	AlgorithmFNV1a = "fnv1a"
+)
+
+// algorithms holds the builtin hash algorithms, keyed by name.
+var algorithms = map[string]Algorithm{
+	AlgorithmMurmur3: DefaultAlgorithm(),
+	AlgorithmFNV:     {New64: fnv.New64},
+	AlgorithmFNV1a:   {New64: fnv.New64a},
+}
+
+// AlgorithmNames returns the sorted names of the builtin algorithms that FindAlgorithm
+// recognizes. This is useful for validation and help text.
+func AlgorithmNames() []string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// FindAlgorithm returns the builtin Algorithm with the given name. Names are case-insensitive,
+// and surrounding whitespace is ignored. The AlgorithmXXX constants in this package give the
+// recognized names. If no such Algorithm exists, this function returns ErrUnknownAlgorithm.
+//
+// The special name AlgorithmAuto resolves to the fastest builtin algorithm on this host.
+func FindAlgorithm(name string) (Algorithm, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == AlgorithmAuto {
+		normalized = autoAlgorithm()
+	}
+
+	if alg, ok := algorithms[normalized]; ok {
+		return alg, nil
+	}
+
+	return Algorithm{}, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, name)
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"hash"
+
+	"github.com/spaolacci/murmur3"
+)
+
+// Hash128 is a hash.Hash that computes 128-bit hashes. The standard library has
+// no such interface.
+type Hash128 interface {
+	hash.Hash
+
+	// Sum128 returns the 128-bit hash of the bytes written so far, as a high
+	// word and a low word.
+	Sum128() (uint64, uint64)
+}
+
+// Algorithm128 represents a hash algorithm that produces 128-bit hashes. This is
+// useful for extremely large hash rings, where 64-bit hashes begin to collide.
+type Algorithm128 struct {
+	// New128 is the constructor for a Hash128 appropriate for this algorithm.
+	// This field is required. If this field is unset, methods on this
+	// Algorithm128 may panic.
+	New128 func() Hash128
+
+	// Sum128 is this algorithm's simple function to compute a hash over a
+	// byte slice, returning the high word and the low word.
+	//
+	// This field is not required. If not supplied, New128 will be used to
+	// create a hash of the given bytes.
+	Sum128 func([]byte) (uint64, uint64)
+}
+
+// Sum128Bytes uses Sum128 to compute the hash of the given byte slice. If
+// the Sum128 field isn't set, New128 is used to create a Hash128 and write
+// the given bytes.
+func (alg Algorithm128) Sum128Bytes(v []byte) (uint64, uint64) {
+	if alg.Sum128 != nil {
+		return alg.Sum128(v)
+	}
+
+	h := alg.New128()
+	h.Write(v)
+	return h.Sum128()
+}
+
+// DefaultAlgorithm128 returns the default 128-bit hash algorithm for medley, which
+// is the 128-bit variant of murmur3. The high word of each hash is the same as the
+// hash computed by DefaultAlgorithm.
+func DefaultAlgorithm128() Algorithm128 {
+	return Algorithm128{
+		New128: func() Hash128 { return murmur3.New128() },
+		Sum128: murmur3.Sum128,
+	}
+}
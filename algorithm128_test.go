@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"testing"
+
+	"github.com/spaolacci/murmur3"
+	"github.com/stretchr/testify/suite"
+)
+
+type Algorithm128Suite struct {
+	suite.Suite
+}
+
+func (suite *Algorithm128Suite) TestSum128Bytes() {
+	var (
+		input  = []byte("test hash value")
+		h1, h2 = murmur3.Sum128(input)
+	)
+
+	hi, lo := DefaultAlgorithm128().Sum128Bytes(input)
+	suite.Equal(h1, hi)
+	suite.Equal(h2, lo)
+
+	// without Sum128, New128 is used
+	hi, lo = Algorithm128{New128: DefaultAlgorithm128().New128}.Sum128Bytes(input)
+	suite.Equal(h1, hi)
+	suite.Equal(h2, lo)
+}
+
+func (suite *Algorithm128Suite) TestHighWord() {
+	// the high word agrees with the default 64-bit algorithm
+	for _, input := range []string{"", "a", "medley", "0123456789abcdef0123456789abcdef"} {
+		hi, _ := DefaultAlgorithm128().Sum128Bytes([]byte(input))
+		suite.Equal(DefaultAlgorithm().Sum64String(input), hi)
+	}
+}
+
+func TestAlgorithm128(t *testing.T) {
+	suite.Run(t, new(Algorithm128Suite))
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// AlgorithmAuto is the name FindAlgorithm resolves to the fastest builtin algorithm,
+	// as measured by CompareAlgorithms the first time it is requested in a process.
+	//
+	// Measurements vary between hosts, so different processes may resolve this name to
+	// different algorithms. Only use it when every process that must agree on placement
+	// shares the same resolution, e.g. for a Ring that is never shared across processes.
+	AlgorithmAuto = "auto"
+
+	// compareIterations is the number of times each measurement hashes its key.
+	compareIterations = 2000
+)
+
+// DefaultKeySizes are the key sizes, in bytes, used by CompareAlgorithms when none are supplied.
+var DefaultKeySizes = []int{16, 64, 256}
+
+// AlgorithmTiming is the measured cost of a single algorithm at a single key size.
+type AlgorithmTiming struct {
+	// Algorithm is the name of the measured algorithm.
+	Algorithm string
+
+	// KeySize is the number of bytes hashed.
+	KeySize int
+
+	// Sum64 is the average time for Algorithm.Sum64Bytes.
+	Sum64 time.Duration
+
+	// Write is the average time to create a hash with New64, write the key, and call Sum64.
+	Write time.Duration
+}
+
+// AlgorithmReport holds the results of CompareAlgorithms.
+type AlgorithmReport struct {
+	// Timings holds a timing for each algorithm and key size, sorted by algorithm name
+	// and then by key size.
+	Timings []AlgorithmTiming
+}
+
+// Fastest returns the name of the algorithm whose Sum64Bytes took the least total time
+// across all key sizes. Ties go to the algorithm whose name sorts first. If this report
+// is empty, this method returns the empty string.
+func (ar AlgorithmReport) Fastest() (name string) {
+	var (
+		totals = make(map[string]time.Duration)
+		names  []string
+	)
+
+	for _, t := range ar.Timings {
+		if _, exists := totals[t.Algorithm]; !exists {
+			names = append(names, t.Algorithm)
+		}
+
+		totals[t.Algorithm] += t.Sum64
+	}
+
+	slices.Sort(names)
+	for _, n := range names {
+		if name == "" || totals[n] < totals[name] {
+			name = n
+		}
+	}
+
+	return
+}
+
+// algorithmComparer measures algorithms using a clock, which tests may replace.
+type algorithmComparer struct {
+	now        func() time.Time
+	iterations int
+}
+
+// time returns the average time taken by f over the configured iterations.
+func (ac algorithmComparer) time(f func()) time.Duration {
+	start := ac.now()
+	for range ac.iterations {
+		f()
+	}
+
+	return ac.now().Sub(start) / time.Duration(ac.iterations)
+}
+
+func (ac algorithmComparer) compare(algs map[string]Algorithm, keySizes []int) (report AlgorithmReport) {
+	if len(keySizes) == 0 {
+		keySizes = DefaultKeySizes
+	}
+
+	names := make([]string, 0, len(algs))
+	for name := range algs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	for _, name := range names {
+		alg := algs[name]
+		for _, size := range keySizes {
+			key := make([]byte, size)
+			for i := range key {
+				key[i] = byte(i)
+			}
+
+			report.Timings = append(report.Timings, AlgorithmTiming{
+				Algorithm: name,
+				KeySize:   size,
+				Sum64: ac.time(func() {
+					alg.Sum64Bytes(key)
+				}),
+				Write: ac.time(func() {
+					h := alg.New64()
+					h.Write(key)
+					h.Sum64()
+				}),
+			})
+		}
+	}
+
+	return
+}
+
+// CompareAlgorithms measures the speed of each algorithm at each key size. If keySizes is
+// empty, DefaultKeySizes is used. Measurements are brief, so this function is suitable for
+// use at startup. For precise measurements, use medleytest.BenchmarkAlgorithms.
+func CompareAlgorithms(algs map[string]Algorithm, keySizes []int) AlgorithmReport {
+	return algorithmComparer{now: time.Now, iterations: compareIterations}.compare(algs, keySizes)
+}
+
+var (
+	// autoComparer measures the builtin algorithms to resolve AlgorithmAuto. Tests may replace it.
+	autoComparer = algorithmComparer{now: time.Now, iterations: compareIterations}
+
+	autoLock sync.Mutex
+	autoName string
+)
+
+// autoAlgorithm returns the name of the builtin algorithm that AlgorithmAuto resolves to,
+// measuring the builtin algorithms the first time it is called.
+func autoAlgorithm() string {
+	defer autoLock.Unlock()
+	autoLock.Lock()
+
+	if autoName == "" {
+		autoName = autoComparer.compare(algorithms, nil).Fastest()
+	}
+
+	return autoName
+}
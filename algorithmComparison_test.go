@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AlgorithmComparisonSuite struct {
+	suite.Suite
+
+	previous algorithmComparer
+}
+
+func (suite *AlgorithmComparisonSuite) SetupTest() {
+	suite.previous = autoComparer
+	suite.resetAuto(1234)
+}
+
+func (suite *AlgorithmComparisonSuite) TearDownTest() {
+	autoComparer = suite.previous
+	autoName = ""
+}
+
+// resetAuto forgets any resolution of AlgorithmAuto and installs a fake clock that
+// advances by a seeded, random amount on each call.
+func (suite *AlgorithmComparisonSuite) resetAuto(seed int64) {
+	var (
+		random  = rand.New(rand.NewSource(seed))
+		current = time.Unix(1000, 0)
+	)
+
+	autoName = ""
+	autoComparer = algorithmComparer{
+		now: func() time.Time {
+			current = current.Add(time.Duration(random.Intn(1000)) * time.Microsecond)
+			return current
+		},
+		iterations: 10,
+	}
+}
+
+func (suite *AlgorithmComparisonSuite) TestFastest() {
+	suite.Empty(AlgorithmReport{}.Fastest())
+
+	report := AlgorithmReport{
+		Timings: []AlgorithmTiming{
+			{Algorithm: "b", KeySize: 16, Sum64: 10, Write: 1},
+			{Algorithm: "b", KeySize: 64, Sum64: 20, Write: 1},
+			{Algorithm: "a", KeySize: 16, Sum64: 20, Write: 100},
+			{Algorithm: "a", KeySize: 64, Sum64: 10, Write: 100},
+			{Algorithm: "c", KeySize: 16, Sum64: 31, Write: 0},
+		},
+	}
+
+	// a and b tie, and Write times don't count
+	suite.Equal("a", report.Fastest())
+
+	report.Timings[0].Sum64 = 9
+	suite.Equal("b", report.Fastest())
+}
+
+func (suite *AlgorithmComparisonSuite) TestCompareAlgorithms() {
+	report := CompareAlgorithms(algorithms, []int{8, 128})
+	suite.Require().Len(report.Timings, 2*len(algorithms))
+
+	for i, name := range AlgorithmNames() {
+		for j, size := range []int{8, 128} {
+			timing := report.Timings[i*2+j]
+			suite.Equal(name, timing.Algorithm)
+			suite.Equal(size, timing.KeySize)
+			suite.GreaterOrEqual(timing.Sum64, time.Duration(0))
+			suite.GreaterOrEqual(timing.Write, time.Duration(0))
+		}
+	}
+
+	suite.Contains(AlgorithmNames(), report.Fastest())
+	suite.Len(CompareAlgorithms(algorithms, nil).Timings, len(DefaultKeySizes)*len(algorithms))
+}
+
+func (suite *AlgorithmComparisonSuite) TestAuto() {
+	alg, err := FindAlgorithm(AlgorithmAuto)
+	suite.Require().NoError(err)
+
+	name := autoName
+	suite.Contains(AlgorithmNames(), name)
+	suite.Equal(algorithms[name].Sum64String("test"), alg.Sum64String("test"))
+
+	// the resolution is remembered, and the name is case-insensitive
+	autoComparer.now = func() time.Time {
+		suite.Fail("the builtin algorithms should only be measured once")
+		return time.Time{}
+	}
+
+	again, err := FindAlgorithm(" AUTO ")
+	suite.Require().NoError(err)
+	suite.Equal(alg.Sum64String("test"), again.Sum64String("test"))
+
+	// the same measurements always resolve the same way
+	for range 3 {
+		suite.resetAuto(1234)
+		_, err = FindAlgorithm(AlgorithmAuto)
+		suite.Require().NoError(err)
+		suite.Equal(name, autoName)
+	}
+}
+
+func TestAlgorithmComparison(t *testing.T) {
+	suite.Run(t, new(AlgorithmComparisonSuite))
+}
@@ -5,6 +5,7 @@ package medley
 
 import (
 	"hash/fnv"
+	"sort"
 	"testing"
 
 	"github.com/spaolacci/murmur3"
@@ -98,6 +99,45 @@ func (suite *AlgorithmSuite) TestDefaultAlgorithm() {
 	suite.Equal(expected, alg.Sum64String(suite.hashInput))
 }
 
+func (suite *AlgorithmSuite) testFindAlgorithmBuiltin(name string, expected uint64) func() {
+	return func() {
+		alg, err := FindAlgorithm(name)
+		suite.Require().NoError(err)
+		suite.Require().NotNil(alg.New64)
+		suite.Equal(expected, alg.Sum64String(suite.hashInput))
+	}
+}
+
+func (suite *AlgorithmSuite) testFindAlgorithmUnknown() {
+	alg, err := FindAlgorithm("nosuch")
+	suite.ErrorIs(err, ErrUnknownAlgorithm)
+	suite.Nil(alg.New64)
+	suite.Nil(alg.Sum64)
+}
+
+func (suite *AlgorithmSuite) TestFindAlgorithm() {
+	fnv1a := fnv.New64a()
+	fnv1a.Write([]byte(suite.hashInput))
+
+	suite.Run("murmur3", suite.testFindAlgorithmBuiltin(AlgorithmMurmur3, murmur3.Sum64([]byte(suite.hashInput))))
+	suite.Run("fnv", suite.testFindAlgorithmBuiltin(AlgorithmFNV, suite.expected))
+	suite.Run("fnv1a", suite.testFindAlgorithmBuiltin(AlgorithmFNV1a, fnv1a.Sum64()))
+	suite.Run("CaseInsensitive", suite.testFindAlgorithmBuiltin(" MurMur3\t", murmur3.Sum64([]byte(suite.hashInput))))
+	suite.Run("Unknown", suite.testFindAlgorithmUnknown)
+}
+
+func (suite *AlgorithmSuite) TestAlgorithmNames() {
+	names := AlgorithmNames()
+	suite.Equal([]string{AlgorithmFNV, AlgorithmFNV1a, AlgorithmMurmur3}, names)
+	suite.True(sort.StringsAreSorted(names))
+
+	for _, name := range names {
+		alg, err := FindAlgorithm(name)
+		suite.NoError(err)
+		suite.NotNil(alg.New64)
+	}
+}
+
 func TestAlgorithm(t *testing.T) {
 	suite.Run(t, new(AlgorithmSuite))
 }
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import "fmt"
+
+const (
+	// SourceFallback is the FindResult.Source for services chosen by a FallbackLocator.
+	SourceFallback = "fallback"
+
+	// SourceCache is the FindResult.Source for services returned from a CachingLocator's cache.
+	SourceCache = "cache"
+
+	// SourceMigrating is the FindResult.Source for services chosen by a MigratingLocator
+	// that is partway through a migration.
+	SourceMigrating = "migrating"
+)
+
+// FindResult is the outcome of an annotated lookup, which records the layer of a stack of
+// decorators that decided where an object went.
+type FindResult[S Service] struct {
+	// Service is the service that was found.
+	Service S
+
+	// Source is a short name for the layer that decided on the Service, such as "ring",
+	// "cache", or "fallback". Layers that don't annotate their lookups are named by their
+	// Go type, e.g. "*medley.MockLocator[string]".
+	Source string
+}
+
+// AnnotatedLocator is an optional interface for Locators that can report which layer
+// decided the result of a lookup. Decorators that implement this interface pass through
+// the annotations of the Locators they wrap, unless they decide the result themselves.
+type AnnotatedLocator[S Service] interface {
+	Locator[S]
+
+	// FindAnnotated is like Find, but also reports the source of the result.
+	FindAnnotated(object []byte) (FindResult[S], error)
+}
+
+// Explain performs a lookup that reports which layer decided the result. If the Locator
+// implements AnnotatedLocator, its annotation is returned. Otherwise, the Locator's Find
+// is used and the Locator's type is the Source.
+//
+// This function is intended for debugging, and is slower than Find.
+func Explain[S Service](l Locator[S], object []byte) (result FindResult[S], err error) {
+	if al, ok := l.(AnnotatedLocator[S]); ok {
+		return al.FindAnnotated(object)
+	}
+
+	result.Service, err = l.Find(object)
+	result.Source = fmt.Sprintf("%T", l)
+	return
+}
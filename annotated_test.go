@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// annotatedLocator is an AnnotatedLocator with a fixed service and source.
+type annotatedLocator[S Service] struct {
+	fixedLocator[S]
+	source string
+}
+
+func (al annotatedLocator[S]) FindAnnotated([]byte) (FindResult[S], error) {
+	return FindResult[S]{Service: al.service, Source: al.source}, nil
+}
+
+type AnnotatedSuite struct {
+	suite.Suite
+}
+
+func (suite *AnnotatedSuite) assertResult(l Locator[string], object, expectedService, expectedSource string) {
+	result, err := Explain(l, []byte(object))
+	suite.Require().NoError(err)
+	suite.Equal(FindResult[string]{Service: expectedService, Source: expectedSource}, result, "object=%s", object)
+
+	// annotations never change the result
+	if _, isCache := l.(*CachingLocator[string]); !isCache {
+		svc, err := l.Find([]byte(object))
+		suite.Require().NoError(err)
+		suite.Equal(expectedService, svc)
+	}
+}
+
+func (suite *AnnotatedSuite) TestStack() {
+	var (
+		ul = NewUpdatableLocator[string](annotatedLocator[string]{
+			fixedLocator: fixedLocator[string]{service: "service1"},
+			source:       "inner",
+		})
+
+		cl = NewCachingLocator[string](ul, 0)
+		fl = NewFallbackLocator[string](cl, "fallback1")
+	)
+
+	// a miss is decided by the innermost layer, and then cached
+	suite.assertResult(fl, "key1", "service1", "inner")
+	suite.assertResult(fl, "key1", "service1", SourceCache)
+
+	// once the inner locator has no services, only uncached keys fall back
+	ul.Set(nil)
+	suite.assertResult(fl, "key1", "service1", SourceCache)
+	suite.assertResult(fl, "key2", "fallback1", SourceFallback)
+}
+
+func (suite *AnnotatedSuite) TestDegradation() {
+	var (
+		inner = fixedLocator[string]{service: "service1"}
+		cl    = NewCachingLocator[string](inner, 0)
+		fl    = NewFallbackLocator[string](cl)
+	)
+
+	// a layer without annotations is named by its type
+	suite.assertResult(fl, "key1", "service1", "medley.fixedLocator[string]")
+	suite.assertResult(fl, "key1", "service1", SourceCache)
+	suite.assertResult(inner, "key1", "service1", "medley.fixedLocator[string]")
+
+	// as are unannotated decorators, which hide the layers beneath them
+	pl := NewPairedLocator[string](NewCachingLocator[string](inner, 0), inner, RequireBoth)
+	suite.assertResult(pl, "key1", "service1", "*medley.PairedLocator[string]")
+}
+
+func (suite *AnnotatedSuite) TestPassThrough() {
+	inner := annotatedLocator[string]{
+		fixedLocator: fixedLocator[string]{service: "service1"},
+		source:       "inner",
+	}
+
+	// each layer passes through the annotation of the layer beneath it
+	var l Locator[string] = NewVersionedLocator[string](inner)
+	l = NewAtomicSwapLocator[string](l)
+	l = NewWaitingLocator[string](l, time.Minute, 0)
+	l = NewRetryingLocator[string](l, 0)
+	l = NewSamplingObserver[string](l, 0)
+	l = NewExtractingLocator[string](l, func(object []byte) ([]byte, error) {
+		return object[:1], nil
+	})
+
+	suite.assertResult(l, "key1", "service1", "inner")
+}
+
+func (suite *AnnotatedSuite) TestMigrating() {
+	var (
+		old = annotatedLocator[string]{
+			fixedLocator: fixedLocator[string]{service: "old"},
+			source:       "inner",
+		}
+
+		ml = NewMigratingLocator[string](old, fixedLocator[string]{service: "new"}, 0.0)
+		fl = NewFallbackLocator[string](NewRetryingLocator[string](ml, 0), "fallback1")
+	)
+
+	// without a migration in progress, the side in use decides
+	suite.assertResult(fl, "key1", "old", "inner")
+	ml.SetFraction(1.0)
+	suite.assertResult(fl, "key1", "new", "medley.fixedLocator[string]")
+
+	// otherwise, the migration decides
+	ml.SetFraction(0.5)
+	counts := make(map[string]int)
+	for i := range 100 {
+		result, err := Explain[string](fl, []byte(strconv.Itoa(i)))
+		suite.Require().NoError(err)
+		suite.Require().Equal(SourceMigrating, result.Source)
+		counts[result.Service]++
+	}
+
+	suite.Positive(counts["old"])
+	suite.Positive(counts["new"])
+}
+
+func (suite *AnnotatedSuite) TestChain() {
+	chain := NewChainLocator[string](
+		NewUpdatableLocator[string](nil),
+		annotatedLocator[string]{
+			fixedLocator: fixedLocator[string]{service: "service1"},
+			source:       "regional",
+		},
+		fixedLocator[string]{service: "global"},
+	)
+
+	suite.assertResult(chain, "key1", "service1", "regional")
+
+	result, err := Explain[string](NewChainLocator[string](NewUpdatableLocator[string](nil)), []byte("key1"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Zero(result)
+}
+
+func (suite *AnnotatedSuite) TestStaleGuard() {
+	var (
+		current = time.Now()
+		sg      = NewStaleGuardLocator[string](
+			annotatedLocator[string]{
+				fixedLocator: fixedLocator[string]{service: "service1"},
+				source:       "inner",
+			},
+			time.Minute,
+			true,
+			func() time.Time { return current },
+		)
+
+		fl = NewFallbackLocator[string](sg, "fallback1")
+	)
+
+	suite.assertResult(fl, "key1", "service1", "inner")
+
+	// stale membership wraps ErrNoServices, so the fallback decides
+	current = current.Add(time.Hour)
+	suite.assertResult(fl, "key1", "fallback1", SourceFallback)
+
+	_, err := Explain[string](sg, []byte("key1"))
+	suite.ErrorIs(err, ErrStaleMembership)
+}
+
+func (suite *AnnotatedSuite) TestNoServices() {
+	result, err := Explain[string](NewUpdatableLocator[string](nil), []byte("key1"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Zero(result)
+
+	result, err = Explain[string](NewFallbackLocator[string](NewUpdatableLocator[string](nil)), []byte("key1"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Zero(result)
+}
+
+func TestAnnotated(t *testing.T) {
+	suite.Run(t, new(AnnotatedSuite))
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import "sync"
+
+// AtomicSwapLocator is an updatable Locator whose implementation is replaced by a
+// read-modify-write under a lock. This avoids the window in which lookups fail with
+// ErrNoServices when code clears an UpdatableLocator before setting its replacement,
+// e.g. Set(nil) followed by Set(newRing).
+//
+// Lookups never block on Swap. The zero value of this type is usable, but returns
+// ErrNoServices until an implementation is set. An AtomicSwapLocator must not be
+// copied after first use.
+type AtomicSwapLocator[S Service] struct {
+	ul UpdatableLocator[S]
+
+	lock    sync.Mutex
+	current Locator[S]
+}
+
+var _ AnnotatedLocator[string] = (*AtomicSwapLocator[string])(nil)
+
+// NewAtomicSwapLocator returns an AtomicSwapLocator initialized with the given implementation.
+func NewAtomicSwapLocator[S Service](impl Locator[S]) *AtomicSwapLocator[S] {
+	sl := new(AtomicSwapLocator[S])
+	sl.Set(impl)
+	return sl
+}
+
+// Swap replaces this locator's implementation with the result of build, which is passed the
+// current implementation. Calls to Swap and Set are serialized, so build always sees the most
+// recent implementation, and lookups continue to use the current implementation until build
+// returns. The new implementation is returned.
+//
+// The build function must not call Swap or Set on this locator.
+func (sl *AtomicSwapLocator[S]) Swap(build func(old Locator[S]) Locator[S]) Locator[S] {
+	defer sl.lock.Unlock()
+	sl.lock.Lock()
+
+	sl.current = build(sl.current)
+	sl.ul.Set(sl.current)
+	return sl.current
+}
+
+// Set unconditionally replaces this locator's implementation. As with UpdatableLocator,
+// a nil implementation turns off this locator.
+func (sl *AtomicSwapLocator[S]) Set(impl Locator[S]) {
+	sl.Swap(func(Locator[S]) Locator[S] {
+		return impl
+	})
+}
+
+// Updated returns a channel that is closed the next time the implementation is replaced,
+// in the same manner as UpdatableLocator.Updated.
+func (sl *AtomicSwapLocator[S]) Updated() <-chan struct{} {
+	return sl.ul.Updated()
+}
+
+// Find consults the current implementation for the given object. This method
+// returns ErrNoServices if there is no implementation.
+func (sl *AtomicSwapLocator[S]) Find(object []byte) (S, error) {
+	return sl.ul.Find(object)
+}
+
+// FindAnnotated is like Find, but returns the annotation of the current implementation.
+func (sl *AtomicSwapLocator[S]) FindAnnotated(object []byte) (FindResult[S], error) {
+	return sl.ul.FindAnnotated(object)
+}
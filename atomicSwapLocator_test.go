@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AtomicSwapLocatorSuite struct {
+	suite.Suite
+}
+
+func (suite *AtomicSwapLocatorSuite) TestNaiveWindow() {
+	// the regression baseline: clearing an UpdatableLocator before setting its
+	// replacement fails any lookup that lands in between
+	ul := NewUpdatableLocator[string](fixedLocator[string]{service: "old"})
+	ul.Set(nil)
+
+	_, err := ul.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+
+	ul.Set(fixedLocator[string]{service: "new"})
+	svc, err := ul.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("new", svc)
+}
+
+func (suite *AtomicSwapLocatorSuite) TestSwap() {
+	sl := NewAtomicSwapLocator[string](fixedLocator[string]{service: "old"})
+	updated := sl.Updated()
+
+	result := sl.Swap(func(old Locator[string]) Locator[string] {
+		suite.Equal(fixedLocator[string]{service: "old"}, old)
+
+		// lookups during the swap still use the old implementation
+		svc, err := sl.Find([]byte("test"))
+		suite.NoError(err)
+		suite.Equal("old", svc)
+
+		return fixedLocator[string]{service: "new"}
+	})
+
+	suite.Equal(fixedLocator[string]{service: "new"}, result)
+	svc, err := sl.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("new", svc)
+
+	select {
+	case <-updated:
+	default:
+		suite.Fail("Swap did not close the updated channel")
+	}
+
+	sl.Set(nil)
+	_, err = sl.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+}
+
+func (suite *AtomicSwapLocatorSuite) TestZeroValue() {
+	var sl AtomicSwapLocator[string]
+	_, err := sl.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+
+	sl.Swap(func(old Locator[string]) Locator[string] {
+		suite.Nil(old)
+		return fixedLocator[string]{service: "first"}
+	})
+
+	svc, err := sl.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("first", svc)
+}
+
+func (suite *AtomicSwapLocatorSuite) TestNoWindow() {
+	const (
+		readers = 8
+		swaps   = 1000
+	)
+
+	var (
+		sl   = NewAtomicSwapLocator[string](fixedLocator[string]{service: "initial"})
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if _, err := sl.Find([]byte("test")); !suite.NoError(err) {
+					return
+				}
+			}
+		}()
+	}
+
+	for range swaps {
+		sl.Swap(func(old Locator[string]) Locator[string] {
+			return fixedLocator[string]{service: "next"}
+		})
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func TestAtomicSwapLocator(t *testing.T) {
+	suite.Run(t, new(AtomicSwapLocatorSuite))
+}
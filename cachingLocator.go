@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// DefaultCacheSize is the maximum number of entries a CachingLocator holds
+	// when no size is supplied.
+	DefaultCacheSize = 1024
+)
+
+// cacheEntry is a single, cached result of a Find.
+type cacheEntry[S Service] struct {
+	key     string
+	service S
+}
+
+// CachingLocator is a Locator decorator that caches the results of a wrapped
+// Locator. Cache entries are keyed on the object bytes passed to Find, and the
+// least recently used entry is evicted when the cache is full. Errors from the
+// wrapped Locator are never cached.
+//
+// Methods on this type are safe for concurrent usage. A CachingLocator must
+// not be copied after creation.
+type CachingLocator[S Service] struct {
+	next Locator[S]
+	size int
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+
+	// generation is incremented by Invalidate, so that results computed
+	// before an invalidation are not cached afterward.
+	generation uint64
+}
+
+// NewCachingLocator decorates a Locator with a cache holding at most size entries.
+// If size is nonpositive, DefaultCacheSize is used.
+func NewCachingLocator[S Service](next Locator[S], size int) *CachingLocator[S] {
+	if size < 1 {
+		size = DefaultCacheSize
+	}
+
+	return &CachingLocator[S]{
+		next:    next,
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+var _ AnnotatedLocator[string] = (*CachingLocator[string])(nil)
+
+// Find returns the cached service for the given object, consulting the wrapped
+// Locator on a cache miss. The object is copied before it is cached, so callers
+// are free to reuse their buffers.
+func (cl *CachingLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, hit, generation := cl.get(object)
+	if hit {
+		return
+	}
+
+	svc, err = cl.next.Find(object)
+	if err == nil {
+		cl.add(generation, string(object), svc)
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but reports SourceCache for a cache hit. On a cache miss,
+// the wrapped Locator's annotation is returned.
+func (cl *CachingLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	svc, hit, generation := cl.get(object)
+	if hit {
+		result = FindResult[S]{Service: svc, Source: SourceCache}
+		return
+	}
+
+	result, err = Explain(cl.next, object)
+	if err == nil {
+		cl.add(generation, string(object), result.Service)
+	}
+
+	return
+}
+
+// get returns the cached service for an object. On a cache miss, this method returns
+// the cache's generation, which must be passed to add.
+func (cl *CachingLocator[S]) get(object []byte) (svc S, hit bool, generation uint64) {
+	defer cl.lock.Unlock()
+	cl.lock.Lock()
+
+	// the compiler optimizes this conversion to avoid an allocation
+	if e, ok := cl.entries[string(object)]; ok {
+		cl.order.MoveToFront(e)
+		return e.Value.(*cacheEntry[S]).service, true, 0
+	}
+
+	return svc, false, cl.generation
+}
+
+// add inserts a cache entry, evicting the least recently used entry if necessary.
+// If the cache has been invalidated since the given generation, this method does nothing.
+func (cl *CachingLocator[S]) add(generation uint64, key string, svc S) {
+	defer cl.lock.Unlock()
+	cl.lock.Lock()
+
+	if generation != cl.generation {
+		return
+	} else if e, ok := cl.entries[key]; ok {
+		// another goroutine got here first
+		e.Value.(*cacheEntry[S]).service = svc
+		cl.order.MoveToFront(e)
+		return
+	}
+
+	cl.entries[key] = cl.order.PushFront(&cacheEntry[S]{key: key, service: svc})
+	for cl.order.Len() > cl.size {
+		oldest := cl.order.Back()
+		cl.order.Remove(oldest)
+		delete(cl.entries, oldest.Value.(*cacheEntry[S]).key)
+	}
+}
+
+// Len returns the number of entries currently in the cache.
+func (cl *CachingLocator[S]) Len() int {
+	defer cl.lock.Unlock()
+	cl.lock.Lock()
+	return cl.order.Len()
+}
+
+// Invalidate discards all cached entries. This method should be called whenever
+// the wrapped Locator's set of services changes, e.g. after a ring update.
+func (cl *CachingLocator[S]) Invalidate() {
+	cl.lock.Lock()
+	cl.entries = make(map[string]*list.Element, cl.size)
+	cl.order.Init()
+	cl.generation++
+	cl.lock.Unlock()
+}
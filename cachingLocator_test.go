@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CachingLocatorSuite struct {
+	suite.Suite
+}
+
+func (suite *CachingLocatorSuite) assertFind(cl *CachingLocator[string], object, expected string) {
+	actual, err := cl.Find([]byte(object))
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *CachingLocatorSuite) TestDefaultSize() {
+	cl := NewCachingLocator[string](new(MockLocator[string]), 0)
+	suite.Require().NotNil(cl)
+	suite.Equal(DefaultCacheSize, cl.size)
+	suite.Zero(cl.Len())
+}
+
+func (suite *CachingLocatorSuite) TestFind() {
+	var (
+		l  = new(MockLocator[string])
+		cl = NewCachingLocator[string](l, 10)
+	)
+
+	l.ExpectFindSuccess([]byte("object"), "service1").Once()
+
+	suite.assertFind(cl, "object", "service1")
+	suite.assertFind(cl, "object", "service1") // cache hit
+	suite.Equal(1, cl.Len())
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *CachingLocatorSuite) TestErrorsNotCached() {
+	var (
+		expectedErr = errors.New("expected")
+		l           = new(MockLocator[string])
+		cl          = NewCachingLocator[string](l, 10)
+	)
+
+	l.ExpectFindFail([]byte("object"), expectedErr).Once()
+	l.ExpectFindNoServices([]byte("object")).Once()
+	l.ExpectFindSuccess([]byte("object"), "service1").Once()
+
+	_, err := cl.Find([]byte("object"))
+	suite.ErrorIs(err, expectedErr)
+	suite.Zero(cl.Len())
+
+	_, err = cl.Find([]byte("object"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Zero(cl.Len())
+
+	suite.assertFind(cl, "object", "service1")
+	suite.Equal(1, cl.Len())
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *CachingLocatorSuite) TestEviction() {
+	var (
+		l  = new(MockLocator[string])
+		cl = NewCachingLocator[string](l, 2)
+	)
+
+	l.ExpectFindSuccess([]byte("object1"), "service1").Twice()
+	l.ExpectFindSuccess([]byte("object2"), "service2").Once()
+	l.ExpectFindSuccess([]byte("object3"), "service3").Once()
+
+	suite.assertFind(cl, "object1", "service1")
+	suite.assertFind(cl, "object2", "service2")
+
+	// touching object1 makes object2 the least recently used
+	suite.assertFind(cl, "object1", "service1")
+	suite.assertFind(cl, "object3", "service3")
+	suite.Equal(2, cl.Len())
+
+	// these are still cached
+	suite.assertFind(cl, "object1", "service1")
+	suite.assertFind(cl, "object3", "service3")
+
+	// object2 was evicted, and this evicts object1
+	l.ExpectFindSuccess([]byte("object2"), "service2").Once()
+	suite.assertFind(cl, "object2", "service2")
+	suite.assertFind(cl, "object3", "service3")
+	suite.assertFind(cl, "object1", "service1")
+	suite.Equal(2, cl.Len())
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *CachingLocatorSuite) TestInvalidate() {
+	var (
+		l  = new(MockLocator[string])
+		cl = NewCachingLocator[string](l, 10)
+	)
+
+	l.ExpectFindSuccess([]byte("object"), "service1").Once()
+	suite.assertFind(cl, "object", "service1")
+	suite.Equal(1, cl.Len())
+
+	cl.Invalidate()
+	suite.Zero(cl.Len())
+
+	l.ExpectFindSuccess([]byte("object"), "service2").Once()
+	suite.assertFind(cl, "object", "service2")
+	suite.assertFind(cl, "object", "service2")
+	suite.Equal(1, cl.Len())
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *CachingLocatorSuite) TestCallerMutatesObject() {
+	var (
+		l      = new(MockLocator[string])
+		cl     = NewCachingLocator[string](l, 10)
+		object = []byte("object1")
+	)
+
+	l.ExpectFindSuccess([]byte("object1"), "service1").Once()
+	l.ExpectFindSuccess([]byte("object2"), "service2").Once()
+
+	result, err := cl.Find(object)
+	suite.NoError(err)
+	suite.Equal("service1", result)
+
+	// reuse the buffer, as a caller might
+	object[len(object)-1] = '2'
+	result, err = cl.Find(object)
+	suite.NoError(err)
+	suite.Equal("service2", result)
+
+	suite.assertFind(cl, "object1", "service1")
+	suite.assertFind(cl, "object2", "service2")
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func TestCachingLocator(t *testing.T) {
+	suite.Run(t, new(CachingLocatorSuite))
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"slices"
+)
+
+// ChainLocator consults an ordered list of Locators, returning the first result that is
+// not ErrNoServices. This implements fallback policies such as preferring a service in
+// the caller's region and falling back to a global ring.
+//
+// Unlike MultiLocator, which consults every Locator, a ChainLocator stops at the first
+// Locator with services. Errors other than ErrNoServices are returned immediately.
+//
+// A ChainLocator is immutable and safe for concurrent usage. Lookups do not allocate.
+type ChainLocator[S Service] struct {
+	chain []Locator[S]
+}
+
+// NewChainLocator creates a ChainLocator from the given Locators, in order of preference.
+// The slice is copied.
+func NewChainLocator[S Service](chain ...Locator[S]) *ChainLocator[S] {
+	return &ChainLocator[S]{
+		chain: slices.Clone(chain),
+	}
+}
+
+var _ AnnotatedLocator[string] = (*ChainLocator[string])(nil)
+
+// Find returns the first result from the chain that is not ErrNoServices. If every
+// Locator has no services, or the chain is empty, this method returns ErrNoServices.
+func (cl *ChainLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, _, err = cl.FindFrom(object)
+	return
+}
+
+// FindFrom is like Find, but also returns the index of the Locator in the chain that
+// produced the result, which is useful for metrics. When there are no services in any
+// Locator, the index is -1.
+func (cl *ChainLocator[S]) FindFrom(object []byte) (svc S, index int, err error) {
+	for index = range cl.chain {
+		svc, err = cl.chain[index].Find(object)
+		if !errors.Is(err, ErrNoServices) {
+			return
+		}
+	}
+
+	index = -1
+	err = ErrNoServices
+	return
+}
+
+// FindAnnotated is like Find, but returns the annotation of the Locator in the chain
+// that produced the result.
+func (cl *ChainLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	for _, l := range cl.chain {
+		result, err = Explain(l, object)
+		if !errors.Is(err, ErrNoServices) {
+			return
+		}
+	}
+
+	result, err = FindResult[S]{}, ErrNoServices
+	return
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChainLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+	region *MockLocator[string]
+	zone   *MockLocator[string]
+	global *MockLocator[string]
+}
+
+func (suite *ChainLocatorSuite) SetupTest() {
+	suite.object = []byte("test")
+	suite.region = new(MockLocator[string])
+	suite.zone = new(MockLocator[string])
+	suite.global = new(MockLocator[string])
+}
+
+func (suite *ChainLocatorSuite) TearDownTest() {
+	// no unexpected calls means later locators weren't consulted
+	mock.AssertExpectationsForObjects(suite.T(), suite.region, suite.zone, suite.global)
+}
+
+func (suite *ChainLocatorSuite) newChainLocator() *ChainLocator[string] {
+	return NewChainLocator[string](suite.region, suite.zone, suite.global)
+}
+
+func (suite *ChainLocatorSuite) TestFirstHit() {
+	suite.region.ExpectFindSuccess(suite.object, "regional").Twice()
+	cl := suite.newChainLocator()
+
+	svc, index, err := cl.FindFrom(suite.object)
+	suite.NoError(err)
+	suite.Equal("regional", svc)
+	suite.Zero(index)
+
+	svc, err = cl.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("regional", svc)
+}
+
+func (suite *ChainLocatorSuite) TestFallThrough() {
+	suite.region.ExpectFindNoServices(suite.object).Once()
+	suite.zone.ExpectFindNoServices(suite.object).Once()
+	suite.global.ExpectFindSuccess(suite.object, "global").Once()
+
+	svc, index, err := suite.newChainLocator().FindFrom(suite.object)
+	suite.NoError(err)
+	suite.Equal("global", svc)
+	suite.Equal(2, index)
+}
+
+func (suite *ChainLocatorSuite) TestNoServices() {
+	suite.region.ExpectFindNoServices(suite.object).Once()
+	suite.zone.ExpectFindNoServices(suite.object).Once()
+	suite.global.ExpectFindNoServices(suite.object).Once()
+
+	svc, index, err := suite.newChainLocator().FindFrom(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+	suite.Equal(-1, index)
+
+	_, index, err = NewChainLocator[string]().FindFrom(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Equal(-1, index)
+}
+
+func (suite *ChainLocatorSuite) TestError() {
+	expectedErr := errors.New("expected")
+	suite.region.ExpectFindNoServices(suite.object).Once()
+	suite.zone.ExpectFindFail(suite.object, expectedErr).Once()
+
+	_, index, err := suite.newChainLocator().FindFrom(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Equal(1, index)
+}
+
+func (suite *ChainLocatorSuite) TestAllocations() {
+	cl := NewChainLocator[string](
+		NewUpdatableLocator[string](nil),
+		fixedLocator[string]{service: "global"},
+	)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		cl.FindFrom(suite.object)
+	})
+
+	suite.Zero(allocs)
+}
+
+func TestChainLocator(t *testing.T) {
+	suite.Run(t, new(ChainLocatorSuite))
+}
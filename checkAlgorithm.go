@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+)
+
+const (
+	// DefaultCheckSamples is the number of samples CheckAlgorithm uses when
+	// a nonpositive number of samples is supplied.
+	DefaultCheckSamples = 1000
+
+	// checkSeed is the random seed used to generate CheckAlgorithm inputs, so
+	// that checks are reproducible.
+	checkSeed int64 = 0x4d65646c6579
+
+	// checkInputSize is the size in bytes of each CheckAlgorithm input.
+	checkInputSize = 16
+
+	// minDistinctRatio is the minimum ratio of distinct outputs to samples.
+	minDistinctRatio = 0.99
+
+	// minAvalanche and maxAvalanche bound the average fraction of output bits
+	// that change when a single input bit is flipped.
+	minAvalanche = 0.35
+	maxAvalanche = 0.65
+
+	// minBitBias and maxBitBias bound the fraction of samples in which any
+	// given output bit is set.
+	minBitBias = 0.25
+	maxBitBias = 0.75
+)
+
+var (
+	// ErrAlgorithmCheck indicates that an Algorithm failed CheckAlgorithm. The errors
+	// returned by CheckAlgorithm wrap this error.
+	ErrAlgorithmCheck = errors.New("hash algorithm check failed")
+)
+
+// CheckAlgorithm runs basic sanity tests against an Algorithm. This is useful to detect
+// custom algorithms that would badly skew the distribution of services, e.g. algorithms
+// that produce constant output or only 32 bits of entropy.
+//
+// The following checks are performed against samples pseudorandom inputs:
+//
+//   - Distinct inputs must overwhelmingly produce distinct outputs.
+//   - Every output bit must be set in a reasonable fraction of outputs.
+//   - Flipping a single input bit must change close to half the output bits, on average.
+//   - If both Sum64 and New64 are set, they must produce the same outputs.
+//
+// The inputs are generated deterministically, so the results are reproducible. If samples
+// is nonpositive, DefaultCheckSamples is used. Any failures are joined into a single error,
+// and each failure wraps ErrAlgorithmCheck.
+func CheckAlgorithm(alg Algorithm, samples int) error {
+	if alg.New64 == nil {
+		return fmt.Errorf("%w: New64 is required", ErrAlgorithmCheck)
+	}
+
+	if samples < 1 {
+		samples = DefaultCheckSamples
+	}
+
+	var (
+		random = rand.New(rand.NewSource(checkSeed))
+		input  [checkInputSize]byte
+
+		distinct    = make(map[uint64]bool, samples)
+		bitCounts   [64]int
+		flipped     int
+		disagreeing int
+	)
+
+	for range samples {
+		random.Read(input[:])
+		output := alg.Sum64Bytes(input[:])
+		distinct[output] = true
+		for b := range bitCounts {
+			bitCounts[b] += int(output>>b) & 1
+		}
+
+		if alg.Sum64 != nil {
+			h := alg.New64()
+			h.Write(input[:])
+			if h.Sum64() != output {
+				disagreeing++
+			}
+		}
+
+		// flip a single, random input bit
+		p := random.Intn(checkInputSize * 8)
+		input[p/8] ^= 1 << (p % 8)
+		flipped += bits.OnesCount64(output ^ alg.Sum64Bytes(input[:]))
+	}
+
+	var errs []error
+	if ratio := float64(len(distinct)) / float64(samples); ratio < minDistinctRatio {
+		errs = append(errs, fmt.Errorf("%w: only %.2f%% of outputs were distinct", ErrAlgorithmCheck, ratio*100))
+	}
+
+	var biased []int
+	for b, count := range bitCounts {
+		if bias := float64(count) / float64(samples); bias < minBitBias || bias > maxBitBias {
+			biased = append(biased, b)
+		}
+	}
+
+	if len(biased) > 0 {
+		errs = append(errs, fmt.Errorf("%w: output bits %v were biased", ErrAlgorithmCheck, biased))
+	}
+
+	if avalanche := float64(flipped) / float64(samples*64); avalanche < minAvalanche || avalanche > maxAvalanche {
+		errs = append(errs, fmt.Errorf("%w: flipping one input bit changed %.2f%% of output bits", ErrAlgorithmCheck, avalanche*100))
+	}
+
+	if disagreeing > 0 {
+		errs = append(errs, fmt.Errorf("%w: Sum64 and New64 disagreed for %d of %d inputs", ErrAlgorithmCheck, disagreeing, samples))
+	}
+
+	return errors.Join(errs...)
+}
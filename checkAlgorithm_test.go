@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/spaolacci/murmur3"
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckAlgorithmSuite struct {
+	suite.Suite
+}
+
+func (suite *CheckAlgorithmSuite) testValid(alg Algorithm) func() {
+	return func() {
+		suite.NoError(CheckAlgorithm(alg, 0))
+		suite.NoError(CheckAlgorithm(alg, 500))
+	}
+}
+
+func (suite *CheckAlgorithmSuite) TestValid() {
+	suite.Run("Default", suite.testValid(DefaultAlgorithm()))
+	suite.Run("murmur3New64", suite.testValid(Algorithm{New64: murmur3.New64}))
+	suite.Run("fnv", suite.testValid(Algorithm{New64: fnv.New64}))
+	suite.Run("fnv1a", suite.testValid(Algorithm{New64: fnv.New64a}))
+}
+
+func (suite *CheckAlgorithmSuite) testInvalidNoNew64() {
+	err := CheckAlgorithm(Algorithm{Sum64: murmur3.Sum64}, 0)
+	suite.ErrorIs(err, ErrAlgorithmCheck)
+}
+
+func (suite *CheckAlgorithmSuite) testInvalidConstant() {
+	err := CheckAlgorithm(
+		Algorithm{
+			New64: fnv.New64,
+			Sum64: func([]byte) uint64 { return 0x0123456789abcdef },
+		},
+		0,
+	)
+
+	suite.ErrorIs(err, ErrAlgorithmCheck)
+	suite.ErrorContains(err, "distinct")
+	suite.ErrorContains(err, "biased")
+	suite.ErrorContains(err, "flipping one input bit")
+}
+
+func (suite *CheckAlgorithmSuite) testInvalidTruncated() {
+	truncated := func(v []byte) uint64 {
+		return murmur3.Sum64(v) & 0xffffffff
+	}
+
+	err := CheckAlgorithm(Algorithm{New64: murmur3.New64, Sum64: truncated}, 0)
+	suite.ErrorIs(err, ErrAlgorithmCheck)
+	suite.ErrorContains(err, "biased")
+	suite.ErrorContains(err, "flipping one input bit")
+}
+
+func (suite *CheckAlgorithmSuite) testInvalidDisagreement() {
+	// fnv and fnv1a are individually valid, but they don't agree
+	err := CheckAlgorithm(
+		Algorithm{
+			New64: fnv.New64,
+			Sum64: func(v []byte) uint64 {
+				h := fnv.New64a()
+				h.Write(v)
+				return h.Sum64()
+			},
+		},
+		0,
+	)
+
+	suite.ErrorIs(err, ErrAlgorithmCheck)
+	suite.ErrorContains(err, "disagreed")
+	suite.NotContains(err.Error(), "biased")
+}
+
+func (suite *CheckAlgorithmSuite) TestInvalid() {
+	suite.Run("NoNew64", suite.testInvalidNoNew64)
+	suite.Run("Constant", suite.testInvalidConstant)
+	suite.Run("Truncated", suite.testInvalidTruncated)
+	suite.Run("Disagreement", suite.testInvalidDisagreement)
+}
+
+func TestCheckAlgorithm(t *testing.T) {
+	suite.Run(t, new(CheckAlgorithmSuite))
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrCoalescedPanic is returned by Coalescer.Do to callers that shared a call
+	// whose function panicked or exited its goroutine.
+	ErrCoalescedPanic = errors.New("coalesced call did not return")
+)
+
+// coalesceKey identifies calls that can be coalesced.
+type coalesceKey[S Service] struct {
+	service S
+	object  string
+}
+
+// coalescedCall is a single in-flight call, shared by every caller with the same key.
+type coalescedCall[R any] struct {
+	done   chan struct{}
+	result R
+	err    error
+}
+
+// Coalescer combines concurrent work for the same object and service, in the style of
+// singleflight. This is useful when many goroutines look up the same hot object at once,
+// and each would otherwise do the same work against the same service, e.g. open a connection.
+//
+// Results are only shared among calls that overlap. Nothing is cached once a call completes.
+//
+// The zero value of this type is ready to use. A Coalescer must not be copied after first use.
+// Methods on this type are safe for concurrent usage.
+type Coalescer[S Service, R any] struct {
+	noCopy noCopy
+
+	lock  sync.Mutex
+	calls map[coalesceKey[S]]*coalescedCall[R]
+}
+
+// Do uses the Locator to find the service for an object, then invokes fn with that service.
+// If a call to fn for the same service and the same object bytes is already running, this
+// method waits for it and returns its result and error instead. Every caller does its own
+// lookup, and any error from the Locator is returned without invoking fn.
+//
+// If fn panics, the panic is propagated to the caller that invoked fn, and the callers that
+// were sharing the call get ErrCoalescedPanic.
+func (c *Coalescer[S, R]) Do(l Locator[S], object []byte, fn func(S) (R, error)) (result R, err error) {
+	var svc S
+	if svc, err = l.Find(object); err != nil {
+		return
+	}
+
+	// converting to a string copies the object, so callers can reuse their buffers
+	key := coalesceKey[S]{service: svc, object: string(object)}
+
+	c.lock.Lock()
+	if existing, ok := c.calls[key]; ok {
+		c.lock.Unlock()
+
+		<-existing.done
+		return existing.result, existing.err
+	}
+
+	if c.calls == nil {
+		c.calls = make(map[coalesceKey[S]]*coalescedCall[R])
+	}
+
+	call := &coalescedCall[R]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.lock.Unlock()
+
+	c.run(key, call, svc, fn)
+	return call.result, call.err
+}
+
+// run invokes fn on behalf of every caller sharing the given call.
+func (c *Coalescer[S, R]) run(key coalesceKey[S], call *coalescedCall[R], svc S, fn func(S) (R, error)) {
+	returned := false
+	defer func() {
+		if returned {
+			return
+		}
+
+		// recover is nil if fn called runtime.Goexit, which is allowed to continue
+		r := recover()
+		call.err = fmt.Errorf("%w: %v", ErrCoalescedPanic, r)
+		c.finish(key, call)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	call.result, call.err = fn(svc)
+	returned = true
+	c.finish(key, call)
+}
+
+// finish removes a call, so that later callers start a new one, then releases
+// the callers that shared it.
+func (c *Coalescer[S, R]) finish(key coalesceKey[S], call *coalescedCall[R]) {
+	c.lock.Lock()
+	delete(c.calls, key)
+	c.lock.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CoalescerSuite struct {
+	suite.Suite
+
+	object []byte
+}
+
+func (suite *CoalescerSuite) SetupTest() {
+	suite.object = []byte("hot key")
+}
+
+// countingLocator counts the lookups done through a Locator. Each caller of Coalescer.Do
+// does its lookup just before joining or starting a call, so once every caller's lookup is
+// counted, those callers are sharing the in-flight call.
+type countingLocator struct {
+	Locator[string]
+	lookups *atomic.Int32
+}
+
+func (cl countingLocator) Find(object []byte) (string, error) {
+	defer cl.lookups.Add(1)
+	return cl.Locator.Find(object)
+}
+
+// awaitLookups is the barrier used inside coalesced functions. It blocks until the given
+// number of lookups have been counted.
+func awaitLookups(lookups *atomic.Int32, n int32) {
+	for lookups.Load() < n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (suite *CoalescerSuite) TestCoalesce() {
+	const callers = 100
+
+	var (
+		c       Coalescer[string, int]
+		m       = new(MockLocator[string])
+		lookups atomic.Int32
+		l       = countingLocator{Locator: m, lookups: &lookups}
+		calls   atomic.Int32
+		results = make(chan int, callers)
+		wg      sync.WaitGroup
+	)
+
+	// every caller does exactly one lookup
+	m.ExpectFindSuccess(suite.object, "service1").Times(callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// each caller uses its own buffer, which it may reuse afterward
+			object := append([]byte(nil), suite.object...)
+			result, err := c.Do(l, object, func(svc string) (int, error) {
+				suite.Equal("service1", svc)
+				calls.Add(1)
+				awaitLookups(&lookups, callers)
+				return 123, nil
+			})
+
+			clear(object)
+			suite.NoError(err)
+			results <- result
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	suite.Equal(int32(1), calls.Load())
+	for result := range results {
+		suite.Equal(123, result)
+	}
+
+	m.AssertExpectations(suite.T())
+	suite.Empty(c.calls)
+}
+
+func (suite *CoalescerSuite) TestDistinct() {
+	var (
+		c       Coalescer[string, int]
+		release = make(chan struct{})
+		calls   atomic.Int32
+		wg      sync.WaitGroup
+	)
+
+	fn := func(string) (int, error) {
+		calls.Add(1)
+		<-release
+		return 0, nil
+	}
+
+	// the same object on different services, and different objects on the same service
+	for _, l := range []Locator[string]{fixedLocator[string]{service: "service1"}, fixedLocator[string]{service: "service2"}} {
+		for _, object := range []string{"key1", "key2", "key10"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Do(l, []byte(object), fn)
+			}()
+		}
+	}
+
+	suite.Eventually(func() bool { return calls.Load() == 6 }, 5*time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	suite.Equal(int32(6), calls.Load())
+}
+
+func (suite *CoalescerSuite) TestSharedError() {
+	var (
+		c           Coalescer[string, int]
+		lookups     atomic.Int32
+		l           = countingLocator{Locator: fixedLocator[string]{service: "service1"}, lookups: &lookups}
+		expectedErr = errors.New("expected")
+		errs        = make(chan error, 2)
+	)
+
+	for range 2 {
+		go func() {
+			_, err := c.Do(l, suite.object, func(string) (int, error) {
+				awaitLookups(&lookups, 2)
+				return 0, expectedErr
+			})
+
+			errs <- err
+		}()
+	}
+
+	suite.ErrorIs(<-errs, expectedErr)
+	suite.ErrorIs(<-errs, expectedErr)
+}
+
+func (suite *CoalescerSuite) TestPanic() {
+	var (
+		c       Coalescer[string, int]
+		lookups atomic.Int32
+		l       = countingLocator{Locator: fixedLocator[string]{service: "service1"}, lookups: &lookups}
+		shared  = make(chan error, 1)
+	)
+
+	go func() {
+		suite.PanicsWithValue("expected", func() {
+			c.Do(l, suite.object, func(string) (int, error) {
+				awaitLookups(&lookups, 2)
+				panic("expected")
+			})
+		})
+	}()
+
+	suite.Eventually(func() bool {
+		defer c.lock.Unlock()
+		c.lock.Lock()
+		return len(c.calls) == 1
+	}, 5*time.Second, time.Millisecond)
+
+	go func() {
+		_, err := c.Do(l, suite.object, func(string) (int, error) {
+			suite.Fail("the call should have been shared")
+			return 0, nil
+		})
+
+		shared <- err
+	}()
+
+	suite.ErrorIs(<-shared, ErrCoalescedPanic)
+
+	// the coalescer is still usable
+	result, err := c.Do(l, suite.object, func(string) (int, error) { return 1, nil })
+	suite.NoError(err)
+	suite.Equal(1, result)
+}
+
+func (suite *CoalescerSuite) TestGoexit() {
+	var (
+		c    Coalescer[string, int]
+		done = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+		c.Do(fixedLocator[string]{service: "service1"}, suite.object, func(string) (int, error) {
+			runtime.Goexit()
+			return 0, nil
+		})
+	}()
+
+	<-done
+	suite.Empty(c.calls)
+}
+
+func (suite *CoalescerSuite) TestNoCaching() {
+	var (
+		c     Coalescer[string, int]
+		l     = fixedLocator[string]{service: "service1"}
+		calls int
+	)
+
+	for i := range 3 {
+		result, err := c.Do(l, suite.object, func(string) (int, error) {
+			calls++
+			return i, nil
+		})
+
+		suite.NoError(err)
+		suite.Equal(i, result)
+	}
+
+	suite.Equal(3, calls)
+}
+
+func (suite *CoalescerSuite) TestFindError() {
+	var (
+		c           Coalescer[string, int]
+		l           = new(MockLocator[string])
+		expectedErr = errors.New("expected")
+	)
+
+	l.ExpectFindFail(mock.Anything, expectedErr).Once()
+	_, err := c.Do(l, suite.object, func(string) (int, error) {
+		suite.Fail("fn should not be called")
+		return 0, nil
+	})
+
+	suite.ErrorIs(err, expectedErr)
+	l.AssertExpectations(suite.T())
+}
+
+func TestCoalescer(t *testing.T) {
+	suite.Run(t, new(CoalescerSuite))
+}
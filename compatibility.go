@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// CompatibilityVersion is the version of the vectors returned by CompatibilityVectors.
+	// The version changes whenever the vectors change.
+	CompatibilityVersion = 1
+)
+
+var (
+	// ErrVectorMismatch indicates that a Locator did not agree with a compatibility vector.
+	// The errors returned by VerifyVectors wrap this error.
+	ErrVectorMismatch = errors.New("compatibility vector mismatch")
+)
+
+// compatibilityJSON is the canonical form of the compatibility vectors. It is
+// regenerated by the medleytest package's tests when MEDLEY_UPDATE_VECTORS is set.
+//
+//go:embed compatibility.json
+var compatibilityJSON []byte
+
+// VectorLookup is a single object along with the service that must be found for it.
+type VectorLookup struct {
+	// Key is the object that is looked up, as UTF-8 bytes.
+	Key string `json:"key"`
+
+	// Expected is the service that must be found for Key.
+	Expected string `json:"expected"`
+}
+
+// VectorCase is a ring configuration along with the lookups that it must produce.
+type VectorCase struct {
+	// Algorithm is the name of a builtin algorithm, as understood by FindAlgorithm.
+	Algorithm string `json:"algorithm"`
+
+	// VNodes is the number of vnodes for each service.
+	VNodes int `json:"vnodes"`
+
+	// Services are the ring's services. Each service is hashed as its UTF-8 bytes,
+	// as with HashStringTo.
+	Services []string `json:"services"`
+
+	// Lookups are the objects to find and their expected services.
+	Lookups []VectorLookup `json:"lookups"`
+}
+
+// Vectors is a versioned set of compatibility vectors.
+type Vectors struct {
+	// Version is the CompatibilityVersion of these vectors.
+	Version int `json:"version"`
+
+	// Cases are the individual ring configurations.
+	Cases []VectorCase `json:"cases"`
+}
+
+// CompatibilityVectors returns the canonical placements for a variety of rings, which
+// other implementations of medley's consistent hashing, e.g. in other languages, can use
+// to verify that they place objects identically. Tokens are derived the same way as
+// github.com/billhathaway/consistentHash.
+//
+// The vectors are also available as the compatibility.json file in this module, which
+// is the easiest form for other languages to consume.
+func CompatibilityVectors() Vectors {
+	var v Vectors
+	if err := json.Unmarshal(compatibilityJSON, &v); err != nil {
+		// the vectors are embedded, so this can only be a build problem
+		panic(fmt.Errorf("medley: invalid compatibility vectors: %w", err))
+	}
+
+	return v
+}
+
+// VerifyVectors checks that the Locators created by a factory agree with a set of vectors.
+// The factory is invoked once for each case. Every mismatch is joined into a single error,
+// each of which wraps ErrVectorMismatch. Errors from the factory or from lookups are
+// returned as is.
+func VerifyVectors(v Vectors, factory func(algorithm string, vnodes int, services []string) (Locator[string], error)) error {
+	var errs []error
+	for i, c := range v.Cases {
+		l, err := factory(c.Algorithm, c.VNodes, c.Services)
+		if err != nil {
+			return err
+		}
+
+		for _, lookup := range c.Lookups {
+			actual, err := l.Find([]byte(lookup.Key))
+			if err != nil {
+				return err
+			}
+
+			if actual != lookup.Expected {
+				errs = append(errs, fmt.Errorf(
+					"%w: case %d (%s, %d vnodes, %d services): key %q: expected %q, found %q",
+					ErrVectorMismatch, i, c.Algorithm, c.VNodes, len(c.Services), lookup.Key, lookup.Expected, actual,
+				))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
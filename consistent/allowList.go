@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// AllowListAlternatives is the maximum number of allowed alternatives that a
+	// ServiceNotAllowedError lists.
+	AllowListAlternatives = 3
+)
+
+var (
+	// ErrServiceNotAllowed indicates that an object's owner is not on an AllowListLocator's
+	// allow-list. Errors returned by an AllowListLocator wrap this error, and are always
+	// a *ServiceNotAllowedError.
+	ErrServiceNotAllowed = errors.New("service not allowed")
+)
+
+// ServiceNotAllowedError describes an object whose owner is not on an allow-list.
+type ServiceNotAllowedError[S medley.Service] struct {
+	// Service is the owner of the object, which was denied.
+	Service S
+
+	// Alternatives are the nearest allowed successors of the owner, in order, up to
+	// AllowListAlternatives. This is empty if the underlying locator isn't a
+	// SuccessorLocator or none of its services are allowed.
+	Alternatives []S
+}
+
+// Error names the denied service and its nearest allowed alternatives.
+func (snae *ServiceNotAllowedError[S]) Error() string {
+	if len(snae.Alternatives) == 0 {
+		return fmt.Sprintf("%s: %v: no allowed alternatives", ErrServiceNotAllowed, snae.Service)
+	}
+
+	return fmt.Sprintf("%s: %v: nearest allowed %v", ErrServiceNotAllowed, snae.Service, snae.Alternatives)
+}
+
+// Unwrap returns ErrServiceNotAllowed.
+func (snae *ServiceNotAllowedError[S]) Unwrap() error {
+	return ErrServiceNotAllowed
+}
+
+// AllowListMode determines how an AllowListLocator handles an owner that isn't allowed.
+type AllowListMode int
+
+const (
+	// AllowListFallback walks the owner's successors and returns the first allowed service.
+	// If no successor is allowed, or the underlying locator isn't a SuccessorLocator, the
+	// lookup fails as with AllowListStrict. This is the default.
+	AllowListFallback AllowListMode = iota
+
+	// AllowListStrict fails the lookup with a *ServiceNotAllowedError that lists the nearest
+	// allowed alternatives.
+	AllowListStrict
+)
+
+// AllowListLocator is a medley.Locator that only returns services on an allow-list, e.g. the
+// services for which a routing layer has credentials. Objects whose owners are allowed are
+// passed through untouched. Otherwise, the AllowListMode decides the result.
+//
+// The allow-list can be replaced at runtime with SetAllowed. Each lookup uses a single
+// allow-list, so concurrent replacements never mix. Methods on this type are safe for
+// concurrent usage.
+type AllowListLocator[S medley.Service] struct {
+	next       medley.Locator[S]
+	successors SuccessorLocator[S]
+	mode       AllowListMode
+
+	allowed atomic.Pointer[medley.Map[S, bool]]
+}
+
+var _ medley.Locator[string] = (*AllowListLocator[string])(nil)
+
+// NewAllowListLocator creates an AllowListLocator that allows the given services. If the
+// next locator is a SuccessorLocator, e.g. a Ring, its successors are used to find allowed
+// alternatives.
+func NewAllowListLocator[S medley.Service](next medley.Locator[S], mode AllowListMode, allowed ...S) *AllowListLocator[S] {
+	al := &AllowListLocator[S]{
+		next: next,
+		mode: mode,
+	}
+
+	al.successors, _ = next.(SuccessorLocator[S])
+	al.SetAllowed(allowed...)
+	return al
+}
+
+// SetAllowed atomically replaces the allow-list. Lookups in progress finish with the
+// previous allow-list.
+func (al *AllowListLocator[S]) SetAllowed(allowed ...S) {
+	m := make(medley.Map[S, bool], len(allowed))
+	for _, svc := range allowed {
+		m[svc] = true
+	}
+
+	al.allowed.Store(&m)
+}
+
+// IsAllowed tests if a service is on the current allow-list.
+func (al *AllowListLocator[S]) IsAllowed(svc S) bool {
+	return (*al.allowed.Load())[svc]
+}
+
+// Find returns the owner of the given object if it is allowed. Otherwise, depending on this
+// locator's mode, either the first allowed successor is returned or the lookup fails with a
+// *ServiceNotAllowedError. Errors from the underlying locator are returned as is.
+func (al *AllowListLocator[S]) Find(object []byte) (svc S, err error) {
+	allowed := *al.allowed.Load()
+	if svc, err = al.next.Find(object); err != nil || allowed[svc] {
+		return
+	}
+
+	snae := &ServiceNotAllowedError[S]{Service: svc}
+	if al.successors != nil {
+		for candidate := range al.successors.Successors(object) {
+			if !allowed[candidate] {
+				continue
+			}
+
+			if al.mode == AllowListFallback {
+				return candidate, nil
+			}
+
+			snae.Alternatives = append(snae.Alternatives, candidate)
+			if len(snae.Alternatives) >= AllowListAlternatives {
+				break
+			}
+		}
+	}
+
+	var zero S
+	return zero, snae
+}
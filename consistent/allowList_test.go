@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type AllowListLocatorSuite struct {
+	suite.Suite
+
+	ring *Ring[string]
+}
+
+func (suite *AllowListLocatorSuite) SetupSuite() {
+	suite.ring = Strings(services[:10]...).Build()
+}
+
+// allowedSuccessors returns up to n allowed successors of an object on the suite's ring.
+func (suite *AllowListLocatorSuite) allowedSuccessors(object []byte, allowed []string, n int) (alternatives []string) {
+	for svc := range suite.ring.Successors(object) {
+		if len(alternatives) >= n {
+			break
+		}
+
+		if slices.Contains(allowed, svc) {
+			alternatives = append(alternatives, svc)
+		}
+	}
+
+	return
+}
+
+func (suite *AllowListLocatorSuite) TestPassThrough() {
+	for _, mode := range []AllowListMode{AllowListFallback, AllowListStrict} {
+		al := NewAllowListLocator[string](suite.ring, mode, services[:10]...)
+		for _, object := range hashObjects {
+			expected, err := suite.ring.Find(object[:])
+			suite.Require().NoError(err)
+
+			actual, err := al.Find(object[:])
+			suite.Require().NoError(err)
+			suite.Require().Equal(expected, actual)
+		}
+	}
+}
+
+func (suite *AllowListLocatorSuite) TestFallback() {
+	var (
+		allowed = services[:5]
+		al      = NewAllowListLocator[string](suite.ring, AllowListFallback, allowed...)
+		moved   int
+	)
+
+	for _, object := range hashObjects {
+		owner, _ := suite.ring.Find(object[:])
+		svc, err := al.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(suite.allowedSuccessors(object[:], allowed, 1), []string{svc})
+
+		if owner != svc {
+			moved++
+			suite.False(al.IsAllowed(owner))
+		}
+	}
+
+	suite.Positive(moved)
+}
+
+func (suite *AllowListLocatorSuite) TestStrict() {
+	var (
+		allowed = services[:5]
+		al      = NewAllowListLocator[string](suite.ring, AllowListStrict, allowed...)
+		denied  int
+	)
+
+	for _, object := range hashObjects {
+		owner, _ := suite.ring.Find(object[:])
+		svc, err := al.Find(object[:])
+		if slices.Contains(allowed, owner) {
+			suite.Require().NoError(err)
+			suite.Require().Equal(owner, svc)
+			continue
+		}
+
+		denied++
+		suite.Empty(svc)
+		suite.Require().ErrorIs(err, ErrServiceNotAllowed)
+
+		var snae *ServiceNotAllowedError[string]
+		suite.Require().ErrorAs(err, &snae)
+		suite.Equal(owner, snae.Service)
+		suite.Equal(suite.allowedSuccessors(object[:], allowed, AllowListAlternatives), snae.Alternatives)
+		suite.Len(snae.Alternatives, AllowListAlternatives)
+	}
+
+	suite.Positive(denied)
+
+	// fewer allowed services than alternatives
+	al.SetAllowed(services[0])
+	for _, object := range hashObjects {
+		if owner, _ := suite.ring.Find(object[:]); owner != services[0] {
+			_, err := al.Find(object[:])
+			suite.EqualError(err, "service not allowed: "+owner+": nearest allowed ["+services[0]+"]")
+			break
+		}
+	}
+}
+
+func (suite *AllowListLocatorSuite) TestNoAlternatives() {
+	// an UpdatableLocator doesn't supply successors, so even fallback fails
+	al := NewAllowListLocator[string](medley.NewUpdatableLocator[string](suite.ring), AllowListFallback)
+	owner, _ := suite.ring.Find(hashObjects[0][:])
+
+	svc, err := al.Find(hashObjects[0][:])
+	suite.Empty(svc)
+	suite.EqualError(err, "service not allowed: "+owner+": no allowed alternatives")
+
+	var snae *ServiceNotAllowedError[string]
+	suite.Require().ErrorAs(err, &snae)
+	suite.Equal(owner, snae.Service)
+	suite.Empty(snae.Alternatives)
+
+	// the same happens when nothing on the ring is allowed
+	_, err = NewAllowListLocator[string](suite.ring, AllowListFallback, "other").Find(hashObjects[0][:])
+	suite.ErrorIs(err, ErrServiceNotAllowed)
+}
+
+func (suite *AllowListLocatorSuite) TestNoServices() {
+	al := NewAllowListLocator[string](Strings[string]().Build(), AllowListStrict)
+	_, err := al.Find(hashObjects[0][:])
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.NotErrorIs(err, ErrServiceNotAllowed)
+}
+
+func (suite *AllowListLocatorSuite) TestSetAllowed() {
+	al := NewAllowListLocator[string](suite.ring, AllowListStrict)
+	_, err := al.Find(hashObjects[0][:])
+	suite.ErrorIs(err, ErrServiceNotAllowed)
+
+	al.SetAllowed(services[:10]...)
+	suite.True(al.IsAllowed(services[0]))
+	expected, _ := suite.ring.Find(hashObjects[0][:])
+	svc, err := al.Find(hashObjects[0][:])
+	suite.NoError(err)
+	suite.Equal(expected, svc)
+
+	al.SetAllowed()
+	suite.False(al.IsAllowed(services[0]))
+	_, err = al.Find(hashObjects[0][:])
+	suite.ErrorIs(err, ErrServiceNotAllowed)
+}
+
+func (suite *AllowListLocatorSuite) TestConcurrentSetAllowed() {
+	const readers = 8
+
+	var (
+		lists = [][]string{services[:3], services[7:10]}
+		al    = NewAllowListLocator[string](suite.ring, AllowListFallback, lists[0]...)
+		stop  = make(chan struct{})
+		wg    sync.WaitGroup
+	)
+
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// each lookup sees exactly one of the lists
+				object := hashObjects[i%len(hashObjects)][:]
+				svc, err := al.Find(object)
+				if !suite.NoError(err) {
+					return
+				}
+
+				expected := []string{
+					suite.allowedSuccessors(object, lists[0], 1)[0],
+					suite.allowedSuccessors(object, lists[1], 1)[0],
+				}
+
+				if !suite.Contains(expected, svc) {
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 1000 {
+		al.SetAllowed(lists[i%2]...)
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func TestAllowListLocator(t *testing.T) {
+	suite.Run(t, new(AllowListLocatorSuite))
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type AnnotatedSuite struct {
+	suite.Suite
+}
+
+func (suite *AnnotatedSuite) explain(l medley.Locator[string], object []byte) medley.FindResult[string] {
+	result, err := medley.Explain(l, object)
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *AnnotatedSuite) TestRing() {
+	ring := Strings(services[:10]...).Build()
+	for _, object := range hashObjects[:100] {
+		expected, err := ring.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(medley.FindResult[string]{Service: expected, Source: SourceRing}, suite.explain(ring, object[:]))
+	}
+
+	_, err := medley.Explain[string](Strings[string]().Build(), hashObjects[0][:])
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *AnnotatedSuite) TestStack() {
+	var (
+		ring = Strings(services[:10]...).Build()
+		ul   = medley.NewUpdatableLocator[string](ring)
+		cl   = medley.NewCachingLocator[string](ul, 0)
+		fl   = medley.NewFallbackLocator[string](cl, "fallback")
+	)
+
+	object := hashObjects[0][:]
+	expected, _ := ring.Find(object)
+	suite.Equal(medley.FindResult[string]{Service: expected, Source: SourceRing}, suite.explain(fl, object))
+	suite.Equal(medley.FindResult[string]{Service: expected, Source: medley.SourceCache}, suite.explain(fl, object))
+
+	ul.Set(Strings[string]().Build())
+	suite.Equal(medley.FindResult[string]{Service: "fallback", Source: medley.SourceFallback}, suite.explain(fl, hashObjects[1][:]))
+}
+
+func (suite *AnnotatedSuite) TestSticky() {
+	var (
+		previous = Strings(services[:10]...).Build()
+		current  = Strings(services[5:20]...).Build()
+		sl       = NewStickyLocator(previous)
+		counts   = make(map[string]int)
+	)
+
+	sl.Advance(current)
+	for _, object := range hashObjects {
+		result := suite.explain(sl, object[:])
+		counts[result.Source]++
+
+		svc, err := sl.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(svc, result.Service)
+
+		prev, _ := previous.Find(object[:])
+		if result.Source == SourceSticky {
+			suite.Require().Equal(prev, svc)
+		} else {
+			next, _ := current.Find(object[:])
+			suite.Require().Equal(next, svc)
+			suite.Require().NotEqual(prev, next)
+		}
+	}
+
+	// objects stay with their previous owners unless those owners were removed
+	suite.Positive(counts[SourceSticky])
+	suite.Positive(counts[SourceRing])
+
+	_, err := medley.Explain[string](new(StickyLocator[string]), hashObjects[0][:])
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *AnnotatedSuite) TestScored() {
+	var (
+		ring      = Strings(services[:10]...).Build()
+		object    = hashObjects[0][:]
+		owner, _  = ring.Find(object)
+		successor = slices.Collect(ring.Successors(object))[1]
+		busy      = ""
+
+		sl = NewScoredLocator(ring, func(svc string) float64 {
+			if svc == busy {
+				return 1.0
+			}
+
+			return 0.0
+		}, 0.5, 0)
+	)
+
+	suite.Equal(medley.FindResult[string]{Service: owner, Source: SourceRing}, suite.explain(sl, object))
+
+	busy = owner
+	suite.Equal(medley.FindResult[string]{Service: successor, Source: SourceScored}, suite.explain(sl, object))
+}
+
+func (suite *AnnotatedSuite) TestSplit() {
+	var (
+		ring      = Strings(services[:10]...).Build()
+		object    = hashObjects[0][:]
+		owner, _  = ring.Find(object)
+		successor = slices.Collect(ring.Successors(object))[1]
+		sl        = NewSplitLocator(ring, nil)
+	)
+
+	sl.AddSplit(object, 1.0)
+	suite.Equal(medley.FindResult[string]{Service: successor, Source: SourceSplit}, suite.explain(sl, object))
+
+	// a split rule decides even when it keeps the owner
+	sl.AddSplit(object, 0.0)
+	suite.Equal(medley.FindResult[string]{Service: owner, Source: SourceSplit}, suite.explain(sl, object))
+
+	other := hashObjects[1][:]
+	expected, _ := ring.Find(other)
+	suite.Equal(medley.FindResult[string]{Service: expected, Source: SourceRing}, suite.explain(sl, other))
+}
+
+func (suite *AnnotatedSuite) TestDecorators() {
+	var (
+		ring     = Strings(services[:10]...).Build()
+		object   = hashObjects[0][:]
+		owner, _ = ring.Find(object)
+
+		scored = NewScoredLocator(ring, func(svc string) float64 {
+			if svc == owner {
+				return 1.0
+			}
+
+			return 0.0
+		}, 0.5, 0)
+
+		split = NewSplitLocator(ring, nil)
+		ml    = medley.NewMigratingLocator[string](scored, split, 0.0)
+	)
+
+	split.AddSplit(object, 1.0)
+
+	var l medley.Locator[string] = medley.NewVersionedLocator[string](ml)
+	l = medley.NewRetryingLocator[string](medley.NewWaitingLocator[string](l, 0, 0), 0)
+	l = medley.NewSamplingObserver[string](medley.NewAtomicSwapLocator[string](l), 0)
+	l = medley.NewExtractingLocator[string](l, nil)
+
+	for _, fraction := range []float64{0.0, 1.0} {
+		ml.SetFraction(fraction)
+		expected, err := l.Find(object)
+		suite.Require().NoError(err)
+		suite.NotEqual(owner, expected)
+
+		source := SourceScored
+		if fraction > 0.0 {
+			source = SourceSplit
+		}
+
+		suite.Equal(medley.FindResult[string]{Service: expected, Source: source}, suite.explain(l, object))
+	}
+
+	ml.SetFraction(0.5)
+	suite.Equal(medley.SourceMigrating, suite.explain(l, object).Source)
+
+	// objects without a decision pass through to the innermost layer
+	ml.SetFraction(1.0)
+	other := hashObjects[1][:]
+	expected, _ := ring.Find(other)
+	suite.Equal(medley.FindResult[string]{Service: expected, Source: SourceRing}, suite.explain(l, other))
+}
+
+func TestAnnotated(t *testing.T) {
+	suite.Run(t, new(AnnotatedSuite))
+}
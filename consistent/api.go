@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+
+	"github.com/xmidt-org/medley"
+)
+
+// RingAPI is the read-only surface of a Ring. Code that only performs lookups can accept
+// this interface rather than a *Ring, so that its tests can substitute a fake such as
+// medleytest.StaticRing.
+//
+// Methods may be added to this interface in minor releases, as Ring gains read-only
+// methods. Implementations outside this module should expect that, e.g. by embedding
+// a RingAPI or by only being used in tests.
+type RingAPI[S medley.Service] interface {
+	medley.Locator[S]
+
+	// Successors returns the distinct services for an object, beginning with its owner.
+	Successors([]byte) iter.Seq[S]
+
+	// Contains tests if the given service is in the ring.
+	Contains(S) bool
+
+	// Len returns the number of services in the ring.
+	Len() int
+
+	// Services returns the services in the ring, in no particular order.
+	Services() []S
+
+	// Ownership returns the fraction of the hash circle owned by each service.
+	Ownership() medley.Map[S, float64]
+}
+
+var (
+	_ RingAPI[string]          = (*Ring[string])(nil)
+	_ SuccessorLocator[string] = RingAPI[string](nil)
+)
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+// the fake in medleytest must keep up with this interface
+var _ RingAPI[string] = (*medleytest.StaticRing[string])(nil)
+
+// firstTwo is an example of code that only needs the read-only surface of a Ring.
+func firstTwo(r RingAPI[string], object []byte) (services []string) {
+	for svc := range r.Successors(object) {
+		services = append(services, svc)
+		if len(services) == 2 {
+			break
+		}
+	}
+
+	return
+}
+
+type RingAPISuite struct {
+	suite.Suite
+}
+
+func (suite *RingAPISuite) TestRing() {
+	ring := Strings("service1", "service2", "service3").Build()
+	owner, err := ring.Find([]byte("test"))
+	suite.Require().NoError(err)
+
+	successors := firstTwo(ring, []byte("test"))
+	suite.Len(successors, 2)
+	suite.Equal(owner, successors[0])
+}
+
+func (suite *RingAPISuite) TestFake() {
+	fake := medleytest.NewStaticRing(map[string]string{"test": "service2", "other": "service3"})
+	suite.Equal([]string{"service2", "service3"}, firstTwo(fake, []byte("test")))
+	suite.Empty(firstTwo(fake, []byte("missing")))
+
+	_, err := fake.Find([]byte("missing"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func TestRingAPI(t *testing.T) {
+	suite.Run(t, new(RingAPISuite))
+}
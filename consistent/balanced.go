@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrInsufficientCapacity indicates that a ring's services cannot hold every tenant
+	// without exceeding the maximum number of tenants per service.
+	ErrInsufficientCapacity = errors.New("insufficient capacity")
+)
+
+// AssignBalanced assigns tenants to a Ring's services such that no service is assigned more
+// than maxPerService tenants. This avoids the occasional service that pure hashing overloads.
+//
+// Each tenant starts at the service that owns it on the ring. If that service is full, the
+// tenant walks to the next distinct service that is under the cap, in the same order as
+// Successors. The returned map is keyed by each tenant's bytes. Duplicate tenants are assigned
+// once and count once against the cap.
+//
+// Tenants are placed in the given order, so earlier tenants win contested services. The result
+// is deterministic for a given order, but permuting the tenants can change the assignment. Use
+// AssignBalancedByToken for an assignment that doesn't depend on order.
+//
+// If the ring's services cannot hold every distinct tenant, this function returns
+// ErrInsufficientCapacity. If the ring has a KeyExtractor and extraction fails for a tenant,
+// the returned error wraps medley.ErrKeyExtraction.
+func AssignBalanced[S medley.Service](r *Ring[S], tenants [][]byte, maxPerService int) (map[string]S, error) {
+	// the background context is never canceled
+	return AssignBalancedContext(context.Background(), r, tenants, maxPerService)
+}
+
+// AssignBalancedContext is like AssignBalanced, but checks the given context before each batch
+// of BulkBatchSize tenants. The tenants are hashed before any are assigned. If the context is
+// done, this function returns a *CanceledError whose Processed is the number of tenants, in the
+// given order and including duplicates, that were placed before it stopped. That is zero (0)
+// while the tenants are still being hashed. The partial assignment of those tenants is returned
+// along with the error, and is the same as their assignment in the full result.
+func AssignBalancedContext[S medley.Service](ctx context.Context, r *Ring[S], tenants [][]byte, maxPerService int) (map[string]S, error) {
+	placements, err := newPlacements(ctx, r, tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignBalanced(ctx, r, placements, maxPerService)
+}
+
+// AssignBalancedByToken is like AssignBalanced, but places tenants in ascending order of their
+// tokens on the ring rather than in the given order, breaking ties by the tenants' bytes. The
+// result is the same for any permutation of the same tenants.
+//
+// Because placement follows the ring, adding or removing a service only moves the tenants near
+// that service's tokens, along with the tenants that overflowed into or out of their arcs.
+func AssignBalancedByToken[S medley.Service](r *Ring[S], tenants [][]byte, maxPerService int) (map[string]S, error) {
+	// the background context is never canceled
+	return AssignBalancedByTokenContext(context.Background(), r, tenants, maxPerService)
+}
+
+// AssignBalancedByTokenContext is like AssignBalancedByToken, but checks the given context in
+// the same way as AssignBalancedContext. Tenants are placed in token order, so Processed counts
+// the tenants with the smallest tokens, and the partial assignment holds those tenants.
+func AssignBalancedByTokenContext[S medley.Service](ctx context.Context, r *Ring[S], tenants [][]byte, maxPerService int) (map[string]S, error) {
+	placements, err := newPlacements(ctx, r, tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(placements, func(a, b placement) int {
+		if c := cmp.Compare(a.token, b.token); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(a.tenant, b.tenant)
+	})
+
+	return assignBalanced(ctx, r, placements, maxPerService)
+}
+
+// placement is a tenant along with the token of its key.
+type placement struct {
+	tenant []byte
+	token  uint64
+}
+
+// newPlacements computes the token of each tenant's key on the given ring. If the context
+// is done, no tenants have been placed, so the returned *CanceledError reports zero (0).
+func newPlacements[S medley.Service](ctx context.Context, r *Ring[S], tenants [][]byte) ([]placement, error) {
+	var (
+		hasher     = r.config()
+		placements = make([]placement, 0, len(tenants))
+	)
+
+	for i, tenant := range tenants {
+		if i%BulkBatchSize == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, &CanceledError{Err: err}
+			}
+		}
+
+		key, err := medley.ExtractKey(r.extract, tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		placements = append(placements, placement{
+			tenant: tenant,
+			token:  hasher.sum64(key),
+		})
+	}
+
+	return placements, nil
+}
+
+// assignBalanced places each tenant, in order, on the first service at or after its
+// token that is under the cap. If the context is done, the tenants placed so far are
+// returned along with a *CanceledError.
+func assignBalanced[S medley.Service](ctx context.Context, r *Ring[S], placements []placement, maxPerService int) (map[string]S, error) {
+	distinct := make(map[string]bool, len(placements))
+	for _, p := range placements {
+		distinct[string(p.tenant)] = true
+	}
+
+	if capacity := max(0, maxPerService) * len(r.cache); len(distinct) > capacity {
+		return nil, fmt.Errorf("%w: %d tenants, %d services, %d per service", ErrInsufficientCapacity, len(distinct), len(r.cache), maxPerService)
+	}
+
+	var (
+		assignment = make(map[string]S, len(distinct))
+		counts     = make(medley.Map[S, int], len(r.cache))
+	)
+
+	for i, p := range placements {
+		if err := checkBulk(ctx, i); err != nil {
+			return assignment, err
+		}
+
+		if _, placed := assignment[string(p.tenant)]; placed {
+			continue
+		}
+
+		// there is enough capacity, so some service is always under the cap
+		for svc := range r.successors(p.token) {
+			if counts[svc] < maxPerService {
+				counts[svc]++
+				assignment[string(p.tenant)] = svc
+				break
+			}
+		}
+	}
+
+	return assignment, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type BalancedSuite struct {
+	suite.Suite
+
+	ring    *Ring[string]
+	tenants [][]byte
+}
+
+func (suite *BalancedSuite) SetupTest() {
+	suite.ring = Strings(services[:20]...).Build()
+	suite.tenants = make([][]byte, 0, 500)
+	for i := range cap(suite.tenants) {
+		suite.tenants = append(suite.tenants, fmt.Appendf(nil, "tenant-%d", i))
+	}
+}
+
+// shuffled returns a permutation of the test tenants.
+func (suite *BalancedSuite) shuffled(seed uint64) [][]byte {
+	tenants := slices.Clone(suite.tenants)
+	rand.New(rand.NewPCG(seed, seed)).Shuffle(len(tenants), func(i, j int) {
+		tenants[i], tenants[j] = tenants[j], tenants[i]
+	})
+
+	return tenants
+}
+
+func (suite *BalancedSuite) assertBalanced(assignment map[string]string, maxPerService int) {
+	suite.Require().Len(assignment, len(suite.tenants))
+
+	counts := make(map[string]int)
+	for _, tenant := range suite.tenants {
+		svc, ok := assignment[string(tenant)]
+		suite.Require().True(ok, "tenant %s not assigned", tenant)
+		suite.Require().True(suite.ring.Contains(svc) || svc == "new.example.net")
+		counts[svc]++
+	}
+
+	for svc, count := range counts {
+		suite.LessOrEqual(count, maxPerService, "service %s over the cap", svc)
+	}
+}
+
+func (suite *BalancedSuite) TestCap() {
+	for _, maxPerService := range []int{25, 26, 30, 100} {
+		assignment, err := AssignBalanced(suite.ring, suite.tenants, maxPerService)
+		suite.Require().NoError(err)
+		suite.assertBalanced(assignment, maxPerService)
+
+		assignment, err = AssignBalancedByToken(suite.ring, suite.tenants, maxPerService)
+		suite.Require().NoError(err)
+		suite.assertBalanced(assignment, maxPerService)
+	}
+}
+
+func (suite *BalancedSuite) TestUncapped() {
+	// with enough room, every tenant lands on its owner
+	assignment, err := AssignBalanced(suite.ring, suite.tenants, len(suite.tenants))
+	suite.Require().NoError(err)
+
+	for _, tenant := range suite.tenants {
+		owner, err := suite.ring.Find(tenant)
+		suite.Require().NoError(err)
+		suite.Equal(owner, assignment[string(tenant)])
+	}
+}
+
+func (suite *BalancedSuite) TestDuplicates() {
+	tenants := append(slices.Clone(suite.tenants), suite.tenants[0], suite.tenants[1])
+	assignment, err := AssignBalanced(suite.ring, tenants, 25)
+	suite.Require().NoError(err)
+	suite.assertBalanced(assignment, 25)
+}
+
+func (suite *BalancedSuite) TestInsufficientCapacity() {
+	for _, maxPerService := range []int{24, 0, -1} {
+		assignment, err := AssignBalanced(suite.ring, suite.tenants, maxPerService)
+		suite.ErrorIs(err, ErrInsufficientCapacity)
+		suite.Nil(assignment)
+
+		assignment, err = AssignBalancedByToken(suite.ring, suite.tenants, maxPerService)
+		suite.ErrorIs(err, ErrInsufficientCapacity)
+		suite.Nil(assignment)
+	}
+
+	// an empty ring only holds no tenants
+	empty := Strings[string]().Build()
+	_, err := AssignBalanced(empty, suite.tenants, 100)
+	suite.ErrorIs(err, ErrInsufficientCapacity)
+
+	assignment, err := AssignBalanced(empty, nil, 100)
+	suite.NoError(err)
+	suite.Empty(assignment)
+}
+
+func (suite *BalancedSuite) TestKeyExtractionError() {
+	r := Strings(services[:20]...).
+		Extract(func([]byte) ([]byte, error) { return nil, medley.ErrKeyExtraction }).
+		Build()
+
+	_, err := AssignBalanced(r, suite.tenants, 100)
+	suite.ErrorIs(err, medley.ErrKeyExtraction)
+
+	_, err = AssignBalancedByToken(r, suite.tenants, 100)
+	suite.ErrorIs(err, medley.ErrKeyExtraction)
+}
+
+func (suite *BalancedSuite) TestDeterministic() {
+	expected, err := AssignBalanced(suite.ring, suite.tenants, 26)
+	suite.Require().NoError(err)
+
+	for range 5 {
+		actual, err := AssignBalanced(Strings(services[:20]...).Build(), suite.tenants, 26)
+		suite.Require().NoError(err)
+		suite.Equal(expected, actual)
+	}
+}
+
+func (suite *BalancedSuite) TestOrderIndependent() {
+	expected, err := AssignBalancedByToken(suite.ring, suite.tenants, 26)
+	suite.Require().NoError(err)
+
+	for seed := range uint64(5) {
+		actual, err := AssignBalancedByToken(suite.ring, suite.shuffled(seed), 26)
+		suite.Require().NoError(err)
+		suite.Equal(expected, actual)
+	}
+}
+
+func (suite *BalancedSuite) TestMinimalMovement() {
+	const maxPerService = 30
+
+	before, err := AssignBalancedByToken(suite.ring, suite.tenants, maxPerService)
+	suite.Require().NoError(err)
+
+	suite.ring, _ = Update(suite.ring, append(slices.Clone(services[:20]), "new.example.net")...)
+	after, err := AssignBalancedByToken(suite.ring, suite.tenants, maxPerService)
+	suite.Require().NoError(err)
+	suite.assertBalanced(after, maxPerService)
+
+	var moved, toNew int
+	for tenant, svc := range after {
+		if before[tenant] != svc {
+			moved++
+			if svc == "new.example.net" {
+				toNew++
+			}
+		}
+	}
+
+	// the new service takes about its fair share, and the few other moves are
+	// tenants that no longer overflow from the new service's arcs
+	fairShare := len(suite.tenants) / suite.ring.Len()
+	suite.Positive(toNew)
+	suite.Greater(toNew, moved-toNew)
+	suite.LessOrEqual(moved, 2*fairShare)
+}
+
+func TestBalanced(t *testing.T) {
+	suite.Run(t, new(BalancedSuite))
+}
@@ -4,8 +4,12 @@
 package consistent
 
 import (
-	"reflect"
-	"sort"
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
 
 	"github.com/xmidt-org/medley"
 )
@@ -14,6 +18,10 @@ const (
 	// DefaultVNodes is the default number of nodes used when none is supplied.
 	// This value is consistent with the default in github.com/billhathaway/consistentHash.
 	DefaultVNodes = 200
+
+	// buildChunkSize is the number of services BuildContext hashes between checks
+	// of its context.
+	buildChunkSize = 64
 )
 
 // Builder is a fluent builder for hash Rings. This type can be used
@@ -22,9 +30,16 @@ const (
 //
 // A Builder is used to build a Ring from scratch. To create a Ring that
 // represents an updated set of services, use Update.
+//
+// Methods on this type are safe for concurrent usage, so services discovered by
+// several goroutines can be added to the same Builder. A Builder must not be
+// copied after first use.
 type Builder[S medley.Service] struct {
+	lock     sync.Mutex
 	hasher   hasher[S]
 	services medley.Map[S, bool]
+	onFind   func(FindTrace[S])
+	extract  medley.KeyExtractor
 }
 
 // Strings starts a fluent chain for a Ring whose service object's
@@ -54,14 +69,18 @@ func BasicServices(services ...medley.BasicService) *Builder[medley.BasicService
 // VNodes sets the number of hash nodes used per service. By default,
 // DefaultVNodes is used.
 func (b *Builder[S]) VNodes(v int) *Builder[S] {
+	b.lock.Lock()
 	b.hasher.vnodes = v
+	b.lock.Unlock()
 	return b
 }
 
 // Algorithm sets the medley hash algorithm to use. By default,
 // medley.Murmur3 is used.
 func (b *Builder[S]) Algorithm(a medley.Algorithm) *Builder[S] {
+	b.lock.Lock()
 	b.hasher.alg = a
+	b.lock.Unlock()
 	return b
 }
 
@@ -71,7 +90,136 @@ func (b *Builder[S]) Algorithm(a medley.Algorithm) *Builder[S] {
 // It's usually a good idea to set this, as you can generally get better
 // performance with custom hash bytes.
 func (b *Builder[S]) ServiceHasher(sh medley.ServiceHasher[S]) *Builder[S] {
+	b.lock.Lock()
 	b.hasher.serviceHasher = sh
+	b.lock.Unlock()
+	return b
+}
+
+// SearchPolicy sets how the built Ring chooses the node that owns an object's token. By
+// default, Clockwise is used. Rings created with Update from the built Ring use the same policy.
+func (b *Builder[S]) SearchPolicy(p SearchPolicy) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.policy = p
+	b.lock.Unlock()
+	return b
+}
+
+// OnFind sets a trace hook that is invoked synchronously by the built Ring's Find
+// method after each successful lookup. By default, there is no hook. Rings created
+// with Update from the built Ring share the same hook.
+//
+// The hook is on the lookup path, so it should be fast. Use medley.SampleEvery to
+// only trace a fraction of lookups.
+func (b *Builder[S]) OnFind(f func(FindTrace[S])) *Builder[S] {
+	b.lock.Lock()
+	b.onFind = f
+	b.lock.Unlock()
+	return b
+}
+
+// Extract sets the KeyExtractor that the built Ring applies to each object before hashing
+// it, e.g. to route on a single field of a serialized request. By default, each object is
+// its own key. Rings created with Update from the built Ring share the same extractor.
+func (b *Builder[S]) Extract(f medley.KeyExtractor) *Builder[S] {
+	b.lock.Lock()
+	b.extract = f
+	b.lock.Unlock()
+	return b
+}
+
+// AutoVNodes chooses the number of vnodes from the number of services, using TuneVNodes with
+// the given target imbalance. This overrides VNodes. Rings created with Update from the built
+// Ring are tuned the same way, and every service is rehashed whenever the chosen number of
+// vnodes changes. A target that is not greater than one (1) turns off auto-tuning.
+func (b *Builder[S]) AutoVNodes(targetImbalance float64) *Builder[S] {
+	if !(targetImbalance > 1) {
+		targetImbalance = 0
+	}
+
+	b.lock.Lock()
+	b.hasher.autoImbalance = targetImbalance
+	b.lock.Unlock()
+	return b
+}
+
+// MaxServiceHashBytes limits the number of bytes the ServiceHasher may write for any one
+// service, which protects against a ServiceHasher that writes far more than intended. By
+// default, DefaultMaxServiceHashBytes is used. A nonpositive value means the default.
+//
+// BuildContext and BuildE fail with ErrServiceHashTooLarge if a service exceeds this limit.
+// Build, and Update for Rings built by this Builder, instead hash the service using only its
+// first n bytes, reporting the service to the OnServiceHashTruncated hook.
+func (b *Builder[S]) MaxServiceHashBytes(n int) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.maxBytes = n
+	b.lock.Unlock()
+	return b
+}
+
+// OnServiceHashTruncated sets a hook that is invoked with each service whose hash bytes were
+// truncated to the MaxServiceHashBytes limit, e.g. to log a warning. By default, truncation is
+// silent. Rings created with Update from the built Ring share the same hook.
+//
+// Truncation is deterministic, so a truncated service always hashes to the same tokens. However,
+// services whose hash bytes only differ beyond the limit will collide.
+func (b *Builder[S]) OnServiceHashTruncated(f func(S)) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.onTruncate = f
+	b.lock.Unlock()
+	return b
+}
+
+// UseSharedCache sets the SharedCache consulted for the nodes of each service, both by Build and
+// by Update for Rings built by this Builder. A nil cache, which is the default, turns off sharing.
+// The cache is only used while its configuration matches this Builder's.
+func (b *Builder[S]) UseSharedCache(c *SharedCache[S]) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.shared = c
+	b.lock.Unlock()
+	return b
+}
+
+// VerifyStableHashing turns on detection of a ServiceHasher that writes different bytes for equal
+// services, e.g. because it includes a mutable field. The built Ring stores a digest of each
+// service's hash bytes, and UpdateE rehashes about n of the retained services, chosen at random,
+// and fails with ErrUnstableServiceHash if any digest differs. Rings created with Update from the
+// built Ring keep verifying.
+//
+// A nonpositive n, which is the default, turns off verification. In that case no digests are
+// stored and UpdateE never rehashes a retained service.
+func (b *Builder[S]) VerifyStableHashing(n int) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.verifySample = n
+	b.lock.Unlock()
+	return b
+}
+
+// MaxRingNodes limits the total number of nodes in a Ring, i.e. the number of services times
+// their vnodes, which protects against a large membership combined with many vnodes. The limit
+// is checked before any storage is allocated. A nonpositive value, which is the default, means
+// there is no limit. Rings under the limit are unaffected.
+//
+// BuildContext, BuildE, UpdateE and UpdateVNodesE fail with ErrRingTooLarge if a Ring would
+// exceed this limit, unless ReduceVNodesToFit is set. Build, Update and UpdateVNodes, which
+// can't fail, always reduce vnodes to fit, although a Ring never has fewer than one vnode per
+// service.
+func (b *Builder[S]) MaxRingNodes(n int) *Builder[S] {
+	b.lock.Lock()
+	b.hasher.maxNodes = n
+	b.lock.Unlock()
+	return b
+}
+
+// ReduceVNodesToFit allows Rings that would exceed MaxRingNodes to be created with fewer vnodes
+// instead of failing. Every service's vnodes are reduced in proportion, so the balance of the
+// Ring degrades gracefully. When the membership shrinks, later updates restore the requested
+// vnodes. Even with this option, a Ring that would exceed the limit with one vnode per service
+// fails with ErrRingTooLarge.
+func (b *Builder[S]) ReduceVNodesToFit() *Builder[S] {
+	b.lock.Lock()
+	b.hasher.reduceToFit = true
+	b.lock.Unlock()
 	return b
 }
 
@@ -80,6 +228,9 @@ func (b *Builder[S]) ServiceHasher(sh medley.ServiceHasher[S]) *Builder[S] {
 //
 // When Build is called, the set of services known to this builder is reset.
 func (b *Builder[S]) Services(services ...S) *Builder[S] {
+	defer b.lock.Unlock()
+	b.lock.Lock()
+
 	if b.services == nil {
 		b.services = make(medley.Map[S, bool], len(services))
 	}
@@ -91,23 +242,29 @@ func (b *Builder[S]) Services(services ...S) *Builder[S] {
 	return b
 }
 
-// newHasher creates a token hasher using this builder's configuration.
-// This method enforces defaults, so the returned hasher is ready to use.
-func (b *Builder[S]) newHasher() (h hasher[S]) {
-	h = b.hasher
-	if h.vnodes < 1 {
-		h.vnodes = DefaultVNodes
-	}
+// ServicesSeq is like Services, but consumes a sequence of services, e.g. from a discovery
+// client that pages through results. Duplicates are ignored as they arrive, so the memory
+// used is bounded by the number of distinct services rather than the length of the sequence.
+func (b *Builder[S]) ServicesSeq(services iter.Seq[S]) *Builder[S] {
+	defer b.lock.Unlock()
+	b.lock.Lock()
 
-	if reflect.ValueOf(h.alg).IsZero() {
-		h.alg = medley.DefaultAlgorithm()
+	if b.services == nil {
+		b.services = make(medley.Map[S, bool])
 	}
 
-	if h.serviceHasher == nil {
-		h.serviceHasher = medley.DefaultServiceHasher[S]
+	for svc := range services {
+		b.services[svc] = true
 	}
 
-	return
+	return b
+}
+
+// newHasher creates a token hasher using this builder's configuration.
+// This method enforces defaults, so the returned hasher is ready to use.
+// The lock must be held when calling this method.
+func (b *Builder[S]) newHasher() hasher[S] {
+	return b.hasher.withDefaults()
 }
 
 // Build creates a brand new Ring instance. The set of services known to this
@@ -116,21 +273,102 @@ func (b *Builder[S]) newHasher() (h hasher[S]) {
 // This Builder can be reused to create multiple Rings, although Services will
 // need to be added between calls to Build. However, the Update function more
 // efficiently handles creating a new Ring with an updated set of services.
+//
+// Services whose hash bytes exceed MaxServiceHashBytes are truncated. Use BuildE to
+// fail instead.
 func (b *Builder[S]) Build() *Ring[S] {
-	hasher := b.newHasher()
+	// the background context is never canceled and truncation is allowed, so there is never an error
+	r, _ := b.build(context.Background(), nil, false)
+	return r
+}
+
+// BuildE is like Build, but fails with ErrServiceHashTooLarge if any service's hash bytes
+// exceed MaxServiceHashBytes. On failure, the services are left in this Builder.
+func (b *Builder[S]) BuildE() (*Ring[S], error) {
+	return b.BuildContext(context.Background(), nil)
+}
+
+// BuildContext is like BuildE, but hashes services in chunks of buildChunkSize. This is
+// useful for very large Rings, which can take seconds to build.
+//
+// Between chunks, the given context is checked. If it is canceled, no Ring is returned
+// along with the context's error, and the services are left in this Builder so that the
+// build can be retried. If progress is not nil, it is called after each chunk with the
+// number of services hashed so far and the total number of services.
+func (b *Builder[S]) BuildContext(ctx context.Context, progress func(done, total int)) (*Ring[S], error) {
+	return b.build(ctx, progress, true)
+}
+
+// build implements the various Build methods. If strict is set, a service whose hash
+// bytes exceed the limit fails the build rather than being truncated.
+func (b *Builder[S]) build(ctx context.Context, progress func(done, total int), strict bool) (*Ring[S], error) {
+	// only the snapshot needs the lock, so other goroutines aren't blocked while hashing
+	b.lock.Lock()
+	var (
+		services = b.services
+		hasher   = b.newHasher()
+	)
+
+	hasher.vnodes = hasher.tunedVNodes(services.Len())
+	scale, err := hasher.fitVNodes(services.Len(), services.Len()*hasher.vnodes, strict)
+	if err != nil {
+		b.lock.Unlock()
+		return nil, err
+	} else if scale != nil {
+		hasher.requested = hasher.vnodes
+		hasher.vnodes = scale(hasher.vnodes)
+	}
+
 	r := &Ring[S]{
-		hasher: hasher,
-		cache:  make(medley.Map[S, nodes[S]], b.services.Len()),
-		nodes:  make(nodes[S], 0, hasher.ringSize(b.services.Len())),
+		hasher:  hasher,
+		onFind:  b.onFind,
+		extract: b.extract,
+		cache:   make(medley.Map[S, nodes[S]], services.Len()),
 	}
 
-	for svc := range b.services {
-		snodes := hasher.serviceNodes(svc)
-		r.cache[svc] = snodes
-		r.nodes = append(r.nodes, snodes...)
+	if hasher.verifySample > 0 {
+		r.digests = make(medley.Map[S, uint64], services.Len())
 	}
 
-	sort.Sort(r.nodes)
 	b.services = nil
-	return r
+	b.lock.Unlock()
+
+	var (
+		runs  = make([]nodes[S], 0, services.Len())
+		total = services.Len()
+	)
+
+	for svc := range services {
+		if len(runs)%buildChunkSize == 0 {
+			if err := ctx.Err(); err != nil {
+				b.Services(slices.Collect(maps.Keys(services))...)
+				return nil, err
+			}
+		}
+
+		snodes, truncated := hasher.serviceNodes(svc, hasher.vnodes)
+		if truncated {
+			if strict {
+				b.Services(slices.Collect(maps.Keys(services))...)
+				return nil, fmt.Errorf("%w: service %v: limit %d", ErrServiceHashTooLarge, svc, hasher.maxBytes)
+			}
+
+			hasher.truncated(svc)
+		}
+
+		r.cache[svc] = snodes
+		runs = append(runs, snodes)
+		if r.digests != nil {
+			r.digests[svc] = hasher.digest(svc)
+		}
+
+		if progress != nil && (len(runs)%buildChunkSize == 0 || len(runs) == total) {
+			progress(len(runs), total)
+		}
+	}
+
+	// each service's nodes are already sorted, so merging them is cheaper than a full sort
+	r.nodes = mergeRuns(runs)
+	r.index()
+	return r, nil
 }
@@ -4,8 +4,13 @@
 package consistent
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"hash/fnv"
+	"io"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -82,6 +87,320 @@ func (suite *BuilderSuite) TestBasicServices() {
 	suite.Contains(services, result)
 }
 
+// fullSortNodes computes a ring's nodes by sorting every node at once, which is
+// how rings were originally built. This is used to verify merged builds.
+func fullSortNodes[S medley.Service](h hasher[S], services ...S) (all nodes[S]) {
+	for _, svc := range services {
+		snodes, _ := h.serviceNodes(svc, h.vnodes)
+		all = append(all, snodes...)
+	}
+
+	sort.Sort(all)
+	return
+}
+
+func (suite *BuilderSuite) assertSameNodes(expected, actual nodes[string]) {
+	suite.Require().Len(actual, len(expected))
+	for i := range expected {
+		suite.Require().Equal(expected[i].token, actual[i].token, "token %d", i)
+		suite.Require().Equal(expected[i].service, actual[i].service, "service %d", i)
+	}
+}
+
+func (suite *BuilderSuite) TestMergedBuild() {
+	for _, vnodes := range []int{1, 50, DefaultVNodes} {
+		ring := Strings(services[:]...).VNodes(vnodes).Build()
+		suite.assertSameNodes(fullSortNodes(ring.hasher, services[:]...), ring.nodes)
+
+		// each service's nodes are cached as a sorted run
+		for _, snodes := range ring.cache {
+			suite.True(sort.IsSorted(snodes))
+		}
+
+		updated, _ := Update(ring, services[25:75]...)
+		suite.assertSameNodes(fullSortNodes(ring.hasher, services[25:75]...), updated.nodes)
+
+		// lookups search tokens that mirror the nodes
+		suite.Equal(ring.nodes.tokens(), ring.tokens)
+		suite.Equal(updated.nodes.tokens(), updated.tokens)
+	}
+}
+
+func (suite *BuilderSuite) TestMergeRuns() {
+	var (
+		a = &node[string]{token: 1, service: "a"}
+		b = &node[string]{token: 2, service: "b"}
+		c = &node[string]{token: 2, service: "c"}
+		d = &node[string]{token: 3, service: "d"}
+		e = &node[string]{token: 5, service: "e"}
+	)
+
+	suite.Empty(mergeRuns[string](nil))
+	suite.Equal(nodes[string]{a, d}, mergeRuns([]nodes[string]{nil, {a, d}, {}}))
+
+	// ties go to the earlier run
+	suite.Equal(nodes[string]{a, b, c, d, e}, mergeRuns([]nodes[string]{{b, e}, {a, c}, {d}}))
+	suite.Equal(nodes[string]{a, c, b, d, e}, mergeRuns([]nodes[string]{{a, c}, {b, e}, {d}}))
+}
+
+func (suite *BuilderSuite) TestMergeUpdate() {
+	var (
+		a = &node[string]{token: 1, service: "a"}
+		b = &node[string]{token: 2, service: "b"}
+		c = &node[string]{token: 2, service: "c"}
+		d = &node[string]{token: 3, service: "d"}
+		e = &node[string]{token: 5, service: "e"}
+
+		previous = nodes[string]{a, b, d, e}
+		keep     = func(svc string) bool { return svc != "d" }
+	)
+
+	// ties go to the retained nodes
+	suite.Equal(nodes[string]{a, b, c, e}, mergeUpdate(previous, keep, 3, []nodes[string]{{c}}))
+	suite.Equal(nodes[string]{a, b, d, e}, previous)
+
+	suite.Equal(nodes[string]{c, d}, mergeUpdate(previous, func(string) bool { return false }, 0, []nodes[string]{{d}, {c}}))
+	suite.Equal(nodes[string]{a, b, e}, mergeUpdate(previous, keep, 3, nil))
+	suite.Empty(mergeUpdate[string](nil, keep, 0, nil))
+}
+
+func (suite *BuilderSuite) TestServicesSeq() {
+	var (
+		expected = Strings(services[:20]...).Build()
+
+		// each service appears many times in the stream
+		stream = func(yield func(string) bool) {
+			for i := range 1000 {
+				if !yield(services[i%20]) {
+					return
+				}
+			}
+		}
+	)
+
+	actual := Strings[string]().ServicesSeq(stream).Build()
+	suite.True(expected.Equal(actual))
+	suite.Equal(expected.Fingerprint(), actual.Fingerprint())
+
+	// sequences and slices are cumulative
+	actual = Strings(services[20:25]...).ServicesSeq(stream).Build()
+	suite.True(Strings(services[:25]...).Build().Equal(actual))
+}
+
+func (suite *BuilderSuite) TestConcurrentServices() {
+	const goroutines = 16
+
+	var (
+		b     = Strings[string]()
+		ready = make(chan struct{})
+		wg    sync.WaitGroup
+	)
+
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+
+			// each goroutine adds every service, one at a time, so that the map writes overlap
+			for i := g; i < len(services)+g; i++ {
+				b.Services(services[i%len(services)])
+			}
+
+			b.VNodes(50)
+		}()
+	}
+
+	close(ready)
+	wg.Wait()
+
+	ring := b.Build()
+	suite.Equal(len(services), ring.Len())
+	suite.assertSameNodes(Strings(services[:]...).VNodes(50).Build().nodes, ring.nodes)
+
+	// Build resets the services
+	suite.Zero(b.Build().Len())
+}
+
+func (suite *BuilderSuite) TestBuildContext() {
+	var (
+		expected = Strings(services[:]...).VNodes(50).Build()
+		progress [][2]int
+	)
+
+	ring, err := Strings(services[:]...).VNodes(50).BuildContext(
+		context.Background(),
+		func(done, total int) {
+			progress = append(progress, [2]int{done, total})
+		},
+	)
+
+	suite.Require().NoError(err)
+	suite.True(expected.Equal(ring))
+	suite.Equal([][2]int{{buildChunkSize, len(services)}, {len(services), len(services)}}, progress)
+
+	ring, err = Strings(services[:]...).VNodes(50).BuildContext(context.Background(), nil)
+	suite.Require().NoError(err)
+	suite.True(expected.Equal(ring))
+}
+
+func (suite *BuilderSuite) TestBuildContextProgress() {
+	var (
+		many     = make([]string, 0, 10*buildChunkSize+1)
+		previous int
+		calls    int
+	)
+
+	for i := range cap(many) {
+		many = append(many, fmt.Sprintf("service-%d", i))
+	}
+
+	ring, err := Strings(many...).VNodes(1).BuildContext(
+		context.Background(),
+		func(done, total int) {
+			calls++
+			suite.Greater(done, previous)
+			suite.LessOrEqual(done, total)
+			suite.Equal(len(many), total)
+			previous = done
+		},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(len(many), ring.Len())
+	suite.Equal(len(many), previous)
+	suite.Equal(11, calls)
+}
+
+func (suite *BuilderSuite) TestBuildContextCanceled() {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		b           = Strings(services[:]...).VNodes(50)
+	)
+
+	defer cancel()
+	ring, err := b.BuildContext(ctx, func(done, total int) {
+		// cancel after the first chunk
+		cancel()
+	})
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.Nil(ring)
+
+	// the services remain, so the build can be retried
+	ring, err = b.BuildContext(context.Background(), nil)
+	suite.Require().NoError(err)
+	suite.True(Strings(services[:]...).VNodes(50).Build().Equal(ring))
+
+	// a context canceled up front hashes nothing
+	ring, err = Strings(services[:]...).BuildContext(ctx, func(int, int) {
+		suite.Fail("no progress expected")
+	})
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.Nil(ring)
+}
+
+// oversizedHasher writes a service's name followed by padding, so that the
+// service's hash bytes are larger than the limits used in tests.
+func oversizedHasher(dst io.Writer, svc string) error {
+	if err := medley.HashStringTo(dst, svc); err != nil {
+		return err
+	}
+
+	_, err := dst.Write(bytes.Repeat([]byte{'x'}, 1000))
+	return err
+}
+
+func (suite *BuilderSuite) TestMaxServiceHashBytes() {
+	b := Strings("a.example.com", "oversized.example.com").
+		ServiceHasher(func(dst io.Writer, svc string) error {
+			if svc == "oversized.example.com" {
+				return oversizedHasher(dst, svc)
+			}
+
+			return medley.HashStringTo(dst, svc)
+		}).
+		MaxServiceHashBytes(100)
+
+	ring, err := b.BuildE()
+	suite.ErrorIs(err, ErrServiceHashTooLarge)
+	suite.ErrorContains(err, "oversized.example.com")
+	suite.Nil(ring)
+
+	// the services remain, so the build can be retried with a larger limit
+	ring, err = b.MaxServiceHashBytes(2000).BuildE()
+	suite.Require().NoError(err)
+	suite.Equal(2, ring.Len())
+}
+
+func (suite *BuilderSuite) TestServiceHashTruncation() {
+	var truncated []string
+	newBuilder := func() *Builder[string] {
+		return Strings("a.example.com", "b.example.com").
+			ServiceHasher(oversizedHasher).
+			MaxServiceHashBytes(64).
+			OnServiceHashTruncated(func(svc string) {
+				truncated = append(truncated, svc)
+			})
+	}
+
+	ring := newBuilder().Build()
+	suite.ElementsMatch([]string{"a.example.com", "b.example.com"}, truncated)
+
+	// truncation is deterministic, and uses exactly the first bytes written
+	suite.True(ring.Equal(newBuilder().Build()))
+	suite.assertSameNodes(
+		Strings("a.example.com", "b.example.com").
+			ServiceHasher(func(dst io.Writer, svc string) error {
+				var b bytes.Buffer
+				oversizedHasher(&b, svc)
+				_, err := dst.Write(b.Bytes()[:64])
+				return err
+			}).
+			Build().nodes,
+		ring.nodes,
+	)
+
+	// Update applies the same limit to newly hashed services only
+	truncated = nil
+	updated, _ := Update(ring, "a.example.com", "c.example.com")
+	suite.Equal([]string{"c.example.com"}, truncated)
+	suite.Equal(2, updated.Len())
+}
+
+func (suite *BuilderSuite) TestServiceHashLimitUnaffected() {
+	// services within the limit hash exactly as they would without any limit
+	for _, limit := range []int{0, 64, DefaultMaxServiceHashBytes} {
+		ring, err := Strings(services[:]...).MaxServiceHashBytes(limit).BuildE()
+		suite.Require().NoError(err)
+
+		unlimited := ring.hasher
+		unlimited.maxBytes = 0
+		suite.assertSameNodes(fullSortNodes(unlimited, services[:]...), ring.nodes)
+	}
+}
+
+func (suite *BuilderSuite) TestZeroValue() {
+	var b Builder[string]
+	ring := b.Build()
+	suite.Require().NotNil(ring)
+	suite.Zero(ring.Len())
+	suite.Equal(DefaultVNodes, ring.VNodes())
+
+	_, err := ring.Find(suite.object)
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	ring, err = b.BuildE()
+	suite.Require().NoError(err)
+	suite.Zero(ring.Len())
+
+	// a zero Builder uses the same defaults as any other Builder
+	ring = b.Services("a", "b").Build()
+	suite.True(Services("a", "b").Build().Equal(ring))
+	suite.Equal(DefaultVNodes, ring.VNodes())
+}
+
 func TestBuilder(t *testing.T) {
 	suite.Run(t, new(BuilderSuite))
 }
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// BulkBatchSize is the number of items that the context-aware bulk functions, such as
+	// TransferHintsContext and AssignBalancedContext, process between checks of their context.
+	BulkBatchSize = 1024
+)
+
+// CanceledError is returned by the context-aware bulk functions when their context is
+// canceled or its deadline passes. It unwraps to the context's error, so errors.Is works
+// with context.Canceled and context.DeadlineExceeded.
+type CanceledError struct {
+	// Processed is the number of items that were completely processed before the function
+	// stopped. Each function documents what it counts as an item.
+	Processed int
+
+	// Err is the context's error.
+	Err error
+}
+
+// Error describes how many items were processed before cancellation.
+func (ce *CanceledError) Error() string {
+	return fmt.Sprintf("canceled after %d items: %s", ce.Processed, ce.Err)
+}
+
+// Unwrap returns the context's error.
+func (ce *CanceledError) Unwrap() error {
+	return ce.Err
+}
+
+// checkBulk checks the given context at the start of each batch of BulkBatchSize items.
+// The processed count is the number of items completed so far.
+func checkBulk(ctx context.Context, processed int) error {
+	if processed%BulkBatchSize != 0 {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return &CanceledError{Processed: processed, Err: err}
+	}
+
+	return nil
+}
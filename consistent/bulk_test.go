@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// countdownContext is a context that becomes canceled once its Err method has been
+// called a given number of times, which makes cancellation deterministic.
+type countdownContext struct {
+	context.Context
+	remaining int
+}
+
+func (cc *countdownContext) Err() error {
+	if cc.remaining > 0 {
+		cc.remaining--
+		return nil
+	}
+
+	return context.Canceled
+}
+
+type BulkSuite struct {
+	suite.Suite
+
+	old, new *Ring[string]
+	keys     [][]byte
+}
+
+func (suite *BulkSuite) SetupSuite() {
+	suite.old = Strings(services[:10]...).Build()
+	suite.new = Strings(services[5:15]...).Build()
+	for i := range 5000 {
+		suite.keys = append(suite.keys, fmt.Appendf(nil, "key-%d", i))
+	}
+}
+
+func (suite *BulkSuite) requireCanceled(err error, expectedProcessed int) {
+	var ce *CanceledError
+	suite.Require().ErrorAs(err, &ce)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal(expectedProcessed, ce.Processed)
+	suite.Equal(fmt.Sprintf("canceled after %d items: context canceled", expectedProcessed), err.Error())
+}
+
+func (suite *BulkSuite) TestTransferHintsContext() {
+	expected := slices.Collect(TransferHints(suite.old, suite.new, slices.Values(suite.keys)))
+	suite.Require().NotEmpty(expected)
+
+	var actual []TransferHint[string]
+	for hint, err := range TransferHintsContext(context.Background(), suite.old, suite.new, slices.Values(suite.keys)) {
+		suite.Require().NoError(err)
+		actual = append(actual, hint)
+	}
+
+	suite.Equal(expected, actual)
+}
+
+func (suite *BulkSuite) TestTransferHintsContextCanceled() {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		consumed    int
+
+		// keys cancels the context partway through the stream
+		keys iter.Seq[[]byte] = func(yield func([]byte) bool) {
+			for _, key := range suite.keys {
+				consumed++
+				if consumed == 1500 {
+					cancel()
+				}
+
+				if !yield(key) {
+					return
+				}
+			}
+		}
+
+		hints   []TransferHint[string]
+		lastErr error
+	)
+
+	defer cancel()
+	for hint, err := range TransferHintsContext(ctx, suite.old, suite.new, keys) {
+		if err != nil {
+			lastErr = err
+			suite.Zero(hint)
+			continue
+		}
+
+		hints = append(hints, hint)
+	}
+
+	// the cancellation is noticed at the start of the next batch
+	suite.requireCanceled(lastErr, 2*BulkBatchSize)
+	suite.Equal(2*BulkBatchSize+1, consumed)
+
+	// the partial result is a prefix of the full result
+	expected := slices.Collect(TransferHints(suite.old, suite.new, slices.Values(suite.keys[:2*BulkBatchSize])))
+	suite.Equal(expected, hints)
+}
+
+func (suite *BulkSuite) TestAssignBalancedContext() {
+	for name, assign := range map[string]func(context.Context, *Ring[string], [][]byte, int) (map[string]string, error){
+		"InOrder": AssignBalancedContext[string],
+		"ByToken": AssignBalancedByTokenContext[string],
+	} {
+		suite.Run(name, func() {
+			var (
+				ring          = Strings(services[:10]...).Build()
+				maxPerService = 600
+			)
+
+			expected, err := assign(context.Background(), ring, suite.keys, maxPerService)
+			suite.Require().NoError(err)
+			suite.Len(expected, len(suite.keys))
+
+			// the tenants are hashed in 5 batches, so the third check while placing cancels
+			partial, err := assign(&countdownContext{Context: context.Background(), remaining: 7}, ring, suite.keys, maxPerService)
+			suite.requireCanceled(err, 2*BulkBatchSize)
+			suite.Len(partial, 2*BulkBatchSize)
+			for tenant, svc := range partial {
+				suite.Require().Equal(expected[tenant], svc)
+			}
+
+			// while hashing, no tenants have been placed
+			partial, err = assign(&countdownContext{Context: context.Background(), remaining: 2}, ring, suite.keys, maxPerService)
+			suite.requireCanceled(err, 0)
+			suite.Nil(partial)
+		})
+	}
+
+	// the non-context functions have the same results
+	ring := Strings(services[:10]...).Build()
+	expected, _ := AssignBalancedContext(context.Background(), ring, suite.keys, 600)
+	actual, err := AssignBalanced(ring, suite.keys, 600)
+	suite.Require().NoError(err)
+	suite.Equal(expected, actual)
+
+	expected, _ = AssignBalancedByTokenContext(context.Background(), ring, suite.keys, 600)
+	actual, err = AssignBalancedByToken(ring, suite.keys, 600)
+	suite.Require().NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *BulkSuite) TestDeadline() {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	_, err := AssignBalancedContext(ctx, suite.old, suite.keys, len(suite.keys))
+	suite.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestBulk(t *testing.T) {
+	suite.Run(t, new(BulkSuite))
+}
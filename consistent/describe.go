@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// DescribeMaxServices is the most services Describe lists for each kind of change.
+	// Any remaining services are summarized with a "+N more" suffix.
+	DescribeMaxServices = 10
+
+	// DescribeMaxLabel is the most runes of any one service's label that Describe includes.
+	// Longer labels are cut short and end with an ellipsis.
+	DescribeMaxLabel = 64
+)
+
+// MembershipChanges describes how one set of services differs from another. Each
+// slice is sorted by the services' labels.
+type MembershipChanges[S medley.Service] struct {
+	// Added are the services that are only in the new set.
+	Added []S
+
+	// Removed are the services that are only in the old set.
+	Removed []S
+
+	// Retained are the services that are in both sets.
+	Retained []S
+}
+
+// Changes compares two sets of services. Duplicate services are ignored. The label function
+// is used to sort services, and services with the same label keep the order in which they were
+// given. If label is nil, each service is formatted with fmt.Sprint.
+func Changes[S medley.Service](old, new []S, label func(S) string) (c MembershipChanges[S]) {
+	if label == nil {
+		label = sprintLabel[S]
+	}
+
+	var (
+		inOld = make(medley.Map[S, bool], len(old))
+		inNew = make(medley.Map[S, bool], len(new))
+	)
+
+	for _, svc := range new {
+		inNew[svc] = true
+	}
+
+	for _, svc := range old {
+		if inOld[svc] {
+			continue
+		}
+
+		inOld[svc] = true
+		if inNew[svc] {
+			c.Retained = append(c.Retained, svc)
+		} else {
+			c.Removed = append(c.Removed, svc)
+		}
+	}
+
+	for _, svc := range new {
+		if !inOld[svc] {
+			// marking the service as old skips any duplicates
+			inOld[svc] = true
+			c.Added = append(c.Added, svc)
+		}
+	}
+
+	byLabel := func(a, b S) int {
+		return strings.Compare(label(a), label(b))
+	}
+
+	slices.SortStableFunc(c.Added, byLabel)
+	slices.SortStableFunc(c.Removed, byLabel)
+	slices.SortStableFunc(c.Retained, byLabel)
+	return
+}
+
+// Describe produces a human-readable summary of the differences between two sets of services,
+// suitable for an alert or a log message. The summary includes counts of the services that were
+// added, removed, and retained, and lists the added and removed services by label. At most
+// DescribeMaxServices of each are listed, and labels are cut to DescribeMaxLabel runes, so the
+// summary's length is bounded. The output is stable for the same sets of services.
+//
+// If any options are supplied, the summary also includes the predicted fraction of the keyspace
+// that moves to a different service, as computed by PreviewUpdate for Rings with that vnode and
+// algorithm configuration. Services are hashed by their labels, as with Strings, so the
+// prediction is exact when a service's label is its hash bytes. If the options are invalid,
+// the summary reports the error in place of the prediction.
+func Describe[S medley.Service](old, new []S, label func(S) string, opts ...Option) string {
+	if label == nil {
+		label = sprintLabel[S]
+	}
+
+	var (
+		c = Changes(old, new, label)
+		o strings.Builder
+	)
+
+	fmt.Fprintf(
+		&o,
+		"services: %d -> %d (+%d added, -%d removed, %d retained)\n",
+		len(c.Removed)+len(c.Retained),
+		len(c.Added)+len(c.Retained),
+		len(c.Added),
+		len(c.Removed),
+		len(c.Retained),
+	)
+
+	describeServices(&o, "added", c.Added, label)
+	describeServices(&o, "removed", c.Removed, label)
+
+	if len(opts) > 0 {
+		if moved, err := predictMoved(old, new, label, opts...); err != nil {
+			fmt.Fprintf(&o, "moved keyspace: unknown (%s)\n", err)
+		} else {
+			fmt.Fprintf(&o, "moved keyspace: %.2f%%\n", 100*moved)
+		}
+	}
+
+	return o.String()
+}
+
+// sprintLabel is the default label for services.
+func sprintLabel[S medley.Service](svc S) string {
+	return fmt.Sprint(svc)
+}
+
+// describeServices writes a single line listing the given services, truncated to
+// DescribeMaxServices. Nothing is written if there are no services.
+func describeServices[S medley.Service](o *strings.Builder, kind string, services []S, label func(S) string) {
+	if len(services) == 0 {
+		return
+	}
+
+	fmt.Fprintf(o, "%s (%d): ", kind, len(services))
+	listed := min(len(services), max(0, DescribeMaxServices))
+	for i, svc := range services[:listed] {
+		if i > 0 {
+			o.WriteString(", ")
+		}
+
+		o.WriteString(truncateLabel(label(svc)))
+	}
+
+	if more := len(services) - listed; more > 0 {
+		if listed > 0 {
+			o.WriteString(", ")
+		}
+
+		fmt.Fprintf(o, "+%d more", more)
+	}
+
+	o.WriteByte('\n')
+}
+
+// truncateLabel cuts a label to DescribeMaxLabel runes.
+func truncateLabel(l string) string {
+	if utf8.RuneCountInString(l) <= DescribeMaxLabel {
+		return l
+	}
+
+	runes := []rune(l)[:max(0, DescribeMaxLabel-1)]
+	return string(runes) + "…"
+}
+
+// predictMoved computes the fraction of the keyspace that moves between Rings of
+// the old and new services' labels.
+func predictMoved[S medley.Service](old, new []S, label func(S) string, opts ...Option) (float64, error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	labels := func(services []S) []string {
+		l := make([]string, 0, len(services))
+		for _, svc := range services {
+			l = append(l, label(svc))
+		}
+
+		return l
+	}
+
+	current := configure(o, Strings(labels(old)...)).Build()
+	return PreviewUpdate(current, labels(new)...).Moved, nil
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// updateGolden is the environment variable that causes golden files to be
+// rewritten rather than compared.
+const updateGolden = "MEDLEY_UPDATE_GOLDEN"
+
+type DescribeSuite struct {
+	suite.Suite
+}
+
+// assertGolden compares actual output against a file in testdata.
+func (suite *DescribeSuite) assertGolden(name, actual string) {
+	path := filepath.Join("testdata", name+".golden")
+	if len(os.Getenv(updateGolden)) > 0 {
+		suite.Require().NoError(os.WriteFile(path, []byte(actual), 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	suite.Require().NoError(err, "set %s=1 to create golden files", updateGolden)
+	suite.Equal(string(expected), actual)
+}
+
+func (suite *DescribeSuite) TestGolden() {
+	testCases := []struct {
+		name     string
+		old, new []string
+		opts     []Option
+	}{
+		{
+			name: "describe_unchanged",
+			old:  []string{"a.example.com", "b.example.com"},
+			new:  []string{"b.example.com", "a.example.com"},
+		},
+		{
+			name: "describe_added",
+			old:  []string{"a.example.com", "b.example.com"},
+			new:  []string{"a.example.com", "b.example.com", "d.example.com", "c.example.com"},
+		},
+		{
+			name: "describe_replaced",
+			old:  services[:10],
+			new:  services[5:15],
+			opts: []Option{WithVNodes(DefaultVNodes)},
+		},
+		{
+			name: "describe_scale_out",
+			old:  services[:20],
+			new:  services[:],
+			opts: []Option{WithVNodes(100), WithAlgorithmName(medley.AlgorithmFNV)},
+		},
+		{
+			name: "describe_from_empty",
+			new:  []string{"a.example.com"},
+			opts: []Option{WithVNodes(10)},
+		},
+		{
+			name: "describe_invalid_options",
+			old:  []string{"a.example.com"},
+			new:  []string{"b.example.com"},
+			opts: []Option{WithVNodes(-1)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			suite.assertGolden(testCase.name, Describe(testCase.old, testCase.new, nil, testCase.opts...))
+		})
+	}
+}
+
+func (suite *DescribeSuite) TestStable() {
+	expected := Describe(services[:30], services[20:], nil, WithVNodes(50))
+	for range 5 {
+		suite.Equal(expected, Describe(services[:30], services[20:], nil, WithVNodes(50)))
+	}
+}
+
+func (suite *DescribeSuite) TestTruncation() {
+	var (
+		added = make([]medley.BasicService, 0, 100)
+		label = func(svc medley.BasicService) string { return svc.Host }
+	)
+
+	for i := range cap(added) {
+		added = append(added, medley.BasicService{Host: fmt.Sprintf("%03d.%s", i, strings.Repeat("x", 200))})
+	}
+
+	output := Describe(nil, added, label)
+	suite.Contains(output, "added (100): ")
+	suite.Contains(output, fmt.Sprintf(", +%d more\n", 100-DescribeMaxServices))
+	suite.Equal(DescribeMaxServices, strings.Count(output, "…"))
+	suite.NotContains(output, strings.Repeat("x", DescribeMaxLabel))
+
+	// the output is bounded regardless of the number of services
+	suite.Less(len(Describe(nil, slices.Repeat(added, 100), label)), 2*len(output))
+	suite.Equal(output, Describe(nil, slices.Repeat(added, 100), label))
+}
+
+func (suite *DescribeSuite) TestChanges() {
+	var (
+		old = []string{"e", "c", "a", "c", "b"}
+		new = []string{"f", "a", "d", "b", "d", "g"}
+		c   = Changes(old, new, nil)
+	)
+
+	suite.Equal([]string{"d", "f", "g"}, c.Added)
+	suite.Equal([]string{"c", "e"}, c.Removed)
+	suite.Equal([]string{"a", "b"}, c.Retained)
+
+	// complete: every service appears exactly once
+	c = Changes(services[:60], services[40:], nil)
+	suite.Len(c.Added, len(services)-60)
+	suite.Len(c.Removed, 40)
+	suite.Len(c.Retained, 20)
+	suite.ElementsMatch(services[:], slices.Concat(c.Added, c.Removed, c.Retained))
+
+	for _, s := range [][]string{c.Added, c.Removed, c.Retained} {
+		suite.True(slices.IsSorted(s))
+	}
+
+	suite.Zero(Changes[string](nil, nil, nil))
+}
+
+func (suite *DescribeSuite) TestChangesLabel() {
+	var (
+		old   = []medley.BasicService{{Host: "b"}, {Host: "z", Port: 1}}
+		new   = []medley.BasicService{{Host: "a", Port: 2}, {Host: "b"}, {Host: "c", Port: 1}}
+		label = func(svc medley.BasicService) string { return fmt.Sprint(svc.Port) }
+		c     = Changes(old, new, label)
+	)
+
+	// ties keep their input order
+	suite.Equal([]medley.BasicService{{Host: "c", Port: 1}, {Host: "a", Port: 2}}, c.Added)
+	suite.Equal([]medley.BasicService{{Host: "z", Port: 1}}, c.Removed)
+	suite.Equal([]medley.BasicService{{Host: "b"}}, c.Retained)
+}
+
+func TestDescribe(t *testing.T) {
+	suite.Run(t, new(DescribeSuite))
+}
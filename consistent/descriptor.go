@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc64"
+	"slices"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DescriptorVersion is the current version of the RingDescriptor schema. Version 2
+	// added the Checksum and Signature fields.
+	DescriptorVersion uint32 = 2
+
+	// descriptorVersionNoChecksum is the version of descriptors that predate checksums.
+	// These descriptors are still accepted, so that producers can be upgraded gradually.
+	descriptorVersionNoChecksum uint32 = 1
+)
+
+var (
+	// ErrUnsupportedDescriptorVersion indicates that a RingDescriptor has a schema
+	// version that this package does not understand.
+	ErrUnsupportedDescriptorVersion = errors.New("unsupported ring descriptor version")
+
+	// ErrIntegrity indicates that a RingDescriptor's checksum or signature did not match
+	// its contents, e.g. because the descriptor was truncated or corrupted in transit.
+	ErrIntegrity = errors.New("ring descriptor failed its integrity check")
+)
+
+// descriptorTable is the CRC-64 table used for descriptor checksums.
+var descriptorTable = crc64.MakeTable(crc64.ECMA)
+
+// RingDescriptor is the wire representation of a Ring's membership and configuration.
+// It is intended for processes that push ring membership to other processes.
+//
+// The field numbers in the comments below are stable, so that this struct can be
+// mirrored by a protobuf message:
+//
+//	message RingDescriptor {
+//	  uint32 version = 1;
+//	  string algorithm = 2;
+//	  int64 vnodes = 3;
+//	  repeated bytes services = 4;
+//	  uint64 generation = 5;
+//	  uint64 checksum = 6;
+//	  bytes signature = 7;
+//	}
+type RingDescriptor struct {
+	// Version is the schema version, which is DescriptorVersion for descriptors
+	// created by this package. Field number 1.
+	Version uint32 `json:"version"`
+
+	// Algorithm is the name of the hash algorithm. Field number 2.
+	Algorithm string `json:"algorithm"`
+
+	// VNodes is the number of vnodes per service. Field number 3.
+	VNodes int `json:"vnodes"`
+
+	// Services holds the encoded services. Field number 4.
+	Services [][]byte `json:"services"`
+
+	// Generation is an application-defined counter that identifies this membership,
+	// e.g. a monotonically increasing update number. Field number 5.
+	Generation uint64 `json:"generation"`
+
+	// Checksum is the CRC-64 (ECMA) of the descriptor's canonical encoding. FromDescriptor
+	// rejects a descriptor whose checksum doesn't match. Field number 6.
+	Checksum uint64 `json:"checksum"`
+
+	// Signature is an optional, application-defined signature of the descriptor's canonical
+	// encoding, e.g. an HMAC. See SignDescriptor and VerifyDescriptor. Field number 7.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// Canonical returns the canonical encoding of this descriptor, which is what the Checksum
+// and Signature cover. The encoding includes every field except Checksum and Signature,
+// and services are encoded in the order they appear.
+//
+// All integers are little-endian. The encoding consists of:
+//
+//	version                  uint32
+//	algorithm                uint16 length, then the name
+//	vnodes                   int64
+//	generation               uint64
+//	service count            uint32
+//	services                 for each service, a uint32 length, then the encoded service
+func (d RingDescriptor) Canonical() []byte {
+	size := 4 + 2 + len(d.Algorithm) + 8 + 8 + 4
+	for _, svc := range d.Services {
+		size += 4 + len(svc)
+	}
+
+	data := make([]byte, 0, size)
+	data = binary.LittleEndian.AppendUint32(data, d.Version)
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(d.Algorithm)))
+	data = append(data, d.Algorithm...)
+	data = binary.LittleEndian.AppendUint64(data, uint64(d.VNodes))
+	data = binary.LittleEndian.AppendUint64(data, d.Generation)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(d.Services)))
+	for _, svc := range d.Services {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(svc)))
+		data = append(data, svc...)
+	}
+
+	return data
+}
+
+// checksum computes the checksum of the given canonical encoding.
+func checksum(canonical []byte) uint64 {
+	return crc64.Checksum(canonical, descriptorTable)
+}
+
+// verifyChecksum checks this descriptor's version and checksum. Descriptors that
+// predate checksums are not checked.
+func (d RingDescriptor) verifyChecksum(canonical []byte) error {
+	switch d.Version {
+	case descriptorVersionNoChecksum:
+		return nil
+
+	case DescriptorVersion:
+		if actual := checksum(canonical); actual != d.Checksum {
+			return fmt.Errorf("%w: checksum %x, expected %x", ErrIntegrity, actual, d.Checksum)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedDescriptorVersion, d.Version)
+	}
+}
+
+// SignDescriptor signs a descriptor's canonical encoding with the given function, e.g. an
+// HMAC with a shared key, and stores the result in the descriptor's Signature field.
+func SignDescriptor(d *RingDescriptor, sign func(canonical []byte) ([]byte, error)) error {
+	signature, err := sign(d.Canonical())
+	if err != nil {
+		return err
+	}
+
+	d.Signature = signature
+	return nil
+}
+
+// VerifyDescriptor checks a descriptor's checksum, then checks its signature with the given
+// function, which must return an error if the signature is invalid. A descriptor without a
+// signature fails verification. Any failure wraps ErrIntegrity.
+//
+// Use this function before FromDescriptor when descriptors must be authenticated.
+func VerifyDescriptor(d RingDescriptor, verify func(canonical, signature []byte) error) error {
+	canonical := d.Canonical()
+	if err := d.verifyChecksum(canonical); err != nil {
+		return err
+	}
+
+	if len(d.Signature) == 0 {
+		return fmt.Errorf("%w: no signature", ErrIntegrity)
+	}
+
+	if err := verify(canonical, d.Signature); err != nil {
+		return fmt.Errorf("%w: %w", ErrIntegrity, err)
+	}
+
+	return nil
+}
+
+// algorithmName determines the name of an algorithm by comparing it to the builtin algorithms.
+func algorithmName(alg medley.Algorithm) (string, error) {
+	for _, name := range medley.AlgorithmNames() {
+		builtin, _ := medley.FindAlgorithm(name)
+		if sameAlgorithm(alg, builtin) {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: the ring's algorithm is not a builtin algorithm", medley.ErrUnknownAlgorithm)
+}
+
+// ToDescriptor produces the wire representation of a Ring, using enc to encode each service.
+// The ring's algorithm must be one of the builtin algorithms returned by medley.AlgorithmNames.
+// The returned descriptor has a checksum, but no signature. Services are sorted by their
+// encoded bytes, so the same ring always produces the same canonical form.
+func ToDescriptor[S medley.Service](r *Ring[S], generation uint64, enc func(S) []byte) (d RingDescriptor, err error) {
+	d.Algorithm, err = algorithmName(r.config().alg)
+	if err != nil {
+		return
+	}
+
+	d.Version = DescriptorVersion
+	d.VNodes = r.config().vnodes
+	d.Generation = generation
+	d.Services = make([][]byte, 0, len(r.cache))
+	for svc := range r.cache {
+		d.Services = append(d.Services, enc(svc))
+	}
+
+	slices.SortFunc(d.Services, bytes.Compare)
+	d.Checksum = checksum(d.Canonical())
+	return
+}
+
+// FromDescriptor rebuilds a Ring from its wire representation, using dec to decode each
+// service. The given Builder supplies any configuration not carried by the descriptor, such
+// as the ServiceHasher. If b is nil, a new Builder is used. The Builder's vnodes and algorithm
+// are replaced by those in the descriptor.
+//
+// The descriptor's algorithm is first looked up in extensions, which may be nil, and then
+// with medley.FindAlgorithm.
+//
+// The descriptor's checksum is verified before anything else, and a mismatch results in
+// ErrIntegrity. Descriptors with version 1, which predates checksums, are not checked. Nothing
+// is added to the Builder unless the entire descriptor is valid.
+func FromDescriptor[S medley.Service](d RingDescriptor, dec func([]byte) (S, error), b *Builder[S], extensions map[string]medley.Algorithm) (*Ring[S], error) {
+	if err := d.verifyChecksum(d.Canonical()); err != nil {
+		return nil, err
+	}
+
+	alg, ok := extensions[d.Algorithm]
+	if !ok {
+		var err error
+		if alg, err = medley.FindAlgorithm(d.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	services := make([]S, 0, len(d.Services))
+	for _, encoded := range d.Services {
+		svc, err := dec(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, svc)
+	}
+
+	if b == nil {
+		b = new(Builder[S])
+	}
+
+	return b.Services(services...).VNodes(d.VNodes).Algorithm(alg).Build(), nil
+}
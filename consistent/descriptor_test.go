@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type DescriptorSuite struct {
+	suite.Suite
+}
+
+func encodeString(s string) []byte {
+	return []byte(s)
+}
+
+func decodeString(b []byte) (string, error) {
+	return string(b), nil
+}
+
+func encodeBasicService(s medley.BasicService) []byte {
+	b, _ := json.Marshal(s)
+	return b
+}
+
+func decodeBasicService(b []byte) (s medley.BasicService, err error) {
+	err = json.Unmarshal(b, &s)
+	return
+}
+
+// withChecksum sets the checksum of a hand-built descriptor.
+func withChecksum(d RingDescriptor) RingDescriptor {
+	d.Checksum = checksum(d.Canonical())
+	return d
+}
+
+// transmit simulates sending a descriptor over the wire.
+func (suite *DescriptorSuite) transmit(d RingDescriptor) (received RingDescriptor) {
+	data, err := json.Marshal(d)
+	suite.Require().NoError(err)
+	suite.Require().NoError(json.Unmarshal(data, &received))
+	return
+}
+
+func (suite *DescriptorSuite) testRoundTripStrings() {
+	original := Strings(services[:10]...).VNodes(50).Algorithm(medley.Algorithm{New64: fnv.New64a}).Build()
+
+	d, err := ToDescriptor(original, 42, encodeString)
+	suite.Require().NoError(err)
+	suite.Equal(DescriptorVersion, d.Version)
+	suite.Equal(medley.AlgorithmFNV1a, d.Algorithm)
+	suite.Equal(50, d.VNodes)
+	suite.Equal(uint64(42), d.Generation)
+	suite.Len(d.Services, 10)
+
+	received := suite.transmit(d)
+	suite.Equal(uint64(42), received.Generation)
+
+	rebuilt, err := FromDescriptor(received, decodeString, Strings[string](), nil)
+	suite.Require().NoError(err)
+	suite.True(original.Equal(rebuilt))
+}
+
+func (suite *DescriptorSuite) testRoundTripBasicServices() {
+	original := BasicServices(
+		medley.BasicService{Scheme: "https", Host: "service1.net", Port: 443},
+		medley.BasicService{Scheme: "http", Host: "service2.net", Port: 8080, Path: "/api"},
+	).Build()
+
+	d, err := ToDescriptor(original, 7, encodeBasicService)
+	suite.Require().NoError(err)
+	suite.Equal(medley.AlgorithmMurmur3, d.Algorithm)
+
+	rebuilt, err := FromDescriptor(suite.transmit(d), decodeBasicService, BasicServices(), nil)
+	suite.Require().NoError(err)
+	suite.True(original.Equal(rebuilt))
+}
+
+func (suite *DescriptorSuite) TestRoundTrip() {
+	suite.Run("Strings", suite.testRoundTripStrings)
+	suite.Run("BasicServices", suite.testRoundTripBasicServices)
+}
+
+func (suite *DescriptorSuite) TestExtensions() {
+	custom := medley.Algorithm{New64: fnv.New64a}
+	rebuilt, err := FromDescriptor(
+		withChecksum(RingDescriptor{
+			Version:   DescriptorVersion,
+			Algorithm: "custom",
+			VNodes:    10,
+			Services:  [][]byte{[]byte("service1"), []byte("service2")},
+		}),
+		decodeString,
+		nil,
+		map[string]medley.Algorithm{"custom": custom},
+	)
+
+	suite.Require().NoError(err)
+	suite.True(Services("service1", "service2").VNodes(10).Algorithm(custom).Build().Equal(rebuilt))
+}
+
+func (suite *DescriptorSuite) testErrorUnknownAlgorithm() {
+	_, err := ToDescriptor(
+		Strings("service1").Algorithm(medley.Algorithm{New64: fnv.New64, Sum64: func([]byte) uint64 { return 1 }}).Build(),
+		1,
+		encodeString,
+	)
+
+	suite.ErrorIs(err, medley.ErrUnknownAlgorithm)
+
+	rebuilt, err := FromDescriptor(
+		withChecksum(RingDescriptor{Version: DescriptorVersion, Algorithm: "nosuch"}),
+		decodeString,
+		nil,
+		nil,
+	)
+
+	suite.ErrorIs(err, medley.ErrUnknownAlgorithm)
+	suite.Nil(rebuilt)
+}
+
+func (suite *DescriptorSuite) testErrorVersion() {
+	rebuilt, err := FromDescriptor(
+		RingDescriptor{Version: DescriptorVersion + 1, Algorithm: medley.AlgorithmMurmur3},
+		decodeString,
+		nil,
+		nil,
+	)
+
+	suite.ErrorIs(err, ErrUnsupportedDescriptorVersion)
+	suite.Nil(rebuilt)
+}
+
+func (suite *DescriptorSuite) testErrorDecode() {
+	var (
+		expectedErr = errors.New("expected")
+		b           = Strings[string]()
+	)
+
+	rebuilt, err := FromDescriptor(
+		withChecksum(RingDescriptor{
+			Version:   DescriptorVersion,
+			Algorithm: medley.AlgorithmMurmur3,
+			Services:  [][]byte{[]byte("service1")},
+		}),
+		func([]byte) (string, error) { return "", expectedErr },
+		b,
+		nil,
+	)
+
+	suite.ErrorIs(err, expectedErr)
+	suite.Nil(rebuilt)
+	suite.Empty(b.services)
+}
+
+func (suite *DescriptorSuite) TestErrors() {
+	suite.Run("UnknownAlgorithm", suite.testErrorUnknownAlgorithm)
+	suite.Run("Version", suite.testErrorVersion)
+	suite.Run("Decode", suite.testErrorDecode)
+}
+
+func (suite *DescriptorSuite) TestChecksum() {
+	original := Strings(services[:10]...).VNodes(50).Build()
+	d, err := ToDescriptor(original, 42, encodeString)
+	suite.Require().NoError(err)
+	suite.Equal(checksum(d.Canonical()), d.Checksum)
+
+	data, err := json.Marshal(d)
+	suite.Require().NoError(err)
+
+	// corrupt a byte within each field's JSON value, including the services and checksum
+	var corrupted int
+	for _, field := range []string{`"algorithm":"`, `"vnodes":`, `"services":["`, `"generation":`, `"checksum":`} {
+		offset := bytes.Index(data, []byte(field))
+		suite.Require().GreaterOrEqual(offset, 0, field)
+		offset += len(field)
+
+		for _, delta := range []int{0, 1, 3} {
+			var (
+				damaged  = bytes.Clone(data)
+				received RingDescriptor
+			)
+
+			// swap digits for digits and letters for letters, so the JSON still parses
+			switch c := damaged[offset+delta]; {
+			case c >= '0' && c <= '9':
+				damaged[offset+delta] = '0' + (c-'0'+1)%10
+			default:
+				damaged[offset+delta] ^= 0x01
+			}
+
+			if json.Unmarshal(damaged, &received) != nil {
+				continue
+			}
+
+			b := Strings[string]()
+			rebuilt, err := FromDescriptor(received, decodeString, b, nil)
+			suite.ErrorIs(err, ErrIntegrity, "%s+%d", field, delta)
+			suite.Nil(rebuilt)
+			suite.Empty(b.services, "nothing is applied on failure")
+			corrupted++
+		}
+	}
+
+	suite.Positive(corrupted)
+}
+
+func (suite *DescriptorSuite) TestDeterministic() {
+	original := Strings(services[:50]...).Build()
+	first, err := ToDescriptor(original, 7, encodeString)
+	suite.Require().NoError(err)
+	canonical := first.Canonical()
+
+	// the same membership built in a different order also has the same canonical form
+	reversed := slices.Clone(services[:50])
+	slices.Reverse(reversed)
+	rebuilt := Strings(reversed...).Build()
+
+	for _, r := range []*Ring[string]{original, original, original, rebuilt} {
+		d, err := ToDescriptor(r, 7, encodeString)
+		suite.Require().NoError(err)
+		suite.Equal(canonical, d.Canonical())
+		suite.Equal(first.Checksum, d.Checksum)
+	}
+}
+
+func (suite *DescriptorSuite) TestTruncation() {
+	d, err := ToDescriptor(Strings(services[:10]...).Build(), 1, encodeString)
+	suite.Require().NoError(err)
+
+	// a services list cut short in transit
+	d.Services = d.Services[:9]
+	rebuilt, err := FromDescriptor(d, decodeString, nil, nil)
+	suite.ErrorIs(err, ErrIntegrity)
+	suite.Nil(rebuilt)
+}
+
+func (suite *DescriptorSuite) TestVersion1() {
+	// descriptors that predate checksums are still accepted
+	rebuilt, err := FromDescriptor(
+		RingDescriptor{
+			Version:   1,
+			Algorithm: medley.AlgorithmMurmur3,
+			VNodes:    10,
+			Services:  [][]byte{[]byte("service1")},
+		},
+		decodeString,
+		Strings[string](),
+		nil,
+	)
+
+	suite.Require().NoError(err)
+	suite.True(Strings("service1").VNodes(10).Build().Equal(rebuilt))
+}
+
+func (suite *DescriptorSuite) TestSignature() {
+	var (
+		key      = []byte("test key")
+		wrongKey = []byte("wrong key")
+
+		sign = func(key []byte) func([]byte) ([]byte, error) {
+			return func(canonical []byte) ([]byte, error) {
+				mac := hmac.New(sha256.New, key)
+				mac.Write(canonical)
+				return mac.Sum(nil), nil
+			}
+		}
+
+		verify = func(key []byte) func([]byte, []byte) error {
+			return func(canonical, signature []byte) error {
+				expected, _ := sign(key)(canonical)
+				if !hmac.Equal(expected, signature) {
+					return errors.New("signature mismatch")
+				}
+
+				return nil
+			}
+		}
+	)
+
+	d, err := ToDescriptor(Strings(services[:10]...).Build(), 1, encodeString)
+	suite.Require().NoError(err)
+	suite.ErrorIs(VerifyDescriptor(d, verify(key)), ErrIntegrity, "unsigned descriptors fail")
+
+	suite.Require().NoError(SignDescriptor(&d, sign(key)))
+	suite.NotEmpty(d.Signature)
+
+	received := suite.transmit(d)
+	suite.NoError(VerifyDescriptor(received, verify(key)))
+	suite.ErrorIs(VerifyDescriptor(received, verify(wrongKey)), ErrIntegrity)
+
+	// a tampered descriptor with a recomputed checksum still fails the signature
+	tampered := withChecksum(RingDescriptor{
+		Version:    received.Version,
+		Algorithm:  received.Algorithm,
+		VNodes:     received.VNodes,
+		Services:   received.Services[:9],
+		Generation: received.Generation,
+		Signature:  received.Signature,
+	})
+
+	suite.ErrorIs(VerifyDescriptor(tampered, verify(key)), ErrIntegrity)
+
+	// the signed descriptor still round trips
+	rebuilt, err := FromDescriptor(received, decodeString, Strings[string](), nil)
+	suite.Require().NoError(err)
+	suite.Equal(10, rebuilt.Len())
+
+	// signing errors are returned as is
+	expectedErr := errors.New("expected")
+	suite.ErrorIs(SignDescriptor(&d, func([]byte) ([]byte, error) { return nil, expectedErr }), expectedErr)
+}
+
+func TestDescriptor(t *testing.T) {
+	suite.Run(t, new(DescriptorSuite))
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent_test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+// The hash algorithm and encodings are stable, so the services chosen for these keys
+// never change for a given set of services.
+
+func Example() {
+	ring := consistent.Strings(
+		"a.example.net",
+		"b.example.net",
+		"c.example.net",
+	).Build()
+
+	for _, key := range []string{"device-1", "device-2", "device-3"} {
+		svc, err := medley.FindString[string](ring, key)
+		fmt.Println(key, svc, err)
+	}
+
+	// Output:
+	// device-1 c.example.net <nil>
+	// device-2 a.example.net <nil>
+	// device-3 b.example.net <nil>
+}
+
+func ExampleUpdate() {
+	var (
+		ring    = consistent.Strings("a.example.net", "b.example.net").Build()
+		locator = medley.NewUpdatableLocator[string](ring)
+	)
+
+	svc, _ := medley.FindString[string](locator, "device-1")
+	fmt.Println(svc)
+
+	// service discovery found a new service, so the ring is rebuilt and swapped in
+	next, updated := consistent.Update(ring, "a.example.net", "b.example.net", "c.example.net")
+	fmt.Println("updated:", updated)
+	if updated {
+		locator.Set(next)
+	}
+
+	// only the keys that the new service takes over move
+	svc, _ = medley.FindString[string](locator, "device-1")
+	fmt.Println(svc)
+
+	// the same services are not an update, and the current ring is returned
+	same, updated := consistent.Update(next, "c.example.net", "b.example.net", "a.example.net")
+	fmt.Println("updated:", updated, same == next)
+
+	// Output:
+	// b.example.net
+	// updated: true
+	// c.example.net
+	// updated: false true
+}
+
+func ExampleBuilder_ServiceHasher() {
+	type endpoint struct {
+		Region string
+		Host   string
+		Port   uint16
+	}
+
+	// a ServiceHasher writes a canonical encoding of each field, so that services
+	// with different fields never produce the same hash input
+	hashEndpoint := func(dst io.Writer, e endpoint) error {
+		return medley.NewHashBuilder(dst).
+			WriteDelimitedString(e.Region).
+			WriteDelimitedString(e.Host).
+			WriteUvarint(uint64(e.Port)).
+			Err()
+	}
+
+	ring := consistent.Services(
+		endpoint{Region: "east", Host: "a.example.net", Port: 8080},
+		endpoint{Region: "east", Host: "b.example.net", Port: 8080},
+		endpoint{Region: "west", Host: "c.example.net", Port: 8080},
+	).ServiceHasher(hashEndpoint).Build()
+
+	svc, err := medley.FindString[endpoint](ring, "device-1")
+	fmt.Printf("%+v %v\n", svc, err)
+
+	// Output:
+	// {Region:west Host:c.example.net Port:8080} <nil>
+}
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xmidt-org/medley"
+)
+
+// ExportFormat is the format written by Ring.ExportOwnership.
+type ExportFormat int
+
+const (
+	// ExportCSV is a CSV format with a "token,service" header, followed by one row for
+	// each node. Tokens are unsigned decimal integers.
+	ExportCSV ExportFormat = iota
+
+	// ExportBinary is a compact binary format suitable for bulk loading. All integers are
+	// little-endian. The format consists of:
+	//
+	//	magic "MEDLEYOW"   8 bytes
+	//	version            uint32
+	//	row count          uint64
+	//	rows               for each node, a uint64 token, a uint32 label length, then the label
+	ExportBinary
+)
+
+const (
+	// ExportVersion is the current version of the ExportBinary format.
+	ExportVersion uint32 = 1
+
+	// exportMagic identifies the ExportBinary format.
+	exportMagic = "MEDLEYOW"
+)
+
+var (
+	// ErrUnknownExportFormat indicates that an ExportFormat is not recognized.
+	ErrUnknownExportFormat = errors.New("unknown export format")
+
+	// ErrInvalidExport indicates that exported ownership data is truncated or malformed.
+	ErrInvalidExport = errors.New("invalid ownership export")
+
+	// ErrUnsupportedExportVersion indicates that ExportBinary data has a version that
+	// this package does not understand.
+	ErrUnsupportedExportVersion = errors.New("unsupported ownership export version")
+)
+
+// OwnershipRow is a single node read by ReadOwnership.
+type OwnershipRow struct {
+	Token uint64
+	Label string
+}
+
+// ExportOwnership writes every node of this ring, sorted by token, for offline analysis.
+// The label function converts each service to the text that is written, and is called once
+// per service. If label is nil, services are formatted with fmt.Sprint.
+//
+// Rows are streamed to w, so the export is never materialized in memory.
+func (r *Ring[S]) ExportOwnership(w io.Writer, format ExportFormat, label func(S) string) error {
+	if label == nil {
+		label = func(svc S) string {
+			return fmt.Sprint(svc)
+		}
+	}
+
+	labels := make(medley.Map[S, string], len(r.cache))
+	for svc := range r.cache {
+		labels[svc] = label(svc)
+	}
+
+	switch format {
+	case ExportCSV:
+		return r.exportCSV(w, labels)
+
+	case ExportBinary:
+		return r.exportBinary(w, labels)
+
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownExportFormat, format)
+	}
+}
+
+func (r *Ring[S]) exportCSV(w io.Writer, labels medley.Map[S, string]) error {
+	var (
+		cw     = csv.NewWriter(w)
+		record = make([]string, 2)
+		token  []byte
+	)
+
+	cw.Write([]string{"token", "service"})
+	for _, n := range r.nodes {
+		token = strconv.AppendUint(token[:0], n.token, 10)
+		record[0], record[1] = string(token), labels[n.service]
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r *Ring[S]) exportBinary(w io.Writer, labels medley.Map[S, string]) error {
+	var (
+		le     = binary.LittleEndian
+		bw     = bufio.NewWriter(w)
+		header = make([]byte, 0, 20)
+		row    [12]byte
+	)
+
+	header = append(header, exportMagic...)
+	header = le.AppendUint32(header, ExportVersion)
+	header = le.AppendUint64(header, uint64(len(r.nodes)))
+	bw.Write(header)
+
+	for _, n := range r.nodes {
+		l := labels[n.service]
+		le.PutUint64(row[:], n.token)
+		le.PutUint32(row[8:], uint32(len(l)))
+		bw.Write(row[:])
+		bw.WriteString(l)
+	}
+
+	// bufio.Writer retains the first error, so any write error is reported here
+	return bw.Flush()
+}
+
+// ReadOwnership reads the rows written by Ring.ExportOwnership in the given format, e.g.
+// to verify an export.
+func ReadOwnership(rd io.Reader, format ExportFormat) ([]OwnershipRow, error) {
+	switch format {
+	case ExportCSV:
+		return readOwnershipCSV(rd)
+
+	case ExportBinary:
+		return readOwnershipBinary(rd)
+
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownExportFormat, format)
+	}
+}
+
+func readOwnershipCSV(rd io.Reader) ([]OwnershipRow, error) {
+	cr := csv.NewReader(rd)
+	cr.FieldsPerRecord = 2
+	cr.ReuseRecord = true
+
+	if _, err := cr.Read(); err != nil {
+		return nil, fmt.Errorf("%w: missing header: %w", ErrInvalidExport, err)
+	}
+
+	var rows []OwnershipRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+		}
+
+		token, err := strconv.ParseUint(record[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+		}
+
+		rows = append(rows, OwnershipRow{Token: token, Label: record[1]})
+	}
+}
+
+func readOwnershipBinary(rd io.Reader) ([]OwnershipRow, error) {
+	var (
+		le     = binary.LittleEndian
+		br     = bufio.NewReader(rd)
+		header [20]byte
+		row    [12]byte
+	)
+
+	if _, err := io.ReadFull(br, header[:]); err != nil || string(header[:8]) != exportMagic {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidExport)
+	}
+
+	if version := le.Uint32(header[8:]); version != ExportVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedExportVersion, version)
+	}
+
+	count := le.Uint64(header[12:])
+	rows := make([]OwnershipRow, 0, min(count, 1<<16))
+	for range count {
+		if _, err := io.ReadFull(br, row[:]); err != nil {
+			return nil, fmt.Errorf("%w: truncated row", ErrInvalidExport)
+		}
+
+		// copying grows the label as data arrives, so a corrupt length can't force a huge allocation
+		var label strings.Builder
+		if _, err := io.CopyN(&label, br, int64(le.Uint32(row[8:]))); err != nil {
+			return nil, fmt.Errorf("%w: truncated label", ErrInvalidExport)
+		}
+
+		rows = append(rows, OwnershipRow{Token: le.Uint64(row[:]), Label: label.String()})
+	}
+
+	if _, err := br.ReadByte(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected trailing data", ErrInvalidExport)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct {
+	err error
+}
+
+func (fw failingWriter) Write([]byte) (int, error) {
+	return 0, fw.err
+}
+
+type ExportSuite struct {
+	suite.Suite
+
+	ring *Ring[string]
+}
+
+func (suite *ExportSuite) SetupTest() {
+	suite.ring = Strings(services[:50]...).VNodes(20).Build()
+}
+
+// expectedRows returns the rows that an export of a ring should contain.
+func (suite *ExportSuite) expectedRows(r *Ring[string], label func(string) string) (rows []OwnershipRow) {
+	for token, svc := range r.Tokens() {
+		rows = append(rows, OwnershipRow{Token: token, Label: label(svc)})
+	}
+
+	return
+}
+
+func (suite *ExportSuite) roundTrip(r *Ring[string], format ExportFormat, label func(string) string) []OwnershipRow {
+	var b bytes.Buffer
+	suite.Require().NoError(r.ExportOwnership(&b, format, label))
+
+	rows, err := ReadOwnership(&b, format)
+	suite.Require().NoError(err)
+	return rows
+}
+
+func (suite *ExportSuite) TestRoundTrip() {
+	identity := func(svc string) string { return svc }
+	for _, format := range []ExportFormat{ExportCSV, ExportBinary} {
+		rows := suite.roundTrip(suite.ring, format, nil)
+		suite.Len(rows, 50*20)
+		suite.Equal(suite.expectedRows(suite.ring, identity), rows)
+
+		// tokens are sorted
+		for i := 1; i < len(rows); i++ {
+			suite.LessOrEqual(rows[i-1].Token, rows[i].Token)
+		}
+
+		empty, _ := Update(suite.ring)
+		suite.Empty(suite.roundTrip(empty, format, nil))
+	}
+}
+
+func (suite *ExportSuite) TestLabels() {
+	var calls int
+	label := func(svc string) string {
+		calls++
+		return `svc, "` + svc + `"` + "\nline"
+	}
+
+	for _, format := range []ExportFormat{ExportCSV, ExportBinary} {
+		calls = 0
+		rows := suite.roundTrip(suite.ring, format, label)
+
+		// the label function is called once per service, not once per node
+		suite.Equal(50, calls)
+		suite.Equal(suite.expectedRows(suite.ring, label), rows)
+	}
+}
+
+func (suite *ExportSuite) TestCSVEscaping() {
+	r := Strings("a,b", `c"d`).VNodes(1).Build()
+
+	var b bytes.Buffer
+	suite.Require().NoError(r.ExportOwnership(&b, ExportCSV, nil))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	suite.Require().Len(lines, 3)
+	suite.Equal("token,service", lines[0])
+	for _, line := range lines[1:] {
+		if strings.Contains(line, "a,b") {
+			suite.True(strings.HasSuffix(line, `,"a,b"`), line)
+		} else {
+			suite.True(strings.HasSuffix(line, `,"c""d"`), line)
+		}
+	}
+}
+
+func (suite *ExportSuite) TestBinaryHeader() {
+	var b bytes.Buffer
+	suite.Require().NoError(suite.ring.ExportOwnership(&b, ExportBinary, nil))
+	data := b.Bytes()
+
+	suite.Equal("MEDLEYOW", string(data[:8]))
+	suite.Equal(ExportVersion, binary.LittleEndian.Uint32(data[8:]))
+	suite.Equal(uint64(50*20), binary.LittleEndian.Uint64(data[12:]))
+
+	future := bytes.Clone(data)
+	binary.LittleEndian.PutUint32(future[8:], ExportVersion+1)
+	_, err := ReadOwnership(bytes.NewReader(future), ExportBinary)
+	suite.ErrorIs(err, ErrUnsupportedExportVersion)
+
+	for _, invalid := range [][]byte{nil, []byte("NOTMAGIC"), data[:len(data)-1], data[:30], append(bytes.Clone(data), 0)} {
+		_, err = ReadOwnership(bytes.NewReader(invalid), ExportBinary)
+		suite.ErrorIs(err, ErrInvalidExport)
+	}
+}
+
+func (suite *ExportSuite) TestInvalidCSV() {
+	for _, invalid := range []string{"", "token,service\n123\n", "token,service\nnotanumber,a\n"} {
+		_, err := ReadOwnership(strings.NewReader(invalid), ExportCSV)
+		suite.ErrorIs(err, ErrInvalidExport)
+	}
+}
+
+func (suite *ExportSuite) TestUnknownFormat() {
+	suite.ErrorIs(suite.ring.ExportOwnership(new(bytes.Buffer), ExportFormat(99), nil), ErrUnknownExportFormat)
+
+	_, err := ReadOwnership(new(bytes.Buffer), ExportFormat(99))
+	suite.ErrorIs(err, ErrUnknownExportFormat)
+}
+
+func (suite *ExportSuite) TestWriteError() {
+	expectedErr := errors.New("expected")
+	for _, format := range []ExportFormat{ExportCSV, ExportBinary} {
+		suite.ErrorIs(suite.ring.ExportOwnership(failingWriter{err: expectedErr}, format, nil), expectedErr)
+	}
+}
+
+func TestExport(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
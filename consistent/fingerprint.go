@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import "github.com/xmidt-org/medley"
+
+// mix64 is the splitmix64 finalizer, which spreads every input bit across the result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// fingerprint computes an order-independent digest of a ring's nodes along with its
+// vnodes, algorithm, and search policy. The algorithm is represented by its hashes of the probe objects,
+// as with sameAlgorithm, so equivalent custom algorithms have the same fingerprint.
+func fingerprint[S medley.Service](h hasher[S], ns nodes[S]) uint64 {
+	config := mix64(uint64(h.vnodes))
+	for _, probe := range algorithmProbes {
+		config = mix64(config ^ h.alg.Sum64String(probe))
+	}
+
+	if h.policy != Clockwise {
+		// the default policy isn't mixed in, so fingerprints from before policies existed are unchanged
+		config = mix64(config ^ uint64(h.policy))
+	}
+
+	// addition is commutative, so the order in which services were added doesn't matter
+	var sum uint64
+	for _, n := range ns {
+		sum += mix64(n.token)
+	}
+
+	return mix64(config ^ sum)
+}
+
+// index computes the lookup tokens, fingerprint, and collisions for this ring's nodes.
+// Every function that creates a Ring must call this once the nodes are in place.
+func (r *Ring[S]) index() {
+	r.tokens = r.nodes.tokens()
+	r.fingerprint = fingerprint(r.hasher, r.nodes)
+	r.collisions = countCollisions(r.tokens)
+}
+
+// Fingerprint returns a digest of this ring's membership and hash configuration. Rings with
+// the same services, vnodes, and algorithm have the same fingerprint, regardless of the order
+// in which services were added, so processes can cheaply check that they route identically
+// without exchanging their services. The fingerprint is computed when the ring is created,
+// and is stable across processes and releases.
+//
+// The digest covers every token on the ring, so services with per-service vnodes, e.g. due to
+// UpdateVNodes, are reflected as well. Different rings have the same fingerprint only by a
+// 64-bit hash collision.
+func (r *Ring[S]) Fingerprint() uint64 {
+	if r.hasher.serviceHasher == nil {
+		// the zero Ring was never indexed
+		return fingerprint(r.config(), nil)
+	}
+
+	return r.fingerprint
+}
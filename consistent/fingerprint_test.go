@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type FingerprintSuite struct {
+	suite.Suite
+}
+
+func (suite *FingerprintSuite) TestOrderIndependent() {
+	var (
+		expected = Strings(services[:20]...).Build().Fingerprint()
+		reversed = slices.Clone(services[:20])
+	)
+
+	slices.Reverse(reversed)
+	suite.Equal(expected, Strings(reversed...).Build().Fingerprint())
+	suite.Equal(expected, Strings(services[10:20]...).Services(services[:10]...).Build().Fingerprint())
+
+	// rings updated from different starting points agree
+	fromLarger, _ := Update(Strings(services[:50]...).Build(), services[:20]...)
+	fromOther, _ := Update(Strings(services[50:]...).Build(), reversed...)
+	suite.Equal(expected, fromLarger.Fingerprint())
+	suite.Equal(expected, fromOther.Fingerprint())
+
+	// merged rings agree with a ring built all at once
+	merged, err := Merge(Strings(services[:10]...).Build(), Strings(services[10:20]...).Build())
+	suite.Require().NoError(err)
+	suite.Equal(expected, merged.Fingerprint())
+}
+
+func (suite *FingerprintSuite) TestSensitivity() {
+	base := Strings(services[:20]...).Build().Fingerprint()
+
+	suite.NotEqual(base, Strings(services[:19]...).Build().Fingerprint())
+	suite.NotEqual(base, Strings(services[1:21]...).Build().Fingerprint())
+	suite.NotEqual(base, Strings(services[:20]...).VNodes(DefaultVNodes+1).Build().Fingerprint())
+	suite.NotEqual(base, Strings(services[:20]...).Algorithm(medley.Algorithm{New64: fnv.New64}).Build().Fingerprint())
+
+	// an empty ring still reflects its configuration
+	suite.NotEqual(Strings[string]().Build().Fingerprint(), Strings[string]().VNodes(10).Build().Fingerprint())
+
+	// an equivalent algorithm is the same configuration
+	suite.Equal(base, Strings(services[:20]...).Algorithm(medley.Algorithm{New64: medley.DefaultAlgorithm().New64}).Build().Fingerprint())
+}
+
+func (suite *FingerprintSuite) TestEmptyManagerRing() {
+	rm := NewRingManager[string](Strings[string]())
+	suite.Equal(Strings[string]().Build().Fingerprint(), rm.empty.Fingerprint())
+}
+
+func (suite *FingerprintSuite) TestStable() {
+	// these values must never change, since processes compare fingerprints across releases
+	suite.Equal(uint64(0xae492b6017299382), Strings[string]().Build().Fingerprint())
+	suite.Equal(uint64(0x20544fdb0ac1ebb4), Strings(services[:10]...).Build().Fingerprint())
+	suite.Equal(uint64(0x6fdcfcccb1f98c78), Strings("a.example.com", "b.example.com").VNodes(10).Build().Fingerprint())
+}
+
+func (suite *FingerprintSuite) TestCollisions() {
+	const sets = 5000
+
+	var (
+		random       = rand.New(rand.NewPCG(1, 2))
+		fingerprints = make(map[uint64]string, sets)
+	)
+
+	for range sets {
+		var membership []string
+		for _, svc := range services {
+			if random.IntN(2) == 0 {
+				membership = append(membership, svc)
+			}
+		}
+
+		key := strings.Join(membership, ",")
+		f := Strings(membership...).VNodes(5).Build().Fingerprint()
+		if existing, ok := fingerprints[f]; ok {
+			suite.Equal(existing, key, "fingerprint collision")
+		}
+
+		fingerprints[f] = key
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	suite.Run(t, new(FingerprintSuite))
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/medley"
+)
+
+// FlapDamper publishes updates of a Ring to a medley.UpdatableLocator, deferring the removal
+// of services so that a discovery source which briefly drops services doesn't migrate their
+// keys back and forth.
+//
+// Services that are added are hashed immediately. When a service disappears from a snapshot,
+// it is pending removal and keeps serving its keys for a grace period. If the service reappears
+// within that period, its removal is canceled and the Ring is not rebuilt. Otherwise, the
+// removal is applied by the first Set or Refresh after the grace period.
+//
+// A service that reappears and then disappears again starts a new, full grace period. So, a
+// service that keeps flapping faster than the grace period is never removed.
+//
+// Methods on this type are safe for concurrent usage.
+type FlapDamper[S medley.Service] struct {
+	dst   *medley.UpdatableLocator[S]
+	grace time.Duration
+	now   func() time.Time
+
+	lock     sync.Mutex
+	current  *Ring[S]
+	snapshot []S
+	pending  medley.Map[S, time.Time]
+}
+
+// NewFlapDamper creates a FlapDamper that publishes Rings to the given UpdatableLocator. The
+// initial Ring is published immediately, and its services are the initial snapshot. If grace
+// is nonpositive, removals are applied immediately. If now is nil, time.Now is used.
+func NewFlapDamper[S medley.Service](dst *medley.UpdatableLocator[S], initial *Ring[S], grace time.Duration, now func() time.Time) *FlapDamper[S] {
+	if now == nil {
+		now = time.Now
+	}
+
+	dst.Set(initial)
+	fd := &FlapDamper[S]{
+		dst:     dst,
+		grace:   grace,
+		now:     now,
+		current: initial,
+		pending: make(medley.Map[S, time.Time]),
+	}
+
+	for svc := range initial.All() {
+		fd.snapshot = append(fd.snapshot, svc)
+	}
+
+	return fd
+}
+
+// Set records a new snapshot of services from discovery and updates the Ring. The Ring contains
+// the snapshot's services along with any services whose removal is still pending. This method
+// returns true if a new Ring was published.
+func (fd *FlapDamper[S]) Set(services ...S) bool {
+	defer fd.lock.Unlock()
+	fd.lock.Lock()
+
+	fd.snapshot = append(fd.snapshot[:0], services...)
+	return fd.apply()
+}
+
+// Refresh reapplies the most recent snapshot, so that removals whose grace period has passed
+// take effect without waiting for the next snapshot. This method is typically called on a
+// timer. It returns true if a new Ring was published.
+func (fd *FlapDamper[S]) Refresh() bool {
+	defer fd.lock.Unlock()
+	fd.lock.Lock()
+
+	return fd.apply()
+}
+
+// apply updates the pending removals and the Ring from the current snapshot. The lock
+// must be held.
+func (fd *FlapDamper[S]) apply() (updated bool) {
+	var (
+		now      = fd.now()
+		incoming = make(medley.Map[S, bool], len(fd.snapshot))
+	)
+
+	for _, svc := range fd.snapshot {
+		incoming[svc] = true
+		delete(fd.pending, svc) // reappearing cancels any pending removal
+	}
+
+	services := append([]S(nil), fd.snapshot...)
+	for svc := range fd.current.All() {
+		if incoming[svc] {
+			continue
+		}
+
+		deadline, exists := fd.pending[svc]
+		if !exists {
+			deadline = now.Add(fd.grace)
+			fd.pending[svc] = deadline
+		}
+
+		if now.Before(deadline) {
+			services = append(services, svc)
+		} else {
+			delete(fd.pending, svc)
+		}
+	}
+
+	var next *Ring[S]
+	if next, updated = Update(fd.current, services...); updated {
+		fd.current = next
+		fd.dst.Set(next)
+	}
+
+	return
+}
+
+// Pending returns the services whose removal is pending, along with the time after which each
+// removal will be applied. The returned map is a copy.
+func (fd *FlapDamper[S]) Pending() medley.Map[S, time.Time] {
+	defer fd.lock.Unlock()
+	fd.lock.Lock()
+
+	return maps.Clone(fd.pending)
+}
+
+// Ring returns the most recently published Ring.
+func (fd *FlapDamper[S]) Ring() *Ring[S] {
+	defer fd.lock.Unlock()
+	fd.lock.Lock()
+
+	return fd.current
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+const testGrace = 30 * time.Second
+
+type FlapDamperSuite struct {
+	suite.Suite
+
+	current time.Time
+	ul      *medley.UpdatableLocator[string]
+	fd      *FlapDamper[string]
+}
+
+func (suite *FlapDamperSuite) SetupTest() {
+	suite.current = time.Now()
+	suite.ul = medley.NewUpdatableLocator[string](nil)
+	suite.fd = NewFlapDamper(suite.ul, Strings(services[:5]...).VNodes(10).Build(), testGrace, suite.now)
+	suite.Require().NotNil(suite.fd)
+}
+
+// now is the fake clock.
+func (suite *FlapDamperSuite) now() time.Time {
+	return suite.current
+}
+
+func (suite *FlapDamperSuite) advance(d time.Duration) {
+	suite.current = suite.current.Add(d)
+}
+
+// assertServices verifies the services of the current Ring.
+func (suite *FlapDamperSuite) assertServices(expected ...string) {
+	ring := suite.fd.Ring()
+	suite.Equal(len(expected), ring.Len())
+	for _, svc := range expected {
+		suite.True(ring.Contains(svc), "service=%s", svc)
+	}
+}
+
+func (suite *FlapDamperSuite) TestInitial() {
+	suite.assertServices(services[:5]...)
+	suite.Empty(suite.fd.Pending())
+
+	svc, err := suite.ul.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Contains(services[:5], svc)
+
+	// the initial snapshot is the initial Ring
+	suite.False(suite.fd.Refresh())
+	suite.False(suite.fd.Set(services[:5]...))
+}
+
+func (suite *FlapDamperSuite) TestFlapSuppressed() {
+	initial := suite.fd.Ring()
+
+	suite.False(suite.fd.Set(services[:4]...))
+	suite.Equal(
+		medley.Map[string, time.Time]{services[4]: suite.current.Add(testGrace)},
+		suite.fd.Pending(),
+	)
+
+	suite.advance(testGrace / 2)
+	suite.False(suite.fd.Refresh())
+	suite.False(suite.fd.Set(services[:5]...))
+	suite.Empty(suite.fd.Pending())
+
+	// no Ring was ever rebuilt
+	suite.Same(initial, suite.fd.Ring())
+	suite.assertServices(services[:5]...)
+}
+
+func (suite *FlapDamperSuite) TestRemoval() {
+	updated := suite.ul.Updated()
+	suite.False(suite.fd.Set(services[:4]...))
+
+	// the removal applies after, and not at, the end of the grace period
+	suite.advance(testGrace - time.Nanosecond)
+	suite.False(suite.fd.Refresh())
+	suite.Len(suite.fd.Pending(), 1)
+
+	suite.advance(time.Nanosecond)
+	suite.True(suite.fd.Refresh())
+	suite.Empty(suite.fd.Pending())
+	suite.assertServices(services[:4]...)
+
+	select {
+	case <-updated:
+	default:
+		suite.Fail("the new Ring should have been published")
+	}
+
+	// a removed service that reappears is an ordinary addition
+	suite.True(suite.fd.Set(services[:5]...))
+	suite.assertServices(services[:5]...)
+}
+
+func (suite *FlapDamperSuite) TestRepeatedFlapping() {
+	initial := suite.fd.Ring()
+
+	// each reappearance cancels the removal, and each disappearance starts a new window,
+	// so flapping faster than the grace period never rebuilds the Ring
+	for range 10 {
+		suite.False(suite.fd.Set(services[:4]...))
+		suite.Equal(suite.current.Add(testGrace), suite.fd.Pending()[services[4]])
+
+		suite.advance(testGrace * 3 / 4)
+		suite.False(suite.fd.Set(services[:5]...))
+		suite.Empty(suite.fd.Pending())
+
+		suite.advance(testGrace / 2)
+	}
+
+	suite.Same(initial, suite.fd.Ring())
+
+	// a repeated disappearance doesn't extend the window
+	suite.False(suite.fd.Set(services[:4]...))
+	suite.advance(testGrace / 2)
+	suite.False(suite.fd.Set(services[:4]...))
+	suite.advance(testGrace / 2)
+	suite.True(suite.fd.Set(services[:4]...))
+	suite.assertServices(services[:4]...)
+}
+
+func (suite *FlapDamperSuite) TestAddAndRemove() {
+	// one snapshot adds services[5] and drops services[0]
+	suite.True(suite.fd.Set(services[1:6]...))
+	suite.assertServices(services[:6]...)
+	suite.Equal([]string{services[0]}, slices.Collect(maps.Keys(suite.fd.Pending())))
+
+	// another drops services[1] and adds services[6], while services[0] is still pending
+	suite.advance(testGrace / 2)
+	suite.True(suite.fd.Set(services[2:7]...))
+	suite.assertServices(services[:7]...)
+	suite.ElementsMatch([]string{services[0], services[1]}, slices.Collect(maps.Keys(suite.fd.Pending())))
+
+	suite.advance(testGrace / 2)
+	suite.True(suite.fd.Refresh())
+	suite.assertServices(services[1:7]...)
+
+	suite.advance(testGrace / 2)
+	suite.True(suite.fd.Refresh())
+	suite.assertServices(services[2:7]...)
+	suite.Empty(suite.fd.Pending())
+}
+
+func (suite *FlapDamperSuite) TestNoGrace() {
+	fd := NewFlapDamper(suite.ul, Strings(services[:5]...).Build(), 0, nil)
+	suite.True(fd.Set(services[:4]...))
+	suite.Equal(4, fd.Ring().Len())
+	suite.Empty(fd.Pending())
+}
+
+func TestFlapDamper(t *testing.T) {
+	suite.Run(t, new(FlapDamperSuite))
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrGenerationNotRetained indicates that a lookup was for a time before the
+	// earliest generation that a GenerationalLocator still retains.
+	ErrGenerationNotRetained = errors.New("no ring generation retained for that time")
+
+	// ErrGenerationOutOfOrder indicates an attempt to advance a GenerationalLocator
+	// to a generation that takes effect before its newest generation.
+	ErrGenerationOutOfOrder = errors.New("ring generation is older than the newest generation")
+)
+
+// generation is a Ring along with the time it took effect.
+type generation[S medley.Service] struct {
+	at   time.Time
+	ring *Ring[S]
+}
+
+// find uses this generation's ring to find the service for an object. A nil ring has no services.
+func (g generation[S]) find(object []byte) (svc S, err error) {
+	if g.ring == nil {
+		err = medley.ErrNoServices
+		return
+	}
+
+	return g.ring.Find(object)
+}
+
+// GenerationalLocator is a medley.Locator that remembers a bounded history of Rings, so that
+// objects can be routed to the service that owned them at some time in the past. This is useful
+// for replaying events, which must be routed as they would have been when they occurred.
+//
+// Each generation is effective from the time it was advanced to until the next generation's
+// time. The newest generation is effective from its time onward.
+//
+// Methods on this type are safe for concurrent usage. Lookups take no locks.
+type GenerationalLocator[S medley.Service] struct {
+	maxGenerations int
+	maxAge         time.Duration
+
+	// now is the clock used for age-based eviction. Tests may replace it.
+	now func() time.Time
+
+	lock sync.Mutex
+
+	// history is the immutable, ascending sequence of retained generations
+	history atomic.Pointer[[]generation[S]]
+}
+
+// NewGenerationalLocator creates a GenerationalLocator with no generations. At most
+// maxGenerations generations are retained, and a generation is evicted once it has not
+// been effective for longer than maxAge. A nonpositive value means no limit. The newest
+// generation is never evicted.
+func NewGenerationalLocator[S medley.Service](maxGenerations int, maxAge time.Duration) *GenerationalLocator[S] {
+	return &GenerationalLocator[S]{
+		maxGenerations: maxGenerations,
+		maxAge:         maxAge,
+		now:            time.Now,
+	}
+}
+
+var _ medley.Locator[string] = (*GenerationalLocator[string])(nil)
+
+// Advance appends a generation that takes effect at the given time, then evicts any generations
+// beyond the retention limits. The time must not be before the newest generation's time, or this
+// method returns ErrGenerationOutOfOrder. A generation at the same time as the newest generation
+// replaces it. The ring may be nil, in which case there are no services during that generation.
+func (gl *GenerationalLocator[S]) Advance(ring *Ring[S], at time.Time) error {
+	defer gl.lock.Unlock()
+	gl.lock.Lock()
+
+	var current []generation[S]
+	if p := gl.history.Load(); p != nil {
+		current = *p
+	}
+
+	if n := len(current); n > 0 {
+		newest := current[n-1].at
+		if at.Before(newest) {
+			return fmt.Errorf("%w: %s is before %s", ErrGenerationOutOfOrder, at, newest)
+		} else if at.Equal(newest) {
+			current = current[:n-1]
+		}
+	}
+
+	// copy on write, since lookups may be reading the current history
+	next := make([]generation[S], 0, len(current)+1)
+	next = append(next, gl.retained(current, at)...)
+	next = append(next, generation[S]{at: at, ring: ring})
+	if gl.maxGenerations > 0 && len(next) > gl.maxGenerations {
+		next = next[len(next)-gl.maxGenerations:]
+	}
+
+	gl.history.Store(&next)
+	return nil
+}
+
+// retained returns the suffix of the given generations that are not evicted by age once a new
+// generation takes effect at the given time. Each generation stops being effective when the
+// next generation takes effect.
+func (gl *GenerationalLocator[S]) retained(current []generation[S], at time.Time) []generation[S] {
+	if gl.maxAge <= 0 {
+		return current
+	}
+
+	cutoff := gl.now().Add(-gl.maxAge)
+	i := sort.Search(len(current), func(i int) bool {
+		end := at
+		if i+1 < len(current) {
+			end = current[i+1].at
+		}
+
+		return !end.Before(cutoff)
+	})
+
+	return current[i:]
+}
+
+// Generations returns the number of retained generations.
+func (gl *GenerationalLocator[S]) Generations() int {
+	if p := gl.history.Load(); p != nil {
+		return len(*p)
+	}
+
+	return 0
+}
+
+// Find uses the newest generation to find the service for an object. If there are no
+// generations, this method returns medley.ErrNoServices.
+func (gl *GenerationalLocator[S]) Find(object []byte) (svc S, err error) {
+	p := gl.history.Load()
+	if p == nil || len(*p) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	history := *p
+	return history[len(history)-1].find(object)
+}
+
+// FindAt finds the service for an object using the generation that was effective at the
+// given time. If t is before the earliest retained generation, this method returns
+// ErrGenerationNotRetained. If there are no generations, this method returns medley.ErrNoServices.
+func (gl *GenerationalLocator[S]) FindAt(object []byte, t time.Time) (svc S, err error) {
+	p := gl.history.Load()
+	if p == nil || len(*p) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	history := *p
+
+	// i is the first generation that takes effect after t, so the previous one covers t
+	i := sort.Search(len(history), func(i int) bool {
+		return history[i].at.After(t)
+	})
+
+	if i == 0 {
+		err = fmt.Errorf("%w: %s is before %s", ErrGenerationNotRetained, t, history[0].at)
+		return
+	}
+
+	return history[i-1].find(object)
+}
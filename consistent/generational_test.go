@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type GenerationalLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+	now    time.Time
+	start  time.Time
+	rings  []*Ring[string]
+}
+
+func (suite *GenerationalLocatorSuite) SetupTest() {
+	suite.object = []byte("test")
+	suite.start = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.now = suite.start
+
+	// disjoint rings, so a result identifies the generation that produced it
+	suite.rings = nil
+	for i := 0; i < 5; i++ {
+		suite.rings = append(suite.rings, Strings(services[i*10:(i+1)*10]...).Build())
+	}
+}
+
+func (suite *GenerationalLocatorSuite) newGenerationalLocator(maxGenerations int, maxAge time.Duration) *GenerationalLocator[string] {
+	gl := NewGenerationalLocator[string](maxGenerations, maxAge)
+	gl.now = func() time.Time { return suite.now }
+	return gl
+}
+
+// at returns the time that the given generation takes effect in these tests.
+func (suite *GenerationalLocatorSuite) at(generation int) time.Time {
+	return suite.start.Add(time.Duration(generation) * time.Hour)
+}
+
+// advance adds the given generation at its time, moving the clock forward.
+func (suite *GenerationalLocatorSuite) advance(gl *GenerationalLocator[string], generation int) {
+	suite.now = suite.at(generation)
+	suite.Require().NoError(gl.Advance(suite.rings[generation], suite.now))
+}
+
+// assertFoundBy asserts that a lookup at the given time used the given generation.
+func (suite *GenerationalLocatorSuite) assertFoundBy(gl *GenerationalLocator[string], t time.Time, generation int) {
+	svc, err := gl.FindAt(suite.object, t)
+	suite.Require().NoError(err)
+	suite.True(suite.rings[generation].Contains(svc), "expected generation %d at %s", generation, t)
+}
+
+func (suite *GenerationalLocatorSuite) TestEmpty() {
+	gl := suite.newGenerationalLocator(0, 0)
+	suite.Zero(gl.Generations())
+
+	_, err := gl.Find(suite.object)
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	_, err = gl.FindAt(suite.object, suite.now)
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *GenerationalLocatorSuite) TestBoundaries() {
+	gl := suite.newGenerationalLocator(0, 0)
+	for g := range suite.rings {
+		suite.advance(gl, g)
+	}
+
+	suite.Equal(len(suite.rings), gl.Generations())
+	for g := range suite.rings {
+		// a generation is effective from its own time, inclusive
+		suite.assertFoundBy(gl, suite.at(g), g)
+		suite.assertFoundBy(gl, suite.at(g).Add(30*time.Minute), g)
+		if g > 0 {
+			suite.assertFoundBy(gl, suite.at(g).Add(-time.Nanosecond), g-1)
+		}
+	}
+
+	// the newest generation is effective from then on
+	suite.assertFoundBy(gl, suite.at(100), len(suite.rings)-1)
+
+	svc, err := gl.Find(suite.object)
+	suite.NoError(err)
+	suite.True(suite.rings[len(suite.rings)-1].Contains(svc))
+}
+
+func (suite *GenerationalLocatorSuite) TestBeforeEarliest() {
+	gl := suite.newGenerationalLocator(0, 0)
+	suite.advance(gl, 1)
+
+	_, err := gl.FindAt(suite.object, suite.at(1).Add(-time.Nanosecond))
+	suite.ErrorIs(err, ErrGenerationNotRetained)
+	suite.assertFoundBy(gl, suite.at(1), 1)
+}
+
+func (suite *GenerationalLocatorSuite) TestOutOfOrder() {
+	gl := suite.newGenerationalLocator(0, 0)
+	suite.advance(gl, 2)
+
+	suite.ErrorIs(gl.Advance(suite.rings[1], suite.at(1)), ErrGenerationOutOfOrder)
+	suite.Equal(1, gl.Generations())
+
+	// the same time replaces the newest generation
+	suite.NoError(gl.Advance(suite.rings[3], suite.at(2)))
+	suite.Equal(1, gl.Generations())
+	suite.assertFoundBy(gl, suite.at(2), 3)
+}
+
+func (suite *GenerationalLocatorSuite) TestNilRing() {
+	gl := suite.newGenerationalLocator(0, 0)
+	suite.Require().NoError(gl.Advance(nil, suite.at(0)))
+
+	_, err := gl.Find(suite.object)
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	_, err = gl.FindAt(suite.object, suite.at(0))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *GenerationalLocatorSuite) TestEvictByCount() {
+	gl := suite.newGenerationalLocator(3, 0)
+	for g := range suite.rings {
+		suite.advance(gl, g)
+		suite.LessOrEqual(gl.Generations(), 3)
+	}
+
+	suite.Equal(3, gl.Generations())
+	_, err := gl.FindAt(suite.object, suite.at(2).Add(-time.Nanosecond))
+	suite.ErrorIs(err, ErrGenerationNotRetained)
+
+	for g := 2; g < len(suite.rings); g++ {
+		suite.assertFoundBy(gl, suite.at(g), g)
+	}
+}
+
+func (suite *GenerationalLocatorSuite) TestEvictByAge() {
+	gl := suite.newGenerationalLocator(0, 90*time.Minute)
+	suite.advance(gl, 0)
+	suite.advance(gl, 1)
+	suite.Equal(2, gl.Generations())
+
+	// generation 0 stopped being effective at hour 1, which is within 90 minutes of hour 2
+	suite.advance(gl, 2)
+	suite.Equal(3, gl.Generations())
+
+	// now generation 0 has been ineffective for 2 hours
+	suite.advance(gl, 3)
+	suite.Equal(3, gl.Generations())
+	_, err := gl.FindAt(suite.object, suite.at(1).Add(-time.Nanosecond))
+	suite.ErrorIs(err, ErrGenerationNotRetained)
+	suite.assertFoundBy(gl, suite.at(1), 1)
+
+	// the newest generation is never evicted, however old it is
+	suite.now = suite.at(100)
+	suite.Require().NoError(gl.Advance(suite.rings[4], suite.at(4)))
+	suite.Equal(1, gl.Generations())
+	suite.assertFoundBy(gl, suite.at(4), 4)
+	suite.assertFoundBy(gl, suite.at(100), 4)
+}
+
+func (suite *GenerationalLocatorSuite) TestConcurrency() {
+	const readers = 8
+
+	var (
+		gl   = NewGenerationalLocator[string](3, 0)
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	suite.Require().NoError(gl.Advance(suite.rings[0], suite.at(0)))
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+
+				default:
+					gl.Find(suite.object)
+					gl.FindAt(suite.object, suite.at(2))
+				}
+			}
+		}()
+	}
+
+	for g := 1; g < 100; g++ {
+		suite.Require().NoError(gl.Advance(suite.rings[g%len(suite.rings)], suite.at(g)))
+	}
+
+	close(done)
+	wg.Wait()
+	suite.Equal(3, gl.Generations())
+}
+
+func TestGenerationalLocator(t *testing.T) {
+	suite.Run(t, new(GenerationalLocatorSuite))
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrUpdateRejected indicates that an update violated an UpdateGuard, e.g. because
+	// it would have left too few services.
+	ErrUpdateRejected = errors.New("update rejected")
+
+	// ErrInvalidGuard indicates that a GuardOption had an invalid value.
+	ErrInvalidGuard = errors.New("invalid update guard")
+)
+
+// UpdateGuard protects a Ring from updates that are most likely mistakes, such as an empty
+// service list delivered by a buggy discovery push. The zero value accepts every update.
+type UpdateGuard struct {
+	minServices int
+
+	// maxRemovedFraction only applies if limitRemoved is set, so that the zero value
+	// allows any number of removals
+	limitRemoved       bool
+	maxRemovedFraction float64
+}
+
+// GuardOption is a configurable option for an UpdateGuard.
+type GuardOption func(*UpdateGuard) error
+
+// MinServices rejects any update that would shrink a Ring to fewer than n services.
+// Updates that grow a Ring are always accepted, even if the Ring is still below n.
+func MinServices(n int) GuardOption {
+	return func(g *UpdateGuard) error {
+		if n < 0 {
+			return fmt.Errorf("MinServices: %w: %d is negative", ErrInvalidGuard, n)
+		}
+
+		g.minServices = n
+		return nil
+	}
+}
+
+// MaxRemovedFraction rejects any update that removes more than the given fraction of a
+// Ring's current services at once. The fraction must be in [0, 1], and one (1) allows
+// any number of removals.
+func MaxRemovedFraction(f float64) GuardOption {
+	return func(g *UpdateGuard) error {
+		if !(f >= 0 && f <= 1) {
+			return fmt.Errorf("MaxRemovedFraction: %w: %v is not in [0, 1]", ErrInvalidGuard, f)
+		}
+
+		g.limitRemoved, g.maxRemovedFraction = true, f
+		return nil
+	}
+}
+
+// NewUpdateGuard creates an UpdateGuard from a set of options.
+func NewUpdateGuard(opts ...GuardOption) (*UpdateGuard, error) {
+	g := new(UpdateGuard)
+	for _, o := range opts {
+		if err := o(g); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// check applies this guard to an update from current services to next services, of which
+// removed are no longer present.
+func (g *UpdateGuard) check(current, next, removed int) error {
+	if g == nil {
+		return nil
+	}
+
+	if next < g.minServices && next < current {
+		return fmt.Errorf("%w: %d services is below the minimum of %d", ErrUpdateRejected, next, g.minServices)
+	}
+
+	if current > 0 && g.limitRemoved {
+		if f := float64(removed) / float64(current); f > g.maxRemovedFraction {
+			return fmt.Errorf(
+				"%w: removing %d of %d services (%.2f) exceeds the maximum fraction of %.2f",
+				ErrUpdateRejected, removed, current, f, g.maxRemovedFraction,
+			)
+		}
+	}
+
+	return nil
+}
+
+// UpdateGuarded is like Update, but first checks the update against a guard. If the guard
+// rejects the update, the current Ring is returned along with an error wrapping
+// ErrUpdateRejected. A nil guard accepts every update. To bypass the guard, e.g. for an
+// intentional scale to zero, use Update.
+func UpdateGuarded[S medley.Service](current *Ring[S], g *UpdateGuard, services ...S) (next *Ring[S], updated bool, err error) {
+	var (
+		incoming = make(medley.Map[S, bool], len(services))
+		removed  int
+	)
+
+	for _, svc := range services {
+		incoming[svc] = true
+	}
+
+	for svc := range current.cache {
+		if !incoming[svc] {
+			removed++
+		}
+	}
+
+	if err = g.check(len(current.cache), len(incoming), removed); err != nil {
+		next = current
+		return
+	}
+
+	next, updated = Update(current, services...)
+	return
+}
+
+// GuardedUpdater publishes guarded updates of a Ring to a medley.UpdatableLocator. A rejected
+// update leaves the UpdatableLocator untouched, so the current Ring keeps serving.
+//
+// Methods on this type are safe for concurrent usage.
+type GuardedUpdater[S medley.Service] struct {
+	dst   *medley.UpdatableLocator[S]
+	guard *UpdateGuard
+
+	lock    sync.Mutex
+	current *Ring[S]
+}
+
+// NewGuardedUpdater creates a GuardedUpdater that publishes Rings to the given UpdatableLocator.
+// The initial Ring is published immediately.
+func NewGuardedUpdater[S medley.Service](dst *medley.UpdatableLocator[S], initial *Ring[S], g *UpdateGuard) *GuardedUpdater[S] {
+	dst.Set(initial)
+	return &GuardedUpdater[S]{
+		dst:     dst,
+		guard:   g,
+		current: initial,
+	}
+}
+
+// Set updates the Ring with the given services, subject to this updater's guard. This method
+// returns true if a new Ring was published. If the guard rejects the update, the error wraps
+// ErrUpdateRejected.
+func (gu *GuardedUpdater[S]) Set(services ...S) (bool, error) {
+	defer gu.lock.Unlock()
+	gu.lock.Lock()
+
+	next, updated, err := UpdateGuarded(gu.current, gu.guard, services...)
+	if updated {
+		gu.current = next
+		gu.dst.Set(next)
+	}
+
+	return updated, err
+}
+
+// Force is like Set, but bypasses the guard, e.g. for an intentional scale to zero.
+func (gu *GuardedUpdater[S]) Force(services ...S) bool {
+	defer gu.lock.Unlock()
+	gu.lock.Lock()
+
+	next, updated := Update(gu.current, services...)
+	if updated {
+		gu.current = next
+		gu.dst.Set(next)
+	}
+
+	return updated
+}
+
+// Ring returns the most recently published Ring.
+func (gu *GuardedUpdater[S]) Ring() *Ring[S] {
+	defer gu.lock.Unlock()
+	gu.lock.Lock()
+
+	return gu.current
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type UpdateGuardSuite struct {
+	suite.Suite
+
+	initial *Ring[string]
+	guard   *UpdateGuard
+}
+
+func (suite *UpdateGuardSuite) SetupTest() {
+	suite.initial = Strings(services[:10]...).VNodes(10).Build()
+
+	var err error
+	suite.guard, err = NewUpdateGuard(MinServices(3), MaxRemovedFraction(0.3))
+	suite.Require().NoError(err)
+}
+
+// assertUntouched verifies that nothing was published to an UpdatableLocator.
+func (suite *UpdateGuardSuite) assertUntouched(updated <-chan struct{}) {
+	select {
+	case <-updated:
+		suite.Fail("the UpdatableLocator should not have been set")
+	default:
+	}
+}
+
+func (suite *UpdateGuardSuite) TestInvalidOptions() {
+	for _, o := range []GuardOption{MinServices(-1), MaxRemovedFraction(-0.1), MaxRemovedFraction(1.5), MaxRemovedFraction(math.NaN())} {
+		g, err := NewUpdateGuard(o)
+		suite.ErrorIs(err, ErrInvalidGuard)
+		suite.Nil(g)
+	}
+}
+
+func (suite *UpdateGuardSuite) TestNilGuard() {
+	next, updated, err := UpdateGuarded(suite.initial, nil)
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Zero(next.Len())
+}
+
+func (suite *UpdateGuardSuite) TestZeroValue() {
+	for _, g := range []*UpdateGuard{new(UpdateGuard), {}} {
+		next, updated, err := UpdateGuarded(suite.initial, g, services[7:10]...)
+		suite.NoError(err)
+		suite.True(updated)
+		suite.Equal(3, next.Len())
+
+		next, updated, err = UpdateGuarded(suite.initial, g)
+		suite.NoError(err)
+		suite.True(updated)
+		suite.Zero(next.Len())
+	}
+}
+
+func (suite *UpdateGuardSuite) TestNoRemovals() {
+	g, err := NewUpdateGuard(MaxRemovedFraction(0))
+	suite.Require().NoError(err)
+
+	next, updated, err := UpdateGuarded(suite.initial, g, services[1:10]...)
+	suite.ErrorIs(err, ErrUpdateRejected)
+	suite.False(updated)
+	suite.Same(suite.initial, next)
+
+	_, updated, err = UpdateGuarded(suite.initial, g, services[:11]...)
+	suite.NoError(err)
+	suite.True(updated)
+}
+
+func (suite *UpdateGuardSuite) TestEmpty() {
+	next, updated, err := UpdateGuarded(suite.initial, suite.guard)
+	suite.ErrorIs(err, ErrUpdateRejected)
+	suite.ErrorContains(err, "minimum of 3")
+	suite.False(updated)
+	suite.Same(suite.initial, next)
+}
+
+func (suite *UpdateGuardSuite) TestMassRemoval() {
+	// removing 4 of 10 services exceeds the fraction, even though new services are added
+	next, updated, err := UpdateGuarded(suite.initial, suite.guard, services[4:20]...)
+	suite.ErrorIs(err, ErrUpdateRejected)
+	suite.ErrorContains(err, "removing 4 of 10")
+	suite.False(updated)
+	suite.Same(suite.initial, next)
+}
+
+func (suite *UpdateGuardSuite) TestGradual() {
+	var (
+		current = suite.initial
+		updated bool
+		err     error
+	)
+
+	// shrink three services at a time down to the minimum, which is always within the fraction
+	for _, size := range []int{7, 5, 4, 3} {
+		current, updated, err = UpdateGuarded(current, suite.guard, services[:size]...)
+		suite.Require().NoError(err)
+		suite.True(updated)
+		suite.Equal(size, current.Len())
+	}
+
+	_, _, err = UpdateGuarded(current, suite.guard, services[:2]...)
+	suite.ErrorIs(err, ErrUpdateRejected)
+
+	// growing is always allowed, even below the minimum
+	empty, _ := Update(suite.initial)
+	current, updated, err = UpdateGuarded(empty, suite.guard, services[0])
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Equal(1, current.Len())
+
+	// an unchanged set of services is not an update
+	current, updated, err = UpdateGuarded(suite.initial, suite.guard, services[:10]...)
+	suite.NoError(err)
+	suite.False(updated)
+	suite.Same(suite.initial, current)
+}
+
+func (suite *UpdateGuardSuite) TestGuardedUpdater() {
+	var (
+		ul = medley.NewUpdatableLocator[string](nil)
+		gu = NewGuardedUpdater(ul, suite.initial, suite.guard)
+	)
+
+	suite.Same(suite.initial, gu.Ring())
+
+	// rejected updates don't touch the locator
+	notify := ul.Updated()
+	updated, err := gu.Set()
+	suite.ErrorIs(err, ErrUpdateRejected)
+	suite.False(updated)
+	suite.Same(suite.initial, gu.Ring())
+	suite.assertUntouched(notify)
+
+	updated, err = gu.Set(services[1:10]...)
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Equal(9, gu.Ring().Len())
+
+	svc, err := ul.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Contains(services[1:10], svc)
+
+	// forcing bypasses the guard
+	suite.True(gu.Force())
+	suite.Zero(gu.Ring().Len())
+
+	_, err = ul.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.False(gu.Force())
+}
+
+func TestUpdateGuard(t *testing.T) {
+	suite.Run(t, new(UpdateGuardSuite))
+}
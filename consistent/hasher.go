@@ -5,16 +5,99 @@ package consistent
 
 import (
 	"bytes"
+	"cmp"
+	"errors"
+	"reflect"
+	"slices"
 	"strconv"
 
 	"github.com/xmidt-org/medley"
 )
 
+const (
+	// DefaultMaxServiceHashBytes is the default limit on the number of bytes a
+	// ServiceHasher may write for a single service.
+	DefaultMaxServiceHashBytes = 1 << 20
+)
+
+var (
+	// ErrServiceHashTooLarge indicates that a ServiceHasher wrote more bytes for a
+	// service than the limit set by Builder.MaxServiceHashBytes.
+	ErrServiceHashTooLarge = errors.New("service hash bytes exceed the limit")
+)
+
 // hasher implements all the low-level hashing logic for hash Rings.
 type hasher[S medley.Service] struct {
 	vnodes        int
 	alg           medley.Algorithm
 	serviceHasher medley.ServiceHasher[S]
+
+	// maxBytes is the limit on the hash bytes for each service. A nonpositive
+	// value means there is no limit.
+	maxBytes int
+
+	// onTruncate is invoked, if set, when a service's hash bytes are truncated.
+	onTruncate func(S)
+
+	// autoImbalance, if positive, is the target imbalance used to tune vnodes
+	// to the number of services. See TuneVNodes.
+	autoImbalance float64
+
+	// policy determines which token owns a key. It doesn't affect the tokens.
+	policy SearchPolicy
+
+	// shared, if set, memoizes service nodes across rings. It doesn't affect the tokens.
+	shared *SharedCache[S]
+
+	// verifySample, if positive, is the number of retained services whose hash bytes
+	// UpdateE compares to their digests. It doesn't affect the tokens.
+	verifySample int
+
+	// maxNodes, if positive, is the limit on the number of nodes in a ring. When
+	// reduceToFit is set, or a ring can't fail, vnodes are reduced to fit the limit.
+	maxNodes    int
+	reduceToFit bool
+
+	// requested is the number of vnodes before any reduction to fit maxNodes, so that
+	// later rings can grow back. Zero (0) means no reduction has happened.
+	requested int
+}
+
+// tunedVNodes returns the vnodes for a ring with the given number of services, before any
+// reduction to fit maxNodes. Unless this hasher is auto-tuned, that is just the requested vnodes.
+func (h hasher[S]) tunedVNodes(services int) int {
+	switch {
+	case h.autoImbalance > 0:
+		return TuneVNodes(services, h.autoImbalance)
+
+	case h.requested > 0:
+		return h.requested
+
+	default:
+		return h.vnodes
+	}
+}
+
+// withDefaults returns a copy of this hasher with defaults applied to any configuration
+// that wasn't set, so the returned hasher is ready to use.
+func (h hasher[S]) withDefaults() hasher[S] {
+	if h.vnodes < 1 {
+		h.vnodes = DefaultVNodes
+	}
+
+	if reflect.ValueOf(h.alg).IsZero() {
+		h.alg = medley.DefaultAlgorithm()
+	}
+
+	if h.serviceHasher == nil {
+		h.serviceHasher = medley.DefaultServiceHasher[S]
+	}
+
+	if h.maxBytes < 1 {
+		h.maxBytes = DefaultMaxServiceHashBytes
+	}
+
+	return h
 }
 
 // sum64 uses this hasher's algorithm to compute the hash token for
@@ -23,44 +106,171 @@ func (h hasher[S]) sum64(object []byte) uint64 {
 	return h.alg.Sum64Bytes(object)
 }
 
-// ringSize returns the total number of nodes required to store the given
-// number of services.
-func (h hasher[S]) ringSize(serviceCount int) int {
-	return h.vnodes * serviceCount
+// sameConfig tests if this hasher has the same configuration as another hasher.
+// Function fields are compared by their code pointers, which means that two distinct
+// closures created from the same function literal will be considered the same.
+func (h hasher[S]) sameConfig(other hasher[S]) bool {
+	return h.vnodes == other.vnodes &&
+		h.maxBytes == other.maxBytes &&
+		h.autoImbalance == other.autoImbalance &&
+		h.policy == other.policy &&
+		funcPointer(h.alg.New64) == funcPointer(other.alg.New64) &&
+		funcPointer(h.alg.Sum64) == funcPointer(other.alg.Sum64) &&
+		funcPointer(h.serviceHasher) == funcPointer(other.serviceHasher)
 }
 
-// base computes the hash bytes for a service used as the base
-// for each computed token.
-func (h hasher[S]) base(service S) []byte {
-	var b bytes.Buffer
-	h.serviceHasher(&b, service)
-	return b.Bytes()
+// funcPointer returns the code pointer for a function value. A nil function
+// yields zero (0).
+func funcPointer(f any) uintptr {
+	v := reflect.ValueOf(f)
+	if v.IsNil() {
+		return 0
+	}
+
+	return v.Pointer()
 }
 
-// serviceNodes computes the individual ring nodes for a single service.
-func (h hasher[S]) serviceNodes(svc S) (snodes nodes[S]) {
-	snodes = make(nodes[S], 0, h.vnodes)
+// algorithmProbes are the objects hashed to determine if two algorithms are the same.
+var algorithmProbes = [...]string{"", "a", "medley", "0123456789abcdef0123456789abcdef"}
+
+// sameAlgorithm tests if two algorithms produce the same hashes for a fixed set of
+// probe objects. Unlike sameConfig, this treats distinct but equivalent functions as the same.
+func sameAlgorithm(a, b medley.Algorithm) bool {
+	for _, probe := range algorithmProbes {
+		if a.Sum64String(probe) != b.Sum64String(probe) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// limitedBuffer accumulates service hash bytes up to a limit. Writes beyond the
+// limit are truncated and fail with ErrServiceHashTooLarge, so that well-behaved
+// ServiceHashers stop early.
+type limitedBuffer struct {
+	bytes.Buffer
+	remaining int
+	truncated bool
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if len(p) <= lb.remaining {
+		lb.remaining -= len(p)
+		return lb.Buffer.Write(p)
+	}
+
+	n, _ := lb.Buffer.Write(p[:lb.remaining])
+	lb.remaining = 0
+	lb.truncated = true
+	return n, ErrServiceHashTooLarge
+}
+
+// base computes the hash bytes for a service used as the base for each computed token.
+// If the service's hash bytes exceed this hasher's limit, the base is truncated to the
+// limit and this method returns true.
+func (h hasher[S]) base(service S) ([]byte, bool) {
+	if h.maxBytes < 1 {
+		var b bytes.Buffer
+		h.serviceHasher(&b, service)
+		return b.Bytes(), false
+	}
+
+	lb := limitedBuffer{remaining: h.maxBytes}
+	h.serviceHasher(&lb, service)
+	return lb.Bytes(), lb.truncated
+}
+
+// serviceNodes computes the given number of individual ring nodes for a single service.
+// The returned nodes are sorted by token, so that rings can merge them rather than sorting
+// every node. A service's tokens for fewer vnodes are always a subset of its tokens for more vnodes.
+//
+// All of a service's nodes are allocated in a single backing array, which greatly
+// reduces the number of objects the garbage collector must track. Nodes are never
+// modified after creation, so rings can freely share and reorder pointers to them.
+//
+// If the service's hash bytes were truncated, the nodes are computed from the truncated
+// bytes and this method returns true. The onTruncate hook is left to the caller.
+//
+// If this hasher has a matching SharedCache, and the cached nodes have the same check as
+// the nodes this hasher computes, the nodes come from that cache.
+func (h hasher[S]) serviceNodes(svc S, vnodes int) (snodes nodes[S], truncated bool) {
+	if h.shared != nil && h.shared.matches(h, vnodes) {
+		base, over := h.base(svc)
+		if snodes, truncated, ok := h.shared.serviceNodes(svc, h.check(base)); ok {
+			return snodes, truncated
+		}
+
+		return h.baseNodes(svc, base, 0, vnodes), over
+	}
+
+	return h.incrementNodes(svc, 0, vnodes)
+}
+
+// nodeCheck identifies the nodes computed for a service's hash bytes. It is the service's
+// rank and the token of its first vnode increment, so two hashers with a different algorithm
+// or ServiceHasher have different checks for a service, even if their functions share code.
+type nodeCheck struct {
+	rank  uint64
+	token uint64
+}
+
+// check computes the nodeCheck for a service's hash bytes.
+func (h hasher[S]) check(base []byte) nodeCheck {
+	hash := h.alg.New64()
+	hash.Write([]byte("0="))
+	hash.Write(base)
+	return nodeCheck{rank: h.sum64(base), token: hash.Sum64()}
+}
+
+// incrementNodes is like serviceNodes, but only computes the nodes for the vnode increments
+// in [from, to). A service's nodes for n vnodes are exactly its nodes for the increments in [0, n).
+func (h hasher[S]) incrementNodes(svc S, from, to int) (snodes nodes[S], truncated bool) {
+	base, truncated := h.base(svc)
+	snodes = h.baseNodes(svc, base, from, to)
+	return
+}
+
+// baseNodes is like incrementNodes, but computes the nodes from the service's hash bytes.
+func (h hasher[S]) baseNodes(svc S, base []byte, from, to int) (snodes nodes[S]) {
+	count := max(0, to-from)
+	snodes = make(nodes[S], 0, count)
+	backing := make([]node[S], count)
 
 	var (
 		hash = h.alg.New64()
-		base = h.base(svc)
 
 		// a stack-allocated prefixBuffer to minimize allocations for the prefix bytes
 		prefixBuffer [8]byte
 
 		// prefix can grow beyond the initial buffer, but it's unlikely
 		prefix = prefixBuffer[:]
+
+		// rank orders this service's nodes against other services' colliding nodes
+		rank = h.sum64(base)
 	)
 
-	for increment := int64(0); increment < int64(h.vnodes); increment++ {
+	for i := range backing {
 		hash.Reset()
-		prefix = strconv.AppendInt(prefix[:0], increment, 10)
+		prefix = strconv.AppendInt(prefix[:0], int64(from+i), 10)
 		prefix = append(prefix, '=')
 		hash.Write(prefix)
 		hash.Write(base)
 
-		snodes = append(snodes, &node[S]{token: hash.Sum64(), service: svc})
+		backing[i] = node[S]{token: hash.Sum64(), rank: rank, service: svc}
+		snodes = append(snodes, &backing[i])
 	}
 
+	slices.SortFunc(snodes, func(a, b *node[S]) int {
+		return cmp.Compare(a.token, b.token)
+	})
+
 	return
 }
+
+// truncated reports a service whose hash bytes were truncated to the onTruncate hook, if any.
+func (h hasher[S]) truncated(svc S) {
+	if h.onTruncate != nil {
+		h.onTruncate(svc)
+	}
+}
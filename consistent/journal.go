@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultJournalSize is the number of events a MemoryJournal retains when
+	// no size is supplied.
+	DefaultJournalSize = 256
+)
+
+// ChangeOp identifies the kind of membership change described by a ChangeEvent.
+type ChangeOp int
+
+const (
+	// ChangeSet indicates that a tenant's services were replaced, which may have
+	// created the tenant.
+	ChangeSet ChangeOp = iota
+
+	// ChangeDelete indicates that a tenant was deleted.
+	ChangeDelete
+)
+
+// String returns a human-readable name for this operation.
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangeSet:
+		return "set"
+
+	case ChangeDelete:
+		return "delete"
+
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent describes a single membership change applied by a RingManager.
+type ChangeEvent[S medley.Service] struct {
+	// Time is when the change was applied.
+	Time time.Time
+
+	// Op is the kind of change.
+	Op ChangeOp
+
+	// Tenant is the name of the tenant that changed.
+	Tenant string
+
+	// Added holds the services that joined the tenant's Ring, in no particular order.
+	Added []S
+
+	// Removed holds the services that left the tenant's Ring, in no particular order.
+	Removed []S
+}
+
+// Journal receives a ChangeEvent for each membership change applied by a RingManager.
+// Events are recorded synchronously while the tenant is locked, so a Journal sees the
+// changes to any one tenant in the order they were applied. Implementations should be fast.
+type Journal[S medley.Service] interface {
+	Record(ChangeEvent[S])
+}
+
+// MemoryJournal is a Journal that retains the most recent events in memory, which is
+// useful for audit endpoints and tests. Older events are discarded once the journal is full.
+//
+// Methods on this type are safe for concurrent usage.
+type MemoryJournal[S medley.Service] struct {
+	lock   sync.Mutex
+	events []ChangeEvent[S]
+	next   int
+	full   bool
+}
+
+var _ Journal[string] = (*MemoryJournal[string])(nil)
+
+// NewMemoryJournal creates a MemoryJournal that retains at most size events.
+// If size is nonpositive, DefaultJournalSize is used.
+func NewMemoryJournal[S medley.Service](size int) *MemoryJournal[S] {
+	if size < 1 {
+		size = DefaultJournalSize
+	}
+
+	return &MemoryJournal[S]{
+		events: make([]ChangeEvent[S], size),
+	}
+}
+
+// Record adds an event to this journal, discarding the oldest event if the journal is full.
+func (mj *MemoryJournal[S]) Record(e ChangeEvent[S]) {
+	defer mj.lock.Unlock()
+	mj.lock.Lock()
+
+	mj.events[mj.next] = e
+	mj.next = (mj.next + 1) % len(mj.events)
+	mj.full = mj.full || mj.next == 0
+}
+
+// Snapshot returns the retained events, oldest first.
+func (mj *MemoryJournal[S]) Snapshot() []ChangeEvent[S] {
+	defer mj.lock.Unlock()
+	mj.lock.Lock()
+
+	if !mj.full {
+		return append([]ChangeEvent[S](nil), mj.events[:mj.next]...)
+	}
+
+	snapshot := make([]ChangeEvent[S], 0, len(mj.events))
+	snapshot = append(snapshot, mj.events[mj.next:]...)
+	return append(snapshot, mj.events[:mj.next]...)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalSuite struct {
+	suite.Suite
+
+	now     time.Time
+	journal *MemoryJournal[string]
+	rm      *RingManager[string]
+}
+
+func (suite *JournalSuite) SetupTest() {
+	suite.now = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.journal = NewMemoryJournal[string](0)
+	suite.rm = NewRingManager(Strings[string]().VNodes(50))
+	suite.rm.SetJournal(suite.journal, func() time.Time {
+		suite.now = suite.now.Add(time.Second)
+		return suite.now
+	})
+}
+
+// last returns the most recent event, which must exist.
+func (suite *JournalSuite) last() ChangeEvent[string] {
+	events := suite.journal.Snapshot()
+	suite.Require().NotEmpty(events)
+	return events[len(events)-1]
+}
+
+func (suite *JournalSuite) assertEvent(op ChangeOp, tenant string, added, removed []string) {
+	e := suite.last()
+	suite.Equal(suite.now, e.Time)
+	suite.Equal(op, e.Op)
+	suite.Equal(tenant, e.Tenant)
+	suite.ElementsMatch(added, e.Added)
+	suite.ElementsMatch(removed, e.Removed)
+}
+
+func (suite *JournalSuite) TestChangeOp() {
+	suite.Equal("set", ChangeSet.String())
+	suite.Equal("delete", ChangeDelete.String())
+	suite.Equal("unknown", ChangeOp(-1).String())
+}
+
+func (suite *JournalSuite) TestSet() {
+	suite.rm.Set("tenant", services[:4]...)
+	suite.assertEvent(ChangeSet, "tenant", services[:4], nil)
+
+	// a partial add only reports the new services
+	suite.rm.Set("tenant", services[:6]...)
+	suite.assertEvent(ChangeSet, "tenant", services[4:6], nil)
+
+	// a partial remove only reports the departed services
+	suite.rm.Set("tenant", services[1:6]...)
+	suite.assertEvent(ChangeSet, "tenant", nil, services[:1])
+
+	suite.rm.Set("tenant", services[3:8]...)
+	suite.assertEvent(ChangeSet, "tenant", services[6:8], services[1:3])
+	suite.Len(suite.journal.Snapshot(), 4)
+}
+
+func (suite *JournalSuite) TestNoOps() {
+	suite.rm.Set("tenant", services[:4]...)
+	suite.rm.Set("tenant", services[:4]...)
+	suite.rm.Set("tenant", services[3], services[2], services[1], services[0])
+
+	// an empty tenant is created without any change to its services
+	suite.rm.Set("empty")
+
+	rejected := errors.New("rejected")
+	suite.rm.SetIf("tenant", func(*Ring[string]) error { return rejected }, services[:8]...)
+	suite.rm.SetIf("new", func(*Ring[string]) error { return rejected }, services[:8]...)
+	suite.rm.Delete("nosuch")
+
+	suite.Len(suite.journal.Snapshot(), 1)
+}
+
+func (suite *JournalSuite) TestDelete() {
+	suite.rm.Set("tenant", services[:4]...)
+	suite.True(suite.rm.Delete("tenant"))
+	suite.assertEvent(ChangeDelete, "tenant", nil, services[:4])
+
+	suite.rm.Set("empty")
+	suite.True(suite.rm.Delete("empty"))
+	suite.assertEvent(ChangeDelete, "empty", nil, nil)
+	suite.Len(suite.journal.Snapshot(), 3)
+}
+
+func (suite *JournalSuite) TestNoJournal() {
+	suite.rm.SetJournal(nil, nil)
+	suite.rm.Set("tenant", services[:4]...)
+	suite.rm.Delete("tenant")
+	suite.Empty(suite.journal.Snapshot())
+}
+
+func (suite *JournalSuite) TestRetention() {
+	mj := NewMemoryJournal[string](3)
+	suite.Empty(mj.Snapshot())
+
+	for i := range 3 {
+		mj.Record(ChangeEvent[string]{Tenant: fmt.Sprintf("tenant%d", i)})
+	}
+
+	tenants := func() (names []string) {
+		for _, e := range mj.Snapshot() {
+			names = append(names, e.Tenant)
+		}
+
+		return
+	}
+
+	suite.Equal([]string{"tenant0", "tenant1", "tenant2"}, tenants())
+
+	for i := 3; i < 8; i++ {
+		mj.Record(ChangeEvent[string]{Tenant: fmt.Sprintf("tenant%d", i)})
+		suite.Equal(
+			[]string{fmt.Sprintf("tenant%d", i-2), fmt.Sprintf("tenant%d", i-1), fmt.Sprintf("tenant%d", i)},
+			tenants(),
+		)
+	}
+
+	suite.Len(NewMemoryJournal[string](0).events, DefaultJournalSize)
+}
+
+func TestJournal(t *testing.T) {
+	suite.Run(t, new(JournalSuite))
+}
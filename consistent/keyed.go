@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"reflect"
+
+	"github.com/xmidt-org/medley"
+)
+
+// KeyedBuilder is a fluent builder for KeyedRings. Use KeyedServices to start a
+// build chain.
+type KeyedBuilder[S any, K medley.Service] struct {
+	builder Builder[K]
+	key     func(S) K
+	values  map[K]S
+}
+
+// KeyedServices starts a fluent chain for a KeyedRing, which holds service values that
+// need not be comparable, such as structs with map or slice fields. The key function
+// projects each service value onto a comparable key, and the ring hashes only that key.
+//
+// More services may be added via the builder's Services method.
+func KeyedServices[S any, K medley.Service](key func(S) K, services ...S) *KeyedBuilder[S, K] {
+	kb := &KeyedBuilder[S, K]{
+		key: key,
+	}
+
+	return kb.Services(services...)
+}
+
+// VNodes sets the number of hash nodes used per service. By default,
+// DefaultVNodes is used.
+func (kb *KeyedBuilder[S, K]) VNodes(v int) *KeyedBuilder[S, K] {
+	kb.builder.VNodes(v)
+	return kb
+}
+
+// Algorithm sets the medley hash algorithm to use. By default,
+// medley.Murmur3 is used.
+func (kb *KeyedBuilder[S, K]) Algorithm(a medley.Algorithm) *KeyedBuilder[S, K] {
+	kb.builder.Algorithm(a)
+	return kb
+}
+
+// ServiceHasher establishes the sequence of bytes used to hash a service's key.
+// By default, medley.DefaultServiceHasher is used.
+func (kb *KeyedBuilder[S, K]) ServiceHasher(sh medley.ServiceHasher[K]) *KeyedBuilder[S, K] {
+	kb.builder.ServiceHasher(sh)
+	return kb
+}
+
+// Services adds services to the KeyedRing that is built by this builder. Multiple
+// uses of this method are cumulative. If more than one service has the same key,
+// the last one wins.
+//
+// When Build is called, the set of services known to this builder is reset.
+func (kb *KeyedBuilder[S, K]) Services(services ...S) *KeyedBuilder[S, K] {
+	if kb.values == nil {
+		kb.values = make(map[K]S, len(services))
+	}
+
+	for _, svc := range services {
+		k := kb.key(svc)
+		kb.values[k] = svc
+		kb.builder.Services(k)
+	}
+
+	return kb
+}
+
+// Build creates a brand new KeyedRing instance. The set of services known to this
+// builder is reset.
+func (kb *KeyedBuilder[S, K]) Build() *KeyedRing[S, K] {
+	kr := &KeyedRing[S, K]{
+		ring:   kb.builder.Build(),
+		key:    kb.key,
+		values: kb.values,
+	}
+
+	if kr.values == nil {
+		kr.values = make(map[K]S)
+	}
+
+	kb.values = nil
+	return kr
+}
+
+// KeyedRing is a hash Ring of service values that are identified by a comparable key.
+// The underlying Ring hashes only the keys, so any other fields of a service value
+// have no effect on where objects are located.
+//
+// Because its service values need not be comparable, a KeyedRing is not a
+// medley.Locator. Use Ring to obtain the underlying Locator of keys.
+//
+// KeyedRings are immutable once created. To handle an updated set of services,
+// use the UpdateKeyed function.
+type KeyedRing[S any, K medley.Service] struct {
+	ring   *Ring[K]
+	key    func(S) K
+	values map[K]S
+}
+
+// Find performs a hash on the given object and returns the full value of the nearest
+// service. If this ring is empty, this method returns medley.ErrNoServices.
+func (kr *KeyedRing[S, K]) Find(object []byte) (svc S, err error) {
+	var k K
+	if k, err = kr.ring.Find(object); err == nil {
+		svc = kr.values[k]
+	}
+
+	return
+}
+
+// Value returns the service value with the given key, if one exists in this ring.
+func (kr *KeyedRing[S, K]) Value(k K) (svc S, exists bool) {
+	svc, exists = kr.values[k]
+	return
+}
+
+// Len returns the number of services hashed by this ring.
+func (kr *KeyedRing[S, K]) Len() int {
+	return kr.ring.Len()
+}
+
+// Ring returns the underlying Ring of service keys.
+func (kr *KeyedRing[S, K]) Ring() *Ring[K] {
+	return kr.ring
+}
+
+// UpdateKeyed checks if a set of services constitutes an update to the given KeyedRing.
+// Services are matched to those in the current ring by key, so a service whose key is
+// unchanged keeps its position on the ring even when its other fields change.
+//
+// If the services have the same keys and deeply equal values as those in the current
+// ring, the current ring is returned as is along with false. Otherwise, a new, distinct
+// KeyedRing is returned along with true. The underlying Ring is only rehashed when the
+// set of keys changes. If more than one service has the same key, the last one wins.
+//
+// The current KeyedRing is not modified by this function.
+func UpdateKeyed[S any, K medley.Service](current *KeyedRing[S, K], services ...S) (next *KeyedRing[S, K], updated bool) {
+	var (
+		values = make(map[K]S, len(services))
+		keys   = make([]K, 0, len(services))
+	)
+
+	for _, svc := range services {
+		k := current.key(svc)
+		if _, exists := values[k]; !exists {
+			keys = append(keys, k)
+		}
+
+		values[k] = svc
+	}
+
+	ring, updated := Update(current.ring, keys...)
+	if !updated && reflect.DeepEqual(values, current.values) {
+		next = current
+		return
+	}
+
+	next = &KeyedRing[S, K]{
+		ring:   ring,
+		key:    current.key,
+		values: values,
+	}
+
+	updated = true
+	return
+}
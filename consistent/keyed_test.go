@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// descriptor is a rich service value that is not comparable.
+type descriptor struct {
+	Name   string
+	Labels map[string]string
+}
+
+func descriptorKey(d descriptor) string {
+	return d.Name
+}
+
+type KeyedSuite struct {
+	suite.Suite
+}
+
+// descriptors creates a descriptor for each of the given services, all with the given label value.
+func (suite *KeyedSuite) descriptors(label string, names ...string) []descriptor {
+	ds := make([]descriptor, 0, len(names))
+	for _, name := range names {
+		ds = append(ds, descriptor{
+			Name:   name,
+			Labels: map[string]string{"zone": label},
+		})
+	}
+
+	return ds
+}
+
+func (suite *KeyedSuite) newKeyedRing(ds ...descriptor) *KeyedRing[descriptor, string] {
+	kr := KeyedServices(descriptorKey, ds...).ServiceHasher(medley.HashStringTo[string]).Build()
+	suite.Require().NotNil(kr)
+	return kr
+}
+
+func (suite *KeyedSuite) TestEmpty() {
+	kr := KeyedServices(descriptorKey).Build()
+	suite.Zero(kr.Len())
+
+	_, err := kr.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *KeyedSuite) TestFind() {
+	var (
+		kr       = suite.newKeyedRing(suite.descriptors("east", services[:10]...)...)
+		expected = Strings(services[:10]...).Build()
+	)
+
+	suite.Equal(10, kr.Len())
+	suite.True(expected.Equal(kr.Ring()))
+	for _, object := range hashObjects {
+		name, err := expected.Find(object[:])
+		suite.Require().NoError(err)
+
+		d, err := kr.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(name, d.Name)
+		suite.Equal("east", d.Labels["zone"])
+	}
+
+	d, exists := kr.Value(services[0])
+	suite.True(exists)
+	suite.Equal(services[0], d.Name)
+
+	_, exists = kr.Value("nosuch")
+	suite.False(exists)
+}
+
+func (suite *KeyedSuite) TestDuplicateKeys() {
+	var (
+		first  = suite.descriptors("east", services[0])
+		second = suite.descriptors("west", services[0])
+		kr     = suite.newKeyedRing(append(first, second...)...)
+	)
+
+	suite.Equal(1, kr.Len())
+	d, err := kr.Find([]byte("test"))
+	suite.Require().NoError(err)
+	suite.Equal("west", d.Labels["zone"])
+}
+
+func (suite *KeyedSuite) TestUpdateNoChange() {
+	kr := suite.newKeyedRing(suite.descriptors("east", services[:10]...)...)
+	next, updated := UpdateKeyed(kr, suite.descriptors("east", services[:10]...)...)
+	suite.False(updated)
+	suite.Same(kr, next)
+}
+
+func (suite *KeyedSuite) TestUpdateLabelsOnly() {
+	kr := suite.newKeyedRing(suite.descriptors("east", services[:10]...)...)
+	next, updated := UpdateKeyed(kr, suite.descriptors("west", services[:10]...)...)
+	suite.True(updated)
+	suite.NotSame(kr, next)
+
+	// the keys didn't change, so the underlying ring is reused as is
+	suite.Same(kr.Ring(), next.Ring())
+	for _, object := range hashObjects {
+		before, err := kr.Find(object[:])
+		suite.Require().NoError(err)
+
+		after, err := next.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(before.Name, after.Name)
+		suite.Equal("east", before.Labels["zone"])
+		suite.Equal("west", after.Labels["zone"])
+	}
+}
+
+func (suite *KeyedSuite) TestUpdateMembership() {
+	kr := suite.newKeyedRing(suite.descriptors("east", services[:10]...)...)
+	next, updated := UpdateKeyed(kr, suite.descriptors("east", services[5:15]...)...)
+	suite.True(updated)
+	suite.Equal(10, next.Len())
+	suite.True(Strings(services[5:15]...).Build().Equal(next.Ring()))
+
+	// existing keys reuse the nodes already computed
+	for _, svc := range services[5:10] {
+		suite.Same(kr.Ring().cache[svc][0], next.Ring().cache[svc][0])
+	}
+
+	_, exists := next.Value(services[0])
+	suite.False(exists)
+
+	d, exists := next.Value(services[14])
+	suite.True(exists)
+	suite.Equal(services[14], d.Name)
+}
+
+func TestKeyed(t *testing.T) {
+	suite.Run(t, new(KeyedSuite))
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRingTooLarge indicates that a Ring would have more nodes than the limit set by
+	// Builder.MaxRingNodes. No storage is allocated for such a Ring.
+	ErrRingTooLarge = errors.New("ring exceeds the maximum number of nodes")
+)
+
+// fitVNodes checks a ring of the given number of services, with the given total number of
+// nodes, against this hasher's node limit. If the ring is under the limit, or there is no
+// limit, the returned scale is nil.
+//
+// Otherwise, scale reduces a number of vnodes in proportion to the limit, so that the ring
+// fits when every service has at least one vnode. If strict is set, this fails with
+// ErrRingTooLarge unless this hasher reduces vnodes to fit, and always fails when even one
+// vnode per service would exceed the limit.
+func (h hasher[S]) fitVNodes(services, total int, strict bool) (scale func(int) int, err error) {
+	switch {
+	case h.maxNodes < 1 || total <= h.maxNodes:
+		return nil, nil
+
+	case strict && (!h.reduceToFit || services > h.maxNodes):
+		return nil, fmt.Errorf("%w: %d services with %d nodes, limit %d", ErrRingTooLarge, services, total, h.maxNodes)
+
+	default:
+		return func(v int) int {
+			return max(1, v*h.maxNodes/total)
+		}, nil
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LimitSuite struct {
+	suite.Suite
+}
+
+func (suite *LimitSuite) TestUnderLimit() {
+	var (
+		expected = Strings(services[:50]...).VNodes(20).Build()
+		actual   = Strings(services[:50]...).VNodes(20).MaxRingNodes(1000).Build()
+	)
+
+	suite.True(expected.Equal(actual))
+	suite.Equal(expected.Fingerprint(), actual.Fingerprint())
+	suite.Equal(20, actual.VNodes())
+	suite.Zero(actual.hasher.requested)
+
+	actual, err := Strings(services[:50]...).VNodes(20).MaxRingNodes(1000).BuildE()
+	suite.Require().NoError(err)
+	suite.True(expected.Equal(actual))
+
+	expectedUpdate, _ := Update(expected, services[25:75]...)
+	actualUpdate, updated, err := UpdateE(actual, services[25:75]...)
+	suite.Require().NoError(err)
+	suite.True(updated)
+	suite.True(expectedUpdate.Equal(actualUpdate))
+}
+
+func (suite *LimitSuite) TestBuildE() {
+	b := Strings(services[:50]...).VNodes(100).MaxRingNodes(1000)
+	r, err := b.BuildE()
+	suite.ErrorIs(err, ErrRingTooLarge)
+	suite.Nil(r)
+
+	r, err = b.BuildContext(context.Background(), nil)
+	suite.ErrorIs(err, ErrRingTooLarge)
+	suite.Nil(r)
+
+	// on failure, the services are left in the builder
+	r, err = b.MaxRingNodes(0).BuildE()
+	suite.Require().NoError(err)
+	suite.Equal(50, r.Len())
+	suite.Len(r.nodes, 5000)
+}
+
+func (suite *LimitSuite) TestReduce() {
+	testCases := []struct {
+		name     string
+		services int
+		vnodes   int
+		limit    int
+		expected int
+	}{
+		{name: "Even", services: 50, vnodes: 100, limit: 1000, expected: 20},
+		{name: "Uneven", services: 30, vnodes: 100, limit: 1000, expected: 33},
+		{name: "Exact", services: 10, vnodes: 100, limit: 1000, expected: 100},
+		{name: "OneVNode", services: 50, vnodes: 100, limit: 50, expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			r, err := Strings(services[:testCase.services]...).
+				VNodes(testCase.vnodes).
+				MaxRingNodes(testCase.limit).
+				ReduceVNodesToFit().
+				BuildE()
+
+			suite.Require().NoError(err)
+			suite.Equal(testCase.expected, r.VNodes())
+			suite.Len(r.nodes, testCase.services*testCase.expected)
+			suite.LessOrEqual(len(r.nodes), testCase.limit)
+
+			// Build always reduces, since it can't fail
+			r = Strings(services[:testCase.services]...).VNodes(testCase.vnodes).MaxRingNodes(testCase.limit).Build()
+			suite.Equal(testCase.expected, r.VNodes())
+		})
+	}
+}
+
+func (suite *LimitSuite) TestTooManyServices() {
+	_, err := Strings(services[:50]...).MaxRingNodes(10).ReduceVNodesToFit().BuildE()
+	suite.ErrorIs(err, ErrRingTooLarge)
+
+	// a Ring always has at least one vnode per service
+	r := Strings(services[:50]...).MaxRingNodes(10).Build()
+	suite.Equal(1, r.VNodes())
+	suite.Len(r.nodes, 50)
+}
+
+func (suite *LimitSuite) TestUpdateE() {
+	original := Strings(services[:10]...).VNodes(20).MaxRingNodes(1000).Build()
+
+	next, updated, err := UpdateE(original, services[:60]...)
+	suite.ErrorIs(err, ErrRingTooLarge)
+	suite.False(updated)
+	suite.Same(original, next)
+
+	// Update reduces vnodes, since it can't fail
+	reduced, updated := Update(original, services[:60]...)
+	suite.True(updated)
+	suite.Equal(16, reduced.VNodes())
+	suite.Len(reduced.nodes, 960)
+
+	// the requested vnodes are restored once the ring fits again
+	restored, updated, err := UpdateE(reduced, services[:10]...)
+	suite.Require().NoError(err)
+	suite.True(updated)
+	suite.Equal(20, restored.VNodes())
+	suite.True(original.Equal(restored))
+}
+
+func (suite *LimitSuite) TestUpdateReduceToFit() {
+	original := Strings(services[:10]...).VNodes(20).MaxRingNodes(1000).ReduceVNodesToFit().Build()
+
+	reduced, updated, err := UpdateE(original, services[:60]...)
+	suite.Require().NoError(err)
+	suite.True(updated)
+	suite.Equal(16, reduced.VNodes())
+	for _, snodes := range reduced.cache {
+		suite.Len(snodes, 16)
+	}
+
+	_, _, err = UpdateE(original, services[:100]...)
+	suite.Require().NoError(err)
+
+	_, _, err = UpdateE(Strings(services[:10]...).MaxRingNodes(50).ReduceVNodesToFit().Build(), services[:51]...)
+	suite.ErrorIs(err, ErrRingTooLarge)
+}
+
+func (suite *LimitSuite) TestUpdateVNodesE() {
+	var (
+		original = Strings(services[:10]...).VNodes(20).MaxRingNodes(250).Build()
+		vnodes   = func(svc string) int {
+			if svc == services[0] {
+				return 100
+			}
+
+			return 0
+		}
+	)
+
+	// the override counts toward the limit: 9*20 + 100 = 280
+	_, updated, err := UpdateVNodesE(original, vnodes, services[:10]...)
+	suite.ErrorIs(err, ErrRingTooLarge)
+	suite.False(updated)
+
+	// every service is reduced in proportion: 20*250/280 = 17, 100*250/280 = 89
+	reduced, updated := UpdateVNodes(original, vnodes, services[:10]...)
+	suite.True(updated)
+	suite.Equal(17, reduced.VNodes())
+	suite.Len(reduced.cache[services[0]], 89)
+	suite.Len(reduced.cache[services[1]], 17)
+	suite.Len(reduced.nodes, 9*17+89)
+}
+
+func TestLimit(t *testing.T) {
+	suite.Run(t, new(LimitSuite))
+}
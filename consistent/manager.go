@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/medley"
+)
+
+// tenant holds the ring state for a single tenant of a RingManager.
+type tenant[S medley.Service] struct {
+	locator *medley.UpdatableLocator[S]
+
+	lock    sync.Mutex
+	ring    *Ring[S]
+	deleted bool
+}
+
+// RingManager maintains a distinct Ring for each of several tenants. All tenants'
+// Rings share the same hash configuration.
+//
+// Lookups through the Locators returned by Get never block on updates. Methods on
+// this type are safe for concurrent usage.
+type RingManager[S medley.Service] struct {
+	// empty is an empty Ring with the shared configuration, used to build each
+	// tenant's first Ring
+	empty *Ring[S]
+
+	lock    sync.RWMutex
+	tenants map[string]*tenant[S]
+	journal Journal[S]
+	now     func() time.Time
+}
+
+// NewRingManager creates a RingManager whose Rings use the given Builder's
+// configuration. Any services already added to the Builder are ignored. If
+// the Builder is nil, the default configuration is used.
+func NewRingManager[S medley.Service](b *Builder[S]) *RingManager[S] {
+	if b == nil {
+		b = new(Builder[S])
+	}
+
+	b.lock.Lock()
+	empty := &Ring[S]{
+		hasher:  b.newHasher(),
+		onFind:  b.onFind,
+		extract: b.extract,
+	}
+
+	empty.index()
+	b.lock.Unlock()
+	return &RingManager[S]{
+		empty:   empty,
+		tenants: make(map[string]*tenant[S]),
+		now:     time.Now,
+	}
+}
+
+// SetJournal establishes the Journal that records each membership change applied by this
+// manager, using the given clock for event times. A nil Journal turns off journaling, and
+// a nil clock means time.Now.
+//
+// Changes that have no effect, such as a Set with a tenant's current services, are not recorded.
+func (rm *RingManager[S]) SetJournal(j Journal[S], now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+
+	defer rm.lock.Unlock()
+	rm.lock.Lock()
+	rm.journal = j
+	rm.now = now
+}
+
+// record sends a change to the journal, if there is one. The old Ring may be nil,
+// and a nil new Ring means the tenant was deleted. The tenant's lock must be held.
+func (rm *RingManager[S]) record(op ChangeOp, name string, old, new *Ring[S]) {
+	rm.lock.RLock()
+	j, now := rm.journal, rm.now
+	rm.lock.RUnlock()
+
+	if j == nil {
+		return
+	}
+
+	e := ChangeEvent[S]{
+		Time:   now(),
+		Op:     op,
+		Tenant: name,
+	}
+
+	if new != nil {
+		for svc := range new.cache {
+			if old == nil || !old.Contains(svc) {
+				e.Added = append(e.Added, svc)
+			}
+		}
+	}
+
+	if old != nil {
+		for svc := range old.cache {
+			if new == nil || !new.Contains(svc) {
+				e.Removed = append(e.Removed, svc)
+			}
+		}
+	}
+
+	j.Record(e)
+}
+
+// Get returns the Locator for a tenant. The returned Locator always reflects the
+// tenant's most recent Ring. If the tenant is deleted, the Locator returns
+// medley.ErrNoServices.
+//
+// If the tenant does not exist, this method returns false.
+func (rm *RingManager[S]) Get(name string) (medley.Locator[S], bool) {
+	rm.lock.RLock()
+	t, exists := rm.tenants[name]
+	rm.lock.RUnlock()
+
+	if exists {
+		return t.locator, true
+	}
+
+	return nil, false
+}
+
+// Ring returns the current Ring for a tenant. If the tenant does not exist,
+// this method returns false.
+func (rm *RingManager[S]) Ring(name string) (*Ring[S], bool) {
+	rm.lock.RLock()
+	t, exists := rm.tenants[name]
+	rm.lock.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	defer t.lock.Unlock()
+	t.lock.Lock()
+	return t.ring, !t.deleted
+}
+
+// getOrCreate returns the named tenant, creating it if necessary.
+func (rm *RingManager[S]) getOrCreate(name string) *tenant[S] {
+	rm.lock.RLock()
+	t, exists := rm.tenants[name]
+	rm.lock.RUnlock()
+
+	if exists {
+		return t
+	}
+
+	defer rm.lock.Unlock()
+	rm.lock.Lock()
+	if t, exists = rm.tenants[name]; !exists {
+		t = &tenant[S]{
+			locator: medley.NewUpdatableLocator[S](nil),
+			ring:    rm.empty,
+		}
+
+		rm.tenants[name] = t
+	}
+
+	return t
+}
+
+// Set updates a tenant's Ring to contain exactly the given services, creating the
+// tenant if necessary. As with Update, services already hashed by the tenant's
+// current Ring are not rehashed.
+//
+// This method returns true if the tenant's Ring was changed.
+func (rm *RingManager[S]) Set(name string, services ...S) bool {
+	updated, _ := rm.SetIf(name, nil, services...)
+	return updated
+}
+
+// SetIf is like Set, but only applies a change if the validate function accepts the
+// tenant's new Ring, e.g. to enforce a minimum number of services or a maximum
+// Ownership for any one service. If validate returns an error, that error is returned
+// and the tenant is left untouched. A tenant that does not exist is not created.
+//
+// Validation happens before the new Ring is published, so lookups through the
+// tenant's Locator never observe a rejected Ring. If validate is nil, every change
+// is accepted. The validate function must not call methods on this RingManager
+// for the same tenant.
+func (rm *RingManager[S]) SetIf(name string, validate func(*Ring[S]) error, services ...S) (bool, error) {
+	for {
+		rm.lock.RLock()
+		_, exists := rm.tenants[name]
+		rm.lock.RUnlock()
+
+		// validate a new tenant's first Ring before the tenant is created
+		var (
+			first        *Ring[S]
+			firstUpdated bool
+		)
+
+		if !exists && validate != nil {
+			first, firstUpdated = Update(rm.empty, services...)
+			if err := validate(first); err != nil {
+				return false, err
+			}
+		}
+
+		t := rm.getOrCreate(name)
+		t.lock.Lock()
+		if t.deleted {
+			// the tenant was deleted concurrently, so start over
+			t.lock.Unlock()
+			continue
+		}
+
+		var (
+			next    *Ring[S]
+			updated bool
+		)
+
+		if first != nil && t.ring == rm.empty {
+			next, updated = first, firstUpdated
+		} else {
+			next, updated = Update(t.ring, services...)
+			if updated && validate != nil {
+				if err := validate(next); err != nil {
+					t.lock.Unlock()
+					return false, err
+				}
+			}
+		}
+
+		if updated || t.ring == rm.empty {
+			old := t.ring
+			t.ring = next
+			t.locator.Set(next)
+			if updated {
+				rm.record(ChangeSet, name, old, next)
+			}
+		}
+
+		t.lock.Unlock()
+		return updated, nil
+	}
+}
+
+// Delete removes a tenant. Any Locators previously returned by Get for this tenant
+// will return medley.ErrNoServices from then on. This method returns false if the
+// tenant did not exist.
+func (rm *RingManager[S]) Delete(name string) bool {
+	rm.lock.Lock()
+	t, exists := rm.tenants[name]
+	delete(rm.tenants, name)
+	rm.lock.Unlock()
+
+	if exists {
+		t.lock.Lock()
+		old := t.ring
+		t.deleted = true
+		t.ring = nil
+		t.locator.Set(nil)
+		rm.record(ChangeDelete, name, old, nil)
+		t.lock.Unlock()
+	}
+
+	return exists
+}
+
+// Tenants returns the sorted names of all the tenants in this manager.
+func (rm *RingManager[S]) Tenants() []string {
+	rm.lock.RLock()
+	names := make([]string, 0, len(rm.tenants))
+	for name := range rm.tenants {
+		names = append(names, name)
+	}
+
+	rm.lock.RUnlock()
+	slices.Sort(names)
+	return names
+}
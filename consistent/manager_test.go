@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type RingManagerSuite struct {
+	suite.Suite
+}
+
+func (suite *RingManagerSuite) newRingManager() *RingManager[string] {
+	rm := NewRingManager(Strings[string]().VNodes(50))
+	suite.Require().NotNil(rm)
+	return rm
+}
+
+func (suite *RingManagerSuite) TestEmpty() {
+	rm := NewRingManager[string](nil)
+	suite.Require().NotNil(rm)
+	suite.Empty(rm.Tenants())
+
+	l, ok := rm.Get("nosuch")
+	suite.False(ok)
+	suite.Nil(l)
+
+	r, ok := rm.Ring("nosuch")
+	suite.False(ok)
+	suite.Nil(r)
+
+	suite.False(rm.Delete("nosuch"))
+}
+
+func (suite *RingManagerSuite) TestSet() {
+	rm := suite.newRingManager()
+	suite.True(rm.Set("tenant1", services[:4]...))
+	suite.True(rm.Set("tenant2", services[4:8]...))
+	suite.Equal([]string{"tenant1", "tenant2"}, rm.Tenants())
+
+	l1, ok := rm.Get("tenant1")
+	suite.Require().True(ok)
+	l2, ok := rm.Get("tenant2")
+	suite.Require().True(ok)
+
+	// the configuration is shared, so these rings match a builder with that configuration
+	expected1 := Strings(services[:4]...).VNodes(50).Build()
+	expected2 := Strings(services[4:8]...).VNodes(50).Build()
+	for _, object := range hashObjects {
+		result, err := l1.Find(object[:])
+		suite.NoError(err)
+		suite.Equal(suite.find(expected1, object[:]), result)
+
+		result, err = l2.Find(object[:])
+		suite.NoError(err)
+		suite.Equal(suite.find(expected2, object[:]), result)
+	}
+}
+
+func (suite *RingManagerSuite) find(r *Ring[string], object []byte) string {
+	result, err := r.Find(object)
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *RingManagerSuite) TestUpdateReuse() {
+	rm := suite.newRingManager()
+	suite.True(rm.Set("tenant", services[:4]...))
+	original, ok := rm.Ring("tenant")
+	suite.Require().True(ok)
+
+	// no change
+	suite.False(rm.Set("tenant", services[:4]...))
+	same, ok := rm.Ring("tenant")
+	suite.Require().True(ok)
+	suite.Same(original, same)
+
+	suite.True(rm.Set("tenant", services[2:6]...))
+	updated, ok := rm.Ring("tenant")
+	suite.Require().True(ok)
+	suite.NotSame(original, updated)
+
+	// unchanged services reuse the nodes already computed
+	for _, svc := range services[2:4] {
+		suite.Require().Contains(updated.cache, svc)
+		suite.Same(original.cache[svc][0], updated.cache[svc][0])
+	}
+}
+
+func (suite *RingManagerSuite) TestDelete() {
+	rm := suite.newRingManager()
+	rm.Set("tenant1", services[:4]...)
+	rm.Set("tenant2", services[4:8]...)
+
+	// simulate an in-flight lookup holding onto the locator
+	l, ok := rm.Get("tenant1")
+	suite.Require().True(ok)
+	_, err := l.Find([]byte("test"))
+	suite.NoError(err)
+
+	suite.True(rm.Delete("tenant1"))
+	suite.Equal([]string{"tenant2"}, rm.Tenants())
+
+	_, err = l.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	_, ok = rm.Get("tenant1")
+	suite.False(ok)
+
+	// recreating the tenant gives a new locator
+	suite.True(rm.Set("tenant1", services[:4]...))
+	recreated, ok := rm.Get("tenant1")
+	suite.Require().True(ok)
+	suite.NotSame(l, recreated)
+
+	_, err = recreated.Find([]byte("test"))
+	suite.NoError(err)
+}
+
+// minServices returns a validator that rejects Rings with fewer than n services.
+func (suite *RingManagerSuite) minServices(n int, rejected error) func(*Ring[string]) error {
+	return func(r *Ring[string]) error {
+		if r.Len() < n {
+			return rejected
+		}
+
+		return nil
+	}
+}
+
+func (suite *RingManagerSuite) TestSetIfAccepted() {
+	var (
+		rm        = suite.newRingManager()
+		validated []*Ring[string]
+		validate  = func(r *Ring[string]) error {
+			validated = append(validated, r)
+			return nil
+		}
+	)
+
+	updated, err := rm.SetIf("tenant", validate, services[:4]...)
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Require().Len(validated, 1)
+
+	r, ok := rm.Ring("tenant")
+	suite.Require().True(ok)
+	suite.Same(validated[0], r)
+
+	updated, err = rm.SetIf("tenant", validate, services[2:6]...)
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Require().Len(validated, 2)
+
+	r, ok = rm.Ring("tenant")
+	suite.Require().True(ok)
+	suite.Same(validated[1], r)
+	suite.ElementsMatch(services[2:6], r.Services())
+
+	// no change means no validation
+	updated, err = rm.SetIf("tenant", validate, services[2:6]...)
+	suite.NoError(err)
+	suite.False(updated)
+	suite.Len(validated, 2)
+}
+
+func (suite *RingManagerSuite) TestSetIfRejected() {
+	var (
+		rejected = errors.New("rejected")
+		rm       = suite.newRingManager()
+	)
+
+	// a rejected tenant is never created
+	updated, err := rm.SetIf("tenant", suite.minServices(3, rejected), services[:2]...)
+	suite.ErrorIs(err, rejected)
+	suite.False(updated)
+	suite.Empty(rm.Tenants())
+
+	updated, err = rm.SetIf("tenant", suite.minServices(3, rejected), services[:4]...)
+	suite.NoError(err)
+	suite.True(updated)
+
+	var (
+		before, _ = rm.Ring("tenant")
+		l, _      = rm.Get("tenant")
+		expected  = make([]string, len(hashObjects))
+	)
+
+	for i, object := range hashObjects {
+		expected[i], err = l.Find(object[:])
+		suite.Require().NoError(err)
+	}
+
+	updated, err = rm.SetIf("tenant", suite.minServices(3, rejected), services[3:5]...)
+	suite.ErrorIs(err, rejected)
+	suite.False(updated)
+
+	// the tenant is left untouched
+	after, ok := rm.Ring("tenant")
+	suite.True(ok)
+	suite.Same(before, after)
+	for i, object := range hashObjects {
+		actual, err := l.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected[i], actual)
+	}
+}
+
+func (suite *RingManagerSuite) TestSetIfConcurrentLookups() {
+	var (
+		rejected = errors.New("rejected")
+		rm       = suite.newRingManager()
+		stop     = make(chan struct{})
+		wg       sync.WaitGroup
+	)
+
+	rm.Set("tenant", services[:4]...)
+	l, _ := rm.Get("tenant")
+
+	expected := make([]string, len(hashObjects))
+	for i, object := range hashObjects {
+		expected[i], _ = l.Find(object[:])
+	}
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// a rejected change is never observed
+				j := i % len(hashObjects)
+				actual, err := l.Find(hashObjects[j][:])
+				if !suite.NoError(err) || !suite.Equal(expected[j], actual) {
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 100 {
+		_, err := rm.SetIf("tenant", suite.minServices(3, rejected), services[i%50:i%50+2]...)
+		suite.ErrorIs(err, rejected)
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func (suite *RingManagerSuite) TestConcurrency() {
+	var (
+		rm = suite.newRingManager()
+		wg sync.WaitGroup
+	)
+
+	for i := range 8 {
+		name := fmt.Sprintf("tenant%d", i%4)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 10 {
+				rm.Set(name, services[j:j+4]...)
+				if j%5 == 0 {
+					rm.Delete(name)
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for _, object := range hashObjects[:100] {
+				if l, ok := rm.Get(name); ok {
+					l.Find(object[:])
+				}
+
+				rm.Tenants()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestRingManager(t *testing.T) {
+	suite.Run(t, new(RingManagerSuite))
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"reflect"
+
+	"github.com/xmidt-org/medley"
+)
+
+// estimateSize computes the bytes held by a Ring with the given number of services and
+// nodes, excluding any bytes the services refer to. The estimate is tied to the Ring's
+// storage layout, so it must be updated whenever that layout changes:
+//
+//   - each node is stored once, in the backing array allocated per service by the hasher
+//   - each node is referenced by a pointer in the ring's nodes and in its service's cache entry
+//   - each node's token is copied into the ring's tokens
+//   - each cache entry holds a service and a nodes slice header, and the map's buckets and
+//     spare capacity are estimated to double that
+func estimateSize[S medley.Service](services, nodeCount uint64) uint64 {
+	var (
+		nodeSize  = uint64(reflect.TypeFor[node[S]]().Size())
+		ptrSize   = uint64(reflect.TypeFor[*node[S]]().Size())
+		tokenSize = uint64(reflect.TypeFor[uint64]().Size())
+		entrySize = 2 * uint64(reflect.TypeFor[S]().Size()+reflect.TypeFor[nodes[S]]().Size())
+	)
+
+	return nodeCount*(nodeSize+2*ptrSize+tokenSize) + services*entrySize
+}
+
+// MemoryEstimate returns an estimate of the bytes held by this Ring, which is useful for
+// capacity planning. The estimate covers the ring's nodes, tokens, and per-service cache.
+//
+// Services are stored by value, so the estimate includes each service's own size. Any
+// memory that a service refers to, such as a string's bytes, is only included if sizer is
+// supplied, in which case sizer is called once for each service. Memory shared with other
+// Rings, such as the nodes an updated Ring reuses, is counted in full for each Ring.
+func (r *Ring[S]) MemoryEstimate(sizer func(S) uint64) uint64 {
+	estimate := estimateSize[S](uint64(len(r.cache)), uint64(len(r.nodes)))
+	if sizer != nil {
+		for svc := range r.cache {
+			estimate += sizer(svc)
+		}
+	}
+
+	return estimate
+}
+
+// EstimateRingSize estimates the bytes held by a Ring before it is built, given the number
+// of services, the number of vnodes per service, and the average number of bytes each service
+// refers to, e.g. the average length of string services. The estimate is the same as the
+// one returned by Ring.MemoryEstimate for such a Ring.
+func EstimateRingSize[S medley.Service](services, vnodes, avgServiceBytes int) uint64 {
+	if services < 1 {
+		return 0
+	}
+
+	if vnodes < 1 {
+		vnodes = DefaultVNodes
+	}
+
+	return estimateSize[S](uint64(services), uint64(services)*uint64(vnodes)) +
+		uint64(services)*uint64(max(avgServiceBytes, 0))
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemorySuite struct {
+	suite.Suite
+}
+
+func stringSize(svc string) uint64 {
+	return uint64(len(svc))
+}
+
+func (suite *MemorySuite) heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+func (suite *MemorySuite) TestEmpty() {
+	suite.Zero(Strings[string]().Build().MemoryEstimate(stringSize))
+	suite.Zero(EstimateRingSize[string](0, DefaultVNodes, 10))
+}
+
+func (suite *MemorySuite) TestEstimateRingSize() {
+	// use services of the same length, so the average is exact
+	names := make([]string, 50)
+	for i := range names {
+		names[i] = fmt.Sprintf("service-%03d", i)
+	}
+
+	for _, vnodes := range []int{1, 10, DefaultVNodes} {
+		r := Strings(names...).VNodes(vnodes).Build()
+		suite.Equal(
+			EstimateRingSize[string](len(names), vnodes, len(names[0])),
+			r.MemoryEstimate(stringSize),
+		)
+	}
+
+	// the sizer only adds the bytes services refer to
+	r := Strings(names...).Build()
+	suite.Equal(r.MemoryEstimate(nil)+uint64(len(names)*len(names[0])), r.MemoryEstimate(stringSize))
+	suite.Equal(EstimateRingSize[string](len(names), 0, 0), r.MemoryEstimate(nil))
+}
+
+func (suite *MemorySuite) TestMemStats() {
+	if testing.Short() {
+		suite.T().Skip("skipping memory measurement in short mode")
+	}
+
+	const serviceCount = 2000
+	names := make([]string, serviceCount)
+	for i := range names {
+		names[i] = fmt.Sprintf("memory-service-%d.example.com", i)
+	}
+
+	before := suite.heapAlloc()
+	r := Strings(names...).Build()
+	after := suite.heapAlloc()
+	runtime.KeepAlive(r)
+
+	// the service names were allocated before the ring, so don't count them
+	var (
+		estimate = r.MemoryEstimate(nil)
+		actual   = after - before
+	)
+
+	suite.T().Logf("estimate=%d actual=%d", estimate, actual)
+	suite.InEpsilon(float64(actual), float64(estimate), 0.25)
+}
+
+func TestMemory(t *testing.T) {
+	suite.Run(t, new(MemorySuite))
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrHasherMismatch indicates that Rings being merged were not built with
+	// the same hash configuration.
+	ErrHasherMismatch = errors.New("rings do not share the same hash configuration")
+
+	// ErrDuplicateService indicates that the same service appeared in more than
+	// one of the Rings being merged.
+	ErrDuplicateService = errors.New("service exists in more than one ring")
+)
+
+// Merger is a fluent builder that combines several Rings into a single Ring.
+// The zero value of this type is usable, and is the strictest configuration:
+// all Rings must share the same hash configuration and no service may appear
+// in more than one Ring.
+type Merger[S medley.Service] struct {
+	allowHasherMismatch bool
+	dedupe              bool
+}
+
+// AllowHasherMismatch controls whether Rings with different hash configurations
+// may be merged. By default, a mismatch results in ErrHasherMismatch.
+//
+// The hash configuration of a Ring is compared by vnodes and by the function
+// pointers of the algorithm and ServiceHasher. Go cannot compare function values
+// directly, so two different closures created by the same function literal look
+// identical, while two equivalent but distinct functions look different. Use this
+// option when the caller knows that Rings are compatible despite such a mismatch.
+//
+// When a mismatch is allowed, the merged Ring uses the hash configuration of
+// the first Ring.
+func (m *Merger[S]) AllowHasherMismatch(v bool) *Merger[S] {
+	m.allowHasherMismatch = v
+	return m
+}
+
+// DedupeServices controls how a service that exists in more than one Ring is handled.
+// By default, such a service results in ErrDuplicateService. When deduping is enabled,
+// the nodes from the first Ring that contains the service are used and the others
+// are discarded.
+func (m *Merger[S]) DedupeServices(v bool) *Merger[S] {
+	m.dedupe = v
+	return m
+}
+
+// Merge combines the given Rings into a new, distinct Ring. None of the given
+// Rings are modified. Nil Rings are ignored. If no Rings are supplied, the returned
+// Ring is empty and uses the default hash configuration.
+//
+// Since each Ring's nodes are already sorted, the merged Ring is produced by
+// merging the sorted nodes rather than rehashing or resorting.
+func (m *Merger[S]) Merge(rings ...*Ring[S]) (*Ring[S], error) {
+	var (
+		first    *Ring[S]
+		total    int
+		services int
+	)
+
+	for _, r := range rings {
+		if r == nil {
+			continue
+		} else if first == nil {
+			first = r
+		} else if !m.allowHasherMismatch && !first.config().sameConfig(r.config()) {
+			return nil, ErrHasherMismatch
+		}
+
+		total += len(r.nodes)
+		services += len(r.cache)
+	}
+
+	if first == nil {
+		return new(Builder[S]).Build(), nil
+	}
+
+	var (
+		merged = &Ring[S]{
+			hasher:  first.config(),
+			onFind:  first.onFind,
+			extract: first.extract,
+			cache:   make(medley.Map[S, nodes[S]], services),
+		}
+
+		// owner tracks which ring, by position in the rings slice, owns each service
+		owner = make(map[S]int, services)
+		h     = make(mergeHeap[S], 0, len(rings))
+	)
+
+	for i, r := range rings {
+		if r == nil {
+			continue
+		}
+
+		for svc, snodes := range r.cache {
+			if _, exists := owner[svc]; exists {
+				if !m.dedupe {
+					return nil, ErrDuplicateService
+				}
+
+				total -= len(snodes)
+				continue
+			}
+
+			owner[svc] = i
+			merged.cache[svc] = snodes
+		}
+
+		if len(r.nodes) > 0 {
+			h = append(h, mergeCursor[S]{ring: i, nodes: r.nodes})
+		}
+	}
+
+	merged.nodes = make(nodes[S], 0, total)
+	heap.Init(&h)
+	for h.Len() > 0 {
+		c := &h[0]
+		n := c.nodes[0]
+		if owner[n.service] == c.ring {
+			merged.nodes = append(merged.nodes, n)
+		}
+
+		c.nodes = c.nodes[1:]
+		if len(c.nodes) > 0 {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+
+	merged.index()
+	return merged, nil
+}
+
+// Merge combines Rings using the default Merger configuration. All Rings must share
+// the same hash configuration, and no service may appear in more than one Ring.
+//
+// Use a Merger directly to relax these restrictions.
+func Merge[S medley.Service](rings ...*Ring[S]) (*Ring[S], error) {
+	return new(Merger[S]).Merge(rings...)
+}
+
+// mergeCursor is the unconsumed, sorted nodes of a single Ring being merged.
+type mergeCursor[S medley.Service] struct {
+	ring  int
+	nodes nodes[S]
+}
+
+// mergeHeap is a min-heap of cursors ordered by each cursor's next node, as by node.before.
+// Remaining ties are broken by ring position to keep merges deterministic.
+type mergeHeap[S medley.Service] []mergeCursor[S]
+
+func (mh mergeHeap[S]) Len() int {
+	return len(mh)
+}
+
+func (mh mergeHeap[S]) Less(i, j int) bool {
+	ni, nj := mh[i].nodes[0], mh[j].nodes[0]
+	return ni.before(nj) || (!nj.before(ni) && mh[i].ring < mh[j].ring)
+}
+
+func (mh mergeHeap[S]) Swap(i, j int) {
+	mh[i], mh[j] = mh[j], mh[i]
+}
+
+func (mh *mergeHeap[S]) Push(v any) {
+	*mh = append(*mh, v.(mergeCursor[S]))
+}
+
+func (mh *mergeHeap[S]) Pop() any {
+	old := *mh
+	last := len(old) - 1
+	v := old[last]
+	*mh = old[:last]
+	return v
+}
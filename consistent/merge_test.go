@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"hash/fnv"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type MergeSuite struct {
+	suite.Suite
+}
+
+// assertSameLocations verifies that two rings map every hash object to the same service.
+func (suite *MergeSuite) assertSameLocations(expected, actual *Ring[string]) {
+	for _, object := range hashObjects {
+		expectedResult, expectedErr := expected.Find(object[:])
+		actualResult, actualErr := actual.Find(object[:])
+		suite.Equal(expectedErr, actualErr)
+		suite.Equal(expectedResult, actualResult)
+	}
+}
+
+func (suite *MergeSuite) TestMerge() {
+	var (
+		expected = Strings(services[:]...).Build()
+		r1       = Strings(services[:30]...).Build()
+		r2       = Strings(services[30:70]...).Build()
+		r3       = Strings(services[70:]...).Build()
+	)
+
+	merged, err := Merge(r1, nil, r2, r3)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(merged)
+	suite.True(sort.IsSorted(merged.nodes))
+	suite.Len(merged.nodes, len(expected.nodes))
+	suite.Len(merged.cache, len(services))
+	suite.assertSameLocations(expected, merged)
+}
+
+func (suite *MergeSuite) TestMergeEmpty() {
+	merged, err := Merge[string]()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(merged)
+
+	_, err = merged.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *MergeSuite) testDuplicateServiceError() {
+	merged, err := Merge(
+		Strings(services[:60]...).Build(),
+		Strings(services[40:]...).Build(),
+	)
+
+	suite.ErrorIs(err, ErrDuplicateService)
+	suite.Nil(merged)
+}
+
+func (suite *MergeSuite) testDuplicateServiceDedupe() {
+	merged, err := new(Merger[string]).
+		DedupeServices(true).
+		Merge(
+			Strings(services[:60]...).Build(),
+			Strings(services[40:]...).Build(),
+		)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(merged)
+	suite.True(sort.IsSorted(merged.nodes))
+	suite.assertSameLocations(Strings(services[:]...).Build(), merged)
+}
+
+func (suite *MergeSuite) TestDuplicateService() {
+	suite.Run("Error", suite.testDuplicateServiceError)
+	suite.Run("Dedupe", suite.testDuplicateServiceDedupe)
+}
+
+func (suite *MergeSuite) testHasherMismatchVNodes() {
+	merged, err := Merge(
+		Strings(services[:50]...).Build(),
+		Strings(services[50:]...).VNodes(100).Build(),
+	)
+
+	suite.ErrorIs(err, ErrHasherMismatch)
+	suite.Nil(merged)
+}
+
+func (suite *MergeSuite) testHasherMismatchAlgorithm() {
+	merged, err := Merge(
+		Strings(services[:50]...).Build(),
+		Strings(services[50:]...).Algorithm(medley.Algorithm{New64: fnv.New64}).Build(),
+	)
+
+	suite.ErrorIs(err, ErrHasherMismatch)
+	suite.Nil(merged)
+}
+
+func (suite *MergeSuite) testHasherMismatchServiceHasher() {
+	merged, err := Merge(
+		Strings(services[:50]...).Build(),
+		Services(services[50:]...).Build(),
+	)
+
+	suite.ErrorIs(err, ErrHasherMismatch)
+	suite.Nil(merged)
+}
+
+func (suite *MergeSuite) testHasherMismatchAllowed() {
+	merged, err := new(Merger[string]).
+		AllowHasherMismatch(true).
+		Merge(
+			Strings(services[:50]...).Build(),
+			Strings(services[50:]...).VNodes(100).Build(),
+		)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(merged)
+	suite.True(sort.IsSorted(merged.nodes))
+	suite.Equal(DefaultVNodes, merged.hasher.vnodes)
+	suite.Len(merged.nodes, 50*DefaultVNodes+50*100)
+}
+
+func (suite *MergeSuite) TestHasherMismatch() {
+	suite.Run("VNodes", suite.testHasherMismatchVNodes)
+	suite.Run("Algorithm", suite.testHasherMismatchAlgorithm)
+	suite.Run("ServiceHasher", suite.testHasherMismatchServiceHasher)
+	suite.Run("Allowed", suite.testHasherMismatchAllowed)
+}
+
+func TestMerge(t *testing.T) {
+	suite.Run(t, new(MergeSuite))
+}
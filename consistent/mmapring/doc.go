@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package mmapring shares very large hash rings between processes on the same host.
+
+One process writes a ring with consistent.Ring.WriteStorage. Other processes open
+that file with OpenRing, which memory maps it read-only, so every process shares
+the same physical memory for the ring's tokens. On platforms without memory mapping,
+OpenRing reads the file instead.
+*/
+package mmapring
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package mmapring
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the given file into memory, since memory mapping isn't supported.
+func mapFile(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	_, err := io.ReadFull(f, data)
+	return data, err
+}
+
+// unmapFile releases memory returned by mapFile.
+func unmapFile([]byte) error {
+	return nil
+}
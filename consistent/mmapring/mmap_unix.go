@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package mmapring
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the given file read-only into memory.
+func mapFile(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// unmapFile releases memory returned by mapFile.
+func unmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mmapring
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+// MappedRing is a read-only hash ring backed by a memory-mapped file.
+// Lookups are identical to those of the ring that wrote the file.
+//
+// A MappedRing must not be used after Close is called.
+type MappedRing[S medley.Service] struct {
+	*consistent.StorageRing[S]
+
+	closeOnce sync.Once
+	data      []byte
+}
+
+var _ medley.Locator[string] = (*MappedRing[string])(nil)
+
+// OpenRing memory maps a file written by consistent.Ring.WriteStorage, using dec to
+// decode each service. The file's header and checksum are verified before the ring
+// is returned. The file may be removed or replaced once it is open.
+func OpenRing[S medley.Service](path string, dec func([]byte) (S, error)) (*MappedRing[S], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("%w: empty file", consistent.ErrInvalidStorage)
+	}
+
+	data, err := mapFile(f, int(info.Size()))
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := consistent.DecodeStorage(data, dec)
+	if err != nil {
+		unmapFile(data)
+		return nil, err
+	}
+
+	return &MappedRing[S]{
+		StorageRing: sr,
+		data:        data,
+	}, nil
+}
+
+// Close releases the memory mapping. Subsequent calls to Close do nothing.
+func (mr *MappedRing[S]) Close() (err error) {
+	mr.closeOnce.Do(func() {
+		err = unmapFile(mr.data)
+		mr.data = nil
+	})
+
+	return
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mmapring
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+func encodeString(s string) []byte {
+	return []byte(s)
+}
+
+func decodeString(b []byte) (string, error) {
+	return string(b), nil
+}
+
+type MappedRingSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (suite *MappedRingSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "ring")
+}
+
+func (suite *MappedRingSuite) TestOpenRing() {
+	services := make([]string, 0, 200)
+	for i := range cap(services) {
+		services = append(services, fmt.Sprintf("service-%d.example.net", i))
+	}
+
+	original := consistent.Strings(services...).Build()
+	suite.Require().NoError(original.WriteStorage(suite.path, encodeString))
+
+	// simulate a follower process, which only has the file
+	mr, err := OpenRing(suite.path, decodeString)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(mr)
+	defer mr.Close()
+
+	suite.Equal(len(services), mr.Len())
+	suite.Equal(consistent.DefaultVNodes, mr.VNodes())
+
+	var (
+		random = rand.New(rand.NewSource(1234))
+		object = make([]byte, 16)
+	)
+
+	for range 100_000 {
+		random.Read(object)
+		expected, err := original.Find(object)
+		suite.Require().NoError(err)
+
+		actual, err := mr.Find(object)
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, actual)
+	}
+
+	// the file can be replaced while it's mapped
+	updated, _ := consistent.Update(original, services[:10]...)
+	suite.Require().NoError(updated.WriteStorage(suite.path, encodeString))
+	suite.Equal(len(services), mr.Len())
+	actual, err := mr.Find(object)
+	suite.NoError(err)
+	expected, _ := original.Find(object)
+	suite.Equal(expected, actual)
+
+	suite.NoError(mr.Close())
+	suite.NoError(mr.Close())
+}
+
+func (suite *MappedRingSuite) TestMissingFile() {
+	mr, err := OpenRing(suite.path, decodeString)
+	suite.ErrorIs(err, os.ErrNotExist)
+	suite.Nil(mr)
+}
+
+func (suite *MappedRingSuite) TestEmptyFile() {
+	suite.Require().NoError(os.WriteFile(suite.path, nil, 0o600))
+	mr, err := OpenRing(suite.path, decodeString)
+	suite.ErrorIs(err, consistent.ErrInvalidStorage)
+	suite.Nil(mr)
+}
+
+func (suite *MappedRingSuite) TestCorruptFile() {
+	original := consistent.Strings("service1", "service2").Build()
+	suite.Require().NoError(original.WriteStorage(suite.path, encodeString))
+
+	data, err := os.ReadFile(suite.path)
+	suite.Require().NoError(err)
+	data[len(data)-1]++
+	suite.Require().NoError(os.WriteFile(suite.path, data, 0o600))
+
+	mr, err := OpenRing(suite.path, decodeString)
+	suite.ErrorIs(err, consistent.ErrInvalidStorage)
+	suite.Nil(mr)
+}
+
+func (suite *MappedRingSuite) TestEmptyRing() {
+	empty, _ := consistent.Update(consistent.Strings("service1").Build())
+	suite.Require().NoError(empty.WriteStorage(suite.path, encodeString))
+
+	mr, err := OpenRing(suite.path, decodeString)
+	suite.Require().NoError(err)
+	defer mr.Close()
+
+	_, err = mr.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func TestMappedRing(t *testing.T) {
+	suite.Run(t, new(MappedRingSuite))
+}
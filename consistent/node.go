@@ -3,14 +3,30 @@
 
 package consistent
 
-import "github.com/xmidt-org/medley"
+import (
+	"slices"
+
+	"github.com/xmidt-org/medley"
+)
 
 // node is a single hash ring node for a service.
 type node[S medley.Service] struct {
-	token   uint64
+	token uint64
+
+	// rank is a digest of the service's hash bytes. It orders the nodes of different
+	// services whose tokens collide, so that rings with the same membership have the
+	// same order no matter how they were created.
+	rank uint64
+
 	service S
 }
 
+// before is the total order of nodes on a ring: by token, and then by rank. Nodes for
+// the same service, or for services with identical hash bytes, are not ordered.
+func (n *node[S]) before(other *node[S]) bool {
+	return n.token < other.token || (n.token == other.token && n.rank < other.rank)
+}
+
 // nodes is the Ring's primary storage.
 type nodes[S medley.Service] []*node[S]
 
@@ -19,9 +35,126 @@ func (ns nodes[S]) Len() int {
 }
 
 func (ns nodes[S]) Less(i, j int) bool {
-	return ns[i].token < ns[j].token
+	return ns[i].before(ns[j])
 }
 
 func (ns nodes[S]) Swap(i, j int) {
 	ns[i], ns[j] = ns[j], ns[i]
 }
+
+// tokens returns the tokens of these nodes, in the same order.
+func (ns nodes[S]) tokens() []uint64 {
+	tokens := make([]uint64, len(ns))
+	for i, n := range ns {
+		tokens[i] = n.token
+	}
+
+	return tokens
+}
+
+// searchTokens returns the index of the smallest token that is greater than or equal to the
+// given token, moving clockwise around the ring. The returned index wraps around to zero (0)
+// if the token is larger than every token. The tokens must be sorted and nonempty. They are
+// contiguous in memory, which makes this much faster than chasing node pointers for large rings.
+func searchTokens(tokens []uint64, token uint64) int {
+	i, _ := slices.BinarySearch(tokens, token)
+	if i >= len(tokens) {
+		i = 0
+	}
+
+	return i
+}
+
+// mergeRuns merges individually sorted runs of nodes into a single, new sorted nodes.
+// Runs are merged pairwise, which requires log2(len(runs)) linear passes. Nodes are
+// ordered as by node.before, and remaining ties are broken in favor of the earlier run.
+// None of the runs are modified.
+func mergeRuns[S medley.Service](runs []nodes[S]) nodes[S] {
+	total := 0
+	for _, run := range runs {
+		total += len(run)
+	}
+
+	var (
+		src = make(nodes[S], 0, total)
+
+		// bounds holds the start of each run in src, followed by the end of the last run
+		bounds = make([]int, 1, len(runs)+1)
+	)
+
+	for _, run := range runs {
+		if len(run) > 0 {
+			src = append(src, run...)
+			bounds = append(bounds, len(src))
+		}
+	}
+
+	if len(bounds) <= 2 {
+		return src
+	}
+
+	dst := make(nodes[S], total)
+	for len(bounds) > 2 {
+		// merged reuses bounds' storage, since it never overtakes the pairs being read
+		merged := bounds[:1]
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo, mid, hi := bounds[i], bounds[i+1], bounds[i+1]
+			if i+2 < len(bounds) {
+				hi = bounds[i+2]
+			}
+
+			mergeInto(dst[lo:hi], src[lo:mid], src[mid:hi])
+			merged = append(merged, hi)
+		}
+
+		src, dst = dst, src
+		bounds = merged
+	}
+
+	return src
+}
+
+// mergeUpdate merges fresh, individually sorted runs into the nodes of a previous Ring. Only the
+// previous nodes for which keep returns true are retained, and kept must be their exact count.
+//
+// Unlike mergeRuns over every run, the result is the only allocation proportional to the size
+// of the Ring. The retained nodes are filtered into the tail of the result and then merged
+// forward, which never overtakes the retained nodes still to be read. Nodes are ordered as by
+// node.before, so the result is the same as mergeRuns over every service's nodes. The previous
+// nodes are not modified.
+func mergeUpdate[S medley.Service](previous nodes[S], keep func(S) bool, kept int, fresh []nodes[S]) nodes[S] {
+	var (
+		merged   = mergeRuns(fresh)
+		result   = make(nodes[S], kept+len(merged))
+		retained = result[len(merged):len(merged)]
+	)
+
+	for _, n := range previous {
+		if keep(n.service) {
+			retained = append(retained, n)
+		}
+	}
+
+	mergeInto(result, retained, merged)
+	return result
+}
+
+// mergeInto merges two sorted nodes into dst, which must have exactly enough room
+// for both. Nodes are ordered as by node.before, and remaining ties are broken in favor of x.
+func mergeInto[S medley.Service](dst, x, y nodes[S]) {
+	i, j, k := 0, 0, 0
+	for i < len(x) && j < len(y) {
+		if y[j].before(x[i]) {
+			dst[k] = y[j]
+			j++
+		} else {
+			dst[k] = x[i]
+			i++
+		}
+
+		k++
+	}
+
+	k += copy(dst[k:], x[i:])
+	copy(dst[k:], y[j:])
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/xmidt-org/medley"
+)
+
+// MaxVNodes is the largest number of vnodes per service accepted by WithVNodes.
+// Larger values are almost certainly mistakes that would consume enormous amounts
+// of memory when a Ring is built.
+var MaxVNodes = 100_000
+
+var (
+	// ErrInvalidVNodes indicates that a number of vnodes was either nonpositive
+	// or larger than MaxVNodes.
+	ErrInvalidVNodes = errors.New("invalid vnodes")
+
+	// ErrInvalidAlgorithm indicates that a hash algorithm could not be used, e.g.
+	// because it had no New64 constructor.
+	ErrInvalidAlgorithm = errors.New("invalid hash algorithm")
+
+	// ErrConflictingOptions indicates that options were supplied that cannot be used together.
+	ErrConflictingOptions = errors.New("conflicting options")
+)
+
+// options is the configuration assembled from a set of Options.
+type options struct {
+	vnodes        int
+	alg           medley.Algorithm
+	algorithmName string
+	hasAlgorithm  bool
+	validate      bool
+}
+
+// Option is a configurable option for the convenience Ring constructors, such
+// as NewStringRing. Options may be supplied in any order.
+type Option func(*options) error
+
+// WithVNodes sets the number of vnodes per service. If not supplied, DefaultVNodes is used.
+// The number of vnodes must be positive and no larger than MaxVNodes.
+func WithVNodes(v int) Option {
+	return func(o *options) error {
+		if v < 1 {
+			return fmt.Errorf("WithVNodes: %w: %d is not positive", ErrInvalidVNodes, v)
+		} else if v > MaxVNodes {
+			return fmt.Errorf("WithVNodes: %w: %d exceeds the maximum of %d", ErrInvalidVNodes, v, MaxVNodes)
+		}
+
+		o.vnodes = v
+		return nil
+	}
+}
+
+// WithAlgorithm sets the hash algorithm. This option cannot be used with WithAlgorithmName.
+// If neither is supplied, medley.DefaultAlgorithm is used. The algorithm's New64 field
+// is required.
+func WithAlgorithm(alg medley.Algorithm) Option {
+	return func(o *options) error {
+		if alg.New64 == nil {
+			return fmt.Errorf("WithAlgorithm: %w: New64 is required", ErrInvalidAlgorithm)
+		}
+
+		o.alg = alg
+		o.hasAlgorithm = true
+		return nil
+	}
+}
+
+// WithAlgorithmName sets the hash algorithm by name, using medley.FindAlgorithm.
+// This option cannot be used with WithAlgorithm.
+func WithAlgorithmName(name string) Option {
+	return func(o *options) error {
+		o.algorithmName = name
+		return nil
+	}
+}
+
+// WithValidation runs medley.CheckAlgorithm against the configured hash algorithm,
+// which guards against custom algorithms that would badly skew the distribution of
+// services. If the algorithm fails the check, the Ring is not built.
+func WithValidation() Option {
+	return func(o *options) error {
+		o.validate = true
+		return nil
+	}
+}
+
+// newOptions applies a set of Options and checks that the result is valid.
+// All problems are reported together, and each problem identifies its option.
+func newOptions(opts ...Option) (o options, err error) {
+	var errs []error
+	for _, opt := range opts {
+		if optErr := opt(&o); optErr != nil {
+			errs = append(errs, optErr)
+		}
+	}
+
+	if o.hasAlgorithm && len(o.algorithmName) > 0 {
+		errs = append(errs, fmt.Errorf("%w: WithAlgorithm and WithAlgorithmName", ErrConflictingOptions))
+	} else if len(o.algorithmName) > 0 {
+		var findErr error
+		if o.alg, findErr = medley.FindAlgorithm(o.algorithmName); findErr != nil {
+			errs = append(errs, fmt.Errorf("WithAlgorithmName: %w", findErr))
+		}
+	}
+
+	if len(errs) == 0 && o.validate {
+		alg := o.alg
+		if reflect.ValueOf(alg).IsZero() {
+			alg = medley.DefaultAlgorithm()
+		}
+
+		if checkErr := medley.CheckAlgorithm(alg, medley.DefaultCheckSamples); checkErr != nil {
+			errs = append(errs, fmt.Errorf("WithValidation: %w", checkErr))
+		}
+	}
+
+	err = errors.Join(errs...)
+	return
+}
+
+// configure applies these options to a Builder.
+func configure[S medley.Service](o options, b *Builder[S]) *Builder[S] {
+	return b.VNodes(o.vnodes).Algorithm(o.alg)
+}
+
+// NewStringRing is a convenience for building a Ring of string services. This function
+// is equivalent to using Strings with the builder methods that correspond to the options.
+func NewStringRing(services []string, opts ...Option) (*Ring[string], error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return configure(o, Strings(services...)).Build(), nil
+}
+
+// NewBasicServiceRing is a convenience for building a Ring of medley.BasicService services.
+// This function is equivalent to using BasicServices with the builder methods that correspond
+// to the options.
+func NewBasicServiceRing(services []medley.BasicService, opts ...Option) (*Ring[medley.BasicService], error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return configure(o, BasicServices(services...)).Build(), nil
+}
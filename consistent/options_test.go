@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type OptionsSuite struct {
+	suite.Suite
+}
+
+// assertSameLocations verifies that two rings map every hash object to the same service.
+func assertSameLocations[S medley.Service](suite *OptionsSuite, expected, actual *Ring[S]) {
+	suite.Require().NotNil(actual)
+	suite.Equal(expected.hasher.vnodes, actual.hasher.vnodes)
+	suite.Len(actual.nodes, len(expected.nodes))
+
+	for _, object := range hashObjects {
+		expectedResult, expectedErr := expected.Find(object[:])
+		actualResult, actualErr := actual.Find(object[:])
+		suite.Equal(expectedErr, actualErr)
+		suite.Equal(expectedResult, actualResult)
+	}
+}
+
+func (suite *OptionsSuite) testNewStringRingDefault() {
+	ring, err := NewStringRing(services[:])
+	suite.Require().NoError(err)
+	assertSameLocations(suite, Strings(services[:]...).Build(), ring)
+}
+
+func (suite *OptionsSuite) testNewStringRingCustom() {
+	expected := Strings(services[:]...).
+		VNodes(50).
+		Algorithm(medley.Algorithm{New64: fnv.New64a}).
+		Build()
+
+	// option order shouldn't matter
+	ring, err := NewStringRing(services[:], WithAlgorithm(medley.Algorithm{New64: fnv.New64a}), WithVNodes(50))
+	suite.Require().NoError(err)
+	assertSameLocations(suite, expected, ring)
+
+	ring, err = NewStringRing(services[:], WithVNodes(50), WithAlgorithmName(medley.AlgorithmFNV1a))
+	suite.Require().NoError(err)
+	assertSameLocations(suite, expected, ring)
+}
+
+func (suite *OptionsSuite) testNewStringRingEmpty() {
+	ring, err := NewStringRing(nil)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(ring)
+
+	_, err = ring.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *OptionsSuite) TestNewStringRing() {
+	suite.Run("Default", suite.testNewStringRingDefault)
+	suite.Run("Custom", suite.testNewStringRingCustom)
+	suite.Run("Empty", suite.testNewStringRingEmpty)
+}
+
+func (suite *OptionsSuite) TestNewBasicServiceRing() {
+	basicServices := []medley.BasicService{
+		{Host: "service1.net"},
+		{Host: "service2.net", Port: 8080},
+		{Host: "service3.net", Path: "/foo/bar"},
+	}
+
+	ring, err := NewBasicServiceRing(basicServices, WithVNodes(100), WithAlgorithmName(medley.AlgorithmFNV))
+	suite.Require().NoError(err)
+	assertSameLocations(
+		suite,
+		BasicServices(basicServices...).VNodes(100).Algorithm(medley.Algorithm{New64: fnv.New64}).Build(),
+		ring,
+	)
+}
+
+func (suite *OptionsSuite) testValidationSuccess() {
+	ring, err := NewStringRing(services[:], WithValidation())
+	suite.Require().NoError(err)
+	assertSameLocations(suite, Strings(services[:]...).Build(), ring)
+
+	ring, err = NewStringRing(services[:], WithValidation(), WithAlgorithmName(medley.AlgorithmFNV))
+	suite.Require().NoError(err)
+	suite.NotNil(ring)
+}
+
+func (suite *OptionsSuite) testValidationFailure() {
+	constant := medley.Algorithm{
+		New64: fnv.New64,
+		Sum64: func([]byte) uint64 { return 123 },
+	}
+
+	ring, err := NewStringRing(services[:], WithAlgorithm(constant), WithValidation())
+	suite.ErrorIs(err, medley.ErrAlgorithmCheck)
+	suite.Nil(ring)
+
+	// without validation, the broken algorithm is accepted
+	ring, err = NewStringRing(services[:], WithAlgorithm(constant))
+	suite.NoError(err)
+	suite.NotNil(ring)
+}
+
+func (suite *OptionsSuite) TestValidation() {
+	suite.Run("Success", suite.testValidationSuccess)
+	suite.Run("Failure", suite.testValidationFailure)
+}
+
+func (suite *OptionsSuite) testErrorInvalidVNodes() {
+	ring, err := NewStringRing(services[:], WithVNodes(0))
+	suite.ErrorIs(err, ErrInvalidVNodes)
+	suite.Nil(ring)
+
+	basicRing, err := NewBasicServiceRing(nil, WithVNodes(-1))
+	suite.ErrorIs(err, ErrInvalidVNodes)
+	suite.Nil(basicRing)
+}
+
+func (suite *OptionsSuite) testErrorTooManyVNodes() {
+	ring, err := NewStringRing(services[:], WithVNodes(MaxVNodes+1))
+	suite.ErrorIs(err, ErrInvalidVNodes)
+	suite.ErrorContains(err, "WithVNodes")
+	suite.Nil(ring)
+
+	// the maximum itself is allowed
+	ring, err = NewStringRing(services[:1], WithVNodes(MaxVNodes))
+	suite.NoError(err)
+	suite.NotNil(ring)
+}
+
+func (suite *OptionsSuite) testErrorNilNew64() {
+	ring, err := NewStringRing(services[:], WithAlgorithm(medley.Algorithm{}))
+	suite.ErrorIs(err, ErrInvalidAlgorithm)
+	suite.ErrorContains(err, "WithAlgorithm")
+	suite.Nil(ring)
+}
+
+func (suite *OptionsSuite) testErrorCombined() {
+	ring, err := NewStringRing(
+		services[:],
+		WithVNodes(MaxVNodes*2),
+		WithAlgorithm(medley.Algorithm{}),
+		WithAlgorithmName("nosuch"),
+	)
+
+	suite.ErrorIs(err, ErrInvalidVNodes)
+	suite.ErrorIs(err, ErrInvalidAlgorithm)
+	suite.ErrorIs(err, medley.ErrUnknownAlgorithm)
+	suite.ErrorContains(err, "WithVNodes")
+	suite.ErrorContains(err, "WithAlgorithm")
+	suite.ErrorContains(err, "WithAlgorithmName")
+	suite.Nil(ring)
+}
+
+func (suite *OptionsSuite) testErrorUnknownAlgorithm() {
+	ring, err := NewStringRing(services[:], WithAlgorithmName("nosuch"))
+	suite.ErrorIs(err, medley.ErrUnknownAlgorithm)
+	suite.Nil(ring)
+}
+
+func (suite *OptionsSuite) testErrorConflictingAlgorithms() {
+	ring, err := NewStringRing(
+		services[:],
+		WithAlgorithmName(medley.AlgorithmFNV),
+		WithAlgorithm(medley.DefaultAlgorithm()),
+	)
+
+	suite.ErrorIs(err, ErrConflictingOptions)
+	suite.Nil(ring)
+}
+
+func (suite *OptionsSuite) TestErrors() {
+	suite.Run("InvalidVNodes", suite.testErrorInvalidVNodes)
+	suite.Run("TooManyVNodes", suite.testErrorTooManyVNodes)
+	suite.Run("NilNew64", suite.testErrorNilNew64)
+	suite.Run("Combined", suite.testErrorCombined)
+	suite.Run("UnknownAlgorithm", suite.testErrorUnknownAlgorithm)
+	suite.Run("ConflictingAlgorithms", suite.testErrorConflictingAlgorithms)
+}
+
+func TestOptions(t *testing.T) {
+	suite.Run(t, new(OptionsSuite))
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+type OrderingSuite struct {
+	suite.Suite
+}
+
+// firstN returns up to n services from a ring's Successors for an object.
+func firstN(r *Ring[string], object []byte, n int) (services []string) {
+	for svc := range r.Successors(object) {
+		if len(services) >= n {
+			break
+		}
+
+		services = append(services, svc)
+	}
+
+	return
+}
+
+// constructions creates rings with the given services in every supported way. Each builder
+// returned by newBuilder must have the same configuration.
+func (suite *OrderingSuite) constructions(newBuilder func(...string) *Builder[string], members []string) map[string]*Ring[string] {
+	var (
+		half    = len(members) / 2
+		rings   = make(map[string]*Ring[string])
+		reverse = slices.Clone(members)
+	)
+
+	slices.Reverse(reverse)
+	rings["Build"] = newBuilder(members...).Build()
+	rings["BuildReversed"] = newBuilder(reverse...).Build()
+	rings["UpdateFromEmpty"], _ = Update(newBuilder().Build(), members...)
+	rings["UpdateFromFirstHalf"], _ = Update(newBuilder(members[:half]...).Build(), members...)
+	rings["UpdateFromSecondHalf"], _ = Update(newBuilder(members[half:]...).Build(), reverse...)
+	rings["UpdateFromOthers"], _ = Update(newBuilder(append([]string{"other1", "other2"}, members[1:]...)...).Build(), members...)
+	rings["UpdateSeq"], _ = UpdateSeq(newBuilder(members[:1]...).Build(), slices.Values(reverse))
+
+	var err error
+	rings["Merge"], err = Merge(newBuilder(members[:half]...).Build(), newBuilder(members[half:]...).Build())
+	suite.Require().NoError(err)
+
+	rings["MergeReversed"], err = Merge(newBuilder(members[half:]...).Build(), newBuilder(members[:half]...).Build())
+	suite.Require().NoError(err)
+
+	return rings
+}
+
+func (suite *OrderingSuite) assertSameOrder(rings map[string]*Ring[string], objects [][]byte) {
+	expected := rings["Build"]
+	for name, r := range rings {
+		suite.Require().Equal(expected.Fingerprint(), r.Fingerprint(), name)
+		suite.Require().True(expected.Equal(r), name)
+		for _, object := range objects {
+			for _, n := range []int{1, 2, 3, 5} {
+				suite.Require().Equal(firstN(expected, object, n), firstN(r, object, n), "%s: n=%d", name, n)
+			}
+		}
+	}
+}
+
+func (suite *OrderingSuite) TestCollisions() {
+	// every service collides with another service on at least one token
+	sa := medleytest.NewScriptedAlgorithm(0)
+	for svc, tokens := range map[string][]uint64{
+		"a": {10, 20, 30},
+		"b": {10, 30, 50},
+		"c": {10, 20, 60},
+		"d": {50, 60, 70},
+	} {
+		suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], svc, tokens...))
+	}
+
+	newBuilder := func(members ...string) *Builder[string] {
+		return Strings(members...).Algorithm(sa.Algorithm()).VNodes(3)
+	}
+
+	objects := [][]byte{[]byte("object")}
+	for token := range uint64(75) {
+		object := []byte{byte(token)}
+		sa.Script(object, token)
+		objects = append(objects, object)
+	}
+
+	// Build iterates over a map, so repeat to cover different orders
+	for range 20 {
+		rings := suite.constructions(newBuilder, []string{"a", "b", "c", "d"})
+		suite.assertSameOrder(rings, objects)
+	}
+
+	// each colliding token still has exactly one owner
+	ring := newBuilder("a", "b", "c", "d").Build()
+	suite.Equal(6, ring.CollisionCount())
+}
+
+func (suite *OrderingSuite) TestFixtures() {
+	newBuilder := func(members ...string) *Builder[string] {
+		return Strings(members...).VNodes(50)
+	}
+
+	objects := make([][]byte, len(hashObjects))
+	for i := range hashObjects {
+		objects[i] = hashObjects[i][:]
+	}
+
+	suite.assertSameOrder(suite.constructions(newBuilder, services[:20]), objects)
+}
+
+func TestOrdering(t *testing.T) {
+	suite.Run(t, new(OrderingSuite))
+}
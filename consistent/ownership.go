@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xmidt-org/medley"
+)
+
+// Arc is an inclusive range of tokens on a Ring. Start is never greater than End, so
+// a range that wraps around the ring is represented by two Arcs.
+type Arc struct {
+	Start uint64
+	End   uint64
+}
+
+// Contains tests if the given token falls within this Arc.
+func (a Arc) Contains(token uint64) bool {
+	return a.Start <= token && token <= a.End
+}
+
+// ownedArcs computes the sorted, non-overlapping Arcs of tokens that map to the given
+// service. Adjacent Arcs are merged.
+func ownedArcs[S medley.Service](r *Ring[S], svc S) (arcs []Arc) {
+	if r == nil || len(r.nodes) == 0 {
+		return
+	}
+
+	add := func(a Arc) {
+		if n := len(arcs); n > 0 && arcs[n-1].End != math.MaxUint64 && arcs[n-1].End+1 == a.Start {
+			arcs[n-1].End = a.End
+		} else {
+			arcs = append(arcs, a)
+		}
+	}
+
+	// the first node also owns the tokens after the last node, which wrap around
+	last := r.nodes[len(r.nodes)-1].token
+	if r.nodes[0].service == svc {
+		add(Arc{Start: 0, End: r.nodes[0].token})
+	}
+
+	for i := 1; i < len(r.nodes); i++ {
+		// a node with the same token as its predecessor owns nothing
+		if n := r.nodes[i]; n.service == svc && r.nodes[i-1].token < n.token {
+			add(Arc{Start: r.nodes[i-1].token + 1, End: n.token})
+		}
+	}
+
+	if r.nodes[0].service == svc && last != math.MaxUint64 {
+		add(Arc{Start: last + 1, End: math.MaxUint64})
+	}
+
+	return
+}
+
+// subtractArcs returns the tokens in a that are not in b. Both a and b must be sorted and
+// non-overlapping, as returned by ownedArcs.
+func subtractArcs(a, b []Arc) (diff []Arc) {
+	j := 0
+	for _, arc := range a {
+		for j < len(b) && b[j].End < arc.Start {
+			j++
+		}
+
+		start, covered := arc.Start, false
+		for k := j; !covered && k < len(b) && b[k].Start <= arc.End; k++ {
+			if b[k].Start > start {
+				diff = append(diff, Arc{Start: start, End: b[k].Start - 1})
+			}
+
+			if b[k].End >= arc.End {
+				covered = true
+			} else {
+				start = b[k].End + 1
+			}
+		}
+
+		if !covered {
+			diff = append(diff, Arc{Start: start, End: arc.End})
+		}
+	}
+
+	return
+}
+
+// OwnershipChange describes how a new Ring changed the tokens owned by a local service.
+type OwnershipChange[S medley.Service] struct {
+	// Local is the service whose ownership changed.
+	Local S
+
+	// Gained are the Arcs the local service owns in the new Ring but did not own before.
+	Gained []Arc
+
+	// Lost are the Arcs the local service owned in the previous Ring but no longer owns.
+	Lost []Arc
+}
+
+// OwnershipWatcher tracks which objects a single, local service owns, which is useful
+// for processes that each handle the objects hashed to their own service. Each new Ring
+// is installed with Set, typically alongside setting a medley.UpdatableLocator.
+//
+// Methods on this type are safe for concurrent usage.
+type OwnershipWatcher[S medley.Service] struct {
+	local    S
+	onChange func(OwnershipChange[S])
+
+	lock    sync.Mutex
+	current atomic.Pointer[Ring[S]]
+}
+
+// NewOwnershipWatcher creates an OwnershipWatcher for the given local service. The initial
+// Ring may be nil, in which case the local service owns nothing until Set is called. The
+// onChange callback is invoked by Set whenever the local service's ownership changes.
+func NewOwnershipWatcher[S medley.Service](local S, initial *Ring[S], onChange func(OwnershipChange[S])) *OwnershipWatcher[S] {
+	ow := &OwnershipWatcher[S]{
+		local:    local,
+		onChange: onChange,
+	}
+
+	ow.current.Store(initial)
+	return ow
+}
+
+// Local returns the service this watcher tracks.
+func (ow *OwnershipWatcher[S]) Local() S {
+	return ow.local
+}
+
+// Ring returns the current Ring, which may be nil.
+func (ow *OwnershipWatcher[S]) Ring() *Ring[S] {
+	return ow.current.Load()
+}
+
+// Owns tests if the local service owns the given object in the current Ring. This
+// is always consistent with the current Ring's Find.
+func (ow *OwnershipWatcher[S]) Owns(object []byte) bool {
+	r := ow.current.Load()
+	if r == nil {
+		return false
+	}
+
+	svc, err := r.Find(object)
+	return err == nil && svc == ow.local
+}
+
+// Arcs returns the Arcs of tokens the local service owns in the current Ring.
+func (ow *OwnershipWatcher[S]) Arcs() []Arc {
+	return ownedArcs(ow.current.Load(), ow.local)
+}
+
+// Set installs a new Ring and returns the resulting change in the local service's
+// ownership. A nil Ring means the local service owns nothing.
+//
+// If the ownership changed, the onChange callback is invoked before this method returns.
+// Calls to Set are serialized, so the callback sees changes in order, and it must not call
+// Set itself.
+func (ow *OwnershipWatcher[S]) Set(next *Ring[S]) (change OwnershipChange[S]) {
+	defer ow.lock.Unlock()
+	ow.lock.Lock()
+
+	var (
+		before = ownedArcs(ow.current.Load(), ow.local)
+		after  = ownedArcs(next, ow.local)
+	)
+
+	ow.current.Store(next)
+	change = OwnershipChange[S]{
+		Local:  ow.local,
+		Gained: subtractArcs(after, before),
+		Lost:   subtractArcs(before, after),
+	}
+
+	if ow.onChange != nil && (len(change.Gained) > 0 || len(change.Lost) > 0) {
+		ow.onChange(change)
+	}
+
+	return
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OwnershipWatcherSuite struct {
+	suite.Suite
+
+	before *Ring[string]
+	after  *Ring[string]
+}
+
+func (suite *OwnershipWatcherSuite) SetupTest() {
+	suite.before = Strings(services[:10]...).Build()
+
+	// services[3] leaves and services[10:12] join
+	next := slices.Concat(services[:3], services[4:12])
+	suite.after, _ = Update(suite.before, next...)
+}
+
+func inArcs(arcs []Arc, token uint64) bool {
+	for _, a := range arcs {
+		if a.Contains(token) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (suite *OwnershipWatcherSuite) TestSubtractArcs() {
+	testCases := []struct {
+		a, b, expected []Arc
+	}{
+		{a: nil, b: []Arc{{0, 10}}, expected: nil},
+		{a: []Arc{{0, 10}}, b: nil, expected: []Arc{{0, 10}}},
+		{a: []Arc{{0, 10}}, b: []Arc{{0, 10}}, expected: nil},
+		{a: []Arc{{0, 10}}, b: []Arc{{3, 5}}, expected: []Arc{{0, 2}, {6, 10}}},
+		{a: []Arc{{5, 10}}, b: []Arc{{0, 6}, {9, 20}}, expected: []Arc{{7, 8}}},
+		{a: []Arc{{0, 4}, {10, 14}}, b: []Arc{{3, 11}}, expected: []Arc{{0, 2}, {12, 14}}},
+		{a: []Arc{{0, math.MaxUint64}}, b: []Arc{{10, math.MaxUint64}}, expected: []Arc{{0, 9}}},
+	}
+
+	for _, testCase := range testCases {
+		suite.Equal(testCase.expected, subtractArcs(testCase.a, testCase.b), "%v - %v", testCase.a, testCase.b)
+	}
+}
+
+func (suite *OwnershipWatcherSuite) TestSingleService() {
+	r := Strings("only").Build()
+	suite.Equal([]Arc{{Start: 0, End: math.MaxUint64}}, ownedArcs(r, "only"))
+	suite.Empty(ownedArcs(r, "nosuch"))
+}
+
+func (suite *OwnershipWatcherSuite) TestMembershipChange() {
+	var (
+		local   = services[0]
+		changes []OwnershipChange[string]
+		ow      = NewOwnershipWatcher(local, suite.before, func(c OwnershipChange[string]) {
+			changes = append(changes, c)
+		})
+	)
+
+	suite.Equal(local, ow.Local())
+	suite.Same(suite.before, ow.Ring())
+	change := ow.Set(suite.after)
+	suite.Same(suite.after, ow.Ring())
+	suite.Equal([]OwnershipChange[string]{change}, changes)
+	suite.Equal(local, change.Local)
+	suite.Equal(ownedArcs(suite.after, local), ow.Arcs())
+
+	var gained, lost int
+	for _, object := range hashObjects {
+		before, err := suite.before.FindNode(object[:])
+		suite.Require().NoError(err)
+
+		after, err := suite.after.FindNode(object[:])
+		suite.Require().NoError(err)
+
+		suite.Equal(after.Service == local, ow.Owns(object[:]))
+		suite.Equal(after.Service == local, inArcs(ow.Arcs(), after.KeyToken))
+		suite.Equal(before.Service != local && after.Service == local, inArcs(change.Gained, after.KeyToken))
+		suite.Equal(before.Service == local && after.Service != local, inArcs(change.Lost, after.KeyToken))
+
+		if inArcs(change.Gained, after.KeyToken) {
+			gained++
+		}
+
+		if inArcs(change.Lost, after.KeyToken) {
+			lost++
+		}
+	}
+
+	// the local service loses objects to the new services and gains some of services[3]'s objects
+	suite.Positive(lost)
+	suite.Positive(gained)
+
+	// installing the same ring again changes nothing
+	change = ow.Set(suite.after)
+	suite.Empty(change.Gained)
+	suite.Empty(change.Lost)
+	suite.Len(changes, 1)
+}
+
+func (suite *OwnershipWatcherSuite) TestRemoved() {
+	var (
+		local   = services[3]
+		changes int
+		ow      = NewOwnershipWatcher(local, suite.before, func(OwnershipChange[string]) {
+			changes++
+		})
+	)
+
+	change := ow.Set(suite.after)
+	suite.Equal(1, changes)
+	suite.Empty(change.Gained)
+	suite.Equal(ownedArcs(suite.before, local), change.Lost)
+	suite.Empty(ow.Arcs())
+
+	for _, object := range hashObjects {
+		suite.False(ow.Owns(object[:]))
+	}
+
+	// removing the ring entirely is a full loss for a service that was in it
+	ow = NewOwnershipWatcher(services[0], suite.after, nil)
+	change = ow.Set(nil)
+	suite.Equal(ownedArcs(suite.after, services[0]), change.Lost)
+	suite.False(ow.Owns(hashObjects[0][:]))
+}
+
+func (suite *OwnershipWatcherSuite) TestNotInRing() {
+	ow := NewOwnershipWatcher("nosuch", nil, func(OwnershipChange[string]) {
+		suite.Fail("no change expected")
+	})
+
+	suite.False(ow.Owns(hashObjects[0][:]))
+	change := ow.Set(suite.before)
+	suite.Empty(change.Gained)
+	suite.Empty(change.Lost)
+	suite.False(ow.Owns(hashObjects[0][:]))
+}
+
+func TestOwnershipWatcher(t *testing.T) {
+	suite.Run(t, new(OwnershipWatcherSuite))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrInvalidPartition indicates that a partition index cannot be encoded as a
+	// partition key, i.e. it is negative or larger than math.MaxUint32.
+	ErrInvalidPartition = errors.New("invalid partition")
+)
+
+// partitionKey is the stable encoding of a partition index: its 4-byte, big-endian
+// form. Changing this encoding reassigns every partition.
+func partitionKey(partition int) (key [4]byte) {
+	binary.BigEndian.PutUint32(key[:], uint32(partition))
+	return
+}
+
+// partitionOwner returns the service that owns the given partition. This ring
+// must not be empty, and the partition must be valid.
+func (r *Ring[S]) partitionOwner(partition int) S {
+	key := partitionKey(partition)
+	return r.tokenOwner(r.hasher.sum64(key[:]))
+}
+
+// PartitionOwner returns the service that owns a single partition, e.g. a topic partition
+// in a Kafka-style consumer group. This is the same service that AssignPartitions assigns
+// the partition to.
+//
+// If the partition is negative or larger than math.MaxUint32, this function returns
+// ErrInvalidPartition. If the ring is empty, this function returns medley.ErrNoServices.
+func PartitionOwner[S medley.Service](r *Ring[S], partition int) (svc S, err error) {
+	switch {
+	case partition < 0 || int64(partition) > math.MaxUint32:
+		err = fmt.Errorf("%w: %d", ErrInvalidPartition, partition)
+
+	case len(r.nodes) == 0:
+		err = medley.ErrNoServices
+
+	default:
+		svc = r.partitionOwner(partition)
+	}
+
+	return
+}
+
+// AssignPartitions assigns the partitions [0, partitionCount) to a Ring's services. The
+// returned map holds each service's partitions in ascending order. Services that own no
+// partitions are not in the map, and the map is empty if the ring is empty or partitionCount
+// is not positive. Partitions beyond math.MaxUint32 are not assigned.
+//
+// Each partition's key is its index as a 4-byte, big-endian unsigned integer, which is
+// hashed with the ring's algorithm. This encoding is stable, so every process with the same
+// ring assigns partitions identically, as does Find for the encoded key on a ring without a
+// KeyExtractor. The ring's KeyExtractor and OnFind hook are not used.
+//
+// As with any object, adding a service only moves partitions to that service, and removing a
+// service only moves that service's partitions.
+func AssignPartitions[S medley.Service](r *Ring[S], partitionCount int) map[S][]int {
+	assignment := make(map[S][]int, len(r.cache))
+	if len(r.nodes) == 0 {
+		return assignment
+	}
+
+	for partition := 0; partition < partitionCount && int64(partition) <= math.MaxUint32; partition++ {
+		svc := r.partitionOwner(partition)
+		assignment[svc] = append(assignment[svc], partition)
+	}
+
+	return assignment
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type PartitionsSuite struct {
+	suite.Suite
+}
+
+// assertCovers asserts that an assignment holds every partition exactly once, in ascending
+// order for each service.
+func (suite *PartitionsSuite) assertCovers(assignment map[string][]int, partitionCount int) {
+	seen := make([]bool, partitionCount)
+	for svc, partitions := range assignment {
+		suite.NotEmpty(partitions, "service %s", svc)
+		suite.True(slices.IsSorted(partitions), "service %s", svc)
+		for _, p := range partitions {
+			suite.Require().False(seen[p], "partition %d assigned twice", p)
+			seen[p] = true
+		}
+	}
+
+	suite.NotContains(seen, false)
+}
+
+func (suite *PartitionsSuite) TestStableAssignment() {
+	// the partition key encoding is a contract, so these assignments must never change
+	ring := Strings("a.example.net", "b.example.net", "c.example.net").Build()
+	suite.Equal(
+		map[string][]int{
+			"a.example.net": {2, 5, 6, 9, 10},
+			"b.example.net": {8, 11},
+			"c.example.net": {0, 1, 3, 4, 7},
+		},
+		AssignPartitions(ring, 12),
+	)
+}
+
+func (suite *PartitionsSuite) TestCoverage() {
+	ring := Strings(services[:10]...).Build()
+	for _, partitionCount := range []int{1, 7, 64, 1000} {
+		assignment := AssignPartitions(ring, partitionCount)
+		suite.assertCovers(assignment, partitionCount)
+
+		for svc, partitions := range assignment {
+			for _, p := range partitions {
+				owner, err := PartitionOwner(ring, p)
+				suite.Require().NoError(err)
+				suite.Require().Equal(svc, owner)
+			}
+		}
+	}
+}
+
+func (suite *PartitionsSuite) TestEncoding() {
+	ring := Strings(services[:10]...).Build()
+	for p := range 100 {
+		owner, err := PartitionOwner(ring, p)
+		suite.Require().NoError(err)
+
+		expected, err := ring.Find(binary.BigEndian.AppendUint32(nil, uint32(p)))
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, owner)
+	}
+
+	_, err := PartitionOwner(ring, math.MaxUint32)
+	suite.NoError(err)
+}
+
+func (suite *PartitionsSuite) TestMinimalReassignment() {
+	const partitionCount = 1000
+
+	var (
+		ring    = Strings(services[:10]...).Build()
+		next, _ = Update(ring, services[:11]...)
+		added   = services[10]
+
+		before = AssignPartitions(ring, partitionCount)
+		after  = AssignPartitions(next, partitionCount)
+		owners = make(map[int]string, partitionCount)
+	)
+
+	suite.assertCovers(after, partitionCount)
+	for svc, partitions := range before {
+		for _, p := range partitions {
+			owners[p] = svc
+		}
+	}
+
+	// every partition that moved, moved to the new service
+	var moved int
+	for svc, partitions := range after {
+		for _, p := range partitions {
+			if owners[p] != svc {
+				moved++
+				suite.Equal(added, svc, "partition %d", p)
+			}
+		}
+	}
+
+	suite.Equal(len(after[added]), moved)
+	suite.Positive(moved)
+}
+
+func (suite *PartitionsSuite) TestEmpty() {
+	empty := Strings[string]().Build()
+	suite.Empty(AssignPartitions(empty, 10))
+
+	_, err := PartitionOwner(empty, 0)
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	ring := Strings(services[:10]...).Build()
+	suite.Empty(AssignPartitions(ring, 0))
+	suite.Empty(AssignPartitions(ring, -1))
+}
+
+func (suite *PartitionsSuite) TestInvalidPartition() {
+	ring := Strings(services[:10]...).Build()
+	for _, p := range []int{-1, math.MinInt, math.MaxUint32 + 1} {
+		_, err := PartitionOwner(ring, p)
+		suite.ErrorIs(err, ErrInvalidPartition)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	suite.Run(t, new(PartitionsSuite))
+}
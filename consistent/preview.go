@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import "github.com/xmidt-org/medley"
+
+// UpdatePreview describes the effect that an Update would have on a Ring.
+type UpdatePreview[S medley.Service] struct {
+	// Delta is the change in the fraction of the hash circle owned by each service
+	// that is in either the current Ring or the updated Ring. A service that is added
+	// has a positive delta, while a service that is removed has a negative delta.
+	Delta medley.Map[S, float64]
+
+	// Moved is the fraction of the hash circle whose owner changes, which is the
+	// expected fraction of objects that would be routed to a different service.
+	// If either Ring is empty, every object changes hands and Moved is 1.0.
+	Moved float64
+}
+
+// PreviewUpdate computes the effect of applying an Update to the current Ring with the
+// given services, without modifying the current Ring or returning the updated one. As
+// with Update, only services that are not already in the current Ring are hashed.
+//
+// If the services do not constitute an update, the zero UpdatePreview is returned.
+func PreviewUpdate[S medley.Service](current *Ring[S], services ...S) (preview UpdatePreview[S]) {
+	next, updated := Update(current, services...)
+	if !updated {
+		return
+	}
+
+	preview.Delta = next.Ownership()
+	for svc, fraction := range current.Ownership() {
+		preview.Delta[svc] -= fraction
+	}
+
+	preview.Moved = movedFraction(current.nodes, next.nodes)
+	return
+}
+
+// movedFraction computes the fraction of the hash circle whose owner differs between
+// two sets of sorted nodes. Both sets are swept together, so that each arc between
+// consecutive tokens of either set has a single owner in each set.
+func movedFraction[S medley.Service](old, new nodes[S]) (moved float64) {
+	if len(old) == 0 || len(new) == 0 {
+		return 1.0
+	}
+
+	var (
+		i, j int
+
+		// the first arc wraps around zero from the last token of either set
+		previous = max(old[len(old)-1].token, new[len(new)-1].token)
+	)
+
+	for i < len(old) || j < len(new) {
+		var token uint64
+		if j >= len(new) || (i < len(old) && old[i].token <= new[j].token) {
+			token = old[i].token
+		} else {
+			token = new[j].token
+		}
+
+		// the arc (previous, token] is owned by the next node of each set, wrapping to the first
+		if old[i%len(old)].service != new[j%len(new)].service {
+			// unsigned subtraction handles the arc that wraps around zero
+			moved += float64(token-previous) / (1 << 64)
+		}
+
+		if i < len(old) && old[i].token == token {
+			i++
+		}
+
+		if j < len(new) && new[j].token == token {
+			j++
+		}
+
+		previous = token
+	}
+
+	return
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type PreviewUpdateSuite struct {
+	suite.Suite
+
+	// hashed counts the calls to the ServiceHasher
+	hashed int
+	ring   *Ring[string]
+}
+
+func (suite *PreviewUpdateSuite) SetupTest() {
+	suite.hashed = 0
+	suite.ring = Strings(services[:20]...).
+		ServiceHasher(func(dst io.Writer, svc string) error {
+			suite.hashed++
+			return medley.HashStringTo(dst, svc)
+		}).
+		Build()
+}
+
+// measureMoved samples random objects and measures the fraction whose owner changes.
+func (suite *PreviewUpdateSuite) measureMoved(current, next *Ring[string]) float64 {
+	const samples = 100_000
+
+	var (
+		random = rand.New(rand.NewSource(3321))
+		object = make([]byte, 16)
+		moved  int
+	)
+
+	for range samples {
+		random.Read(object)
+		before, err := current.Find(object)
+		suite.Require().NoError(err)
+
+		after, err := next.Find(object)
+		suite.Require().NoError(err)
+		if before != after {
+			moved++
+		}
+	}
+
+	return float64(moved) / samples
+}
+
+func (suite *PreviewUpdateSuite) testPreview(updated []string) {
+	preview := PreviewUpdate(suite.ring, updated...)
+	next, _ := Update(suite.ring, updated...)
+
+	// the preview agrees with actually applying the update
+	suite.InDelta(suite.measureMoved(suite.ring, next), preview.Moved, 0.01)
+
+	var (
+		currentOwnership = suite.ring.Ownership()
+		nextOwnership    = next.Ownership()
+		gained, total    float64
+	)
+
+	all := make(map[string]bool)
+	for _, svc := range append(suite.ring.Services(), updated...) {
+		all[svc] = true
+	}
+
+	suite.Len(preview.Delta, len(all))
+	for svc, delta := range preview.Delta {
+		suite.InDelta(nextOwnership[svc]-currentOwnership[svc], delta, 1e-12, "service %s", svc)
+		total += delta
+		if delta > 0 {
+			gained += delta
+		}
+	}
+
+	// ownership is conserved, and everything a service gains must have moved
+	suite.InDelta(0.0, total, 1e-9)
+	suite.GreaterOrEqual(preview.Moved+1e-9, gained)
+}
+
+func (suite *PreviewUpdateSuite) TestAdd() {
+	suite.testPreview(services[:25])
+}
+
+func (suite *PreviewUpdateSuite) TestRemove() {
+	suite.testPreview(services[5:20])
+}
+
+func (suite *PreviewUpdateSuite) TestReplace() {
+	suite.testPreview(services[10:30])
+}
+
+func (suite *PreviewUpdateSuite) TestNoRehashing() {
+	suite.hashed = 0
+	PreviewUpdate(suite.ring, services[:25]...)
+	suite.Equal(5, suite.hashed)
+
+	suite.hashed = 0
+	PreviewUpdate(suite.ring, services[5:20]...)
+	suite.Zero(suite.hashed)
+}
+
+func (suite *PreviewUpdateSuite) TestNoChange() {
+	suite.Zero(PreviewUpdate(suite.ring, services[:20]...))
+	suite.Zero(PreviewUpdate(suite.ring, suite.ring.Services()...))
+}
+
+func (suite *PreviewUpdateSuite) TestEmpty() {
+	preview := PreviewUpdate(suite.ring)
+	suite.Equal(1.0, preview.Moved)
+	suite.Len(preview.Delta, 20)
+
+	empty, _ := Update(suite.ring)
+	preview = PreviewUpdate(empty, services[:3]...)
+	suite.Equal(1.0, preview.Moved)
+	suite.Len(preview.Delta, 3)
+}
+
+func TestPreviewUpdate(t *testing.T) {
+	suite.Run(t, new(PreviewUpdateSuite))
+}
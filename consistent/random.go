@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math/rand/v2"
+
+	"github.com/xmidt-org/medley"
+)
+
+// Random returns a random service, for traffic that has no natural key. A uniformly random
+// token is chosen, and its owner is returned. Services are therefore chosen in proportion to
+// their Ownership rather than uniformly.
+//
+// If rng is nil, the global source from math/rand/v2 is used. A non-nil rng must not be used
+// concurrently with other calls. If this ring is empty, this method returns
+// medley.ErrNoServices. The OnFind hook is not invoked, since there is no key.
+func (r *Ring[S]) Random(rng *rand.Rand) (svc S, err error) {
+	switch {
+	case len(r.nodes) == 0:
+		err = medley.ErrNoServices
+
+	case rng != nil:
+		svc = r.tokenOwner(rng.Uint64())
+
+	default:
+		svc = r.tokenOwner(rand.Uint64())
+	}
+
+	return
+}
+
+// RandomFromSeed is a deterministic version of Random. The token is derived from the seed with
+// the splitmix64 finalizer, so the same seed always selects the same service from the same ring,
+// and consecutive seeds are spread across the ring.
+func (r *Ring[S]) RandomFromSeed(seed uint64) (svc S, err error) {
+	if len(r.nodes) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	token := seed + 0x9e3779b97f4a7c15
+	token = (token ^ (token >> 30)) * 0xbf58476d1ce4e5b9
+	token = (token ^ (token >> 27)) * 0x94d049bb133111eb
+	token ^= token >> 31
+
+	svc = r.tokenOwner(token)
+	return
+}
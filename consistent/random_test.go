@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+const randomDraws = 200000
+
+type RandomSuite struct {
+	suite.Suite
+}
+
+// assertDistribution draws many random services and checks that each service's share of
+// the draws matches its ownership.
+func (suite *RandomSuite) assertDistribution(ring *Ring[string], seed uint64) {
+	var (
+		rng    = rand.New(rand.NewPCG(seed, seed))
+		counts = make(map[string]int)
+	)
+
+	for range randomDraws {
+		svc, err := ring.Random(rng)
+		suite.Require().NoError(err)
+		counts[svc]++
+	}
+
+	ownership := ring.Ownership()
+	suite.Len(counts, len(ownership))
+	for svc, fraction := range ownership {
+		suite.InDelta(fraction, float64(counts[svc])/randomDraws, 0.01, "service=%s", svc)
+	}
+}
+
+func (suite *RandomSuite) TestDistribution() {
+	for _, policy := range []SearchPolicy{Clockwise, CounterClockwise, NearestAbsolute} {
+		ring := Strings(services[:10]...).SearchPolicy(policy).VNodes(20).Build()
+		suite.assertDistribution(ring, uint64(policy)+1)
+	}
+}
+
+func (suite *RandomSuite) TestWeighted() {
+	// services[0] has ten times the vnodes of the others
+	ring, updated := UpdateVNodes(
+		Strings(services[:5]...).VNodes(20).Build(),
+		func(svc string) int {
+			if svc == services[0] {
+				return 200
+			}
+
+			return 0
+		},
+		services[:5]...,
+	)
+
+	suite.Require().True(updated)
+	suite.Greater(ring.Ownership()[services[0]], 0.5)
+	suite.assertDistribution(ring, 1234)
+}
+
+func (suite *RandomSuite) TestSkewedArcs() {
+	// a owns three quarters of the ring, and b owns one quarter
+	ring := new(SearchSuite).handRing(Clockwise, map[uint64]string{
+		1 << 62: "a",
+		1 << 63: "b",
+	})
+
+	suite.InDelta(0.75, ring.Ownership()["a"], 1e-9)
+	suite.assertDistribution(ring, 5678)
+}
+
+func (suite *RandomSuite) TestSingleService() {
+	ring := Strings("single").Build()
+	rng := rand.New(rand.NewPCG(1, 2))
+	for seed := range uint64(100) {
+		svc, err := ring.Random(rng)
+		suite.NoError(err)
+		suite.Equal("single", svc)
+
+		svc, err = ring.RandomFromSeed(seed)
+		suite.NoError(err)
+		suite.Equal("single", svc)
+	}
+
+	svc, err := ring.Random(nil)
+	suite.NoError(err)
+	suite.Equal("single", svc)
+}
+
+func (suite *RandomSuite) TestRandomFromSeed() {
+	var (
+		ring   = Strings(services[:10]...).Build()
+		copied = Strings(services[:10]...).Build()
+		counts = make(map[string]int)
+	)
+
+	for seed := range uint64(randomDraws) {
+		expected, err := ring.RandomFromSeed(seed)
+		suite.Require().NoError(err)
+
+		actual, err := copied.RandomFromSeed(seed)
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, actual)
+
+		counts[expected]++
+	}
+
+	// consecutive seeds are spread in proportion to ownership, too
+	for svc, fraction := range ring.Ownership() {
+		suite.InDelta(fraction, float64(counts[svc])/randomDraws, 0.01, "service=%s", svc)
+	}
+}
+
+func (suite *RandomSuite) TestEmpty() {
+	for _, ring := range []*Ring[string]{Strings[string]().Build(), new(Ring[string])} {
+		_, err := ring.Random(nil)
+		suite.ErrorIs(err, medley.ErrNoServices)
+
+		_, err = ring.RandomFromSeed(0)
+		suite.ErrorIs(err, medley.ErrNoServices)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	suite.Run(t, new(RandomSuite))
+}
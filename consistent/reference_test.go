@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+const (
+	// propertySeed is the random seed for generating service sets and mutations
+	propertySeed int64 = 3049583405983453
+
+	// propertyRounds is the number of membership mutations applied to each ring
+	propertyRounds = 10
+)
+
+// ReferenceSuite verifies that Rings agree with medleytest.ReferenceLocator.
+type ReferenceSuite struct {
+	suite.Suite
+
+	random *rand.Rand
+}
+
+func (suite *ReferenceSuite) SetupTest() {
+	suite.random = rand.New(rand.NewSource(propertySeed))
+}
+
+// randomServices returns a random, nonempty subset of a larger pool of services.
+func (suite *ReferenceSuite) randomServices() (result []string) {
+	count := 1 + suite.random.Intn(20)
+	for _, i := range suite.random.Perm(50)[:count] {
+		result = append(result, fmt.Sprintf("property-%d.example.net", i))
+	}
+
+	return
+}
+
+// assertAgrees checks that a ring agrees with the reference implementation, both in
+// its tokens and in every lookup.
+func (suite *ReferenceSuite) assertAgrees(alg medley.Algorithm, vnodes int, ring *Ring[string], services []string) {
+	var fromRing []medleytest.ReferenceNode[string]
+	for token, svc := range ring.Tokens() {
+		fromRing = append(fromRing, medleytest.ReferenceNode[string]{Token: token, Service: svc})
+	}
+
+	suite.Require().True(sort.SliceIsSorted(fromRing, func(i, j int) bool {
+		return fromRing[i].Token < fromRing[j].Token
+	}))
+
+	fromReference := medleytest.ReferenceNodes(alg, vnodes, medley.HashStringTo[string], services...)
+	suite.ElementsMatch(fromReference, fromRing)
+
+	reference := medleytest.ReferenceLocator[string]{Algorithm: alg, Nodes: fromReference}
+	for _, object := range hashObjects {
+		expected, expectedErr := reference.Find(object[:])
+		actual, actualErr := ring.Find(object[:])
+		suite.Equal(expectedErr, actualErr)
+		suite.Equal(expected, actual)
+	}
+}
+
+func (suite *ReferenceSuite) testAgreement(alg medley.Algorithm, vnodes int) func() {
+	return func() {
+		services := suite.randomServices()
+		ring := Strings(services...).Algorithm(alg).VNodes(vnodes).Build()
+		suite.assertAgrees(alg, vnodes, ring, services)
+
+		for range propertyRounds {
+			services = suite.randomServices()
+			ring, _ = Update(ring, services...)
+			suite.assertAgrees(alg, vnodes, ring, services)
+		}
+	}
+}
+
+func (suite *ReferenceSuite) TestAgreement() {
+	suite.Run("Default", suite.testAgreement(medley.DefaultAlgorithm(), DefaultVNodes))
+	suite.Run("FewVNodes", suite.testAgreement(medley.DefaultAlgorithm(), 3))
+	suite.Run("fnv", suite.testAgreement(medley.Algorithm{New64: fnv.New64}, 50))
+}
+
+func (suite *ReferenceSuite) TestScriptedLayout() {
+	// three services with hand-chosen arcs: a owns (7000, 1000] and (3000, 5000], and so on
+	sa := medleytest.NewScriptedAlgorithm(0)
+	for svc, tokens := range map[string][]uint64{
+		"a.example.net": {1000, 5000},
+		"b.example.net": {2000, 6000},
+		"c.example.net": {3000, 7000},
+	} {
+		suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], svc, tokens...))
+	}
+
+	ring := Strings("a.example.net", "b.example.net", "c.example.net").
+		Algorithm(sa.Algorithm()).
+		VNodes(2).
+		Build()
+
+	var tokens []uint64
+	for token := range ring.Tokens() {
+		tokens = append(tokens, token)
+	}
+
+	suite.Equal([]uint64{1000, 2000, 3000, 5000, 6000, 7000}, tokens)
+
+	testCases := []struct {
+		key      uint64
+		expected string
+	}{
+		{key: 0, expected: "a.example.net"},
+		{key: 1000, expected: "a.example.net"},
+		{key: 1001, expected: "b.example.net"},
+		{key: 2500, expected: "c.example.net"},
+		{key: 4000, expected: "a.example.net"},
+		{key: 5500, expected: "b.example.net"},
+		{key: 6500, expected: "c.example.net"},
+		{key: 7001, expected: "a.example.net"},
+		{key: ^uint64(0), expected: "a.example.net"},
+	}
+
+	for _, testCase := range testCases {
+		object := fmt.Sprintf("key-%d", testCase.key)
+		sa.ScriptString(object, testCase.key)
+
+		svc, err := ring.Find([]byte(object))
+		suite.NoError(err)
+		suite.Equal(testCase.expected, svc, "key token %d", testCase.key)
+	}
+}
+
+func TestReference(t *testing.T) {
+	suite.Run(t, new(ReferenceSuite))
+}
+
+func TestChurn(t *testing.T) {
+	var keys [][]byte
+	for _, object := range hashObjects {
+		keys = append(keys, object[:])
+	}
+
+	medleytest.Churn(t, medleytest.ChurnConfig{
+		Seed:        propertySeed,
+		Keys:        keys,
+		MaxMovement: 0.35,
+		Build: func(services []string) medley.Locator[string] {
+			return Strings(services...).Build()
+		},
+		Update: func(current medley.Locator[string], services []string) medley.Locator[string] {
+			next, _ := Update(current.(*Ring[string]), services...)
+			return next
+		},
+		Reference: func(services []string) medley.Locator[string] {
+			alg := medley.DefaultAlgorithm()
+			return medleytest.ReferenceLocator[string]{
+				Algorithm: alg,
+				Nodes:     medleytest.ReferenceNodes(alg, DefaultVNodes, medley.HashStringTo[string], services...),
+			}
+		},
+	})
+}
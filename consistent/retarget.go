@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+
+	"github.com/xmidt-org/medley"
+)
+
+// resizeNodes returns a service's nodes for the given number of vnodes, starting from the
+// service's current nodes. Since a service's nodes for n vnodes are exactly its nodes for the
+// increments in [0, n), only the increments that are added or removed are hashed, and the
+// current nodes that remain are reused.
+func (h hasher[S]) resizeNodes(svc S, current nodes[S], vnodes int) nodes[S] {
+	switch n := len(current); {
+	case vnodes > n:
+		added, _ := h.incrementNodes(svc, n, vnodes)
+		resized := make(nodes[S], vnodes)
+		mergeInto(resized, current, added)
+		return resized
+
+	case vnodes < n:
+		removed, _ := h.incrementNodes(svc, vnodes, n)
+		drop := make(map[uint64]int, len(removed))
+		for _, r := range removed {
+			drop[r.token]++
+		}
+
+		resized := make(nodes[S], 0, vnodes)
+		for _, c := range current {
+			if drop[c.token] > 0 {
+				drop[c.token]--
+				continue
+			}
+
+			resized = append(resized, c)
+		}
+
+		return resized
+
+	default:
+		return current
+	}
+}
+
+// resize creates a ring with the same services as this ring, but with the given vnodes
+// for every service.
+func (r *Ring[S]) resize(vnodes int) *Ring[S] {
+	next := &Ring[S]{
+		hasher:  r.config(),
+		onFind:  r.onFind,
+		extract: r.extract,
+		cache:   make(medley.Map[S, nodes[S]], len(r.cache)),
+	}
+
+	next.hasher.vnodes = vnodes
+	next.hasher.autoImbalance = 0
+	next.hasher.requested = 0
+
+	runs := make([]nodes[S], 0, len(r.cache))
+	for svc, snodes := range r.cache {
+		resized := next.hasher.resizeNodes(svc, snodes, vnodes)
+		next.cache[svc] = resized
+		runs = append(runs, resized)
+	}
+
+	next.nodes = mergeRuns(runs)
+	next.index()
+	return next
+}
+
+// Retarget returns a sequence of rings that gradually migrates this ring to a different number
+// of vnodes per service, which spreads the movement of objects over several steps rather than
+// moving them all at once. A nonpositive vnodes means DefaultVNodes.
+//
+// Each step moves every service an equal share of the way to the target vnodes, adding or
+// removing the service's highest vnode increments. Each ring in the sequence is the same as a
+// ring built from scratch with that step's vnodes, and the last ring is the same as a ring built
+// with the target vnodes. Only the added or removed vnodes are hashed at each step.
+//
+// There are at most steps rings, but never more than the difference in vnodes. A nonpositive
+// steps means a single step. If this ring already uses the target vnodes, the sequence is empty.
+// Rings in the sequence are not auto-tuned, even if this ring was built with Builder.AutoVNodes.
+//
+// Rings are computed as the sequence is iterated, so stopping early avoids computing the
+// remaining rings. This ring is not modified.
+func (r *Ring[S]) Retarget(vnodes, steps int) iter.Seq[*Ring[S]] {
+	if vnodes < 1 {
+		vnodes = DefaultVNodes
+	}
+
+	var (
+		from  = r.config().vnodes
+		delta = vnodes - from
+	)
+
+	steps = min(max(steps, 1), max(delta, -delta))
+	return func(f func(*Ring[S]) bool) {
+		current := r
+		for k := 1; k <= steps; k++ {
+			current = current.resize(from + delta*k/steps)
+			if !f(current) {
+				return
+			}
+		}
+	}
+}
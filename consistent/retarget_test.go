@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetargetSuite struct {
+	suite.Suite
+}
+
+// assertValid asserts that a ring is internally consistent and uses the given vnodes.
+func (suite *RetargetSuite) assertValid(r *Ring[string], vnodes int) {
+	suite.Require().True(sort.IsSorted(r.nodes))
+	suite.Equal(r.nodes.tokens(), r.tokens)
+	suite.Equal(vnodes, r.VNodes())
+	suite.Len(r.nodes, vnodes*r.Len())
+
+	counts := make(map[string]int)
+	for _, n := range r.nodes {
+		counts[n.service]++
+	}
+
+	for svc, snodes := range r.cache {
+		suite.Equal(vnodes, counts[svc])
+		suite.Len(snodes, vnodes)
+		suite.True(sort.IsSorted(snodes))
+	}
+}
+
+func (suite *RetargetSuite) testRetarget(from, to, steps int, expected []int) {
+	var (
+		original = Strings(services[:50]...).VNodes(from).Build()
+		rings    = slices.Collect(original.Retarget(to, steps))
+		final    = Strings(services[:50]...).VNodes(to).Build()
+	)
+
+	suite.Require().Len(rings, len(expected))
+	for i, r := range rings {
+		suite.assertValid(r, expected[i])
+
+		// each step is the same as building with that step's vnodes
+		suite.True(Strings(services[:50]...).VNodes(expected[i]).Build().Equal(r), "step %d", i)
+	}
+
+	suite.True(final.Equal(rings[len(rings)-1]))
+	suite.Equal(final.Fingerprint(), rings[len(rings)-1].Fingerprint())
+
+	// the fraction of the keyspace left to move shrinks with every step
+	previous := movedFraction(original.nodes, final.nodes)
+	for _, r := range rings {
+		remaining := movedFraction(r.nodes, final.nodes)
+		suite.Less(remaining, previous)
+		previous = remaining
+	}
+
+	suite.Zero(previous)
+
+	// the original is untouched
+	suite.assertValid(original, from)
+}
+
+func (suite *RetargetSuite) TestGrow() {
+	suite.testRetarget(50, 200, 3, []int{100, 150, 200})
+	suite.testRetarget(50, 200, 0, []int{200})
+	suite.testRetarget(1, 4, 10, []int{2, 3, 4})
+}
+
+func (suite *RetargetSuite) TestShrink() {
+	suite.testRetarget(200, 50, 3, []int{150, 100, 50})
+	suite.testRetarget(200, 50, 1, []int{50})
+	suite.testRetarget(100, DefaultVNodes, 2, []int{150, 200})
+}
+
+func (suite *RetargetSuite) TestSpread() {
+	var (
+		original = Strings(services[:50]...).VNodes(50).Build()
+		rebuild  = movedFraction(original.nodes, Strings(services[:50]...).VNodes(250).Build().nodes)
+		previous = original
+	)
+
+	// no single step moves as much as rebuilding all at once
+	for r := range original.Retarget(250, 4) {
+		suite.Less(movedFraction(previous.nodes, r.nodes), rebuild)
+		previous = r
+	}
+}
+
+func (suite *RetargetSuite) TestReuse() {
+	var (
+		original = Strings(services[:10]...).VNodes(50).Build()
+		grown    = slices.Collect(original.Retarget(100, 1))[0]
+		shrunk   = slices.Collect(grown.Retarget(50, 1))[0]
+	)
+
+	// retained vnodes are shared rather than rehashed
+	for svc, snodes := range original.cache {
+		for _, n := range snodes {
+			suite.Contains(grown.cache[svc], n)
+			suite.Contains(shrunk.cache[svc], n)
+		}
+	}
+
+	suite.True(original.Equal(shrunk))
+}
+
+func (suite *RetargetSuite) TestNoChange() {
+	r := Strings(services[:10]...).VNodes(50).Build()
+	suite.Empty(slices.Collect(r.Retarget(50, 5)))
+	suite.Empty(slices.Collect(Strings(services[:10]...).Build().Retarget(0, 5)))
+}
+
+func (suite *RetargetSuite) TestEarlyBreak() {
+	var (
+		r     = Strings(services[:10]...).VNodes(10).Build()
+		steps int
+	)
+
+	for range r.Retarget(100, 10) {
+		steps++
+		if steps == 2 {
+			break
+		}
+	}
+
+	suite.Equal(2, steps)
+}
+
+func (suite *RetargetSuite) TestAutoVNodes() {
+	r := Strings(services[:10]...).AutoVNodes(1.2).Build()
+	rings := slices.Collect(r.Retarget(r.VNodes()+10, 1))
+	suite.Require().Len(rings, 1)
+
+	// the retargeted ring keeps its vnodes through updates
+	updated, _ := Update(rings[0], services[:20]...)
+	suite.Equal(r.VNodes()+10, updated.VNodes())
+}
+
+func TestRetarget(t *testing.T) {
+	suite.Run(t, new(RetargetSuite))
+}
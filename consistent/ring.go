@@ -4,11 +4,28 @@
 package consistent
 
 import (
-	"sort"
+	"iter"
 
 	"github.com/xmidt-org/medley"
 )
 
+const (
+	// SourceRing is the medley.FindResult.Source for services found by a Ring.
+	SourceRing = "ring"
+
+	// SourceSticky is the medley.FindResult.Source for services that a StickyLocator kept
+	// from its previous generation.
+	SourceSticky = "sticky"
+
+	// SourceScored is the medley.FindResult.Source for services that a ScoredLocator chose
+	// over the owner of an object.
+	SourceScored = "scored"
+
+	// SourceSplit is the medley.FindResult.Source for services that a SplitLocator chose
+	// for an object with a split rule.
+	SourceSplit = "split"
+)
+
 // Ring is a hash circle that distributes services randomly
 // along a circle. A Ring should be created through a Builder.
 //
@@ -20,27 +37,134 @@ import (
 //
 // Rings are immutable once created. To handle an updated set of services,
 // use the Update function.
+//
+// The zero Ring is an empty ring with the default configuration. Find returns
+// medley.ErrNoServices, and Update creates a ring with DefaultVNodes and the
+// default algorithm, as Builder.Build would. A nil *Ring is not usable.
+//
+// A Ring built with Strings and the default algorithm returns the same service for every
+// object as a consistentHash with the same members and vnode count, so callers can migrate
+// from that package without moving any objects. Ring lookups search a contiguous slice of
+// tokens and take no locks.
 type Ring[S medley.Service] struct {
-	hasher hasher[S]
+	hasher  hasher[S]
+	onFind  func(FindTrace[S])
+	extract medley.KeyExtractor
 
 	// cache holds each individual service's nodes.  This is used
 	// primarily to quickly rehash a ring, since we don't need to spend
 	// compute computing tokens that we've already computed.
+	//
+	// A service with n nodes always has exactly the nodes for the vnode
+	// increments in [0, n), which lets Retarget add or remove increments.
 	cache medley.Map[S, nodes[S]]
 
+	// digests holds a digest of each service's hash bytes, but only when
+	// the hasher verifies stable hashing. See UpdateE.
+	digests medley.Map[S, uint64]
+
 	// nodes is the ring's storage
 	nodes nodes[S]
+
+	// tokens holds the token of each node, in the same order as nodes. Lookups
+	// search these contiguous tokens rather than the nodes.
+	tokens []uint64
+
+	// fingerprint is the digest returned by Fingerprint
+	fingerprint uint64
+
+	// collisions is the number of nodes whose token equals another node's token
+	collisions int
+}
+
+// config returns this ring's hasher. The zero Ring has no hasher, so in that case the
+// defaults that a Builder would apply are returned.
+func (r *Ring[S]) config() hasher[S] {
+	if r.hasher.serviceHasher == nil {
+		return r.hasher.withDefaults()
+	}
+
+	return r.hasher
+}
+
+// FindTrace describes a single, successful lookup on a Ring.
+type FindTrace[S medley.Service] struct {
+	// Token is the hash of the key of the object passed to Find.
+	Token uint64
+
+	// Service is the service that Find returned.
+	Service S
+
+	// RingSize is the total number of nodes in the Ring.
+	RingSize int
 }
 
-// Find performs a hash on the given object and returns the nearest
+// NodeInfo describes the node that a lookup on a Ring matched.
+type NodeInfo[S medley.Service] struct {
+	// Service is the service that owns the matched node.
+	Service S
+
+	// Token is the matched node's token.
+	Token uint64
+
+	// KeyToken is the hash of the key of the object that was looked up. With the default
+	// Clockwise policy, Token is the smallest token on the ring that is greater than or equal
+	// to KeyToken, unless the lookup wrapped around the ring. In that case, Token is the
+	// smallest token on the ring. See SearchPolicy for the other policies.
+	KeyToken uint64
+
+	// Index is the position of the matched node within the ring's sorted nodes.
+	Index int
+}
+
+// Find performs a hash on the given object's key and returns the nearest
 // service. If this ring is empty, this method returns medley.ErrNoServices.
+//
+// By default, an object is its own key. If this ring has a KeyExtractor and
+// extraction fails, the returned error wraps medley.ErrKeyExtraction.
 func (r *Ring[S]) Find(object []byte) (svc S, err error) {
+	var info NodeInfo[S]
+	info, err = r.FindNode(object)
+	svc = info.Service
+	return
+}
+
+// FindString is like Find, but for a string object. Unless medley is built with the
+// medley_nounsafe tag, the string is not copied, so this method doesn't allocate.
+func (r *Ring[S]) FindString(v string) (S, error) {
+	return medley.FindString[S](r, v)
+}
+
+// FindAnnotated is like Find, but reports SourceRing as the source of the result.
+func (r *Ring[S]) FindAnnotated(object []byte) (result medley.FindResult[S], err error) {
+	result.Service, err = r.Find(object)
+	result.Source = SourceRing
+	return
+}
+
+// FindNode is like Find, but returns a description of the matched node rather than
+// just its service. This is useful for debugging the distribution of objects.
+func (r *Ring[S]) FindNode(object []byte) (info NodeInfo[S], err error) {
 	if len(r.nodes) > 0 {
-		node := r.nearest(
-			r.hasher.sum64(object),
-		)
+		var key []byte
+		if key, err = medley.ExtractKey(r.extract, object); err != nil {
+			return
+		}
+
+		info.KeyToken = r.hasher.sum64(key)
+		info.Index = r.hasher.search(r.tokens, info.KeyToken)
 
-		svc = node.service
+		n := r.nodes[info.Index]
+		info.Service = n.service
+		info.Token = n.token
+
+		if r.onFind != nil {
+			r.onFind(FindTrace[S]{
+				Token:    info.KeyToken,
+				Service:  info.Service,
+				RingSize: len(r.nodes),
+			})
+		}
 	} else {
 		err = medley.ErrNoServices
 	}
@@ -48,20 +172,215 @@ func (r *Ring[S]) Find(object []byte) (svc S, err error) {
 	return
 }
 
-// nearest returns the nearest node to the target hash value.
-func (r *Ring[S]) nearest(target uint64) *node[S] {
-	i := sort.Search(
-		r.nodes.Len(),
-		func(p int) bool {
-			return r.nodes[p].token >= target
-		},
-	)
+// tokenOwner returns the service that owns the given token. This ring must not be empty.
+func (r *Ring[S]) tokenOwner(token uint64) S {
+	return r.nodes[r.hasher.search(r.tokens, token)].service
+}
+
+// Contains tests if the given service is hashed by this ring.
+func (r *Ring[S]) Contains(svc S) bool {
+	_, exists := r.cache[svc]
+	return exists
+}
+
+// Len returns the number of services hashed by this ring.
+func (r *Ring[S]) Len() int {
+	return len(r.cache)
+}
+
+// VNodes returns the number of vnodes per service used by this ring. For a ring built
+// with Builder.AutoVNodes, this is the tuned number of vnodes.
+func (r *Ring[S]) VNodes() int {
+	return r.config().vnodes
+}
+
+// Services returns the services hashed by this ring, in no particular order.
+func (r *Ring[S]) Services() []S {
+	services := make([]S, 0, len(r.cache))
+	for svc := range r.cache {
+		services = append(services, svc)
+	}
+
+	return services
+}
+
+// All returns a sequence of the services hashed by this ring, in no particular order. Unlike
+// Services, this doesn't allocate a slice. Rings are immutable, so the sequence can be iterated
+// any number of times.
+func (r *Ring[S]) All() iter.Seq[S] {
+	return r.cache.All()
+}
+
+// Ownership computes the fraction of the hash circle owned by each service. The
+// fractions sum to 1.0, unless this ring is empty. With the default Clockwise policy,
+// a service owns the arc from the previous token, exclusive, up to and including each
+// of its own tokens. See SearchPolicy for the other policies.
+func (r *Ring[S]) Ownership() medley.Map[S, float64] {
+	ownership := make(medley.Map[S, float64], len(r.cache))
+	switch len(r.nodes) {
+	case 0:
+		return ownership
+
+	case 1:
+		ownership[r.nodes[0].service] = 1.0
+		return ownership
+	}
+
+	for i, n := range r.nodes {
+		// unsigned subtraction handles the arcs that wrap around zero
+		var (
+			previous = n.token - r.nodes[(i-1+len(r.nodes))%len(r.nodes)].token
+			next     = r.nodes[(i+1)%len(r.nodes)].token - n.token
+			arc      float64
+		)
+
+		switch r.hasher.policy {
+		case CounterClockwise:
+			arc = float64(next)
+
+		case NearestAbsolute:
+			// each token owns the half of each neighboring gap closest to it
+			arc = float64(previous)/2 + float64(next)/2
+
+		default:
+			arc = float64(previous)
+		}
+
+		ownership[n.service] += arc / (1 << 64)
+	}
+
+	return ownership
+}
+
+// Tokens returns a sequence of every token on this ring along with its service,
+// in ascending token order.
+func (r *Ring[S]) Tokens() iter.Seq2[uint64, S] {
+	return func(f func(uint64, S) bool) {
+		for _, n := range r.nodes {
+			if !f(n.token, n.service) {
+				return
+			}
+		}
+	}
+}
+
+// Successors returns a sequence of the distinct services on this ring, starting with
+// the owner of the given object and moving clockwise, or in the order of this ring's
+// SearchPolicy. Each service is visited at most once, and each service is the owner of
+// the object if every service before it were removed.
+// The sequence is empty if this ring is empty or if the object's key cannot be extracted.
+//
+// Rings with the same services, vnodes, and algorithm yield the same sequences, regardless of
+// whether they were created by a Builder, Update, or Merge. Services whose tokens collide are
+// ordered by a digest of their hash bytes, so only services with identical hash bytes can be
+// ordered differently.
+func (r *Ring[S]) Successors(object []byte) iter.Seq[S] {
+	key, err := medley.ExtractKey(r.extract, object)
+	if err != nil {
+		return func(func(S) bool) {}
+	}
+
+	return r.successors(r.config().sum64(key))
+}
+
+// successors returns a sequence of the distinct services on this ring, starting with
+// the owner of the given token and following this ring's SearchPolicy.
+func (r *Ring[S]) successors(token uint64) iter.Seq[S] {
+	return func(f func(S) bool) {
+		if len(r.nodes) == 0 {
+			return
+		}
+
+		seen := make(medley.Map[S, bool], len(r.cache))
+		for i := range r.hasher.walk(r.tokens, token) {
+			if len(seen) >= len(r.cache) {
+				return
+			}
+
+			svc := r.nodes[i].service
+			if seen[svc] {
+				continue
+			}
+
+			seen[svc] = true
+			if !f(svc) {
+				return
+			}
+		}
+	}
+}
+
+// FindExcluding returns the service that would own the given object if the excluded services
+// were removed from this ring, without building a new ring. Excluded services that are not in
+// this ring are ignored. If every service is excluded, this method returns medley.ErrNoServices.
+//
+// The result is the same as Find on a ring updated to remove the excluded services. This ring
+// is not modified, and the OnFind hook is not invoked.
+func (r *Ring[S]) FindExcluding(object []byte, exclude ...S) (svc S, err error) {
+	key, err := medley.ExtractKey(r.extract, object)
+	if err != nil {
+		return
+	}
+
+	excluded := make(medley.Map[S, bool], len(exclude))
+	for _, e := range exclude {
+		if _, exists := r.cache[e]; exists {
+			excluded[e] = true
+		}
+	}
+
+	if len(excluded) == len(r.cache) {
+		err = medley.ErrNoServices
+		return
+	}
+
+	hasher := r.config()
+	for i := range hasher.walk(r.tokens, hasher.sum64(key)) {
+		if n := r.nodes[i]; !excluded[n.service] {
+			svc = n.service
+			return
+		}
+	}
 
-	if i >= r.nodes.Len() {
-		i = 0
+	// unreachable, since at least one service is not excluded
+	err = medley.ErrNoServices
+	return
+}
+
+// Equal tests if this ring has the same configuration and the same nodes as
+// another ring. This is useful to determine if two independently built rings will
+// always produce the same lookups.
+//
+// Rings are equal if they use the same number of vnodes and the same SearchPolicy,
+// their algorithms hash a fixed set of probe objects identically, and they have
+// identical sequences of tokens and services. Two nil rings are equal, while a nil ring is never equal
+// to a non-nil ring.
+func (r *Ring[S]) Equal(other *Ring[S]) bool {
+	switch {
+	case r == other:
+		return true
+
+	case r == nil || other == nil:
+		return false
+
+	case r.config().vnodes != other.config().vnodes || len(r.nodes) != len(other.nodes):
+		return false
+
+	case r.config().policy != other.config().policy:
+		return false
 	}
 
-	return r.nodes[i]
+	if !sameAlgorithm(r.config().alg, other.config().alg) {
+		return false
+	}
+
+	for i, n := range r.nodes {
+		if n.token != other.nodes[i].token || n.service != other.nodes[i].service {
+			return false
+		}
+	}
+
+	return true
 }
 
 // Update checks if a set of services constitutes an update to the given Ring.
@@ -75,36 +394,196 @@ func (r *Ring[S]) nearest(target uint64) *node[S] {
 // time spent hashing. This method returns true in this case, to indicate that an update was
 // necessary.
 //
+// Newly hashed services are subject to the current Ring's limit on service hash bytes. A service
+// that exceeds the limit is hashed using its truncated bytes and reported to the Ring's truncation
+// hook, as with Builder.Build.
+//
 // The current Ring is not modified by this function.
 func Update[S medley.Service](current *Ring[S], services ...S) (next *Ring[S], updated bool) {
+	return UpdateVNodes(current, nil, services...)
+}
+
+// UpdateSeq is like Update, but consumes a sequence of services, e.g. from a discovery client
+// that pages through results. The sequence is consumed exactly once. Only distinct services are
+// buffered, so the memory used is bounded by the number of distinct services rather than the
+// length of the sequence. The result is the same as Update with the same services, including
+// returning the current Ring along with false when the services are unchanged.
+func UpdateSeq[S medley.Service](current *Ring[S], services iter.Seq[S]) (next *Ring[S], updated bool) {
 	var (
-		cache                   = make(medley.Map[S, nodes[S]], len(services))
-		nodes                   = make(nodes[S], 0, current.hasher.ringSize(len(services)))
+		distinct []S
+		seen     = make(medley.Map[S, bool], len(current.cache))
+	)
+
+	for svc := range services {
+		if !seen[svc] {
+			seen[svc] = true
+			distinct = append(distinct, svc)
+		}
+	}
+
+	return Update(current, distinct...)
+}
+
+// UpdateVNodes is like Update, but allows the number of vnodes to be overridden for individual
+// services. The vnodes function returns the number of vnodes for a service, and a nonpositive
+// result means the current Ring's vnodes. If vnodes is nil, every service uses the current
+// Ring's vnodes, which is the same as Update.
+//
+// A service whose number of vnodes changed is rehashed. Since a service's tokens for fewer
+// vnodes are a subset of its tokens for more vnodes, growing a service's vnodes only moves
+// objects to that service, and shrinking a service's vnodes only moves objects away from it.
+//
+// Overrides are not remembered by the returned Ring. Each call to this function determines
+// the vnodes for every service.
+func UpdateVNodes[S medley.Service](current *Ring[S], vnodes func(S) int, services ...S) (next *Ring[S], updated bool) {
+	// without strict checks, there is never an error
+	next, updated, _ = update(current, vnodes, false, services)
+	return
+}
+
+// UpdateE is like Update, but can fail instead of creating a Ring that doesn't satisfy the
+// current Ring's Builder configuration:
+//
+//   - With Builder.MaxRingNodes, a Ring that would exceed the limit fails with ErrRingTooLarge,
+//     unless Builder.ReduceVNodesToFit is set. Update reduces vnodes in that case.
+//   - With Builder.VerifyStableHashing, a sample of the retained services is rehashed, and a
+//     service whose hash bytes changed fails with ErrUnstableServiceHash. Update reuses the
+//     nodes of services it already has, so an unstable ServiceHasher would otherwise go
+//     unnoticed until those services are rehashed, e.g. by a later Build.
+//
+// On failure, the current Ring is returned along with false and an error that names the problem.
+// Without either option, UpdateE never fails and behaves exactly like Update.
+func UpdateE[S medley.Service](current *Ring[S], services ...S) (next *Ring[S], updated bool, err error) {
+	return update(current, nil, true, services)
+}
+
+// UpdateVNodesE is like UpdateVNodes, but can fail in the same ways as UpdateE. Overridden
+// vnodes count toward MaxRingNodes, and are reduced in proportion when vnodes are reduced to fit.
+func UpdateVNodesE[S medley.Service](current *Ring[S], vnodes func(S) int, services ...S) (next *Ring[S], updated bool, err error) {
+	return update(current, vnodes, true, services)
+}
+
+// update implements the various Update functions. If strict is set, the failures described
+// by UpdateE are returned rather than tolerated.
+func update[S medley.Service](current *Ring[S], vnodes func(S) int, strict bool, services []S) (next *Ring[S], updated bool, err error) {
+	var (
+		cache   = make(medley.Map[S, nodes[S]], len(services))
+		fresh   = make([]nodes[S], 0, len(services))
+		hasher  = current.config()
+		digests medley.Map[S, uint64]
+
+		// rehashed holds existing services whose nodes were replaced
+		rehashed                medley.Map[S, bool]
 		newCount, existingCount int
+		kept                    int
 	)
 
+	vnodesFor := func(svc S) int {
+		if vnodes != nil {
+			if override := vnodes(svc); override > 0 {
+				return override
+			}
+		}
+
+		return hasher.vnodes
+	}
+
+	if hasher.autoImbalance > 0 || hasher.maxNodes > 0 || hasher.requested > 0 {
+		// when the tuned or reduced vnodes change, every service is rehashed below
+		distinct := make(medley.Map[S, bool], len(services))
+		for _, svc := range services {
+			distinct[svc] = true
+		}
+
+		hasher.vnodes = hasher.tunedVNodes(len(distinct))
+		if hasher.maxNodes > 0 {
+			total := 0
+			for svc := range distinct {
+				total += vnodesFor(svc)
+			}
+
+			scale, err := hasher.fitVNodes(len(distinct), total, strict)
+			if err != nil {
+				return current, false, err
+			} else if scale != nil {
+				// overrides are scaled along with the default, which vnodesFor reads from the hasher
+				unscaled := vnodes
+				vnodes = func(svc S) int {
+					if unscaled != nil {
+						if override := unscaled(svc); override > 0 {
+							return scale(override)
+						}
+					}
+
+					return 0
+				}
+
+				hasher.requested = hasher.vnodes
+				hasher.vnodes = scale(hasher.vnodes)
+			}
+		}
+	}
+
+	if hasher.verifySample > 0 {
+		digests = make(medley.Map[S, uint64], len(services))
+	}
+
 	for update := range current.cache.Update(services...) {
-		if update.Exists {
+		if _, duplicate := cache[update.Service]; duplicate {
+			continue
+		}
+
+		v := vnodesFor(update.Service)
+		if update.Exists && len(update.Value) == v {
 			existingCount++
+			kept += len(update.Value)
 			cache[update.Service] = update.Value
-			nodes = append(nodes, update.Value...)
+			if digests != nil {
+				if digests[update.Service], err = current.verifyDigest(update.Service, strict); err != nil {
+					return current, false, err
+				}
+			}
 		} else {
 			newCount++
-			snodes := current.hasher.serviceNodes(update.Service)
+			if update.Exists {
+				if rehashed == nil {
+					rehashed = make(medley.Map[S, bool])
+				}
+
+				rehashed[update.Service] = true
+			}
+
+			snodes, truncated := hasher.serviceNodes(update.Service, v)
+			if truncated {
+				hasher.truncated(update.Service)
+			}
+
 			cache[update.Service] = snodes
-			nodes = append(nodes, snodes...)
+			fresh = append(fresh, snodes)
+			if digests != nil {
+				digests[update.Service] = hasher.digest(update.Service)
+			}
 		}
 	}
 
 	updated = (newCount > 0 || existingCount != len(current.cache))
 	if updated {
+		// existing nodes are already sorted, so only the fresh runs need a full merge
+		keep := func(svc S) bool {
+			_, ok := cache[svc]
+			return ok && !rehashed[svc]
+		}
+
 		next = &Ring[S]{
-			hasher: current.hasher,
-			cache:  cache,
-			nodes:  nodes,
+			hasher:  hasher,
+			onFind:  current.onFind,
+			extract: current.extract,
+			cache:   cache,
+			digests: digests,
+			nodes:   mergeUpdate(current.nodes, keep, kept, fresh),
 		}
 
-		sort.Sort(next.nodes)
+		next.index()
 	} else {
 		next = current
 	}
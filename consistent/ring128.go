@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/xmidt-org/medley"
+)
+
+// Token128 is a 128-bit token on a Ring128. The first element is the high word. Tokens
+// are ordered lexicographically, so the high word is compared first.
+type Token128 [2]uint64
+
+// Compare returns -1, 0, or +1 depending on whether t is less than, equal to, or greater than u.
+func (t Token128) Compare(u Token128) int {
+	if c := cmp.Compare(t[0], u[0]); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(t[1], u[1])
+}
+
+// node128 is a single hash ring node for a service on a Ring128.
+type node128[S medley.Service] struct {
+	token   Token128
+	service S
+}
+
+// nodes128 is the storage for a Ring128.
+type nodes128[S medley.Service] []*node128[S]
+
+// compareNodes128 orders nodes by token.
+func compareNodes128[S medley.Service](a, b *node128[S]) int {
+	return a.token.Compare(b.token)
+}
+
+// tokens returns the tokens of these nodes, in the same order.
+func (ns nodes128[S]) tokens() []Token128 {
+	tokens := make([]Token128, len(ns))
+	for i, n := range ns {
+		tokens[i] = n.token
+	}
+
+	return tokens
+}
+
+// searchTokens128 is like searchTokens, but for 128-bit tokens.
+func searchTokens128(tokens []Token128, token Token128) int {
+	i, _ := slices.BinarySearchFunc(tokens, token, Token128.Compare)
+	if i >= len(tokens) {
+		i = 0
+	}
+
+	return i
+}
+
+// sortRuns128 combines individually sorted runs of nodes into a single, new sorted nodes.
+// As with mergeRuns, ties are broken in favor of the earlier run.
+func sortRuns128[S medley.Service](runs []nodes128[S]) nodes128[S] {
+	all := slices.Concat(runs...)
+	slices.SortStableFunc(all, compareNodes128[S])
+	return all
+}
+
+// Ring128 is a hash circle that uses 128-bit tokens, which makes collisions between tokens
+// vanishingly unlikely even for rings with many millions of nodes. A Ring128 should be
+// created through Builder.Tokens128.
+//
+// A Ring128 is otherwise the same as a Ring, and is a valid medley.Locator. Tokens of the two
+// sizes never share a ring: a Ring128 can only be updated with Update128, which always produces
+// another Ring128.
+//
+// With the default algorithms, the high word of each 128-bit token is the same as the 64-bit
+// token that a Ring would compute. So, a Ring128 returns the same service as the equivalent Ring
+// for every object, except where the Ring's tokens tie.
+type Ring128[S medley.Service] struct {
+	hasher  hasher[S]
+	alg     medley.Algorithm128
+	extract medley.KeyExtractor
+
+	// cache holds each individual service's nodes, as with Ring.
+	cache medley.Map[S, nodes128[S]]
+
+	nodes  nodes128[S]
+	tokens []Token128
+}
+
+var _ medley.Locator[string] = (*Ring128[string])(nil)
+
+// Find performs a hash on the given object's key and returns the nearest
+// service. If this ring is empty, this method returns medley.ErrNoServices.
+func (r *Ring128[S]) Find(object []byte) (svc S, err error) {
+	if len(r.nodes) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	var key []byte
+	if key, err = medley.ExtractKey(r.extract, object); err == nil {
+		svc = r.findToken(r.sum128(key))
+	}
+
+	return
+}
+
+// sum128 computes the token of an object's key.
+func (r *Ring128[S]) sum128(key []byte) Token128 {
+	hi, lo := r.alg.Sum128Bytes(key)
+	return Token128{hi, lo}
+}
+
+// findToken returns the service nearest to the given token. This ring must not be empty.
+func (r *Ring128[S]) findToken(token Token128) S {
+	return r.nodes[searchTokens128(r.tokens, token)].service
+}
+
+// Contains tests if the given service is hashed by this ring.
+func (r *Ring128[S]) Contains(svc S) bool {
+	_, exists := r.cache[svc]
+	return exists
+}
+
+// Len returns the number of services hashed by this ring.
+func (r *Ring128[S]) Len() int {
+	return len(r.cache)
+}
+
+// VNodes returns the number of vnodes per service used by this ring.
+func (r *Ring128[S]) VNodes() int {
+	return r.hasher.vnodes
+}
+
+// Services returns the services hashed by this ring, in no particular order.
+func (r *Ring128[S]) Services() []S {
+	services := make([]S, 0, len(r.cache))
+	for svc := range r.cache {
+		services = append(services, svc)
+	}
+
+	return services
+}
+
+// Tokens returns a sequence of every token on this ring along with its service,
+// in ascending token order.
+func (r *Ring128[S]) Tokens() iter.Seq2[Token128, S] {
+	return func(f func(Token128, S) bool) {
+		for _, n := range r.nodes {
+			if !f(n.token, n.service) {
+				return
+			}
+		}
+	}
+}
+
+// serviceNodes128 is like hasher.serviceNodes, but computes 128-bit tokens with the given
+// algorithm. The bytes hashed for each node are the same as for 64-bit tokens.
+func serviceNodes128[S medley.Service](h hasher[S], alg medley.Algorithm128, svc S, vnodes int) (snodes nodes128[S], truncated bool) {
+	snodes = make(nodes128[S], 0, vnodes)
+	backing := make([]node128[S], vnodes)
+
+	var (
+		hash       = alg.New128()
+		base, over = h.base(svc)
+
+		prefixBuffer [8]byte
+		prefix       = prefixBuffer[:]
+	)
+
+	for increment := int64(0); increment < int64(vnodes); increment++ {
+		hash.Reset()
+		prefix = strconv.AppendInt(prefix[:0], increment, 10)
+		prefix = append(prefix, '=')
+		hash.Write(prefix)
+		hash.Write(base)
+
+		hi, lo := hash.Sum128()
+		backing[increment] = node128[S]{token: Token128{hi, lo}, service: svc}
+		snodes = append(snodes, &backing[increment])
+	}
+
+	slices.SortFunc(snodes, compareNodes128[S])
+	truncated = over
+	return
+}
+
+// Builder128 is a fluent builder for Ring128 instances. A Builder128 is obtained
+// from Builder.Tokens128, and shares the configuration and services of that Builder.
+//
+// Methods on this type are safe for concurrent usage.
+type Builder128[S medley.Service] struct {
+	b   *Builder[S]
+	alg medley.Algorithm128
+}
+
+// Tokens128 switches this Builder to 128-bit tokens. The returned Builder128 builds Ring128
+// instances using this Builder's services, vnodes, ServiceHasher, hash byte limit, truncation
+// hook, and KeyExtractor. This Builder's Algorithm and OnFind hook are not used, since they
+// only apply to 64-bit tokens.
+func (b *Builder[S]) Tokens128() *Builder128[S] {
+	return &Builder128[S]{b: b}
+}
+
+// Algorithm128 sets the 128-bit hash algorithm to use. By default,
+// medley.DefaultAlgorithm128 is used.
+func (b128 *Builder128[S]) Algorithm128(alg medley.Algorithm128) *Builder128[S] {
+	b128.b.lock.Lock()
+	b128.alg = alg
+	b128.b.lock.Unlock()
+	return b128
+}
+
+// Services adds services to the Ring128 that is built. This is the same as
+// calling Services on the underlying Builder.
+func (b128 *Builder128[S]) Services(services ...S) *Builder128[S] {
+	b128.b.Services(services...)
+	return b128
+}
+
+// Build creates a brand new Ring128 instance. As with Builder.Build, the set of services
+// known to the underlying Builder is reset, and services whose hash bytes exceed the limit
+// are truncated.
+func (b128 *Builder128[S]) Build() *Ring128[S] {
+	b128.b.lock.Lock()
+	var (
+		services = b128.b.services
+		hasher   = b128.b.newHasher()
+		alg      = b128.alg
+	)
+
+	if reflect.ValueOf(alg).IsZero() {
+		alg = medley.DefaultAlgorithm128()
+	}
+
+	hasher.vnodes = hasher.tunedVNodes(services.Len())
+	r := &Ring128[S]{
+		hasher:  hasher,
+		alg:     alg,
+		extract: b128.b.extract,
+		cache:   make(medley.Map[S, nodes128[S]], services.Len()),
+	}
+
+	b128.b.services = nil
+	b128.b.lock.Unlock()
+
+	runs := make([]nodes128[S], 0, services.Len())
+	for svc := range services {
+		snodes, truncated := serviceNodes128(hasher, alg, svc, hasher.vnodes)
+		if truncated {
+			hasher.truncated(svc)
+		}
+
+		r.cache[svc] = snodes
+		runs = append(runs, snodes)
+	}
+
+	r.nodes = sortRuns128(runs)
+	r.tokens = r.nodes.tokens()
+	return r
+}
+
+// Update128 is the same as Update, but for a Ring128. Services that were already hashed
+// by the current Ring128 keep their tokens. The current Ring128 is not modified.
+func Update128[S medley.Service](current *Ring128[S], services ...S) (next *Ring128[S], updated bool) {
+	var (
+		cache                   = make(medley.Map[S, nodes128[S]], len(services))
+		runs                    = make([]nodes128[S], 0, len(services))
+		hasher                  = current.hasher
+		newCount, existingCount int
+	)
+
+	if hasher.autoImbalance > 0 {
+		distinct := make(medley.Map[S, bool], len(services))
+		for _, svc := range services {
+			distinct[svc] = true
+		}
+
+		hasher.vnodes = hasher.tunedVNodes(len(distinct))
+	}
+
+	for update := range current.cache.Update(services...) {
+		if _, duplicate := cache[update.Service]; duplicate {
+			continue
+		}
+
+		if update.Exists && len(update.Value) == hasher.vnodes {
+			existingCount++
+			cache[update.Service] = update.Value
+			runs = append(runs, update.Value)
+		} else {
+			newCount++
+			snodes, truncated := serviceNodes128(hasher, current.alg, update.Service, hasher.vnodes)
+			if truncated {
+				hasher.truncated(update.Service)
+			}
+
+			cache[update.Service] = snodes
+			runs = append(runs, snodes)
+		}
+	}
+
+	updated = (newCount > 0 || existingCount != len(current.cache))
+	if updated {
+		next = &Ring128[S]{
+			hasher:  hasher,
+			alg:     current.alg,
+			extract: current.extract,
+			cache:   cache,
+			nodes:   sortRuns128(runs),
+		}
+
+		next.tokens = next.nodes.tokens()
+	} else {
+		next = current
+	}
+
+	return
+}
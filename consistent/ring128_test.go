@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"hash"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/spaolacci/murmur3"
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+// coarseMask keeps only the top 8 bits of a 64-bit hash, which forces many collisions.
+const coarseMask = uint64(0xff) << 56
+
+// coarseHash64 is a murmur3 64-bit hash reduced to its top 8 bits.
+type coarseHash64 struct {
+	hash.Hash64
+}
+
+func (ch coarseHash64) Sum64() uint64 {
+	return ch.Hash64.Sum64() & coarseMask
+}
+
+// coarseHash128 is a murmur3 128-bit hash whose high word is reduced to its top 8 bits,
+// the same as coarseHash64.
+type coarseHash128 struct {
+	medley.Hash128
+}
+
+func (ch coarseHash128) Sum128() (uint64, uint64) {
+	hi, lo := ch.Hash128.Sum128()
+	return hi & coarseMask, lo
+}
+
+type Ring128Suite struct {
+	suite.Suite
+}
+
+func (suite *Ring128Suite) TestToken128Compare() {
+	testCases := []struct {
+		t, u     Token128
+		expected int
+	}{
+		{Token128{1, 1}, Token128{1, 1}, 0},
+		{Token128{1, 0}, Token128{1, 1}, -1},
+		{Token128{1, math.MaxUint64}, Token128{2, 0}, -1},
+		{Token128{2, 0}, Token128{1, math.MaxUint64}, 1},
+		{Token128{0, 5}, Token128{0, 4}, 1},
+	}
+
+	for _, testCase := range testCases {
+		suite.Equal(testCase.expected, testCase.t.Compare(testCase.u), "%v %v", testCase.t, testCase.u)
+	}
+}
+
+func (suite *Ring128Suite) TestSearchTokens128() {
+	tokens := []Token128{{1, 5}, {1, 10}, {2, 0}, {math.MaxUint64, 0}}
+	suite.True(slices.IsSortedFunc(tokens, Token128.Compare))
+
+	testCases := []struct {
+		token    Token128
+		expected int
+	}{
+		{Token128{0, 0}, 0},
+		{Token128{1, 5}, 0},
+		{Token128{1, 6}, 1},
+		{Token128{1, 10}, 1},
+		{Token128{1, math.MaxUint64}, 2},
+		{Token128{2, 1}, 3},
+		{Token128{math.MaxUint64, 0}, 3},
+
+		// wraps around to the first token
+		{Token128{math.MaxUint64, 1}, 0},
+		{Token128{math.MaxUint64, math.MaxUint64}, 0},
+	}
+
+	for _, testCase := range testCases {
+		suite.Equal(testCase.expected, searchTokens128(tokens, testCase.token), "%v", testCase.token)
+	}
+}
+
+func (suite *Ring128Suite) TestBuild() {
+	r := Strings(services[:]...).VNodes(50).Tokens128().Build()
+	suite.Equal(len(services), r.Len())
+	suite.Equal(50, r.VNodes())
+	suite.ElementsMatch(services[:], r.Services())
+	suite.Len(r.nodes, 50*len(services))
+	suite.True(slices.IsSortedFunc(r.nodes, compareNodes128[string]))
+	suite.Equal(r.nodes.tokens(), r.tokens)
+
+	for _, svc := range services {
+		suite.True(r.Contains(svc))
+	}
+
+	var previous Token128
+	for token, svc := range r.Tokens() {
+		suite.Positive(token.Compare(previous))
+		suite.True(r.Contains(svc))
+		previous = token
+	}
+
+	// the same configuration always yields the same tokens
+	suite.Equal(r.tokens, Strings(services[:]...).VNodes(50).Tokens128().Build().tokens)
+}
+
+func (suite *Ring128Suite) TestEmpty() {
+	r := Strings[string]().Tokens128().Build()
+	_, err := r.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *Ring128Suite) TestKeyExtraction() {
+	r := Strings(services[:10]...).
+		Extract(func([]byte) ([]byte, error) { return nil, medley.ErrKeyExtraction }).
+		Tokens128().
+		Build()
+
+	_, err := r.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrKeyExtraction)
+}
+
+func (suite *Ring128Suite) TestParity() {
+	var (
+		r64  = Strings(services[:]...).Build()
+		r128 = Strings(services[:]...).Tokens128().Build()
+
+		counts = make(map[string]int)
+	)
+
+	// the high words are the 64-bit tokens, so lookups agree wherever 64-bit tokens don't tie
+	for _, object := range hashObjects {
+		expected, err := r64.Find(object[:])
+		suite.Require().NoError(err)
+
+		actual, err := r128.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(expected, actual)
+		counts[actual]++
+	}
+
+	medleytest.AssertUniform(suite.T(), counts, medleytest.WithFractions(r64.Ownership()))
+}
+
+func (suite *Ring128Suite) TestCollisions() {
+	var (
+		r64 = Strings(services[:10]...).
+			Algorithm(medley.Algorithm{New64: func() hash.Hash64 { return coarseHash64{murmur3.New64()} }}).
+			Build()
+
+		r128 = Strings(services[:10]...).
+			Tokens128().
+			Algorithm128(medley.Algorithm128{New128: func() medley.Hash128 { return coarseHash128{murmur3.New128()} }}).
+			Build()
+	)
+
+	// the 64-bit ring has only 256 distinct tokens, so services tie
+	var ties64 int
+	for i := 1; i < len(r64.nodes); i++ {
+		if r64.nodes[i-1].token == r64.nodes[i].token && r64.nodes[i-1].service != r64.nodes[i].service {
+			ties64++
+		}
+	}
+
+	suite.Positive(ties64)
+
+	// the low words break every tie in the 128-bit ring
+	var disambiguated int
+	for i := 1; i < len(r128.nodes); i++ {
+		a, b := r128.nodes[i-1], r128.nodes[i]
+		suite.Require().Negative(a.token.Compare(b.token))
+		if a.token[0] != b.token[0] || a.service == b.service {
+			continue
+		}
+
+		// in the 64-bit ring, every object hashed to this token goes to the same service
+		tied := r64.nodes[searchTokens(r64.tokens, a.token[0])].service
+		suite.Equal(tied, r64.nodes[searchTokens(r64.tokens, b.token[0])].service)
+
+		// in the 128-bit ring, each tied service owns its own tokens
+		suite.Equal(a.service, r128.findToken(a.token))
+		suite.Equal(b.service, r128.findToken(b.token))
+		disambiguated++
+	}
+
+	suite.Positive(disambiguated)
+}
+
+func (suite *Ring128Suite) TestUpdate128() {
+	current := Strings(services[:50]...).Tokens128().Build()
+
+	next, updated := Update128(current, services[:50]...)
+	suite.False(updated)
+	suite.Same(current, next)
+
+	next, updated = Update128(current, services[25:75]...)
+	suite.True(updated)
+	suite.Equal(50, next.Len())
+	suite.Equal(Strings(services[25:75]...).Tokens128().Build().tokens, next.tokens)
+
+	// retained services are not rehashed
+	for _, svc := range services[25:50] {
+		suite.Same(current.cache[svc][0], next.cache[svc][0])
+	}
+
+	// the current ring is not modified
+	suite.ElementsMatch(services[:50], current.Services())
+}
+
+func (suite *Ring128Suite) TestUpdate128Duplicates() {
+	current := Strings(services[:3]...).Tokens128().Build()
+
+	next, updated := Update128(current, services[0], services[1], services[2], services[2], services[1])
+	suite.False(updated)
+	suite.Same(current, next)
+
+	next, updated = Update128(current, services[1], services[3], services[3])
+	suite.True(updated)
+	suite.Equal(2, next.Len())
+	suite.Equal(Strings(services[1], services[3]).Tokens128().Build().tokens, next.tokens)
+}
+
+func TestRing128(t *testing.T) {
+	suite.Run(t, new(Ring128Suite))
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/billhathaway/consistentHash"
+	"github.com/xmidt-org/medley"
 )
 
 var benchmarkVnodes = []int{50, 100, 200}
@@ -17,6 +18,7 @@ func BenchmarkRingCreation(b *testing.B) {
 		b.Run(
 			fmt.Sprintf("vnodes-%d", vnodes),
 			func(b *testing.B) {
+				b.ReportAllocs()
 				for range b.N {
 					Strings(services[:]...).VNodes(vnodes).Build()
 				}
@@ -25,11 +27,42 @@ func BenchmarkRingCreation(b *testing.B) {
 	}
 }
 
+// BenchmarkBuilderServices measures adding services one at a time, which is dominated
+// by the Builder's synchronization.
+func BenchmarkBuilderServices(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		builder := new(Builder[string])
+		for _, svc := range services {
+			builder.Services(svc)
+		}
+	}
+}
+
+// BenchmarkRingCreationFullSort measures building a ring by sorting all of its nodes at
+// once, which is the baseline for the merged build that BenchmarkRingCreation measures.
+// Note that this baseline also pays for sorting each service's nodes.
+func BenchmarkRingCreationFullSort(b *testing.B) {
+	for _, vnodes := range benchmarkVnodes {
+		b.Run(
+			fmt.Sprintf("vnodes-%d", vnodes),
+			func(b *testing.B) {
+				h := new(Builder[string]).VNodes(vnodes).ServiceHasher(medley.HashStringTo[string]).newHasher()
+				b.ReportAllocs()
+				for range b.N {
+					fullSortNodes(h, services[:]...)
+				}
+			},
+		)
+	}
+}
+
 func BenchmarkConsistentHashCreation(b *testing.B) {
 	for _, vnodes := range benchmarkVnodes {
 		b.Run(
 			fmt.Sprintf("vnodes-%d", vnodes),
 			func(b *testing.B) {
+				b.ReportAllocs()
 				for range b.N {
 					ch := consistentHash.New()
 					ch.SetVnodeCount(vnodes)
@@ -41,3 +74,183 @@ func BenchmarkConsistentHashCreation(b *testing.B) {
 		)
 	}
 }
+
+func BenchmarkRingUpdate(b *testing.B) {
+	var (
+		original = Strings(services[:50]...).Build()
+		updated  = services[25:75]
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		Update(original, updated...)
+	}
+}
+
+// BenchmarkRingChurn measures repeated updates that keep the ring the same size, replacing
+// 10% of its services each time.
+func BenchmarkRingChurn(b *testing.B) {
+	for _, vnodes := range benchmarkVnodes {
+		b.Run(
+			fmt.Sprintf("vnodes-%d", vnodes),
+			func(b *testing.B) {
+				var (
+					current = Strings(services[:50]...).VNodes(vnodes).Build()
+
+					// alternating between these replaces 5 of 50 services
+					members = [][]string{services[5:55], services[:50]}
+				)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					current, _ = Update(current, members[(i+1)%len(members)]...)
+				}
+			},
+		)
+	}
+}
+
+// BenchmarkFind compares lookups on a consistentHash and a Ring with the same services
+// and vnodes, which return identical results.
+func BenchmarkFind(b *testing.B) {
+	for _, vnodes := range benchmarkVnodes {
+		b.Run(
+			fmt.Sprintf("vnodes-%d", vnodes),
+			func(b *testing.B) {
+				b.Run("consistentHash", func(b *testing.B) {
+					ch := consistentHash.New()
+					ch.SetVnodeCount(vnodes)
+					for _, svc := range services {
+						ch.Add(svc)
+					}
+
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := range b.N {
+						ch.Get(hashObjects[i%len(hashObjects)][:])
+					}
+				})
+
+				b.Run("ring", func(b *testing.B) {
+					ring := Strings(services[:]...).VNodes(vnodes).Build()
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := range b.N {
+						ring.Find(hashObjects[i%len(hashObjects)][:])
+					}
+				})
+			},
+		)
+	}
+}
+
+func BenchmarkRingFind(b *testing.B) {
+	ring := Strings(services[:]...).Build()
+	b.ResetTimer()
+	for i := range b.N {
+		ring.Find(hashObjects[i%len(hashObjects)][:])
+	}
+}
+
+func BenchmarkRingFindString(b *testing.B) {
+	var (
+		ring    = Strings(services[:]...).Build()
+		objects = make([]string, len(hashObjects))
+	)
+
+	for i, object := range hashObjects {
+		objects[i] = string(object[:])
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		ring.FindString(objects[i%len(objects)])
+	}
+}
+
+// BenchmarkConsistentHashGetParallel measures concurrent lookups against the
+// legacy hash, whose reads take a read lock. This is the baseline for
+// BenchmarkUpdatableRingFindParallel.
+func BenchmarkConsistentHashGetParallel(b *testing.B) {
+	ch := consistentHash.New()
+	for _, svc := range services {
+		ch.Add(svc)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			ch.Get(hashObjects[i%len(hashObjects)][:])
+		}
+	})
+}
+
+// BenchmarkUpdatableRingFindParallel measures concurrent lookups through an
+// UpdatableLocator, which loads the current, immutable Ring without locking.
+func BenchmarkUpdatableRingFindParallel(b *testing.B) {
+	ul := medley.NewUpdatableLocator[string](Strings(services[:]...).Build())
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			ul.Find(hashObjects[i%len(hashObjects)][:])
+		}
+	})
+}
+
+func BenchmarkRingFindOnFind(b *testing.B) {
+	ring := Strings(services[:]...).
+		OnFind(medley.SampleEvery(1000, func(FindTrace[string]) {})).
+		Build()
+
+	b.ResetTimer()
+	for i := range b.N {
+		ring.Find(hashObjects[i%len(hashObjects)][:])
+	}
+}
+
+func BenchmarkCachingLocatorFind(b *testing.B) {
+	cl := medley.NewCachingLocator[string](
+		Strings(services[:]...).Build(),
+		len(hashObjects),
+	)
+
+	// prime the cache, so that this benchmark measures the hit path
+	for _, object := range hashObjects {
+		cl.Find(object[:])
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		cl.Find(hashObjects[i%len(hashObjects)][:])
+	}
+}
+
+// BenchmarkDescriptorRoundTrip measures converting a ring to a descriptor and back. The
+// Checksum sub-benchmark measures just the integrity check, which is a small fraction
+// of the round trip.
+func BenchmarkDescriptorRoundTrip(b *testing.B) {
+	ring := Strings(services[:]...).Build()
+	d, err := ToDescriptor(ring, 1, encodeString)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("RoundTrip", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			d, _ := ToDescriptor(ring, 1, encodeString)
+			FromDescriptor(d, decodeString, Strings[string](), nil)
+		}
+	})
+
+	b.Run("Checksum", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			d.verifyChecksum(d.Canonical())
+		}
+	})
+}
@@ -4,12 +4,23 @@
 package consistent
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"maps"
+	"math/rand"
+	"slices"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/billhathaway/consistentHash"
+	"github.com/spaolacci/murmur3"
 	"github.com/stretchr/testify/suite"
 	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
 )
 
 type RingSuite struct {
@@ -35,14 +46,8 @@ func (suite *RingSuite) SetupSuite() {
 		distribution[result] += 1
 	}
 
-	// the distribution should be close to even
-	expectedCount := objectCount / len(suite.originalServices)
-	for _, actualCount := range distribution {
-		// each count should be within 25% of its expected value.
-		// 25% is just a guess, but it should prevent drift as
-		// the codebase changes.
-		suite.InEpsilon(expectedCount, actualCount, 0.25)
-	}
+	// the distribution should match each service's share of the ring
+	medleytest.AssertUniform(suite.T(), distribution, medleytest.WithFractions(suite.original.Ownership()))
 }
 
 func (suite *RingSuite) update(services ...string) (*Ring[string], bool) {
@@ -103,6 +108,423 @@ func (suite *RingSuite) TestUpdate() {
 	suite.Run("NotNeeded", suite.testUpdateNotNeeded)
 }
 
+func (suite *RingSuite) TestUpdateSeq() {
+	// stream yields each service repeatedly, counting every value it yields
+	stream := func(yielded *int, members ...string) iter.Seq[string] {
+		return func(yield func(string) bool) {
+			for range 10 {
+				for _, svc := range members {
+					*yielded++
+					if !yield(svc) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	suite.Run("Equivalence", func() {
+		for _, members := range [][]string{nil, services[:1], suite.originalServices[1:], services[5:60]} {
+			var (
+				yielded                   int
+				expected, expectedUpdated = Update(suite.original, members...)
+				actual, actualUpdated     = UpdateSeq(suite.original, stream(&yielded, members...))
+			)
+
+			suite.Equal(10*len(members), yielded, "the stream is fully consumed")
+			suite.Equal(expectedUpdated, actualUpdated)
+			suite.True(expected.Equal(actual))
+			suite.Equal(expected.Fingerprint(), actual.Fingerprint())
+		}
+	})
+
+	suite.Run("NotNeeded", func() {
+		var yielded int
+		next, updated := UpdateSeq(suite.original, stream(&yielded, suite.originalServices...))
+		suite.False(updated)
+		suite.Same(suite.original, next)
+		suite.Equal(10*len(suite.originalServices), yielded)
+	})
+}
+
+func (suite *RingSuite) TestUpdateVNodes() {
+	vnodes := func(svc string) int {
+		if svc == suite.originalServices[0] {
+			return 10
+		}
+
+		return 0
+	}
+
+	next, updated := UpdateVNodes(suite.original, vnodes, suite.originalServices...)
+	suite.True(updated)
+	suite.Len(next.cache[suite.originalServices[0]], 10)
+	suite.Len(next.nodes, 10+DefaultVNodes*(len(suite.originalServices)-1))
+
+	// fewer vnodes are a subset of the full set of tokens
+	full := make(map[uint64]bool)
+	for _, n := range suite.original.cache[suite.originalServices[0]] {
+		full[n.token] = true
+	}
+
+	for _, n := range next.cache[suite.originalServices[0]] {
+		suite.True(full[n.token])
+	}
+
+	// the other services were not rehashed
+	for _, svc := range suite.originalServices[1:] {
+		suite.Same(suite.original.cache[svc][0], next.cache[svc][0])
+	}
+
+	same, updated := UpdateVNodes(next, vnodes, suite.originalServices...)
+	suite.False(updated)
+	suite.Same(next, same)
+
+	// overrides aren't remembered
+	restored, updated := Update(next, suite.originalServices...)
+	suite.True(updated)
+	suite.True(suite.original.Equal(restored))
+}
+
+func (suite *RingSuite) TestFindNode() {
+	for _, object := range hashObjects {
+		expected, err := suite.original.Find(object[:])
+		suite.Require().NoError(err)
+
+		info, err := suite.original.FindNode(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(expected, info.Service)
+		suite.Equal(suite.original.hasher.sum64(object[:]), info.KeyToken)
+		suite.Require().True(info.Index >= 0 && info.Index < len(suite.original.nodes))
+		suite.Equal(suite.original.nodes[info.Index].token, info.Token)
+		suite.Equal(suite.original.nodes[info.Index].service, info.Service)
+
+		if info.Token < info.KeyToken {
+			// only a wraparound can produce a smaller token
+			suite.Zero(info.Index)
+			suite.Greater(info.KeyToken, suite.original.nodes[len(suite.original.nodes)-1].token)
+		} else if info.Index > 0 {
+			suite.Less(suite.original.nodes[info.Index-1].token, info.KeyToken)
+		}
+	}
+
+	empty, _ := Update(suite.original)
+	info, err := empty.FindNode([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Zero(info)
+}
+
+func (suite *RingSuite) TestFindString() {
+	for _, object := range hashObjects {
+		expected, err := suite.original.Find(object[:])
+		suite.Require().NoError(err)
+
+		actual, err := suite.original.FindString(string(object[:]))
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, actual)
+	}
+
+	empty, _ := Update(suite.original)
+	svc, err := empty.FindString("test")
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(svc)
+
+	if unsafeStrings {
+		key := string(hashObjects[0][:])
+		suite.Zero(testing.AllocsPerRun(100, func() {
+			suite.original.FindString(key)
+		}))
+	}
+}
+
+func (suite *RingSuite) TestFindNodeWraparound() {
+	var (
+		last   = suite.original.nodes[len(suite.original.nodes)-1]
+		object []byte
+	)
+
+	// find an object that hashes beyond the last token on the ring
+	for i := 0; object == nil; i++ {
+		candidate := []byte(fmt.Sprintf("wraparound-%d", i))
+		if suite.original.hasher.sum64(candidate) > last.token {
+			object = candidate
+		}
+	}
+
+	info, err := suite.original.FindNode(object)
+	suite.Require().NoError(err)
+	suite.Zero(info.Index)
+	suite.Equal(suite.original.nodes[0].token, info.Token)
+	suite.Equal(suite.original.nodes[0].service, info.Service)
+	suite.Less(info.Token, info.KeyToken)
+}
+
+func (suite *RingSuite) TestContains() {
+	for _, svc := range suite.originalServices {
+		suite.True(suite.original.Contains(svc))
+	}
+
+	suite.False(suite.original.Contains("nosuch"))
+}
+
+func (suite *RingSuite) TestTokens() {
+	var (
+		tokens []uint64
+		counts = make(map[string]int)
+	)
+
+	for token, svc := range suite.original.Tokens() {
+		tokens = append(tokens, token)
+		counts[svc]++
+	}
+
+	suite.Len(tokens, len(suite.original.nodes))
+	suite.True(sort.SliceIsSorted(tokens, func(i, j int) bool { return tokens[i] < tokens[j] }))
+	suite.Len(counts, len(suite.originalServices))
+	for _, count := range counts {
+		suite.Equal(DefaultVNodes, count)
+	}
+
+	// stopping early is honored
+	var visited int
+	for range suite.original.Tokens() {
+		visited++
+		break
+	}
+
+	suite.Equal(1, visited)
+}
+
+func (suite *RingSuite) TestSuccessors() {
+	for _, object := range hashObjects[:100] {
+		owner, err := suite.original.Find(object[:])
+		suite.Require().NoError(err)
+
+		successors := slices.Collect(suite.original.Successors(object[:]))
+		suite.Require().Len(successors, len(suite.originalServices))
+		suite.Equal(owner, successors[0])
+		suite.ElementsMatch(suite.originalServices, successors)
+
+		// stopping early is honored
+		for range suite.original.Successors(object[:]) {
+			break
+		}
+	}
+
+	empty, _ := Update(suite.original)
+	suite.Empty(slices.Collect(empty.Successors([]byte("test"))))
+}
+
+func (suite *RingSuite) TestFindExcluding() {
+	for _, excluded := range suite.originalServices {
+		remaining := slices.DeleteFunc(slices.Clone(suite.originalServices), func(svc string) bool {
+			return svc == excluded
+		})
+
+		// excluding a service is the same as removing it
+		rebuilt, _ := Update(suite.original, remaining...)
+		for _, object := range hashObjects[:200] {
+			expected, err := rebuilt.Find(object[:])
+			suite.Require().NoError(err)
+
+			actual, err := suite.original.FindExcluding(object[:], excluded)
+			suite.Require().NoError(err)
+			suite.Equal(expected, actual)
+		}
+	}
+
+	// excluding services not in the ring, or nothing at all, changes nothing
+	for _, object := range hashObjects[:200] {
+		expected, err := suite.original.Find(object[:])
+		suite.Require().NoError(err)
+
+		actual, err := suite.original.FindExcluding(object[:], "nosuch", services[len(services)-1])
+		suite.NoError(err)
+		suite.Equal(expected, actual)
+
+		actual, err = suite.original.FindExcluding(object[:])
+		suite.NoError(err)
+		suite.Equal(expected, actual)
+	}
+
+	svc, err := suite.original.FindExcluding([]byte("test"), append([]string{"nosuch"}, suite.originalServices...)...)
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(svc)
+
+	empty, _ := Update(suite.original)
+	_, err = empty.FindExcluding([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	// the ring itself is untouched
+	suite.Equal(len(suite.originalServices), suite.original.Len())
+}
+
+func (suite *RingSuite) TestServices() {
+	suite.Equal(len(suite.originalServices), suite.original.Len())
+	suite.ElementsMatch(suite.originalServices, suite.original.Services())
+	suite.ElementsMatch(suite.originalServices, slices.Collect(suite.original.All()))
+
+	var visited int
+	for range suite.original.All() {
+		visited++
+		break
+	}
+
+	suite.Equal(1, visited)
+
+	empty, _ := Update(suite.original)
+	suite.Zero(empty.Len())
+	suite.Empty(empty.Services())
+	suite.Empty(slices.Collect(empty.All()))
+}
+
+func (suite *RingSuite) TestOwnership() {
+	ownership := suite.original.Ownership()
+	suite.Len(ownership, len(suite.originalServices))
+
+	total := 0.0
+	for _, svc := range suite.originalServices {
+		suite.Require().Contains(ownership, svc)
+		suite.InEpsilon(1.0/float64(len(suite.originalServices)), ownership[svc], 0.25)
+		total += ownership[svc]
+	}
+
+	suite.InDelta(1.0, total, 1e-9)
+
+	single := Strings("single").VNodes(1).Build()
+	suite.Equal(medley.Map[string, float64]{"single": 1.0}, single.Ownership())
+
+	empty, _ := Update(suite.original)
+	suite.Empty(empty.Ownership())
+}
+
+func (suite *RingSuite) TestEqual() {
+	var (
+		nilRing  *Ring[string]
+		reversed = slices.Clone(suite.originalServices)
+	)
+
+	slices.Reverse(reversed)
+
+	suite.True(nilRing.Equal(nil))
+	suite.False(nilRing.Equal(suite.original))
+	suite.False(suite.original.Equal(nil))
+	suite.True(suite.original.Equal(suite.original))
+
+	sameServices := Strings(reversed...).Build()
+	suite.True(suite.original.Equal(sameServices))
+	suite.True(sameServices.Equal(suite.original))
+
+	// equivalent, but distinct, algorithms are equal
+	suite.True(
+		suite.original.Equal(
+			Strings(reversed...).Algorithm(medley.Algorithm{New64: murmur3.New64}).Build(),
+		),
+	)
+
+	suite.False(suite.original.Equal(Strings(suite.originalServices[1:]...).Build()))
+	suite.False(suite.original.Equal(Strings(append(reversed, "another")...).Build()))
+	suite.False(suite.original.Equal(Strings(suite.originalServices...).VNodes(100).Build()))
+	suite.False(
+		suite.original.Equal(
+			Strings(suite.originalServices...).Algorithm(medley.Algorithm{New64: fnv.New64}).Build(),
+		),
+	)
+}
+
+func (suite *RingSuite) TestOnFind() {
+	var traces []FindTrace[string]
+	traced := Strings(suite.originalServices...).
+		OnFind(func(ft FindTrace[string]) {
+			traces = append(traces, ft)
+		}).
+		Build()
+
+	suite.Require().NotNil(traced)
+	for _, object := range hashObjects {
+		expected, err := suite.original.Find(object[:])
+		suite.Require().NoError(err)
+
+		actual, err := traced.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(expected, actual)
+
+		suite.Require().NotEmpty(traces)
+		last := traces[len(traces)-1]
+		suite.Equal(traced.hasher.sum64(object[:]), last.Token)
+		suite.Equal(actual, last.Service)
+		suite.Equal(len(traced.nodes), last.RingSize)
+	}
+
+	suite.Len(traces, len(hashObjects))
+
+	// updated rings share the hook
+	updated, didUpdate := Update(traced, "new1", "new2")
+	suite.Require().True(didUpdate)
+	result, err := updated.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal(result, traces[len(traces)-1].Service)
+	suite.Equal(len(updated.nodes), traces[len(traces)-1].RingSize)
+
+	// no trace on failure
+	empty, _ := Update(traced)
+	_, err = empty.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Len(traces, len(hashObjects)+1)
+}
+
+func (suite *RingSuite) TestExtract() {
+	errNoMAC := errors.New("no mac")
+	extracting := Strings(suite.originalServices...).
+		Extract(func(object []byte) ([]byte, error) {
+			_, after, found := bytes.Cut(object, []byte("mac="))
+			if !found {
+				return nil, errNoMAC
+			}
+
+			key, _, _ := bytes.Cut(after, []byte(";"))
+			return key, nil
+		}).
+		Build()
+
+	for i := range 100 {
+		mac := fmt.Sprintf("mac-%d", i)
+		expected, err := suite.original.Find([]byte(mac))
+		suite.Require().NoError(err)
+
+		// different payloads with the same embedded key map to the same service
+		for _, payload := range []string{
+			fmt.Sprintf("path=/a;mac=%s;ts=%d", mac, i),
+			fmt.Sprintf("mac=%s", mac),
+			fmt.Sprintf("ts=%d;mac=%s", i*7, mac),
+		} {
+			actual, err := extracting.Find([]byte(payload))
+			suite.Require().NoError(err)
+			suite.Require().Equal(expected, actual)
+
+			// the extractor sees a string's bytes
+			actual, err = medley.FindString[string](extracting, payload)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expected, actual)
+
+			suite.Equal(
+				slices.Collect(suite.original.Successors([]byte(mac))),
+				slices.Collect(extracting.Successors([]byte(payload))),
+			)
+		}
+	}
+
+	_, err := extracting.Find([]byte("path=/"))
+	suite.ErrorIs(err, medley.ErrKeyExtraction)
+	suite.ErrorIs(err, errNoMAC)
+	suite.Empty(slices.Collect(extracting.Successors([]byte("path=/"))))
+
+	// updated rings share the extractor
+	updated, _ := Update(extracting, suite.originalServices[1:]...)
+	_, err = updated.Find([]byte("path=/"))
+	suite.ErrorIs(err, errNoMAC)
+}
+
 func (suite *RingSuite) TestBackwardCompatibility() {
 	ch := consistentHash.New()
 	ch.SetVnodeCount(DefaultVNodes)
@@ -127,6 +549,207 @@ func (suite *RingSuite) TestBackwardCompatibility() {
 	}
 }
 
+func (suite *RingSuite) TestConcurrentFindAndUpdate() {
+	const (
+		readers = 8
+		updates = 50
+	)
+
+	var (
+		ul   = medley.NewUpdatableLocator[string](suite.original)
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// every published ring has services, so lookups never fail
+				svc, err := ul.Find(hashObjects[i%len(hashObjects)][:])
+				if !suite.NoError(err) || !suite.Contains(services, svc) {
+					return
+				}
+			}
+		}()
+	}
+
+	current := suite.original
+	for i := range updates {
+		current, _ = Update(current, services[i%10:i%10+50]...)
+		ul.Set(current)
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func (suite *RingSuite) TestRepeatedUpdates() {
+	var (
+		current = Strings(services[:50]...).VNodes(50).Build()
+		vnodes  = func(svc string) int {
+			// alternate a few services between overridden and default vnodes
+			if svc == services[12] || svc == services[40] {
+				return 20
+			}
+
+			return 0
+		}
+	)
+
+	for step := range 30 {
+		var (
+			previous    = current
+			fingerprint = previous.Fingerprint()
+			members     = services[step%50 : step%50+50] // 10% churn
+		)
+
+		if step%3 == 0 {
+			current, _ = UpdateVNodes(current, vnodes, members...)
+		} else {
+			current, _ = Update(current, members...)
+		}
+
+		// an updated ring is indistinguishable from a freshly built one, except for overrides
+		if step%3 != 0 {
+			expected := Strings(members...).VNodes(50).Build()
+			suite.Require().True(expected.Equal(current), "step=%d", step)
+			suite.Require().Equal(expected.tokens, current.tokens)
+		}
+
+		suite.Require().Equal(current.nodes.tokens(), current.tokens)
+		suite.Require().True(slices.IsSorted(current.tokens))
+		suite.Require().Equal(fingerprint, previous.Fingerprint())
+
+		for _, object := range hashObjects[:100] {
+			svc, err := current.Find(object[:])
+			suite.Require().NoError(err)
+			suite.Require().Contains(members, svc)
+		}
+	}
+}
+
+func (suite *RingSuite) TestConsistentHashAgreement() {
+	random := rand.New(rand.NewSource(9481))
+	for _, vnodes := range []int{1, 50, DefaultVNodes} {
+		var (
+			ch      = consistentHash.New()
+			current = Strings[string]().VNodes(vnodes).Build()
+			members = make(map[string]bool)
+			object  = make([]byte, 16)
+		)
+
+		suite.Require().NoError(ch.SetVnodeCount(vnodes))
+
+		// apply the same membership changes to both, and compare lookups after each
+		for range 10 {
+			for _, i := range random.Perm(len(services))[:10] {
+				svc := services[i]
+				if members[svc] {
+					delete(members, svc)
+					ch.Remove(svc)
+				} else {
+					members[svc] = true
+					ch.Add(svc)
+				}
+			}
+
+			current, _ = Update(current, slices.Collect(maps.Keys(members))...)
+			for range 1000 {
+				random.Read(object)
+				expected, expectedErr := ch.Get(object)
+				actual, actualErr := current.Find(object)
+				suite.Require().Equal(expectedErr == nil, actualErr == nil)
+				suite.Require().Equal(expected, actual, "vnodes=%d", vnodes)
+			}
+		}
+	}
+}
+
+func (suite *RingSuite) TestCompatibilityVectors() {
+	err := medley.VerifyVectors(
+		medley.CompatibilityVectors(),
+		func(algorithm string, vnodes int, services []string) (medley.Locator[string], error) {
+			alg, err := medley.FindAlgorithm(algorithm)
+			if err != nil {
+				return nil, err
+			}
+
+			return Strings(services...).VNodes(vnodes).Algorithm(alg).Build(), nil
+		},
+	)
+
+	suite.NoError(err)
+}
+
+func (suite *RingSuite) TestZeroValue() {
+	var (
+		zero  Ring[string]
+		empty = Strings[string]().Build()
+	)
+
+	// the zero Ring is an empty ring with the default configuration
+	svc, err := zero.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(svc)
+
+	info, err := zero.FindNode([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Zero(info)
+
+	svc, err = zero.FindExcluding([]byte("test"), "a")
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(svc)
+
+	suite.False(zero.Contains("a"))
+	suite.Zero(zero.Len())
+	suite.Equal(DefaultVNodes, zero.VNodes())
+	suite.Empty(zero.Services())
+	suite.Empty(slices.Collect(zero.All()))
+	suite.Empty(zero.Ownership())
+	suite.Empty(maps.Collect(zero.Tokens()))
+	suite.Empty(slices.Collect(zero.Successors([]byte("test"))))
+	suite.Empty(maps.Collect(zero.RangeOwners(0, 100)))
+	suite.Equal(empty.Fingerprint(), zero.Fingerprint())
+	suite.True(zero.Equal(empty))
+	suite.True(empty.Equal(&zero))
+
+	var b bytes.Buffer
+	suite.NoError(zero.ExportOwnership(&b, ExportCSV, nil))
+	suite.Equal(empty.MemoryEstimate(nil), zero.MemoryEstimate(nil))
+
+	d, err := ToDescriptor(&zero, 1, encodeString)
+	suite.Require().NoError(err)
+	suite.Equal(DefaultVNodes, int(d.VNodes))
+	suite.Empty(d.Services)
+
+	subset, err := Subset(&zero, []byte("client"), 3)
+	suite.NoError(err)
+	suite.Zero(subset.Len())
+
+	// updates use the defaults that a Builder would
+	next, updated := Update(&zero, suite.originalServices...)
+	suite.True(updated)
+	suite.True(suite.original.Equal(next))
+	suite.Equal(suite.original.Fingerprint(), next.Fingerprint())
+
+	next, updated = Update(&zero)
+	suite.False(updated)
+	suite.Same(&zero, next)
+
+	rings := slices.Collect(zero.Retarget(DefaultVNodes/2, 1))
+	suite.Require().Len(rings, 1)
+	suite.Zero(rings[0].Len())
+	suite.Equal(DefaultVNodes/2, rings[0].VNodes())
+}
+
 func TestRing(t *testing.T) {
 	suite.Run(t, new(RingSuite))
 }
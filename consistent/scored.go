@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultMaxProbes is the number of services a ScoredLocator examines when
+	// no maximum is supplied.
+	DefaultMaxProbes = 3
+)
+
+// SuccessorLocator is a medley.Locator that can also enumerate the distinct services
+// that follow an object's owner. Ring implements this interface.
+type SuccessorLocator[S medley.Service] interface {
+	medley.Locator[S]
+
+	// Successors returns the distinct services for an object, beginning with its owner.
+	Successors([]byte) iter.Seq[S]
+}
+
+var _ SuccessorLocator[string] = (*Ring[string])(nil)
+
+// ScoredLocator is a medley.Locator that prefers the owner of an object, but moves on
+// to the owner's successors when the owner's score is too high, e.g. because of
+// recent latency.
+//
+// Scores are computed on every lookup, so the score function should be cheap.
+// Callers are responsible for any caching of scores. The Load method of a
+// medley.LoadTracker is a suitable score function for spilling away from busy services.
+type ScoredLocator[S medley.Service] struct {
+	next      SuccessorLocator[S]
+	score     func(S) float64
+	threshold float64
+	maxProbes int
+}
+
+var _ medley.AnnotatedLocator[string] = (*ScoredLocator[string])(nil)
+
+// NewScoredLocator creates a ScoredLocator that consults the given score function for
+// each service it examines. A service qualifies if its score is below the threshold.
+//
+// At most maxProbes services are examined per lookup, including the owner. If maxProbes
+// is nonpositive, DefaultMaxProbes is used.
+func NewScoredLocator[S medley.Service](next SuccessorLocator[S], score func(S) float64, threshold float64, maxProbes int) *ScoredLocator[S] {
+	if maxProbes < 1 {
+		maxProbes = DefaultMaxProbes
+	}
+
+	return &ScoredLocator[S]{
+		next:      next,
+		score:     score,
+		threshold: threshold,
+		maxProbes: maxProbes,
+	}
+}
+
+// Find walks the successors of the given object and returns the first service whose
+// score is below this locator's threshold. If no examined service qualifies, the true
+// owner is returned so that traffic is never dropped. If the underlying locator has
+// no services, this method returns medley.ErrNoServices.
+func (sl *ScoredLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, _, err = sl.find(object)
+	return
+}
+
+// FindAnnotated is like Find, but reports SourceScored when a successor was chosen over
+// the owner. Otherwise, the underlying locator's annotation is returned.
+func (sl *ScoredLocator[S]) FindAnnotated(object []byte) (result medley.FindResult[S], err error) {
+	var spilled bool
+	if result.Service, spilled, err = sl.find(object); spilled {
+		result.Source = SourceScored
+		return
+	}
+
+	return medley.Explain[S](sl.next, object)
+}
+
+// find performs the lookup for Find and FindAnnotated. The spilled flag is true when
+// a successor was chosen over the owner.
+func (sl *ScoredLocator[S]) find(object []byte) (svc S, spilled bool, err error) {
+	var (
+		probes int
+		found  bool
+	)
+
+	for candidate := range sl.next.Successors(object) {
+		if probes == 0 {
+			svc, found = candidate, true
+		}
+
+		if sl.score(candidate) < sl.threshold {
+			return candidate, probes > 0, nil
+		}
+
+		probes++
+		if probes >= sl.maxProbes {
+			break
+		}
+	}
+
+	if !found {
+		err = medley.ErrNoServices
+	}
+
+	return
+}
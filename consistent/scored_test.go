@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type ScoredLocatorSuite struct {
+	suite.Suite
+
+	ring *Ring[string]
+}
+
+func (suite *ScoredLocatorSuite) SetupSuite() {
+	suite.ring = Strings(services[:8]...).Build()
+}
+
+// successors returns the distinct services for an object, beginning with its owner.
+func (suite *ScoredLocatorSuite) successors(object []byte) []string {
+	s := slices.Collect(suite.ring.Successors(object))
+	suite.Require().Len(s, 8)
+	return s
+}
+
+// scores creates a score function that returns the scripted score for a service, or zero
+// if the service has no scripted score. Each service that is scored is recorded in probed.
+func (suite *ScoredLocatorSuite) scores(scripted map[string]float64, probed *[]string) func(string) float64 {
+	return func(svc string) float64 {
+		*probed = append(*probed, svc)
+		return scripted[svc]
+	}
+}
+
+func (suite *ScoredLocatorSuite) TestOwnerHealthy() {
+	var (
+		object     = hashObjects[0][:]
+		successors = suite.successors(object)
+		probed     []string
+		sl         = NewScoredLocator(suite.ring, suite.scores(nil, &probed), 100.0, 0)
+	)
+
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[0], svc)
+	suite.Equal(successors[:1], probed)
+}
+
+func (suite *ScoredLocatorSuite) TestOwnerSlow() {
+	var (
+		object     = hashObjects[1][:]
+		successors = suite.successors(object)
+		probed     []string
+		scripted   = map[string]float64{
+			successors[0]: 250.0,
+			successors[1]: 100.0,
+		}
+
+		sl = NewScoredLocator(suite.ring, suite.scores(scripted, &probed), 100.0, 0)
+	)
+
+	// the threshold is exclusive, so the first successor doesn't qualify either
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[2], svc)
+	suite.Equal(successors[:3], probed)
+}
+
+func (suite *ScoredLocatorSuite) TestAllSlow() {
+	var (
+		object     = hashObjects[2][:]
+		successors = suite.successors(object)
+		probed     []string
+		scripted   = make(map[string]float64)
+	)
+
+	for _, svc := range successors {
+		scripted[svc] = 500.0
+	}
+
+	sl := NewScoredLocator(suite.ring, suite.scores(scripted, &probed), 100.0, len(successors)*2)
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[0], svc)
+	suite.Equal(successors, probed)
+}
+
+func (suite *ScoredLocatorSuite) TestMaxProbes() {
+	var (
+		object     = hashObjects[3][:]
+		successors = suite.successors(object)
+		probed     []string
+		scripted   = map[string]float64{
+			successors[0]: 500.0,
+			successors[1]: 500.0,
+		}
+	)
+
+	// the only qualifying service is beyond the probe bound
+	sl := NewScoredLocator(suite.ring, suite.scores(scripted, &probed), 100.0, 2)
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[0], svc)
+	suite.Equal(successors[:2], probed)
+
+	probed = nil
+	sl = NewScoredLocator(suite.ring, suite.scores(scripted, &probed), 100.0, 3)
+	svc, err = sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[2], svc)
+	suite.Equal(successors[:3], probed)
+}
+
+func (suite *ScoredLocatorSuite) TestNoServices() {
+	var (
+		empty, _ = Update(suite.ring)
+		probed   []string
+		sl       = NewScoredLocator(empty, suite.scores(nil, &probed), 100.0, 0)
+	)
+
+	_, err := sl.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(probed)
+}
+
+func (suite *ScoredLocatorSuite) TestLoadTracker() {
+	var (
+		object     = hashObjects[4][:]
+		successors = suite.successors(object)
+		clock      = time.Now()
+		lt         = medley.NewLoadTracker[string](time.Second, func() time.Time { return clock })
+		sl         = NewScoredLocator(suite.ring, lt.Load, 10.0, 0)
+	)
+
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[0], svc)
+
+	// a burst of load spills lookups to the next service
+	lt.Record(successors[0], 40.0)
+	svc, err = sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[1], svc)
+
+	// one half-life isn't enough for the owner to qualify again
+	clock = clock.Add(time.Second)
+	svc, err = sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[1], svc)
+
+	// after three half-lives, the owner's load is 5.0
+	clock = clock.Add(2 * time.Second)
+	svc, err = sl.Find(object)
+	suite.NoError(err)
+	suite.Equal(successors[0], svc)
+}
+
+func TestScoredLocator(t *testing.T) {
+	suite.Run(t, new(ScoredLocatorSuite))
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+	"slices"
+)
+
+// SearchPolicy determines which node on a Ring owns an object's token. The policy only
+// affects lookups, not the tokens on the ring, so the same services and vnodes support
+// any policy.
+//
+// Lookups, Successors, FindExcluding, and Ownership all follow a ring's policy. RangeOwners
+// and the Moved fraction of PreviewUpdate always describe clockwise ownership. Storage files
+// written by WriteStorage record the policy, but descriptors don't, so set it on the Builder
+// used to load them.
+type SearchPolicy int
+
+const (
+	// Clockwise selects the node with the smallest token that is greater than or equal
+	// to the object's token, wrapping around to the smallest token on the ring. This is
+	// the default, and is compatible with github.com/billhathaway/consistentHash.
+	Clockwise SearchPolicy = iota
+
+	// CounterClockwise selects the node with the largest token that is less than or equal
+	// to the object's token, wrapping around to the largest token on the ring.
+	CounterClockwise
+
+	// NearestAbsolute selects the node whose token is the smallest circular distance from
+	// the object's token in either direction. Ties are broken clockwise.
+	NearestAbsolute
+)
+
+// searchTokensCounterClockwise is like searchTokens, but returns the index of the largest
+// token less than or equal to the given token, wrapping around to the last index.
+func searchTokensCounterClockwise(tokens []uint64, token uint64) int {
+	i, found := slices.BinarySearch(tokens, token)
+	switch {
+	case found:
+		return i
+
+	case i == 0:
+		return len(tokens) - 1
+
+	default:
+		return i - 1
+	}
+}
+
+// searchTokensNearest is like searchTokens, but returns the index of the token with the
+// smallest circular distance from the given token. Ties go to the clockwise token.
+func searchTokensNearest(tokens []uint64, token uint64) int {
+	var (
+		cw  = searchTokens(tokens, token)
+		ccw = (cw - 1 + len(tokens)) % len(tokens)
+	)
+
+	// unsigned subtraction handles the distances that wrap around zero
+	if token-tokens[ccw] < tokens[cw]-token {
+		return ccw
+	}
+
+	return cw
+}
+
+// search returns the index of the token that owns the given token under this policy.
+// The tokens must be sorted and nonempty.
+func (p SearchPolicy) search(tokens []uint64, token uint64) int {
+	switch p {
+	case CounterClockwise:
+		return searchTokensCounterClockwise(tokens, token)
+
+	case NearestAbsolute:
+		return searchTokensNearest(tokens, token)
+
+	default:
+		return searchTokens(tokens, token)
+	}
+}
+
+// search returns the index of the token that owns the given token under this hasher's
+// policy. The tokens must be sorted and nonempty.
+func (h hasher[S]) search(tokens []uint64, token uint64) int {
+	return h.policy.search(tokens, token)
+}
+
+// walk returns a sequence of the indexes of every token, starting with the owner of the
+// given token. Each subsequent index is the owner under this policy once the tokens before
+// it are removed. Each index is visited exactly once. The tokens must be sorted.
+func (p SearchPolicy) walk(tokens []uint64, token uint64) iter.Seq[int] {
+	n := len(tokens)
+	return func(f func(int) bool) {
+		if n == 0 {
+			return
+		}
+
+		switch p {
+		case CounterClockwise:
+			start := searchTokensCounterClockwise(tokens, token)
+			for i := range n {
+				if !f((start - i + n) % n) {
+					return
+				}
+			}
+
+		case NearestAbsolute:
+			// cw and ccw move apart from the token, so they never visit the same index
+			cw := searchTokens(tokens, token)
+			ccw := (cw - 1 + n) % n
+			for range n {
+				next := cw
+				if token-tokens[ccw] < tokens[cw]-token {
+					next, ccw = ccw, (ccw-1+n)%n
+				} else {
+					cw = (cw + 1) % n
+				}
+
+				if !f(next) {
+					return
+				}
+			}
+
+		default:
+			start := searchTokens(tokens, token)
+			for i := range n {
+				if !f((start + i) % n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// walk returns a sequence of the indexes of every token, starting with the owner of the
+// given token under this hasher's policy. See SearchPolicy.walk.
+func (h hasher[S]) walk(tokens []uint64, token uint64) iter.Seq[int] {
+	return h.policy.walk(tokens, token)
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// tokenKey returns an object that identityAlgorithm hashes to the given token.
+func tokenKey(token uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, token)
+}
+
+// identityAlgorithm hashes an 8-byte object to its big-endian value, so that tests
+// can choose the tokens of their keys.
+var identityAlgorithm = medley.Algorithm{
+	Sum64: func(b []byte) uint64 {
+		var padded [8]byte
+		copy(padded[:], b)
+		return binary.BigEndian.Uint64(padded[:])
+	},
+}
+
+type SearchSuite struct {
+	suite.Suite
+}
+
+// handRing builds a ring with exactly the given tokens, using identityAlgorithm.
+func (suite *SearchSuite) handRing(policy SearchPolicy, tokens map[uint64]string) *Ring[string] {
+	r := &Ring[string]{
+		hasher: hasher[string]{
+			vnodes:        1,
+			alg:           identityAlgorithm,
+			serviceHasher: medley.HashStringTo[string],
+			policy:        policy,
+		},
+		cache: make(medley.Map[string, nodes[string]]),
+	}
+
+	for token, svc := range tokens {
+		n := &node[string]{token: token, service: svc}
+		r.cache[svc] = append(r.cache[svc], n)
+		r.nodes = append(r.nodes, n)
+	}
+
+	sort.Sort(r.nodes)
+	r.index()
+	return r
+}
+
+func (suite *SearchSuite) TestFind() {
+	tokens := map[uint64]string{100: "a", 200: "b", 1000: "c"}
+	testCases := []struct {
+		key     uint64
+		cw      string
+		ccw     string
+		nearest string
+	}{
+		{key: 0, cw: "a", ccw: "c", nearest: "a"},
+		{key: 50, cw: "a", ccw: "c", nearest: "a"},
+		{key: 100, cw: "a", ccw: "a", nearest: "a"},
+		{key: 140, cw: "b", ccw: "a", nearest: "a"},
+		{key: 150, cw: "b", ccw: "a", nearest: "b"}, // a tie goes clockwise
+		{key: 160, cw: "b", ccw: "a", nearest: "b"},
+		{key: 200, cw: "b", ccw: "b", nearest: "b"},
+		{key: 599, cw: "c", ccw: "b", nearest: "b"},
+		{key: 600, cw: "c", ccw: "b", nearest: "c"},
+		{key: 1000, cw: "c", ccw: "c", nearest: "c"},
+		{key: 2000, cw: "a", ccw: "c", nearest: "c"},
+		{key: math.MaxUint64, cw: "a", ccw: "c", nearest: "a"},
+	}
+
+	for _, policy := range []SearchPolicy{Clockwise, CounterClockwise, NearestAbsolute} {
+		ring := suite.handRing(policy, tokens)
+		for _, testCase := range testCases {
+			expected := map[SearchPolicy]string{
+				Clockwise:        testCase.cw,
+				CounterClockwise: testCase.ccw,
+				NearestAbsolute:  testCase.nearest,
+			}[policy]
+
+			suite.Run(fmt.Sprintf("policy=%d/key=%d", policy, testCase.key), func() {
+				svc, err := ring.Find(tokenKey(testCase.key))
+				suite.Require().NoError(err)
+				suite.Equal(expected, svc)
+			})
+		}
+	}
+}
+
+func (suite *SearchSuite) TestSingleToken() {
+	for _, policy := range []SearchPolicy{Clockwise, CounterClockwise, NearestAbsolute} {
+		ring := suite.handRing(policy, map[uint64]string{500: "a"})
+		for _, key := range []uint64{0, 499, 500, 501, math.MaxUint64} {
+			svc, err := ring.Find(tokenKey(key))
+			suite.Require().NoError(err)
+			suite.Equal("a", svc)
+		}
+	}
+}
+
+func (suite *SearchSuite) TestSuccessors() {
+	tokens := map[uint64]string{100: "a", 200: "b", 1000: "c", 5000: "d"}
+	testCases := []struct {
+		policy   SearchPolicy
+		key      uint64
+		expected []string
+	}{
+		{policy: Clockwise, key: 150, expected: []string{"b", "c", "d", "a"}},
+		{policy: CounterClockwise, key: 150, expected: []string{"a", "d", "c", "b"}},
+		{policy: NearestAbsolute, key: 150, expected: []string{"b", "a", "c", "d"}},
+		{policy: NearestAbsolute, key: 900, expected: []string{"c", "b", "a", "d"}},
+		{policy: NearestAbsolute, key: math.MaxUint64, expected: []string{"a", "b", "c", "d"}},
+	}
+
+	for _, testCase := range testCases {
+		ring := suite.handRing(testCase.policy, tokens)
+		suite.Equal(
+			testCase.expected,
+			slices.Collect(ring.Successors(tokenKey(testCase.key))),
+			"policy=%d key=%d", testCase.policy, testCase.key,
+		)
+	}
+}
+
+func (suite *SearchSuite) TestOwnership() {
+	const circle = 1 << 64
+	tokens := map[uint64]string{100: "a", 200: "b", 1000: "c"}
+
+	for policy, expected := range map[SearchPolicy]float64{
+		Clockwise:        100,
+		CounterClockwise: 800,
+		NearestAbsolute:  450,
+	} {
+		ownership := suite.handRing(policy, tokens).Ownership()
+
+		var total float64
+		for _, fraction := range ownership {
+			total += fraction
+		}
+
+		suite.InDelta(1.0, total, 1e-9)
+		suite.InDelta(expected/circle, ownership["b"], 1e-18, "policy=%d", policy)
+	}
+}
+
+func (suite *SearchSuite) TestFindExcluding() {
+	for _, policy := range []SearchPolicy{Clockwise, CounterClockwise, NearestAbsolute} {
+		var (
+			ring      = Strings(services[:20]...).SearchPolicy(policy).VNodes(20).Build()
+			exclude   = []string{services[0], services[3], services[7]}
+			remaining []string
+		)
+
+		for _, svc := range services[:20] {
+			if !slices.Contains(exclude, svc) {
+				remaining = append(remaining, svc)
+			}
+		}
+
+		removed, _ := Update(ring, remaining...)
+		for _, object := range hashObjects[:200] {
+			expected, err := removed.Find(object[:])
+			suite.Require().NoError(err)
+
+			actual, err := ring.FindExcluding(object[:], exclude...)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expected, actual, "policy=%d", policy)
+
+			// the first successor not excluded is the same service
+			for svc := range ring.Successors(object[:]) {
+				if !slices.Contains(exclude, svc) {
+					suite.Require().Equal(expected, svc)
+					break
+				}
+			}
+		}
+	}
+}
+
+func (suite *SearchSuite) TestUpdate() {
+	for _, policy := range []SearchPolicy{CounterClockwise, NearestAbsolute} {
+		var (
+			ring       = Strings(services[:10]...).SearchPolicy(policy).Build()
+			updated, _ = Update(ring, services[5:15]...)
+			expected   = Strings(services[5:15]...).SearchPolicy(policy).Build()
+		)
+
+		suite.True(expected.Equal(updated))
+		suite.Equal(expected.Fingerprint(), updated.Fingerprint())
+		for _, object := range hashObjects[:200] {
+			expectedSvc, _ := expected.Find(object[:])
+			actualSvc, _ := updated.Find(object[:])
+			suite.Require().Equal(expectedSvc, actualSvc)
+		}
+
+		// the same tokens route differently under a different policy
+		clockwise := Strings(services[5:15]...).Build()
+		suite.False(clockwise.Equal(updated))
+		suite.NotEqual(clockwise.Fingerprint(), updated.Fingerprint())
+		suite.Equal(clockwise.nodes.tokens(), updated.tokens)
+
+		_, err := Merge(ring, clockwise)
+		suite.ErrorIs(err, ErrHasherMismatch)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	suite.Run(t, new(SearchSuite))
+}
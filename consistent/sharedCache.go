@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultSharedCacheSize is the maximum number of services a SharedCache holds
+	// when no size is supplied.
+	DefaultSharedCacheSize = 4096
+)
+
+// sharedEntry is the cached nodes for a single service. The nodes are computed at most
+// once, by whichever Ring first needs them.
+type sharedEntry[S medley.Service] struct {
+	service S
+
+	once      sync.Once
+	check     nodeCheck
+	nodes     nodes[S]
+	truncated bool
+}
+
+// SharedCache memoizes the nodes of services across every Ring that uses it, so that the vnode
+// tokens of services common to many Rings, e.g. the per-tenant Rings of a RingManager that share
+// a pool of services, are only computed once per process.
+//
+// A SharedCache has its own algorithm, vnodes, and ServiceHasher. A Ring only consults the cache
+// when its own configuration matches, including the number of vnodes for the service being hashed.
+// Since distinct closures can't be told apart, a Ring still writes each service's hash bytes and
+// only uses the cached nodes if a digest of those bytes under its own algorithm matches the cache's.
+// Otherwise, the Ring hashes the service itself, so a cache never changes where objects are placed.
+// When the cache is full, the least recently used service is evicted.
+//
+// Cached nodes are shared by every Ring that uses them, just as a Ring shares nodes with the Rings
+// created from it by Update. They are never modified after they are computed.
+//
+// Methods on this type are safe for concurrent usage. A SharedCache must not be copied after creation.
+type SharedCache[S medley.Service] struct {
+	hasher hasher[S]
+	size   int
+
+	lock    sync.Mutex
+	entries map[S]*list.Element
+	order   *list.List
+}
+
+// NewSharedCache creates a SharedCache holding the nodes of at most size services. If size is
+// nonpositive, DefaultSharedCacheSize is used. The algorithm, vnodes, and ServiceHasher have the
+// same defaults as a Builder, so NewSharedCache(medley.Algorithm{}, 0, nil, 0) matches a Builder
+// with the default configuration.
+func NewSharedCache[S medley.Service](alg medley.Algorithm, vnodes int, sh medley.ServiceHasher[S], size int) *SharedCache[S] {
+	if size < 1 {
+		size = DefaultSharedCacheSize
+	}
+
+	return &SharedCache[S]{
+		hasher: hasher[S]{
+			vnodes:        vnodes,
+			alg:           alg,
+			serviceHasher: sh,
+		}.withDefaults(),
+		size:    size,
+		entries: make(map[S]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// matches tests if this cache could hold the nodes that the given hasher computes for a service
+// with the given number of vnodes. Functions are compared by their code pointers, so a match
+// must still be confirmed for each service with its nodeCheck.
+func (sc *SharedCache[S]) matches(h hasher[S], vnodes int) bool {
+	return vnodes == sc.hasher.vnodes &&
+		h.maxBytes == sc.hasher.maxBytes &&
+		funcPointer(h.alg.New64) == funcPointer(sc.hasher.alg.New64) &&
+		funcPointer(h.alg.Sum64) == funcPointer(sc.hasher.alg.Sum64) &&
+		funcPointer(h.serviceHasher) == funcPointer(sc.hasher.serviceHasher)
+}
+
+// entry returns the cache entry for a service, creating it and evicting the least recently
+// used entry if necessary.
+func (sc *SharedCache[S]) entry(svc S) *sharedEntry[S] {
+	defer sc.lock.Unlock()
+	sc.lock.Lock()
+
+	if e, ok := sc.entries[svc]; ok {
+		sc.order.MoveToFront(e)
+		return e.Value.(*sharedEntry[S])
+	}
+
+	se := &sharedEntry[S]{service: svc}
+	sc.entries[svc] = sc.order.PushFront(se)
+	for sc.order.Len() > sc.size {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.entries, oldest.Value.(*sharedEntry[S]).service)
+	}
+
+	return se
+}
+
+// serviceNodes returns the cached nodes for a service, computing them on a cache miss.
+// The caller must ensure this cache matches its hasher. If the cached nodes don't have
+// the given check, they were computed differently and this method returns false.
+func (sc *SharedCache[S]) serviceNodes(svc S, check nodeCheck) (nodes[S], bool, bool) {
+	se := sc.entry(svc)
+	se.once.Do(func() {
+		base, over := sc.hasher.base(svc)
+		se.check = sc.hasher.check(base)
+		se.nodes, se.truncated = sc.hasher.baseNodes(svc, base, 0, sc.hasher.vnodes), over
+	})
+
+	if se.check != check {
+		return nil, false, false
+	}
+
+	return se.nodes, se.truncated, true
+}
+
+// Len returns the number of services currently cached.
+func (sc *SharedCache[S]) Len() int {
+	defer sc.lock.Unlock()
+	sc.lock.Lock()
+
+	return sc.order.Len()
+}
+
+// Contains tests if the nodes for the given service are currently cached.
+func (sc *SharedCache[S]) Contains(svc S) bool {
+	defer sc.lock.Unlock()
+	sc.lock.Lock()
+
+	_, ok := sc.entries[svc]
+	return ok
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type SharedCacheSuite struct {
+	suite.Suite
+}
+
+// sharedHasher is a ServiceHasher other than the default, shared by the cache and builders.
+func sharedHasher(dst io.Writer, svc string) error {
+	return medley.HashStringTo(dst, svc)
+}
+
+func (suite *SharedCacheSuite) newSharedCache(size int) *SharedCache[string] {
+	sc := NewSharedCache(medley.Algorithm{}, 20, sharedHasher, size)
+	suite.Require().NotNil(sc)
+	return sc
+}
+
+func (suite *SharedCacheSuite) newBuilder(sc *SharedCache[string], services ...string) *Builder[string] {
+	return Services(services...).
+		ServiceHasher(sharedHasher).
+		VNodes(20).
+		UseSharedCache(sc)
+}
+
+// first returns the first node of a service in a ring, which identifies the nodes the ring uses.
+func (suite *SharedCacheSuite) first(r *Ring[string], svc string) *node[string] {
+	suite.Require().Contains(r.cache, svc)
+	return r.cache[svc][0]
+}
+
+func (suite *SharedCacheSuite) TestOncePerService() {
+	var (
+		sc      = suite.newSharedCache(0)
+		shared  = make(map[string]*node[string])
+		tenants []*Ring[string]
+	)
+
+	// tenants whose services overlap
+	for tenant := range 10 {
+		ring := suite.newBuilder(sc, services[tenant:tenant+20]...).Build()
+		suite.Equal(20, ring.Len())
+		tenants = append(tenants, ring)
+	}
+
+	suite.Equal(29, sc.Len())
+	for _, ring := range tenants {
+		for _, svc := range ring.Services() {
+			if _, ok := shared[svc]; !ok {
+				shared[svc] = suite.first(ring, svc)
+			}
+
+			suite.Same(shared[svc], suite.first(ring, svc))
+		}
+	}
+
+	// Update only consults the cache for services the ring doesn't already have
+	ring := suite.newBuilder(sc, services[:20]...).Build()
+	updated, _ := Update(ring, services[10:40]...)
+	suite.Equal(30, updated.Len())
+	suite.Equal(40, sc.Len())
+	suite.True(sc.Contains(services[39]))
+	suite.Same(shared[services[10]], suite.first(updated, services[10]))
+}
+
+func (suite *SharedCacheSuite) TestCorrectness() {
+	var (
+		sc       = suite.newSharedCache(0)
+		expected = Services(services[:30]...).ServiceHasher(sharedHasher).VNodes(20).Build()
+		warm     = suite.newBuilder(sc, services[10:30]...).Build()
+		actual   = suite.newBuilder(sc, services[:30]...).Build()
+	)
+
+	suite.Equal(20, warm.Len())
+	suite.True(expected.Equal(actual))
+	suite.Equal(expected.Fingerprint(), actual.Fingerprint())
+	for _, object := range hashObjects {
+		expectedSvc, err := expected.Find(object[:])
+		suite.Require().NoError(err)
+
+		actualSvc, err := actual.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedSvc, actualSvc)
+	}
+
+	// the same holds for rings derived with Update
+	expectedUpdate, _ := Update(expected, services[5:50]...)
+	actualUpdate, _ := Update(actual, services[5:50]...)
+	suite.True(expectedUpdate.Equal(actualUpdate))
+	suite.Equal(expectedUpdate.Fingerprint(), actualUpdate.Fingerprint())
+}
+
+func (suite *SharedCacheSuite) TestMismatch() {
+	sc := suite.newSharedCache(0)
+
+	// a different number of vnodes bypasses the cache
+	Services(services[:10]...).ServiceHasher(sharedHasher).VNodes(30).UseSharedCache(sc).Build()
+	suite.Zero(sc.Len())
+
+	// as does a different ServiceHasher
+	Strings(services[:10]...).VNodes(20).UseSharedCache(sc).Build()
+	suite.Zero(sc.Len())
+
+	// and per-service vnode overrides
+	ring := suite.newBuilder(sc, services[:10]...).Build()
+	suite.Equal(10, sc.Len())
+	overridden, _ := UpdateVNodes(ring, func(string) int { return 40 }, services[:11]...)
+	suite.Equal(11, overridden.Len())
+	suite.Equal(10, sc.Len())
+	suite.Len(overridden.cache[services[10]], 40)
+}
+
+func (suite *SharedCacheSuite) TestClosures() {
+	prefixed := func(prefix string) medley.ServiceHasher[string] {
+		return func(dst io.Writer, svc string) error {
+			return medley.HashStringTo(dst, prefix+svc)
+		}
+	}
+
+	var (
+		// the closures share code, but not the prefix they capture
+		sc       = NewSharedCache(medley.Algorithm{}, 20, prefixed("A:"), 0)
+		expected = Services(services[:30]...).ServiceHasher(prefixed("B:")).VNodes(20).Build()
+		actual   = Services(services[:30]...).ServiceHasher(prefixed("B:")).VNodes(20).UseSharedCache(sc).Build()
+	)
+
+	// the cache is consulted, but its nodes aren't used
+	suite.Equal(30, sc.Len())
+	suite.True(expected.Equal(actual))
+	for _, object := range hashObjects {
+		expectedSvc, err := expected.Find(object[:])
+		suite.Require().NoError(err)
+
+		actualSvc, err := actual.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedSvc, actualSvc)
+	}
+
+	// the closure the cache was created with still uses the cached nodes
+	cached := Services(services[:30]...).ServiceHasher(prefixed("A:")).VNodes(20).UseSharedCache(sc).Build()
+	suite.Equal(Services(services[:30]...).ServiceHasher(prefixed("A:")).VNodes(20).Build().Fingerprint(), cached.Fingerprint())
+	suite.NotSame(suite.first(actual, services[0]), suite.first(cached, services[0]))
+	suite.Same(
+		suite.first(cached, services[0]),
+		suite.first(Services(services[0]).ServiceHasher(prefixed("A:")).VNodes(20).UseSharedCache(sc).Build(), services[0]),
+	)
+}
+
+func (suite *SharedCacheSuite) TestEviction() {
+	var (
+		sc    = suite.newSharedCache(10)
+		rings = make(map[string]*Ring[string])
+	)
+
+	// build one service at a time, so that the order of use is known
+	for _, svc := range services[:10] {
+		rings[svc] = suite.newBuilder(sc, svc).Build()
+	}
+
+	suite.Equal(10, sc.Len())
+
+	// services[0] becomes the most recently used, so services[1] is evicted next
+	suite.Same(suite.first(rings[services[0]], services[0]), suite.first(suite.newBuilder(sc, services[0]).Build(), services[0]))
+	suite.newBuilder(sc, services[10]).Build()
+	suite.Equal(10, sc.Len())
+	suite.True(sc.Contains(services[0]))
+	suite.False(sc.Contains(services[1]))
+
+	// an evicted service is hashed again
+	suite.NotSame(suite.first(rings[services[1]], services[1]), suite.first(suite.newBuilder(sc, services[1]).Build(), services[1]))
+	suite.Equal(10, sc.Len())
+
+	// rings keep their nodes after eviction
+	ring := suite.newBuilder(sc, services[50:70]...).Build()
+	suite.Equal(10, sc.Len())
+	suite.Equal(20, ring.Len())
+	suite.Equal(400, len(ring.nodes))
+}
+
+func (suite *SharedCacheSuite) TestConcurrentBuilds() {
+	const goroutines = 8
+
+	var (
+		sc = suite.newSharedCache(0)
+		wg sync.WaitGroup
+
+		rings [goroutines]*Ring[string]
+	)
+
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rings[g] = suite.newBuilder(sc, services[:50]...).Build()
+		}()
+	}
+
+	wg.Wait()
+	suite.Equal(50, sc.Len())
+	for _, ring := range rings[1:] {
+		suite.True(rings[0].Equal(ring))
+		for _, svc := range services[:50] {
+			suite.Same(suite.first(rings[0], svc), suite.first(ring, svc))
+		}
+	}
+}
+
+func TestSharedCache(t *testing.T) {
+	suite.Run(t, new(SharedCacheSuite))
+}
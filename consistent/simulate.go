@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/xmidt-org/medley"
+)
+
+// AdditionImpact describes the balance of a Ring after adding one or more candidate services.
+type AdditionImpact[S medley.Service] struct {
+	// Services are the candidates that were added, in the order they were chosen.
+	Services []S
+
+	// MaxOwnership is the largest fraction of the hash circle owned by any one service
+	// after the addition.
+	MaxOwnership float64
+
+	// CoefficientOfVariation is the standard deviation of the services' ownership divided
+	// by its mean after the addition. Zero (0) means every service owns the same fraction.
+	CoefficientOfVariation float64
+
+	// Improvement is the decrease in the coefficient of variation from the original Ring.
+	// A negative value means the addition made the Ring less balanced.
+	Improvement float64
+}
+
+// ownershipStats computes the balance metrics for a Ring. An empty Ring is perfectly balanced.
+func ownershipStats[S medley.Service](r *Ring[S]) (maxOwnership, cv float64) {
+	ownership := r.Ownership()
+	if len(ownership) == 0 {
+		return
+	}
+
+	var (
+		mean     = 1.0 / float64(len(ownership))
+		variance float64
+	)
+
+	for _, fraction := range ownership {
+		maxOwnership = max(maxOwnership, fraction)
+		variance += (fraction - mean) * (fraction - mean)
+	}
+
+	cv = math.Sqrt(variance/float64(len(ownership))) / mean
+	return
+}
+
+// SimulateAdditions estimates how adding services would change the balance of a Ring, e.g. for
+// capacity planning. The given Ring is not modified. Each simulated Ring is created with Update,
+// so only the candidates are hashed.
+//
+// The returned slice begins with one AdditionImpact for each candidate on its own, ranked from the
+// most to the least improvement. If k is greater than one (1), it then contains the best sets of
+// 2 through k candidates, in order of size. These sets are chosen greedily: each one adds the
+// candidate that most improves the previous set. Since k is capped at the number of candidates,
+// the last set may contain every candidate.
+//
+// Candidates that are already in the Ring, and duplicate candidates, are ignored. Ties are ranked
+// by MaxOwnership and then by the order of the candidates.
+func SimulateAdditions[S medley.Service](r *Ring[S], candidates []S, k int) (impacts []AdditionImpact[S]) {
+	var (
+		existing = slices.Collect(r.All())
+		distinct []S
+		seen     = make(medley.Map[S, bool], len(candidates))
+	)
+
+	for _, c := range candidates {
+		if !r.Contains(c) && !seen[c] {
+			seen[c] = true
+			distinct = append(distinct, c)
+		}
+	}
+
+	_, baseline := ownershipStats(r)
+	simulate := func(from *Ring[S], chosen []S) (AdditionImpact[S], *Ring[S]) {
+		next, _ := Update(from, append(slices.Clone(existing), chosen...)...)
+		impact := AdditionImpact[S]{Services: chosen}
+		impact.MaxOwnership, impact.CoefficientOfVariation = ownershipStats(next)
+		impact.Improvement = baseline - impact.CoefficientOfVariation
+		return impact, next
+	}
+
+	compare := func(a, b AdditionImpact[S]) int {
+		return cmp.Or(
+			cmp.Compare(a.CoefficientOfVariation, b.CoefficientOfVariation),
+			cmp.Compare(a.MaxOwnership, b.MaxOwnership),
+		)
+	}
+
+	rings := make(medley.Map[S, *Ring[S]], len(distinct))
+	for _, c := range distinct {
+		var impact AdditionImpact[S]
+		impact, rings[c] = simulate(r, []S{c})
+		impacts = append(impacts, impact)
+	}
+
+	slices.SortStableFunc(impacts, compare)
+	if len(impacts) == 0 {
+		return
+	}
+
+	var (
+		best     = impacts[0]
+		bestRing = rings[best.Services[0]]
+	)
+
+	for size := 2; size <= min(k, len(distinct)); size++ {
+		var (
+			next     AdditionImpact[S]
+			nextRing *Ring[S]
+		)
+
+		for _, c := range distinct {
+			if slices.Contains(best.Services, c) {
+				continue
+			}
+
+			chosen := append(slices.Clone(best.Services), c)
+			if impact, ring := simulate(bestRing, chosen); nextRing == nil || compare(impact, next) < 0 {
+				next, nextRing = impact, ring
+			}
+		}
+
+		impacts = append(impacts, next)
+		best, bestRing = next, nextRing
+	}
+
+	return
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+type SimulateSuite struct {
+	suite.Suite
+}
+
+// imbalancedRing builds a ring with one vnode per service, where a owns three quarters of the
+// hash circle and b owns the rest. Each candidate is scripted to the given token.
+func (suite *SimulateSuite) imbalancedRing(candidates map[string]uint64) *Ring[string] {
+	sa := medleytest.NewScriptedAlgorithm(0)
+	suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], "a", 1<<62))
+	suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], "b", 1<<63))
+	for c, token := range candidates {
+		suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], c, token))
+	}
+
+	return Strings("a", "b").Algorithm(sa.Algorithm()).VNodes(1).Build()
+}
+
+// serviceSets returns the Services of each impact.
+func serviceSets[S medley.Service](impacts []AdditionImpact[S]) (sets [][]S) {
+	for _, impact := range impacts {
+		sets = append(sets, impact.Services)
+	}
+
+	return
+}
+
+func (suite *SimulateSuite) TestRanking() {
+	ring := suite.imbalancedRing(map[string]uint64{
+		"c": 1 << 61,           // splits a's arc unevenly
+		"d": 3 << 62,           // splits a's arc evenly
+		"e": (1 << 63) + 1<<40, // takes a sliver of a's arc
+	})
+
+	impacts := SimulateAdditions(ring, []string{"c", "d", "e"}, 1)
+	suite.Equal([][]string{{"d"}, {"c"}, {"e"}}, serviceSets(impacts))
+
+	// d leaves ownership of 1/2, 1/4, 1/4
+	suite.InDelta(0.5, impacts[0].MaxOwnership, 1e-9)
+	suite.InDelta(0.3536, impacts[0].CoefficientOfVariation, 1e-4)
+	suite.InDelta(0.5-0.3536, impacts[0].Improvement, 1e-4)
+
+	// c and e make the ring worse
+	suite.Negative(impacts[1].Improvement)
+	suite.Negative(impacts[2].Improvement)
+	suite.Greater(impacts[2].MaxOwnership, 0.74)
+}
+
+func (suite *SimulateSuite) TestGreedy() {
+	ring := suite.imbalancedRing(map[string]uint64{
+		"c": 1 << 61,
+		"d": 3 << 62,
+		"e": (1 << 63) + 1<<40,
+	})
+
+	impacts := SimulateAdditions(ring, []string{"e", "c", "d"}, 2)
+	suite.Equal([][]string{{"d"}, {"c"}, {"e"}, {"d", "c"}}, serviceSets(impacts))
+
+	// with d, c splits what remains of a's arc: 1/8, 1/4, 1/4, 3/8
+	suite.InDelta(0.375, impacts[3].MaxOwnership, 1e-9)
+	suite.Positive(impacts[3].Improvement)
+
+	// k is capped at the number of candidates
+	impacts = SimulateAdditions(ring, []string{"e", "c", "d"}, 10)
+	suite.Equal([][]string{{"d"}, {"c"}, {"e"}, {"d", "c"}, {"d", "c", "e"}}, serviceSets(impacts))
+}
+
+func (suite *SimulateSuite) TestNoMutation() {
+	var (
+		ring        = Strings(services[:10]...).VNodes(50).Build()
+		fingerprint = ring.Fingerprint()
+		ownership   = ring.Ownership()
+		nodes       = slices.Clone(ring.nodes)
+	)
+
+	impacts := SimulateAdditions(ring, services[10:15], 3)
+	suite.Len(impacts, 7)
+
+	suite.Equal(fingerprint, ring.Fingerprint())
+	suite.Equal(ownership, ring.Ownership())
+	suite.Equal(nodes, ring.nodes)
+	suite.Equal(10, ring.Len())
+}
+
+func (suite *SimulateSuite) TestAgainstUpdate() {
+	var (
+		ring       = Strings(services[:10]...).VNodes(50).Build()
+		candidates = services[10:20]
+		impacts    = SimulateAdditions(ring, candidates, 3)
+	)
+
+	suite.Require().Len(impacts, len(candidates)+2)
+
+	// singles are ranked, and each matches a ring built with Update
+	for i, impact := range impacts[:len(candidates)] {
+		if i > 0 {
+			suite.LessOrEqual(impacts[i-1].CoefficientOfVariation, impact.CoefficientOfVariation)
+		}
+
+		suite.Require().Len(impact.Services, 1)
+		updated, _ := Update(ring, append(slices.Clone(services[:10]), impact.Services...)...)
+		maxOwnership, cv := ownershipStats(updated)
+		suite.InDelta(maxOwnership, impact.MaxOwnership, 1e-12)
+		suite.InDelta(cv, impact.CoefficientOfVariation, 1e-12)
+	}
+
+	// each greedy set extends the one before it, starting with the best single candidate
+	previous := impacts[0].Services
+	for _, impact := range impacts[len(candidates):] {
+		suite.Equal(previous, impact.Services[:len(previous)])
+		suite.Len(impact.Services, len(previous)+1)
+		previous = impact.Services
+	}
+}
+
+func (suite *SimulateSuite) TestIgnoredCandidates() {
+	ring := Strings(services[:10]...).Build()
+
+	impacts := SimulateAdditions(ring, []string{services[0], services[10], services[10], services[1]}, 5)
+	suite.Equal([][]string{{services[10]}}, serviceSets(impacts))
+
+	suite.Empty(SimulateAdditions(ring, services[:10], 2))
+	suite.Empty(SimulateAdditions(ring, nil, 2))
+}
+
+func (suite *SimulateSuite) TestEmptyRing() {
+	impacts := SimulateAdditions(Strings[string]().Build(), []string{services[0], services[1]}, 2)
+	suite.Require().Len(impacts, 3)
+	suite.InDelta(1.0, impacts[0].MaxOwnership, 1e-9)
+	suite.InDelta(0.0, impacts[0].CoefficientOfVariation, 1e-9)
+	suite.Len(impacts[2].Services, 2)
+}
+
+func TestSimulate(t *testing.T) {
+	suite.Run(t, new(SimulateSuite))
+}
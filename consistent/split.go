@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/xmidt-org/medley"
+)
+
+// SplitLocator is a medley.Locator that splits the traffic for specific objects between
+// their owner and the owner's next distinct successor at a fixed ratio, e.g. to canary
+// a hot key on another service. Objects without a split rule are passed through to the
+// underlying locator untouched.
+//
+// Split rules match the exact bytes of an object. Methods on this type are safe for
+// concurrent usage, provided the random function is also safe for concurrent usage.
+type SplitLocator[S medley.Service] struct {
+	next   SuccessorLocator[S]
+	random func() float64
+
+	lock  sync.RWMutex
+	rules map[string]float64
+}
+
+var _ medley.AnnotatedLocator[string] = (*SplitLocator[string])(nil)
+
+// NewSplitLocator creates a SplitLocator with no split rules. The random function must
+// return values in the range [0.0, 1.0). If random is nil, math/rand.Float64 is used.
+func NewSplitLocator[S medley.Service](next SuccessorLocator[S], random func() float64) *SplitLocator[S] {
+	if random == nil {
+		random = rand.Float64
+	}
+
+	return &SplitLocator[S]{
+		next:   next,
+		random: random,
+		rules:  make(map[string]float64),
+	}
+}
+
+// AddSplit adds or replaces the split rule for an object. The given ratio of lookups
+// for that object are routed to the owner's next distinct successor, and the rest are
+// routed to the owner. The ratio is clamped to the range [0.0, 1.0], and NaN is treated
+// as 0.0. The key is copied.
+func (sl *SplitLocator[S]) AddSplit(key []byte, ratio float64) {
+	switch {
+	case math.IsNaN(ratio) || ratio < 0.0:
+		ratio = 0.0
+
+	case ratio > 1.0:
+		ratio = 1.0
+	}
+
+	sl.lock.Lock()
+	sl.rules[string(key)] = ratio
+	sl.lock.Unlock()
+}
+
+// RemoveSplit removes the split rule for an object, which restores deterministic
+// routing for it. This method returns false if the object had no split rule.
+func (sl *SplitLocator[S]) RemoveSplit(key []byte) bool {
+	defer sl.lock.Unlock()
+	sl.lock.Lock()
+
+	_, exists := sl.rules[string(key)]
+	delete(sl.rules, string(key))
+	return exists
+}
+
+// Find returns the owner of the given object. If the object has a split rule, its
+// owner's next distinct successor may be returned instead. When the owner has no
+// distinct successor, the owner is always returned.
+func (sl *SplitLocator[S]) Find(object []byte) (svc S, err error) {
+	ratio, split := sl.rule(object)
+	if !split {
+		return sl.next.Find(object)
+	}
+
+	return sl.split(object, ratio)
+}
+
+// FindAnnotated is like Find, but reports SourceSplit for an object with a split rule,
+// whichever side of the split was chosen. Otherwise, the underlying locator's annotation
+// is returned.
+func (sl *SplitLocator[S]) FindAnnotated(object []byte) (result medley.FindResult[S], err error) {
+	ratio, split := sl.rule(object)
+	if !split {
+		return medley.Explain[S](sl.next, object)
+	}
+
+	result.Service, err = sl.split(object, ratio)
+	result.Source = SourceSplit
+	return
+}
+
+// rule returns the split ratio for an object, if it has a split rule.
+func (sl *SplitLocator[S]) rule(object []byte) (ratio float64, split bool) {
+	sl.lock.RLock()
+	ratio, split = sl.rules[string(object)]
+	sl.lock.RUnlock()
+	return
+}
+
+// split chooses between an object's owner and its next distinct successor.
+func (sl *SplitLocator[S]) split(object []byte, ratio float64) (svc S, err error) {
+	var (
+		successor = ratio > 0.0 && sl.random() < ratio
+		found     bool
+	)
+
+	for candidate := range sl.next.Successors(object) {
+		svc, found = candidate, true
+		if !successor {
+			break
+		}
+
+		successor = false
+	}
+
+	if !found {
+		err = medley.ErrNoServices
+	}
+
+	return
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type SplitLocatorSuite struct {
+	suite.Suite
+
+	ring *Ring[string]
+	sl   *SplitLocator[string]
+}
+
+func (suite *SplitLocatorSuite) SetupTest() {
+	suite.ring = Strings(services[:8]...).Build()
+	suite.sl = NewSplitLocator(suite.ring, rand.New(rand.NewSource(8472)).Float64)
+}
+
+// successors returns the owner and the next distinct successor of an object.
+func (suite *SplitLocatorSuite) successors(object []byte) (owner, next string) {
+	s := slices.Collect(suite.ring.Successors(object))
+	suite.Require().GreaterOrEqual(len(s), 2)
+	return s[0], s[1]
+}
+
+// counts performs many lookups of an object and counts the results.
+func (suite *SplitLocatorSuite) counts(object []byte, n int) map[string]int {
+	counts := make(map[string]int)
+	for range n {
+		svc, err := suite.sl.Find(object)
+		suite.Require().NoError(err)
+		counts[svc]++
+	}
+
+	return counts
+}
+
+func (suite *SplitLocatorSuite) TestPassThrough() {
+	suite.sl.AddSplit(hashObjects[0][:], 0.5)
+	for _, object := range hashObjects[1:] {
+		expected, err := suite.ring.Find(object[:])
+		suite.Require().NoError(err)
+
+		actual, err := suite.sl.Find(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, actual)
+	}
+}
+
+func (suite *SplitLocatorSuite) TestRatio() {
+	const lookups = 10000
+
+	for _, ratio := range []float64{0.1, 0.5, 0.9} {
+		var (
+			object      = hashObjects[1][:]
+			owner, next = suite.successors(object)
+		)
+
+		suite.sl.AddSplit(object, ratio)
+		counts := suite.counts(object, lookups)
+		suite.Len(counts, 2)
+
+		// the binomial standard deviation is at most 50, so this is over 5 deviations
+		suite.InDelta(ratio*lookups, counts[next], 300, "ratio %f", ratio)
+		suite.Equal(lookups, counts[owner]+counts[next])
+	}
+}
+
+func (suite *SplitLocatorSuite) TestClamped() {
+	var (
+		object      = hashObjects[2][:]
+		owner, next = suite.successors(object)
+	)
+
+	for _, ratio := range []float64{0.0, -1.0, math.NaN()} {
+		suite.sl.AddSplit(object, ratio)
+		suite.Equal(map[string]int{owner: 100}, suite.counts(object, 100))
+	}
+
+	for _, ratio := range []float64{1.0, 2.0, math.Inf(1)} {
+		suite.sl.AddSplit(object, ratio)
+		suite.Equal(map[string]int{next: 100}, suite.counts(object, 100))
+	}
+}
+
+func (suite *SplitLocatorSuite) TestExactMatch() {
+	var (
+		key     = []byte("hot-key")
+		_, next = suite.successors(key)
+	)
+
+	suite.sl.AddSplit(key, 1.0)
+
+	// the key is copied
+	key[0] = 'H'
+	suite.Equal(map[string]int{next: 10}, suite.counts([]byte("hot-key"), 10))
+
+	for _, object := range [][]byte{[]byte("hot-ke"), []byte("hot-key2"), []byte("Hot-key")} {
+		expected, err := suite.ring.Find(object)
+		suite.Require().NoError(err)
+		suite.Equal(map[string]int{expected: 10}, suite.counts(object, 10))
+	}
+}
+
+func (suite *SplitLocatorSuite) TestRemoveSplit() {
+	var (
+		object   = hashObjects[3][:]
+		owner, _ = suite.successors(object)
+	)
+
+	suite.False(suite.sl.RemoveSplit(object))
+	suite.sl.AddSplit(object, 0.5)
+	suite.Len(suite.counts(object, 1000), 2)
+
+	suite.True(suite.sl.RemoveSplit(object))
+	suite.False(suite.sl.RemoveSplit(object))
+	suite.Equal(map[string]int{owner: 1000}, suite.counts(object, 1000))
+}
+
+func (suite *SplitLocatorSuite) TestSingleService() {
+	var (
+		object = []byte("test")
+		sl     = NewSplitLocator(Strings("single").Build(), nil)
+	)
+
+	sl.AddSplit(object, 1.0)
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal("single", svc)
+}
+
+func (suite *SplitLocatorSuite) TestNoServices() {
+	var (
+		object   = []byte("test")
+		empty, _ = Update(suite.ring)
+		sl       = NewSplitLocator(empty, nil)
+	)
+
+	_, err := sl.Find(object)
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	sl.AddSplit(object, 0.5)
+	_, err = sl.Find(object)
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func TestSplitLocator(t *testing.T) {
+	suite.Run(t, new(SplitLocatorSuite))
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type StateRingSuite struct {
+	suite.Suite
+
+	sr *StateRing[string]
+}
+
+func (suite *StateRingSuite) SetupTest() {
+	suite.sr = NewStateRing(Strings(services[:10]...).VNodes(10).Build())
+}
+
+func (suite *StateRingSuite) assertState(svc string, expected ServiceState) {
+	state, exists := suite.sr.State(svc)
+	suite.True(exists, "service %s", svc)
+	suite.Equal(expected, state, "service %s", svc)
+}
+
+func (suite *StateRingSuite) TestString() {
+	suite.Equal("active", StateActive.String())
+	suite.Equal("draining", StateDraining.String())
+	suite.Equal("standby", StateStandby.String())
+	suite.Equal("ServiceState(99)", ServiceState(99).String())
+}
+
+func (suite *StateRingSuite) TestDefaults() {
+	for _, svc := range services[:10] {
+		suite.assertState(svc, StateActive)
+	}
+
+	_, exists := suite.sr.State("nosuch")
+	suite.False(exists)
+	suite.False(suite.sr.SetState("nosuch", StateDraining))
+	_, exists = suite.sr.State("nosuch")
+	suite.False(exists)
+}
+
+func (suite *StateRingSuite) TestRetention() {
+	suite.True(suite.sr.SetState(services[0], StateDraining))
+	suite.True(suite.sr.SetState(services[1], StateStandby))
+	before := suite.sr.Snapshot()
+
+	// setting the same state again publishes nothing
+	suite.True(suite.sr.SetState(services[0], StateDraining))
+	suite.Same(before, suite.sr.Snapshot())
+
+	// retained services keep their states across several updates, and new services are active
+	suite.True(suite.sr.Update(services[:12]...))
+	suite.True(suite.sr.Update(services[:15]...))
+	suite.False(suite.sr.Update(services[:15]...))
+
+	suite.assertState(services[0], StateDraining)
+	suite.assertState(services[1], StateStandby)
+	for _, svc := range services[2:15] {
+		suite.assertState(svc, StateActive)
+	}
+
+	// the ring was not rebuilt by state changes
+	suite.Equal(15, suite.sr.Snapshot().Len())
+}
+
+func (suite *StateRingSuite) TestRemoval() {
+	suite.True(suite.sr.SetState(services[0], StateDraining))
+	suite.True(suite.sr.Update(services[1:10]...))
+
+	_, exists := suite.sr.State(services[0])
+	suite.False(exists)
+
+	// a service that rejoins starts over as active
+	suite.True(suite.sr.Update(services[:10]...))
+	suite.assertState(services[0], StateActive)
+}
+
+func (suite *StateRingSuite) TestFindWithState() {
+	suite.True(suite.sr.SetState(services[3], StateDraining))
+	for _, object := range hashObjects[:200] {
+		svc, state, err := suite.sr.FindWithState(object[:])
+		suite.Require().NoError(err)
+
+		expected := StateActive
+		if svc == services[3] {
+			expected = StateDraining
+		}
+
+		suite.Equal(expected, state)
+
+		found, err := suite.sr.Find(object[:])
+		suite.NoError(err)
+		suite.Equal(svc, found)
+	}
+
+	suite.sr.Update()
+	_, _, err := suite.sr.FindWithState([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *StateRingSuite) TestConcurrentSwap() {
+	const (
+		readers = 8
+		updates = 100
+	)
+
+	var (
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	// services[0] is always draining, in every snapshot
+	suite.Require().True(suite.sr.SetState(services[0], StateDraining))
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				state, exists := suite.sr.State(services[0])
+				if !suite.True(exists) || !suite.Equal(StateDraining, state) {
+					return
+				}
+
+				svc, state, err := suite.sr.FindWithState(hashObjects[i%len(hashObjects)][:])
+				if !suite.NoError(err) || (svc == services[0]) != (state == StateDraining) {
+					suite.Fail("mismatched state", "service %s has state %s", svc, state)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range updates {
+		suite.sr.Update(services[:10+i%10]...)
+		suite.sr.SetState(services[1+i%9], StateStandby)
+		suite.sr.SetState(services[1+i%9], StateActive)
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func TestStateRing(t *testing.T) {
+	suite.Run(t, new(StateRingSuite))
+}
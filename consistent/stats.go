@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+// Stats summarizes the size of a Ring, e.g. for dashboards. The naive math of services times
+// vnodes is wrong for rings with per-service vnodes or colliding tokens, so each count is given
+// separately.
+type Stats struct {
+	// Services is the number of services in the ring.
+	Services int
+
+	// VNodes is the ring's default vnodes per service. Individual services may differ, e.g.
+	// due to UpdateVNodes.
+	VNodes int
+
+	// ConfiguredNodes is the sum of every service's vnodes.
+	ConfiguredNodes int
+
+	// EffectiveNodes is the number of distinct tokens on the ring, i.e. the nodes that
+	// can own objects. It is ConfiguredNodes less Collisions.
+	EffectiveNodes int
+
+	// Collisions is the number of nodes whose token was already taken by another node.
+	// Ties are broken in favor of one node, so the others never own any objects.
+	Collisions int
+}
+
+// countCollisions returns the number of tokens that equal the token before them.
+// The tokens must be sorted.
+func countCollisions(tokens []uint64) (n int) {
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i] == tokens[i-1] {
+			n++
+		}
+	}
+
+	return
+}
+
+// ConfiguredNodeCount returns the sum of every service's vnodes. This includes per-service
+// vnodes, e.g. from UpdateVNodes, as well as nodes whose tokens collide.
+func (r *Ring[S]) ConfiguredNodeCount() int {
+	return len(r.nodes)
+}
+
+// EffectiveNodeCount returns the number of distinct tokens on this ring, which is the number
+// of nodes that can own objects.
+func (r *Ring[S]) EffectiveNodeCount() int {
+	return len(r.nodes) - r.collisions
+}
+
+// CollisionCount returns the number of nodes whose token collided with another node's token.
+// This is computed when the ring is created, whether by a Builder or by Update.
+func (r *Ring[S]) CollisionCount() int {
+	return r.collisions
+}
+
+// Stats returns a summary of the size of this ring.
+func (r *Ring[S]) Stats() Stats {
+	return Stats{
+		Services:        r.Len(),
+		VNodes:          r.VNodes(),
+		ConfiguredNodes: r.ConfiguredNodeCount(),
+		EffectiveNodes:  r.EffectiveNodeCount(),
+		Collisions:      r.CollisionCount(),
+	}
+}
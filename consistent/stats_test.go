@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+type StatsSuite struct {
+	suite.Suite
+}
+
+// assertConsistent verifies a ring's Stats against its nodes.
+func (suite *StatsSuite) assertConsistent(r *Ring[string]) Stats {
+	stats := r.Stats()
+	configured := 0
+	for _, snodes := range r.cache {
+		configured += len(snodes)
+	}
+
+	suite.Equal(r.Len(), stats.Services)
+	suite.Equal(r.VNodes(), stats.VNodes)
+	suite.Equal(configured, stats.ConfiguredNodes)
+	suite.Equal(len(slices.Compact(slices.Clone(r.tokens))), stats.EffectiveNodes)
+	suite.Equal(stats.ConfiguredNodes, stats.EffectiveNodes+stats.Collisions)
+
+	suite.Equal(stats.ConfiguredNodes, r.ConfiguredNodeCount())
+	suite.Equal(stats.EffectiveNodes, r.EffectiveNodeCount())
+	suite.Equal(stats.Collisions, r.CollisionCount())
+	return stats
+}
+
+func (suite *StatsSuite) TestCollisions() {
+	sa := medleytest.NewScriptedAlgorithm(0)
+	for svc, tokens := range map[string][]uint64{
+		"a": {10, 20},
+		"b": {20, 30},
+		"c": {10, 40},
+		"d": {30, 50},
+	} {
+		suite.Require().NoError(medleytest.ScriptService(sa, medley.HashStringTo[string], svc, tokens...))
+	}
+
+	ring := Strings("a", "b", "c").Algorithm(sa.Algorithm()).VNodes(2).Build()
+	suite.Equal(Stats{Services: 3, VNodes: 2, ConfiguredNodes: 6, EffectiveNodes: 4, Collisions: 2}, suite.assertConsistent(ring))
+
+	// retained services keep their cached nodes, and the counts still follow the membership
+	ring, _ = Update(ring, "a", "b")
+	suite.Equal(Stats{Services: 2, VNodes: 2, ConfiguredNodes: 4, EffectiveNodes: 3, Collisions: 1}, suite.assertConsistent(ring))
+
+	ring, _ = Update(ring, "a", "b", "d")
+	suite.Equal(Stats{Services: 3, VNodes: 2, ConfiguredNodes: 6, EffectiveNodes: 4, Collisions: 2}, suite.assertConsistent(ring))
+
+	// with one vnode, b only has the token 20
+	ring, _ = UpdateVNodes(ring, func(svc string) int {
+		if svc == "b" {
+			return 1
+		}
+
+		return 0
+	}, "a", "b", "d")
+
+	suite.Equal(Stats{Services: 3, VNodes: 2, ConfiguredNodes: 5, EffectiveNodes: 4, Collisions: 1}, suite.assertConsistent(ring))
+}
+
+func (suite *StatsSuite) TestUpdates() {
+	ring := Strings(services[:20]...).VNodes(50).Build()
+	suite.Equal(1000, suite.assertConsistent(ring).ConfiguredNodes)
+
+	for i := range 10 {
+		ring, _ = UpdateVNodes(ring, func(svc string) int {
+			if svc == services[i] || svc == services[i+20] {
+				return 10 + i
+			}
+
+			return 0
+		}, services[i:i+25]...)
+
+		stats := suite.assertConsistent(ring)
+		suite.Equal(23*50+2*(10+i), stats.ConfiguredNodes)
+	}
+}
+
+func (suite *StatsSuite) TestZeroRing() {
+	suite.Equal(Stats{VNodes: DefaultVNodes}, new(Ring[string]).Stats())
+	suite.Equal(Stats{VNodes: DefaultVNodes}, Strings[string]().Build().Stats())
+}
+
+func TestStats(t *testing.T) {
+	suite.Run(t, new(StatsSuite))
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"sync/atomic"
+
+	"github.com/xmidt-org/medley"
+)
+
+// generations is an immutable pair of Rings used by a StickyLocator.
+type generations[S medley.Service] struct {
+	previous *Ring[S]
+	current  *Ring[S]
+}
+
+// StickyLocator is a medley.Locator that minimizes changes in assignments as its Ring
+// changes. This is useful for stateful workloads, such as long-lived sessions.
+//
+// A StickyLocator holds two generations of Rings. A lookup returns the owner of an
+// object in the previous Ring, as long as that service still exists in the current
+// Ring. Otherwise, the owner in the current Ring is returned.
+//
+// The zero value of this type is usable, but will return medley.ErrNoServices until
+// Advance is called. Methods on this type are safe for concurrent usage.
+type StickyLocator[S medley.Service] struct {
+	gens atomic.Pointer[generations[S]]
+}
+
+// NewStickyLocator creates a StickyLocator with the given Ring as its current generation.
+// There is no previous generation until Advance is called. The current Ring may be nil,
+// in which case Find returns medley.ErrNoServices.
+func NewStickyLocator[S medley.Service](current *Ring[S]) *StickyLocator[S] {
+	sl := new(StickyLocator[S])
+	sl.gens.Store(&generations[S]{current: current})
+	return sl
+}
+
+var _ medley.AnnotatedLocator[string] = (*StickyLocator[string])(nil)
+
+// Advance makes the given Ring the current generation, and the current generation
+// becomes the previous generation. The oldest generation is discarded.
+func (sl *StickyLocator[S]) Advance(next *Ring[S]) {
+	for {
+		var (
+			old      = sl.gens.Load()
+			previous *Ring[S]
+		)
+
+		if old != nil {
+			previous = old.current
+		}
+
+		if sl.gens.CompareAndSwap(old, &generations[S]{previous: previous, current: next}) {
+			return
+		}
+	}
+}
+
+// Find returns the owner of the object in the previous generation if that service still
+// exists in the current generation. Otherwise, the owner in the current generation is returned.
+func (sl *StickyLocator[S]) Find(object []byte) (svc S, err error) {
+	gens := sl.gens.Load()
+	if gens == nil || gens.current == nil {
+		err = medley.ErrNoServices
+		return
+	}
+
+	if gens.previous != nil {
+		if prev, prevErr := gens.previous.Find(object); prevErr == nil && gens.current.Contains(prev) {
+			return prev, nil
+		}
+	}
+
+	return gens.current.Find(object)
+}
+
+// FindAnnotated is like Find, but reports SourceSticky when the owner in the previous
+// generation was kept. Otherwise, the source is SourceRing.
+func (sl *StickyLocator[S]) FindAnnotated(object []byte) (result medley.FindResult[S], err error) {
+	gens := sl.gens.Load()
+	if gens != nil && gens.current != nil && gens.previous != nil {
+		if prev, prevErr := gens.previous.Find(object); prevErr == nil && gens.current.Contains(prev) {
+			result = medley.FindResult[S]{Service: prev, Source: SourceSticky}
+			return
+		}
+	}
+
+	result.Service, err = sl.Find(object)
+	result.Source = SourceRing
+	return
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type StickyLocatorSuite struct {
+	suite.Suite
+}
+
+// owners returns the owner of each hash object in the given locator.
+func (suite *StickyLocatorSuite) owners(l medley.Locator[string]) []string {
+	owners := make([]string, len(hashObjects))
+	for i, object := range hashObjects {
+		var err error
+		owners[i], err = l.Find(object[:])
+		suite.Require().NoError(err)
+	}
+
+	return owners
+}
+
+func (suite *StickyLocatorSuite) TestEmpty() {
+	var sl StickyLocator[string]
+	_, err := sl.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	_, err = NewStickyLocator[string](nil).Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+
+	sl.Advance(Strings(services[:4]...).Build())
+	result, err := sl.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Contains(services[:4], result)
+}
+
+func (suite *StickyLocatorSuite) TestAdvance() {
+	var (
+		ringA = Strings(services[:4]...).Build()
+		sl    = NewStickyLocator(ringA)
+	)
+
+	suite.Require().NotNil(sl)
+	ownersA := suite.owners(sl)
+	suite.Equal(suite.owners(ringA), ownersA)
+
+	// adding a service: every owner survives, so nothing moves
+	ringB, _ := Update(ringA, services[:5]...)
+	sl.Advance(ringB)
+	suite.Equal(ownersA, suite.owners(sl))
+
+	// the plain ring does move some keys to the new service
+	var (
+		ownersB = suite.owners(ringB)
+		moved   = 0
+	)
+
+	for i, owner := range ownersB {
+		if owner != ownersA[i] {
+			moved++
+		}
+	}
+
+	suite.NotZero(moved)
+
+	// removing a service: the previous generation is now ringB, and
+	// only the keys it assigned to the removed service move
+	ringC, _ := Update(ringB, services[1:5]...)
+	sl.Advance(ringC)
+	ownersC := suite.owners(ringC)
+	for i, owner := range suite.owners(sl) {
+		if ownersB[i] == services[0] {
+			suite.Equal(ownersC[i], owner)
+		} else {
+			suite.Equal(ownersB[i], owner)
+		}
+	}
+
+	// a second advance drops ringA and ringB, so the locator matches ringC
+	sl.Advance(ringC)
+	suite.Equal(ownersC, suite.owners(sl))
+}
+
+func TestStickyLocator(t *testing.T) {
+	suite.Run(t, new(StickyLocatorSuite))
+}
@@ -0,0 +1,340 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// StorageVersion is the current version of the ring storage format.
+	StorageVersion uint32 = 2
+
+	// storageVersionClockwise is the first version of the ring storage format, which
+	// predates search policies. Its rings always use Clockwise.
+	storageVersionClockwise uint32 = 1
+
+	// storageMagic identifies ring storage.
+	storageMagic = "MEDLEYRG"
+
+	// storageHeaderSize is the size of the fixed portion of the header.
+	storageHeaderSize = 32
+)
+
+var (
+	// ErrInvalidStorage indicates that ring storage is truncated, corrupt,
+	// or not ring storage at all.
+	ErrInvalidStorage = errors.New("invalid ring storage")
+
+	// ErrUnsupportedStorageVersion indicates that ring storage has a format
+	// version that this package does not understand.
+	ErrUnsupportedStorageVersion = errors.New("unsupported ring storage version")
+)
+
+// WriteStorage writes this ring to a file in a compact, read-only format that can be shared
+// by several processes. Other processes can load the file with DecodeStorage or, to avoid
+// copying the ring into each process, by memory mapping it with the mmapring package.
+//
+// The enc function encodes each service. The ring's algorithm must be one of the builtin
+// algorithms returned by medley.AlgorithmNames. The file is written to a temporary file
+// and then renamed, so readers never see a partially written file.
+//
+// All integers are little-endian. The file consists of:
+//
+//	magic "MEDLEYRG"         8 bytes
+//	version                  uint32
+//	checksum                 uint32, the CRC-32 (IEEE) of everything after this field
+//	vnodes                   uint32
+//	service count            uint32
+//	node count               uint64
+//	algorithm name           uint16 length, then the name
+//	search policy            uint16
+//	services                 for each service, a uint32 length, then the encoded service
+//	padding                  zero bytes up to a multiple of 8
+//	tokens                   uint64 for each node, in ascending order
+//	service indexes          uint32 for each node, the index of the node's service
+func (r *Ring[S]) WriteStorage(path string, enc func(S) []byte) (err error) {
+	algorithm, err := algorithmName(r.config().alg)
+	if err != nil {
+		return
+	}
+
+	var (
+		data     = r.encodeStorage(algorithm, enc)
+		tempFile *os.File
+	)
+
+	tempFile, err = os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+		}
+	}()
+
+	if _, err = tempFile.Write(data); err != nil {
+		return
+	}
+
+	if err = tempFile.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+// encodeStorage produces the storage format described by WriteStorage.
+func (r *Ring[S]) encodeStorage(algorithm string, enc func(S) []byte) []byte {
+	var (
+		le      = binary.LittleEndian
+		indexes = make(medley.Map[S, uint32], len(r.cache))
+		data    = make([]byte, storageHeaderSize, storageHeaderSize+len(r.nodes)*12)
+	)
+
+	copy(data, storageMagic)
+	le.PutUint32(data[8:], StorageVersion)
+	le.PutUint32(data[16:], uint32(r.config().vnodes))
+	le.PutUint32(data[20:], uint32(len(r.cache)))
+	le.PutUint64(data[24:], uint64(len(r.nodes)))
+
+	data = le.AppendUint16(data, uint16(len(algorithm)))
+	data = append(data, algorithm...)
+	data = le.AppendUint16(data, uint16(r.config().policy))
+
+	// services are numbered in the order they first appear on the ring, which is deterministic
+	for _, n := range r.nodes {
+		if _, exists := indexes[n.service]; !exists {
+			indexes[n.service] = uint32(len(indexes))
+			encoded := enc(n.service)
+			data = le.AppendUint32(data, uint32(len(encoded)))
+			data = append(data, encoded...)
+		}
+	}
+
+	for len(data)%8 != 0 {
+		data = append(data, 0)
+	}
+
+	for _, n := range r.nodes {
+		data = le.AppendUint64(data, n.token)
+	}
+
+	for _, n := range r.nodes {
+		data = le.AppendUint32(data, indexes[n.service])
+	}
+
+	le.PutUint32(data[12:], crc32.ChecksumIEEE(data[16:]))
+	return data
+}
+
+// StorageRing is a read-only ring loaded from the storage written by Ring.WriteStorage.
+// A StorageRing's lookups are identical to those of the Ring that was written, including
+// its SearchPolicy.
+//
+// When possible, a StorageRing refers directly to the storage it was decoded from rather
+// than copying it, so that storage must not be modified or released while the StorageRing
+// is in use.
+type StorageRing[S medley.Service] struct {
+	alg      medley.Algorithm
+	vnodes   int
+	policy   SearchPolicy
+	services []S
+	tokens   []uint64
+	indexes  []uint32
+}
+
+var _ medley.Locator[string] = (*StorageRing[string])(nil)
+
+// Find performs a hash on the given object and returns the nearest
+// service. If this ring is empty, this method returns medley.ErrNoServices.
+func (sr *StorageRing[S]) Find(object []byte) (svc S, err error) {
+	if len(sr.tokens) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	i := sr.policy.search(sr.tokens, sr.alg.Sum64Bytes(object))
+	svc = sr.services[sr.indexes[i]]
+	return
+}
+
+// Len returns the number of services in this ring.
+func (sr *StorageRing[S]) Len() int {
+	return len(sr.services)
+}
+
+// Services returns the services in this ring, in no particular order.
+func (sr *StorageRing[S]) Services() []S {
+	return append([]S(nil), sr.services...)
+}
+
+// VNodes returns the number of vnodes per service of the ring that was written.
+func (sr *StorageRing[S]) VNodes() int {
+	return sr.vnodes
+}
+
+// SearchPolicy returns the search policy of the ring that was written.
+func (sr *StorageRing[S]) SearchPolicy() SearchPolicy {
+	return sr.policy
+}
+
+// storageReader consumes storage data, tracking whether it was truncated.
+type storageReader struct {
+	data      []byte
+	truncated bool
+}
+
+func (sr *storageReader) next(n int) (b []byte) {
+	if sr.truncated || n < 0 || n > len(sr.data) {
+		sr.truncated = true
+		return nil
+	}
+
+	b, sr.data = sr.data[:n], sr.data[n:]
+	return
+}
+
+func (sr *storageReader) uint16() uint16 {
+	if b := sr.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+
+	return 0
+}
+
+func (sr *storageReader) uint32() uint32 {
+	if b := sr.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+
+	return 0
+}
+
+// decodeUint64s decodes the given little-endian data into a new slice of uint64s.
+func decodeUint64s(data []byte) []uint64 {
+	n := len(data) / 8
+	if n == 0 {
+		return nil
+	}
+
+	v := make([]uint64, n)
+	for i := range v {
+		v[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+
+	return v
+}
+
+// decodeUint32s decodes the given little-endian data into a new slice of uint32s.
+func decodeUint32s(data []byte) []uint32 {
+	n := len(data) / 4
+	if n == 0 {
+		return nil
+	}
+
+	v := make([]uint32, n)
+	for i := range v {
+		v[i] = binary.LittleEndian.Uint32(data[i*4:])
+	}
+
+	return v
+}
+
+// DecodeStorage loads a StorageRing from data written by Ring.WriteStorage, using dec to
+// decode each service. The storage's checksum, version, and structure are verified before
+// anything is decoded. Storage written in version 1 of the format, which predates search
+// policies, is loaded with the Clockwise policy.
+//
+// The returned StorageRing may refer directly to data, which must not be modified afterward.
+func DecodeStorage[S medley.Service](data []byte, dec func([]byte) (S, error)) (*StorageRing[S], error) {
+	le := binary.LittleEndian
+	if len(data) < storageHeaderSize || string(data[:8]) != storageMagic {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidStorage)
+	}
+
+	version := le.Uint32(data[8:])
+	if version != StorageVersion && version != storageVersionClockwise {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedStorageVersion, version)
+	}
+
+	if le.Uint32(data[12:]) != crc32.ChecksumIEEE(data[16:]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidStorage)
+	}
+
+	var (
+		vnodes       = le.Uint32(data[16:])
+		serviceCount = le.Uint32(data[20:])
+		nodeCount    = le.Uint64(data[24:])
+		r            = storageReader{data: data[storageHeaderSize:]}
+	)
+
+	if nodeCount > math.MaxInt/12 {
+		return nil, fmt.Errorf("%w: invalid node count %d", ErrInvalidStorage, nodeCount)
+	}
+
+	algorithm := string(r.next(int(r.uint16())))
+	policy := Clockwise
+	if version != storageVersionClockwise {
+		policy = SearchPolicy(r.uint16())
+	}
+
+	encoded := make([][]byte, 0, min(int(serviceCount), len(data)/4))
+	for range serviceCount {
+		encoded = append(encoded, r.next(int(r.uint32())))
+	}
+
+	r.next((8 - (len(data)-len(r.data))%8) % 8)
+	tokens := r.next(int(nodeCount) * 8)
+	indexes := r.next(int(nodeCount) * 4)
+	if r.truncated || len(r.data) > 0 {
+		return nil, fmt.Errorf("%w: unexpected length", ErrInvalidStorage)
+	}
+
+	if policy < Clockwise || policy > NearestAbsolute {
+		return nil, fmt.Errorf("%w: invalid search policy %d", ErrInvalidStorage, policy)
+	}
+
+	alg, err := medley.FindAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	sr := &StorageRing[S]{
+		alg:      alg,
+		vnodes:   int(vnodes),
+		policy:   policy,
+		services: make([]S, 0, len(encoded)),
+		tokens:   uint64s(tokens),
+		indexes:  uint32s(indexes),
+	}
+
+	for _, i := range sr.indexes {
+		if i >= serviceCount {
+			return nil, fmt.Errorf("%w: invalid service index %d", ErrInvalidStorage, i)
+		}
+	}
+
+	for _, e := range encoded {
+		svc, err := dec(e)
+		if err != nil {
+			return nil, err
+		}
+
+		sr.services = append(sr.services, svc)
+	}
+
+	return sr, nil
+}
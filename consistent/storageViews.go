@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !medley_nounsafe
+
+package consistent
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// nativeLittleEndian is true if this platform's byte order is little-endian.
+var nativeLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// uint64s returns the given little-endian data as a slice of uint64s. When this platform
+// is little-endian and data is suitably aligned, the slice refers directly to data.
+func uint64s(data []byte) []uint64 {
+	if len(data) >= 8 && nativeLittleEndian && uintptr(unsafe.Pointer(&data[0]))%unsafe.Alignof(uint64(0)) == 0 {
+		return unsafe.Slice((*uint64)(unsafe.Pointer(&data[0])), len(data)/8)
+	}
+
+	return decodeUint64s(data)
+}
+
+// uint32s returns the given little-endian data as a slice of uint32s. When this platform
+// is little-endian and data is suitably aligned, the slice refers directly to data.
+func uint32s(data []byte) []uint32 {
+	if len(data) >= 4 && nativeLittleEndian && uintptr(unsafe.Pointer(&data[0]))%unsafe.Alignof(uint32(0)) == 0 {
+		return unsafe.Slice((*uint32)(unsafe.Pointer(&data[0])), len(data)/4)
+	}
+
+	return decodeUint32s(data)
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build medley_nounsafe
+
+package consistent
+
+// uint64s returns the given little-endian data as a slice of uint64s. This is the fallback
+// for builds that disallow package unsafe, and it always decodes into a new slice.
+func uint64s(data []byte) []uint64 {
+	return decodeUint64s(data)
+}
+
+// uint32s returns the given little-endian data as a slice of uint32s. This is the fallback
+// for builds that disallow package unsafe, and it always decodes into a new slice.
+func uint32s(data []byte) []uint32 {
+	return decodeUint32s(data)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageViewsSuite struct {
+	suite.Suite
+}
+
+func (suite *StorageViewsSuite) TestDecode() {
+	data := []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	suite.Equal([]uint64{1, 2}, uint64s(data))
+	suite.Equal([]uint64{1, 2}, decodeUint64s(data))
+	suite.Equal([]uint32{1, 0, 2, 0}, uint32s(data))
+	suite.Equal([]uint32{1, 0, 2, 0}, decodeUint32s(data))
+
+	// misaligned data is always decoded
+	suite.Equal([]uint32{0x02000000}, uint32s(data[5:12]))
+	suite.Empty(uint64s(data[:7]))
+	suite.Empty(uint32s(nil))
+}
+
+func (suite *StorageViewsSuite) TestNoUnsafeImport() {
+	ctx := build.Default
+	ctx.BuildTags = append(ctx.BuildTags, "medley_nounsafe")
+
+	pkg, err := ctx.ImportDir(".", 0)
+	suite.Require().NoError(err)
+	suite.NotContains(pkg.Imports, "unsafe", "package unsafe must not be imported under medley_nounsafe")
+}
+
+func TestStorageViews(t *testing.T) {
+	suite.Run(t, new(StorageViewsSuite))
+}
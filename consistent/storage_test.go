@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type StorageSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (suite *StorageSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "ring")
+}
+
+func (suite *StorageSuite) SetupSubTest() {
+	suite.SetupTest()
+}
+
+// write writes a ring and reads back the raw storage.
+func (suite *StorageSuite) write(r *Ring[string]) []byte {
+	suite.Require().NoError(r.WriteStorage(suite.path, encodeString))
+	data, err := os.ReadFile(suite.path)
+	suite.Require().NoError(err)
+	return data
+}
+
+func (suite *StorageSuite) decode(data []byte) (*StorageRing[string], error) {
+	return DecodeStorage(data, decodeString)
+}
+
+func (suite *StorageSuite) testRoundTrip(original *Ring[string]) {
+	sr, err := suite.decode(suite.write(original))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(sr)
+	suite.Equal(original.Len(), sr.Len())
+	suite.ElementsMatch(original.Services(), sr.Services())
+	suite.Equal(original.hasher.vnodes, sr.VNodes())
+	suite.Equal(original.hasher.policy, sr.SearchPolicy())
+
+	for _, object := range hashObjects {
+		expected, expectedErr := original.Find(object[:])
+		actual, actualErr := sr.Find(object[:])
+		suite.Equal(expectedErr, actualErr)
+		suite.Require().Equal(expected, actual)
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(suite.path))
+	suite.Require().NoError(err)
+	suite.Len(entries, 1)
+}
+
+func (suite *StorageSuite) TestRoundTrip() {
+	suite.Run("Default", func() {
+		suite.testRoundTrip(Strings(services[:]...).Build())
+	})
+
+	suite.Run("FNV", func() {
+		suite.testRoundTrip(Strings(services[:10]...).VNodes(37).Algorithm(medley.Algorithm{New64: fnv.New64}).Build())
+	})
+
+	suite.Run("Single", func() {
+		suite.testRoundTrip(Strings("single").VNodes(1).Build())
+	})
+
+	suite.Run("CounterClockwise", func() {
+		suite.testRoundTrip(Strings(services[:]...).SearchPolicy(CounterClockwise).Build())
+	})
+
+	suite.Run("NearestAbsolute", func() {
+		suite.testRoundTrip(Strings(services[:]...).SearchPolicy(NearestAbsolute).Build())
+	})
+}
+
+// downgrade rewrites storage in the current format as version 1, which has no search policy.
+func (suite *StorageSuite) downgrade(data []byte) []byte {
+	var (
+		le        = binary.LittleEndian
+		nodeCount = int(le.Uint64(data[24:]))
+		policyAt  = storageHeaderSize + 2 + int(le.Uint16(data[storageHeaderSize:]))
+		services  = policyAt + 2
+	)
+
+	for range le.Uint32(data[20:]) {
+		services += 4 + int(le.Uint32(data[services:]))
+	}
+
+	downgraded := append([]byte(nil), data[:policyAt]...)
+	downgraded = append(downgraded, data[policyAt+2:services]...)
+	for len(downgraded)%8 != 0 {
+		downgraded = append(downgraded, 0)
+	}
+
+	downgraded = append(downgraded, data[len(data)-nodeCount*12:]...)
+	le.PutUint32(downgraded[8:], storageVersionClockwise)
+	le.PutUint32(downgraded[12:], crc32.ChecksumIEEE(downgraded[16:]))
+	return downgraded
+}
+
+func (suite *StorageSuite) TestVersionClockwise() {
+	var (
+		clockwise = Strings(services[:10]...).Build()
+		data      = suite.write(clockwise)
+	)
+
+	sr, err := suite.decode(suite.downgrade(data))
+	suite.Require().NoError(err)
+	suite.Equal(Clockwise, sr.SearchPolicy())
+	for _, object := range hashObjects {
+		expected, _ := clockwise.Find(object[:])
+		actual, err := sr.Find(object[:])
+		suite.NoError(err)
+		suite.Require().Equal(expected, actual)
+	}
+}
+
+func (suite *StorageSuite) TestEmpty() {
+	empty, _ := Update(Strings(services[:]...).Build())
+	sr, err := suite.decode(suite.write(empty))
+	suite.Require().NoError(err)
+	suite.Zero(sr.Len())
+
+	_, err = sr.Find([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+}
+
+func (suite *StorageSuite) TestUnalignedData() {
+	var (
+		original = Strings(services[:10]...).Build()
+		data     = suite.write(original)
+		shifted  = make([]byte, len(data)+1)
+	)
+
+	// force the tokens to be copied rather than aliased
+	copy(shifted[1:], data)
+	sr, err := suite.decode(shifted[1:])
+	suite.Require().NoError(err)
+	for _, object := range hashObjects {
+		expected, _ := original.Find(object[:])
+		actual, err := sr.Find(object[:])
+		suite.NoError(err)
+		suite.Require().Equal(expected, actual)
+	}
+}
+
+func (suite *StorageSuite) TestCorrupt() {
+	data := suite.write(Strings(services[:10]...).Build())
+
+	suite.Run("Magic", func() {
+		corrupt := append([]byte(nil), data...)
+		corrupt[0] = 'X'
+		_, err := suite.decode(corrupt)
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+
+	suite.Run("Short", func() {
+		_, err := suite.decode(data[:storageHeaderSize-1])
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+
+	suite.Run("Version", func() {
+		corrupt := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint32(corrupt[8:], StorageVersion+1)
+		_, err := suite.decode(corrupt)
+		suite.ErrorIs(err, ErrUnsupportedStorageVersion)
+	})
+
+	suite.Run("Checksum", func() {
+		corrupt := append([]byte(nil), data...)
+		corrupt[len(corrupt)-1]++
+		_, err := suite.decode(corrupt)
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+
+	suite.Run("Truncated", func() {
+		_, err := suite.decode(data[:len(data)-4])
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+
+	suite.Run("NodeCount", func() {
+		// a corrupt header with a valid checksum is still detected
+		corrupt := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint64(corrupt[24:], 1<<40)
+		binary.LittleEndian.PutUint32(corrupt[12:], crc32.ChecksumIEEE(corrupt[16:]))
+		_, err := suite.decode(corrupt)
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+
+	suite.Run("SearchPolicy", func() {
+		corrupt := append([]byte(nil), data...)
+		policyAt := storageHeaderSize + 2 + int(binary.LittleEndian.Uint16(corrupt[storageHeaderSize:]))
+		binary.LittleEndian.PutUint16(corrupt[policyAt:], uint16(NearestAbsolute)+1)
+		binary.LittleEndian.PutUint32(corrupt[12:], crc32.ChecksumIEEE(corrupt[16:]))
+		_, err := suite.decode(corrupt)
+		suite.ErrorIs(err, ErrInvalidStorage)
+	})
+}
+
+func (suite *StorageSuite) TestDecodeError() {
+	data := suite.write(Strings(services[:10]...).Build())
+	expectedErr := errors.New("expected")
+	sr, err := DecodeStorage(data, func([]byte) (string, error) { return "", expectedErr })
+	suite.ErrorIs(err, expectedErr)
+	suite.Nil(sr)
+}
+
+func (suite *StorageSuite) TestCustomAlgorithm() {
+	crc := func() hash.Hash64 { return crc64.New(crc64.MakeTable(crc64.ISO)) }
+	r := Strings(services[:10]...).Algorithm(medley.Algorithm{New64: crc}).Build()
+	suite.ErrorIs(r.WriteStorage(suite.path, encodeString), medley.ErrUnknownAlgorithm)
+
+	_, err := os.Stat(suite.path)
+	suite.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestStorage(t *testing.T) {
+	suite.Run(t, new(StorageSuite))
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmidt-org/medley"
+)
+
+var (
+	// ErrInvalidSubsetSize indicates that a subset size was not positive.
+	ErrInvalidSubsetSize = errors.New("subset size must be positive")
+)
+
+// Subset selects a deterministic subset of a Ring's services for a particular client, which
+// limits the number of services any one client needs connections to.
+//
+// The client's ID is hashed with the ring's algorithm, and the subset is the first size
+// distinct services found by walking from that token, as with Successors. The same client ID always
+// gets the same subset from the same ring. Since each service owns many small arcs of the
+// ring, different clients get well-spread subsets, and every service appears in
+// roughly the same number of subsets.
+//
+// The returned Ring contains only the selected services and uses the same hash configuration
+// as r. The nodes already computed for those services are reused, so no services are rehashed.
+// If size is at least the number of services in r, r itself is returned. A nonpositive size
+// results in ErrInvalidSubsetSize.
+func Subset[S medley.Service](r *Ring[S], clientID []byte, size int) (*Ring[S], error) {
+	switch {
+	case size < 1:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSubsetSize, size)
+
+	case size >= len(r.cache):
+		return r, nil
+	}
+
+	var (
+		subset = &Ring[S]{
+			hasher:  r.config(),
+			onFind:  r.onFind,
+			extract: r.extract,
+			cache:   make(medley.Map[S, nodes[S]], size),
+		}
+
+		runs = make([]nodes[S], 0, size)
+	)
+
+	for svc := range r.successors(r.config().sum64(clientID)) {
+		subset.cache[svc] = r.cache[svc]
+		runs = append(runs, r.cache[svc])
+		if len(runs) >= size {
+			break
+		}
+	}
+
+	subset.nodes = mergeRuns(runs)
+	subset.index()
+	return subset, nil
+}
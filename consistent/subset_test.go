@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/medleytest"
+)
+
+type SubsetSuite struct {
+	suite.Suite
+
+	// hashed counts the calls to the ServiceHasher
+	hashed int
+	ring   *Ring[string]
+}
+
+func (suite *SubsetSuite) SetupTest() {
+	suite.hashed = 0
+	suite.ring = Strings(services[:]...).
+		ServiceHasher(func(dst io.Writer, svc string) error {
+			suite.hashed++
+			return medley.HashStringTo(dst, svc)
+		}).
+		Build()
+
+	suite.Require().Equal(len(services), suite.hashed)
+}
+
+func (suite *SubsetSuite) subset(clientID string, size int) *Ring[string] {
+	subset, err := Subset(suite.ring, []byte(clientID), size)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(subset)
+	suite.Require().True(sort.IsSorted(subset.nodes))
+	return subset
+}
+
+func (suite *SubsetSuite) TestDeterministic() {
+	for i := range 20 {
+		clientID := fmt.Sprintf("client-%d", i)
+		first := suite.subset(clientID, 10)
+		suite.Equal(10, first.Len())
+		suite.True(first.Equal(suite.subset(clientID, 10)))
+
+		// the subset is just a ring of the selected services
+		suite.True(first.Equal(Strings(first.Services()...).Build()))
+	}
+}
+
+func (suite *SubsetSuite) TestNoRehashing() {
+	suite.hashed = 0
+	subset := suite.subset("client", 25)
+	suite.Zero(suite.hashed)
+
+	for svc, snodes := range subset.cache {
+		suite.Require().Contains(suite.ring.cache, svc)
+		suite.Same(suite.ring.cache[svc][0], snodes[0])
+	}
+}
+
+func (suite *SubsetSuite) TestSizeTooLarge() {
+	suite.Same(suite.ring, suite.subset("client", len(services)))
+	suite.Same(suite.ring, suite.subset("client", len(services)+1))
+}
+
+func (suite *SubsetSuite) TestInvalidSize() {
+	for _, size := range []int{0, -1} {
+		subset, err := Subset(suite.ring, []byte("client"), size)
+		suite.ErrorIs(err, ErrInvalidSubsetSize)
+		suite.Nil(subset)
+	}
+}
+
+// coverage computes the expected fraction of subset memberships for each service. A client
+// whose token falls on a node's arc gets the subset that starts at that node, so each subset
+// is weighted by the length of its arc.
+func (suite *SubsetSuite) coverage(size int) map[string]float64 {
+	var (
+		tokens    = suite.ring.tokens
+		fractions = make(map[string]float64, len(services))
+	)
+
+	for i, token := range tokens {
+		// unsigned subtraction handles the arc that wraps around zero
+		arc := float64(token-tokens[(i-1+len(tokens))%len(tokens)]) / math.Exp2(64)
+		selected := 0
+		for svc := range suite.ring.successors(token) {
+			fractions[svc] += arc / float64(size)
+			if selected++; selected >= size {
+				break
+			}
+		}
+	}
+
+	return fractions
+}
+
+func (suite *SubsetSuite) TestCoverage() {
+	const (
+		clients = 1000
+		size    = 10
+	)
+
+	counts := make(map[string]int)
+	for i := range clients {
+		for _, svc := range suite.subset(fmt.Sprintf("client-%d", i), size).Services() {
+			counts[svc]++
+		}
+	}
+
+	// every service is used, in proportion to the arcs from which it is selected
+	suite.Len(counts, len(services))
+	medleytest.AssertUniform(suite.T(), counts, medleytest.WithFractions(suite.coverage(size)))
+}
+
+func TestSubset(t *testing.T) {
+	suite.Run(t, new(SubsetSuite))
+}
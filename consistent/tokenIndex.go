@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+	"sort"
+
+	"github.com/xmidt-org/medley"
+)
+
+// TokenIndex is a sorted set of hash tokens, each associated with a service.
+// Lookups use the same sorted token search as a Ring, and this type can be used
+// as a building block for custom placement schemes.
+//
+// Lookups move clockwise around a circle, wrapping around to the smallest token
+// when a target token is larger than every token in the index.
+//
+// The zero value of this type is an empty, usable index. A TokenIndex is not
+// safe for concurrent modification.
+type TokenIndex[S medley.Service] struct {
+	// tokens and services are parallel slices, so lookups search contiguous tokens
+	tokens   []uint64
+	services []S
+}
+
+// Len returns the number of tokens in this index.
+func (ti *TokenIndex[S]) Len() int {
+	return len(ti.tokens)
+}
+
+// Insert adds a token for a service. Sort must be called after all insertions
+// and before any lookups.
+func (ti *TokenIndex[S]) Insert(token uint64, svc S) {
+	ti.tokens = append(ti.tokens, token)
+	ti.services = append(ti.services, svc)
+}
+
+// Remove deletes every token for which the predicate returns true. The relative
+// order of the remaining tokens is preserved, so Sort need not be called again.
+// This method returns the number of tokens removed.
+func (ti *TokenIndex[S]) Remove(f func(uint64, S) bool) (removed int) {
+	kept := 0
+	for i, token := range ti.tokens {
+		if f(token, ti.services[i]) {
+			removed++
+		} else {
+			ti.tokens[kept], ti.services[kept] = token, ti.services[i]
+			kept++
+		}
+	}
+
+	clear(ti.services[kept:])
+	ti.tokens, ti.services = ti.tokens[:kept], ti.services[:kept]
+	return
+}
+
+// Sort sorts this index by token.
+func (ti *TokenIndex[S]) Sort() {
+	sort.Sort(tokenOrder[S]{ti})
+}
+
+// Closest returns the service whose token is closest to the given token, moving
+// clockwise. If this index is empty, this method returns false.
+func (ti *TokenIndex[S]) Closest(token uint64) (svc S, ok bool) {
+	if len(ti.tokens) > 0 {
+		svc, ok = ti.services[Clockwise.search(ti.tokens, token)], true
+	}
+
+	return
+}
+
+// Successors returns a sequence of all the tokens and their services in this index,
+// starting with the token closest to the given token and moving clockwise. Each
+// token in the index is visited exactly once.
+func (ti *TokenIndex[S]) Successors(token uint64) iter.Seq2[uint64, S] {
+	return func(f func(uint64, S) bool) {
+		for i := range Clockwise.walk(ti.tokens, token) {
+			if !f(ti.tokens[i], ti.services[i]) {
+				return
+			}
+		}
+	}
+}
+
+// tokenOrder sorts a TokenIndex's parallel slices by token.
+type tokenOrder[S medley.Service] struct {
+	ti *TokenIndex[S]
+}
+
+func (to tokenOrder[S]) Len() int {
+	return len(to.ti.tokens)
+}
+
+func (to tokenOrder[S]) Less(i, j int) bool {
+	return to.ti.tokens[i] < to.ti.tokens[j]
+}
+
+func (to tokenOrder[S]) Swap(i, j int) {
+	to.ti.tokens[i], to.ti.tokens[j] = to.ti.tokens[j], to.ti.tokens[i]
+	to.ti.services[i], to.ti.services[j] = to.ti.services[j], to.ti.services[i]
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenIndexSuite struct {
+	suite.Suite
+}
+
+// newIndex creates a sorted index with tokens 100, 200, and 300.
+func (suite *TokenIndexSuite) newIndex() *TokenIndex[string] {
+	ti := new(TokenIndex[string])
+	ti.Insert(300, "service3")
+	ti.Insert(100, "service1")
+	ti.Insert(200, "service2")
+	ti.Sort()
+
+	suite.Require().Equal(3, ti.Len())
+	suite.Require().True(slices.IsSorted(ti.tokens))
+	suite.Require().Equal([]string{"service1", "service2", "service3"}, ti.services)
+	return ti
+}
+
+// successors collects the results of Successors into slices.
+func (suite *TokenIndexSuite) successors(ti *TokenIndex[string], token uint64) (tokens []uint64, svcs []string) {
+	for t, svc := range ti.Successors(token) {
+		tokens = append(tokens, t)
+		svcs = append(svcs, svc)
+	}
+
+	return
+}
+
+func (suite *TokenIndexSuite) TestEmpty() {
+	ti := new(TokenIndex[string])
+	suite.Zero(ti.Len())
+	ti.Sort()
+
+	svc, ok := ti.Closest(123)
+	suite.False(ok)
+	suite.Empty(svc)
+
+	tokens, svcs := suite.successors(ti, 123)
+	suite.Empty(tokens)
+	suite.Empty(svcs)
+
+	suite.Zero(ti.Remove(func(uint64, string) bool { return true }))
+}
+
+func (suite *TokenIndexSuite) TestClosest() {
+	ti := suite.newIndex()
+
+	testCases := []struct {
+		token    uint64
+		expected string
+	}{
+		{token: 0, expected: "service1"},
+		{token: 100, expected: "service1"},
+		{token: 101, expected: "service2"},
+		{token: 200, expected: "service2"},
+		{token: 250, expected: "service3"},
+		{token: 300, expected: "service3"},
+		{token: 301, expected: "service1"}, // wraparound
+		{token: ^uint64(0), expected: "service1"},
+	}
+
+	for _, testCase := range testCases {
+		svc, ok := ti.Closest(testCase.token)
+		suite.True(ok)
+		suite.Equal(testCase.expected, svc, "token: %d", testCase.token)
+	}
+}
+
+func (suite *TokenIndexSuite) testSuccessorsAll() {
+	ti := suite.newIndex()
+
+	tokens, svcs := suite.successors(ti, 150)
+	suite.Equal([]uint64{200, 300, 100}, tokens)
+	suite.Equal([]string{"service2", "service3", "service1"}, svcs)
+
+	tokens, svcs = suite.successors(ti, 301)
+	suite.Equal([]uint64{100, 200, 300}, tokens)
+	suite.Equal([]string{"service1", "service2", "service3"}, svcs)
+}
+
+func (suite *TokenIndexSuite) testSuccessorsHalt() {
+	ti := suite.newIndex()
+
+	var visited []string
+	for _, svc := range ti.Successors(250) {
+		visited = append(visited, svc)
+		if len(visited) == 2 {
+			break
+		}
+	}
+
+	suite.Equal([]string{"service3", "service1"}, visited)
+}
+
+func (suite *TokenIndexSuite) TestSuccessors() {
+	suite.Run("All", suite.testSuccessorsAll)
+	suite.Run("Halt", suite.testSuccessorsHalt)
+}
+
+func (suite *TokenIndexSuite) TestRemove() {
+	ti := suite.newIndex()
+
+	removed := ti.Remove(func(_ uint64, svc string) bool { return svc == "service2" })
+	suite.Equal(1, removed)
+	suite.Equal(2, ti.Len())
+	suite.True(slices.IsSorted(ti.tokens))
+	suite.Equal([]string{"service1", "service3"}, ti.services)
+
+	svc, ok := ti.Closest(150)
+	suite.True(ok)
+	suite.Equal("service3", svc)
+
+	removed = ti.Remove(func(uint64, string) bool { return true })
+	suite.Equal(2, removed)
+	suite.Zero(ti.Len())
+
+	_, ok = ti.Closest(150)
+	suite.False(ok)
+}
+
+func TestTokenIndex(t *testing.T) {
+	suite.Run(t, new(TokenIndexSuite))
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import "iter"
+
+// TokenRange is a half-open interval of tokens, starting at Start and proceeding clockwise
+// around the ring up to but not including End. When Start is greater than End, the range
+// wraps around past the maximum token. When Start equals End, the range is the full circle.
+type TokenRange struct {
+	Start uint64
+	End   uint64
+}
+
+// Contains tests if the given token falls within this range.
+func (tr TokenRange) Contains(token uint64) bool {
+	switch {
+	case tr.Start < tr.End:
+		return tr.Start <= token && token < tr.End
+
+	case tr.Start > tr.End:
+		return token >= tr.Start || token < tr.End
+
+	default:
+		return true
+	}
+}
+
+// RangeOwners yields the services that own the tokens in the range [start, end), in token
+// order. Each yielded TokenRange is a maximal, contiguous segment owned by a single service,
+// and together the segments cover the requested range exactly. As with TokenRange, the range
+// wraps around when start is greater than end, and is the full circle when start equals end.
+//
+// Ownership is always clockwise, regardless of the ring's SearchPolicy. An empty Ring
+// yields nothing.
+func (r *Ring[S]) RangeOwners(start, end uint64) iter.Seq2[TokenRange, S] {
+	return func(yield func(TokenRange, S) bool) {
+		if len(r.tokens) == 0 {
+			return
+		}
+
+		var (
+			// lengths are stored minus one, so that the full circle of 2^64 tokens fits
+			remaining = end - start - 1
+
+			pos     = start
+			i       = searchTokens(r.tokens, pos)
+			segment = TokenRange{Start: start}
+			owner   = r.nodes[i].service
+		)
+
+		for {
+			// node i owns the tokens from pos through its own token
+			owned := r.tokens[i] - pos
+			if owned >= remaining {
+				segment.End = end
+				yield(segment, owner)
+				return
+			}
+
+			remaining -= owned + 1
+
+			pos = r.tokens[i] + 1
+			i = searchTokens(r.tokens, pos)
+			if svc := r.nodes[i].service; svc != owner {
+				segment.End = pos
+				if !yield(segment, owner) {
+					return
+				}
+
+				segment, owner = TokenRange{Start: pos}, svc
+			}
+		}
+	}
+}
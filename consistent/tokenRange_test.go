@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenRangeSuite struct {
+	suite.Suite
+
+	random *rand.Rand
+	ring   *Ring[string]
+}
+
+func (suite *TokenRangeSuite) SetupTest() {
+	suite.random = rand.New(rand.NewSource(3312))
+	suite.ring = Strings(services[:20]...).VNodes(10).Build()
+}
+
+// owner returns the service that owns a token, as Find would.
+func (suite *TokenRangeSuite) owner(r *Ring[string], token uint64) string {
+	return r.nodes[searchTokens(r.tokens, token)].service
+}
+
+// assertTiles verifies that the segments yielded for a range cover it exactly, in order,
+// and agree with the ring's lookups.
+func (suite *TokenRangeSuite) assertTiles(r *Ring[string], start, end uint64) (segments []TokenRange) {
+	var owners []string
+	for segment, owner := range r.RangeOwners(start, end) {
+		segments = append(segments, segment)
+		owners = append(owners, owner)
+	}
+
+	suite.Require().NotEmpty(segments)
+	suite.Equal(start, segments[0].Start)
+	suite.Equal(end, segments[len(segments)-1].End)
+
+	var total uint64
+	for i, segment := range segments {
+		if i > 0 {
+			// no gaps or overlaps, and each segment is maximal
+			suite.Equal(segments[i-1].End, segment.Start)
+			suite.NotEqual(owners[i-1], owners[i])
+		}
+
+		total += segment.End - segment.Start
+
+		// sample the ends and the interior of the segment
+		last := segment.End - 1
+		for _, token := range []uint64{segment.Start, last, segment.Start + (last-segment.Start)/2} {
+			suite.Require().True(segment.Contains(token))
+			suite.Equal(owners[i], suite.owner(r, token), "token %d", token)
+		}
+	}
+
+	// modulo 2^64, the lengths add up to the length of the range
+	suite.Equal(end-start, total)
+	return
+}
+
+func (suite *TokenRangeSuite) TestContains() {
+	suite.True(TokenRange{Start: 5, End: 10}.Contains(5))
+	suite.False(TokenRange{Start: 5, End: 10}.Contains(10))
+	suite.False(TokenRange{Start: 5, End: 10}.Contains(4))
+
+	suite.True(TokenRange{Start: 10, End: 5}.Contains(math.MaxUint64))
+	suite.True(TokenRange{Start: 10, End: 5}.Contains(0))
+	suite.False(TokenRange{Start: 10, End: 5}.Contains(5))
+	suite.False(TokenRange{Start: 10, End: 5}.Contains(7))
+
+	suite.True(TokenRange{Start: 7, End: 7}.Contains(0))
+}
+
+func (suite *TokenRangeSuite) TestEmpty() {
+	for range Strings[string]().Build().RangeOwners(0, 100) {
+		suite.Fail("an empty ring should yield nothing")
+	}
+}
+
+func (suite *TokenRangeSuite) TestRandomRanges() {
+	for range 100 {
+		start, end := suite.random.Uint64(), suite.random.Uint64()
+		suite.assertTiles(suite.ring, min(start, end), max(start, end))
+	}
+}
+
+func (suite *TokenRangeSuite) TestWraparound() {
+	for range 100 {
+		start, end := suite.random.Uint64(), suite.random.Uint64()
+		suite.assertTiles(suite.ring, max(start, end), min(start, end))
+	}
+
+	// the range just around the maximum token
+	segments := suite.assertTiles(suite.ring, math.MaxUint64-10, 10)
+	suite.Len(segments, 1)
+}
+
+func (suite *TokenRangeSuite) TestFullCircle() {
+	for _, start := range []uint64{0, suite.ring.tokens[3], suite.ring.tokens[3] + 1, suite.random.Uint64()} {
+		segments := suite.assertTiles(suite.ring, start, start)
+		suite.GreaterOrEqual(len(segments), suite.ring.Len())
+	}
+
+	// a single service owns the full circle
+	segments := suite.assertTiles(Strings("only").Build(), 12345, 12345)
+	suite.Equal([]TokenRange{{Start: 12345, End: 12345}}, segments)
+}
+
+func (suite *TokenRangeSuite) TestWithinOneArc() {
+	var (
+		start = suite.ring.tokens[5] + 1
+		end   = suite.ring.tokens[6]
+	)
+
+	// the range ends just short of the owning node's token
+	segments := suite.assertTiles(suite.ring, start, end)
+	suite.Equal([]TokenRange{{Start: start, End: end}}, segments)
+
+	// including the node's token itself is still one segment
+	segments = suite.assertTiles(suite.ring, start, end+1)
+	suite.Equal([]TokenRange{{Start: start, End: end + 1}}, segments)
+}
+
+func (suite *TokenRangeSuite) TestEarlyStop() {
+	count := 0
+	for range suite.ring.RangeOwners(0, 0) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	suite.Equal(3, count)
+}
+
+func TestTokenRange(t *testing.T) {
+	suite.Run(t, new(TokenRangeSuite))
+}
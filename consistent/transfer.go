@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xmidt-org/medley"
+)
+
+// TransferHint describes a key whose owner changes between two Rings.
+type TransferHint[S medley.Service] struct {
+	// Key is the key, exactly as it was yielded to TransferHints.
+	Key []byte
+
+	// From is the service that owns the key in the old Ring.
+	From S
+
+	// To is the service that owns the key in the new Ring.
+	To S
+}
+
+// TransferHints compares the owners of known keys in two Rings, yielding a hint for each key
+// whose owner changed. This is useful for pre-warming the caches of services that gain keys
+// after an update.
+//
+// Keys are consumed as the returned sequence is iterated, so keys are never buffered, and hints
+// are yielded in the same order as the keys. Keys whose owner is the same in both Rings are
+// skipped, as are keys without an owner in either Ring, e.g. because a Ring is empty or a key
+// could not be extracted. A hint's Key is the slice yielded by keys, so it must be copied if
+// keys reuses its slices.
+//
+// The Rings' OnFind hooks are not invoked.
+func TransferHints[S medley.Service](old, new *Ring[S], keys iter.Seq[[]byte]) iter.Seq[TransferHint[S]] {
+	return func(f func(TransferHint[S]) bool) {
+		for key := range keys {
+			if hint, ok := transferHint(old, new, key); ok && !f(hint) {
+				return
+			}
+		}
+	}
+}
+
+// TransferHintsContext is like TransferHints, but checks the given context before each batch
+// of BulkBatchSize keys. If the context is done, the returned sequence yields a final, zero
+// hint along with a *CanceledError whose Processed is the number of keys processed, and then
+// stops. The hints yielded before that are the partial result. Otherwise, every error is nil
+// and the hints are the same as TransferHints.
+func TransferHintsContext[S medley.Service](ctx context.Context, old, new *Ring[S], keys iter.Seq[[]byte]) iter.Seq2[TransferHint[S], error] {
+	return func(f func(TransferHint[S], error) bool) {
+		processed := 0
+		for key := range keys {
+			if err := checkBulk(ctx, processed); err != nil {
+				f(TransferHint[S]{}, err)
+				return
+			}
+
+			processed++
+			if hint, ok := transferHint(old, new, key); ok && !f(hint, nil) {
+				return
+			}
+		}
+	}
+}
+
+// transferHint computes the hint for a single key, returning false if the key's owner
+// is the same in both Rings or it has no owner in either Ring.
+func transferHint[S medley.Service](old, new *Ring[S], key []byte) (hint TransferHint[S], ok bool) {
+	hint.Key = key
+	if hint.From, ok = old.owner(key); !ok {
+		return
+	}
+
+	if hint.To, ok = new.owner(key); !ok || hint.From == hint.To {
+		return hint, false
+	}
+
+	return
+}
+
+// owner is like Find, but doesn't invoke the OnFind hook. This method returns
+// false if this ring is empty or the object's key cannot be extracted.
+func (r *Ring[S]) owner(object []byte) (svc S, ok bool) {
+	if len(r.nodes) == 0 {
+		return
+	}
+
+	key, err := medley.ExtractKey(r.extract, object)
+	if err != nil {
+		return
+	}
+
+	return r.tokenOwner(r.hasher.sum64(key)), true
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type TransferHintsSuite struct {
+	suite.Suite
+}
+
+// objects yields each of the hashObjects, counting how many were consumed.
+func (suite *TransferHintsSuite) objects(consumed *int) iter.Seq[[]byte] {
+	return func(f func([]byte) bool) {
+		for i := range hashObjects {
+			*consumed++
+			if !f(hashObjects[i][:]) {
+				return
+			}
+		}
+	}
+}
+
+func (suite *TransferHintsSuite) TestHints() {
+	var (
+		old      = Strings(services[:50]...).Build()
+		new, _   = Update(old, services[10:60]...)
+		consumed int
+		hints    []TransferHint[string]
+	)
+
+	for hint := range TransferHints(old, new, suite.objects(&consumed)) {
+		hints = append(hints, hint)
+	}
+
+	suite.Equal(len(hashObjects), consumed)
+	suite.NotEmpty(hints)
+
+	// every key is either hinted, in order, or has the same owner
+	next := 0
+	for i := range hashObjects {
+		key := hashObjects[i][:]
+		from, err := old.Find(key)
+		suite.Require().NoError(err)
+		to, err := new.Find(key)
+		suite.Require().NoError(err)
+
+		if from == to {
+			continue
+		}
+
+		suite.Require().Less(next, len(hints))
+		suite.Equal(key, hints[next].Key)
+		suite.Equal(from, hints[next].From)
+		suite.Equal(to, hints[next].To)
+		next++
+	}
+
+	suite.Len(hints, next)
+
+	// hints are deterministic
+	var again []TransferHint[string]
+	for hint := range TransferHints(old, new, suite.objects(&consumed)) {
+		again = append(again, hint)
+	}
+
+	suite.Equal(hints, again)
+}
+
+func (suite *TransferHintsSuite) TestNoChanges() {
+	var (
+		r        = Strings(services[:]...).Build()
+		consumed int
+	)
+
+	for range TransferHints(r, r, suite.objects(&consumed)) {
+		suite.Fail("no hints expected")
+	}
+
+	for range TransferHints(r, Strings(services[:]...).Build(), suite.objects(&consumed)) {
+		suite.Fail("no hints expected")
+	}
+
+	suite.Equal(2*len(hashObjects), consumed)
+}
+
+func (suite *TransferHintsSuite) TestNoOwner() {
+	var (
+		empty    = Strings[string]().Build()
+		r        = Strings(services[:]...).Build()
+		failing  = Strings(services[:]...).Extract(func([]byte) ([]byte, error) { return nil, medley.ErrKeyExtraction }).Build()
+		consumed int
+	)
+
+	for _, pair := range [][2]*Ring[string]{{empty, r}, {r, empty}, {failing, r}, {r, failing}} {
+		for range TransferHints(pair[0], pair[1], suite.objects(&consumed)) {
+			suite.Fail("no hints expected")
+		}
+	}
+}
+
+func (suite *TransferHintsSuite) TestEarlyBreak() {
+	var (
+		old      = Strings(services[:50]...).Build()
+		new, _   = Update(old, services[10:60]...)
+		consumed int
+		hints    int
+	)
+
+	for range TransferHints(old, new, suite.objects(&consumed)) {
+		hints++
+		if hints == 3 {
+			break
+		}
+	}
+
+	suite.Equal(3, hints)
+	suite.Less(consumed, len(hashObjects))
+}
+
+func TestTransferHints(t *testing.T) {
+	suite.Run(t, new(TransferHintsSuite))
+}
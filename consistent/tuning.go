@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import "math"
+
+// TuneVNodes returns the number of vnodes per service for which a Ring with the given number
+// of services is expected to have a maximum to mean ownership ratio no greater than the target
+// imbalance, e.g. 1.5 for the busiest service to own at most 50% more than its fair share.
+//
+// A service's ownership is the sum of its vnodes' arcs, so its relative deviation from the mean
+// is about 1/√v. The largest of n such deviations is about √(2 ln n) standard deviations, which
+// gives v = 2 ln n / (target - 1)². Rings with few vnodes are noticeably skewed, so this function
+// applies a safety factor of 1.5, as validated by simulation:
+//
+//	v = ⌈3 ln(n) / (target - 1)²⌉
+//
+// The busiest service's expected share grows with the number of services, so the returned vnodes
+// never decrease as services are added. The growth is logarithmic, though, so large rings need far
+// fewer than DefaultVNodes for a modest target. The result is clamped to [1, MaxVNodes]. If the
+// target is not greater than one (1), MaxVNodes is returned.
+func TuneVNodes(services int, targetImbalance float64) int {
+	if !(targetImbalance > 1) {
+		return MaxVNodes
+	}
+
+	excess := targetImbalance - 1
+	v := math.Ceil(3 * math.Log(float64(max(services, 2))) / (excess * excess))
+	return int(min(max(v, 1), float64(MaxVNodes)))
+}
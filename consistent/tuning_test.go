@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type TuningSuite struct {
+	suite.Suite
+}
+
+// names creates n distinct service names for a trial.
+func (suite *TuningSuite) names(trial, n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("tuning-%d-%d.example.com", trial, i)
+	}
+
+	return names
+}
+
+func (suite *TuningSuite) TestTuneVNodes() {
+	suite.Equal(MaxVNodes, TuneVNodes(10, 1))
+	suite.Equal(MaxVNodes, TuneVNodes(10, 0.5))
+	suite.Equal(MaxVNodes, TuneVNodes(10, math.NaN()))
+	suite.Equal(MaxVNodes, TuneVNodes(1_000_000, 1.0001))
+	suite.Equal(1, TuneVNodes(10, 100))
+
+	// fewer than two services are treated as two
+	suite.Equal(TuneVNodes(2, 1.5), TuneVNodes(0, 1.5))
+	suite.Equal(TuneVNodes(2, 1.5), TuneVNodes(1, 1.5))
+
+	for _, target := range []float64{1.1, 1.25, 1.5, 2, 3} {
+		previous := 0
+		for _, n := range []int{2, 3, 10, 100, 1000, 8000, 100_000} {
+			v := TuneVNodes(n, target)
+			suite.GreaterOrEqual(v, previous, "more services never need fewer vnodes")
+			suite.GreaterOrEqual(v, 1)
+			previous = v
+
+			// a looser target never needs more vnodes
+			suite.LessOrEqual(TuneVNodes(n, target+0.1), v)
+		}
+	}
+
+	// large rings need far fewer vnodes than the default for a modest target
+	suite.Less(TuneVNodes(8000, 1.5), DefaultVNodes)
+}
+
+func (suite *TuningSuite) TestAchievedImbalance() {
+	const trials = 3
+	testCases := []struct {
+		services int
+		target   float64
+	}{
+		{services: 10, target: 2},
+		{services: 100, target: 1.5},
+		{services: 100, target: 1.25},
+		{services: 1000, target: 1.5},
+	}
+
+	for _, testCase := range testCases {
+		var total float64
+		for trial := range trials {
+			r := Strings(suite.names(trial, testCase.services)...).AutoVNodes(testCase.target).Build()
+			suite.Equal(TuneVNodes(testCase.services, testCase.target), r.VNodes())
+
+			var busiest float64
+			for _, o := range r.Ownership() {
+				busiest = max(busiest, o)
+			}
+
+			total += busiest * float64(testCase.services)
+		}
+
+		suite.LessOrEqual(total/trials, testCase.target, "services=%d target=%v", testCase.services, testCase.target)
+	}
+}
+
+func (suite *TuningSuite) TestUpdate() {
+	var (
+		hashed int
+		names  = suite.names(0, 200)
+		b      = Strings(names[:100]...).
+			AutoVNodes(1.5).
+			ServiceHasher(func(dst io.Writer, svc string) error {
+				hashed++
+				return medley.HashStringTo(dst, svc)
+			})
+	)
+
+	r := b.Build()
+	suite.Require().Equal(100, hashed)
+	suite.Require().Equal(TuneVNodes(100, 1.5), r.VNodes())
+	suite.Require().Equal(TuneVNodes(101, 1.5), r.VNodes(), "the test requires the same vnodes for 101 services")
+
+	// the tuned vnodes don't change, so only the new service is hashed
+	hashed = 0
+	updated, _ := Update(r, names[:101]...)
+	suite.Equal(1, hashed)
+	suite.Equal(r.VNodes(), updated.VNodes())
+
+	// the tuned vnodes change, so every service is rehashed
+	hashed = 0
+	grown, _ := Update(updated, names...)
+	suite.Equal(200, hashed)
+	suite.Equal(TuneVNodes(200, 1.5), grown.VNodes())
+	suite.Greater(grown.VNodes(), r.VNodes())
+	for _, snodes := range grown.cache {
+		suite.Len(snodes, grown.VNodes())
+	}
+
+	// duplicates don't count toward the tuned vnodes
+	hashed = 0
+	same, _ := Update(grown, append(names, names...)...)
+	suite.Equal(grown.VNodes(), same.VNodes())
+	suite.Zero(hashed)
+
+	// the result is the same as building from scratch
+	suite.True(grown.Equal(Strings(names...).AutoVNodes(1.5).Build()))
+}
+
+func (suite *TuningSuite) TestDisabled() {
+	suite.Equal(DefaultVNodes, Strings(services[:10]...).Build().VNodes())
+	suite.Equal(50, Strings(services[:10]...).VNodes(50).Build().VNodes())
+	suite.Equal(50, Strings(services[:10]...).AutoVNodes(1.5).AutoVNodes(0).VNodes(50).Build().VNodes())
+}
+
+func TestTuning(t *testing.T) {
+	suite.Run(t, new(TuningSuite))
+}
@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build medley_nounsafe
+
+package consistent
+
+// unsafeStrings indicates that medley converts strings to bytes without copying.
+const unsafeStrings = false
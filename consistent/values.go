@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"reflect"
+
+	"github.com/xmidt-org/medley"
+)
+
+// RingWithValues is a Ring whose services each have an attached value, such as a
+// connection pool or credentials. Because a service and its value are looked up from
+// the same immutable snapshot, callers never observe a service from one ring with a
+// value from another.
+//
+// Attached values have no effect on hashing. RingWithValues are immutable once created.
+// To handle an updated set of services, use the UpdateWithValues function.
+type RingWithValues[S medley.Service, V any] struct {
+	ring   *Ring[S]
+	values medley.Map[S, V]
+}
+
+var _ medley.Locator[string] = (*RingWithValues[string, int])(nil)
+
+// AttachValues creates a RingWithValues from a Ring and the values for its services.
+// Values for services that are not in the ring are ignored, and a service without a
+// value has the zero value of V. The given map is copied, and the Ring is not modified.
+func AttachValues[S medley.Service, V any](r *Ring[S], values map[S]V) *RingWithValues[S, V] {
+	rv := &RingWithValues[S, V]{
+		ring:   r,
+		values: make(medley.Map[S, V], len(values)),
+	}
+
+	for svc, v := range values {
+		if r.Contains(svc) {
+			rv.values[svc] = v
+		}
+	}
+
+	return rv
+}
+
+// Find performs a hash on the given object and returns the nearest service.
+// If this ring is empty, this method returns medley.ErrNoServices.
+func (rv *RingWithValues[S, V]) Find(object []byte) (S, error) {
+	return rv.ring.Find(object)
+}
+
+// FindWithValue is like Find, but also returns the value attached to the service.
+func (rv *RingWithValues[S, V]) FindWithValue(object []byte) (svc S, v V, err error) {
+	if svc, err = rv.ring.Find(object); err == nil {
+		v = rv.values[svc]
+	}
+
+	return
+}
+
+// Value returns the value attached to a service. This method returns false if the
+// service has no attached value, including when the service is not in this ring.
+func (rv *RingWithValues[S, V]) Value(svc S) (v V, exists bool) {
+	v, exists = rv.values[svc]
+	return
+}
+
+// Len returns the number of services hashed by this ring.
+func (rv *RingWithValues[S, V]) Len() int {
+	return rv.ring.Len()
+}
+
+// Ring returns the underlying Ring.
+func (rv *RingWithValues[S, V]) Ring() *Ring[S] {
+	return rv.ring
+}
+
+// UpdateWithValues checks if a set of services and attachments constitutes an update to
+// the given RingWithValues. As with Update, services already hashed by the current ring
+// are not rehashed.
+//
+// Services that remain in the ring keep their current values, unless attach supplies a new
+// value. Services that are added take their values from attach, or the zero value of V if
+// attach has no value for them. Values for services that are not in the updated ring are
+// ignored. The attach map may be nil.
+//
+// If neither the services nor any values change, the current ring is returned as is along
+// with false. Values are compared with ==, so a distinct pointer is a change even if it
+// points to an equal value. If V is not comparable, any value supplied by attach for a
+// service in the ring is a change. Otherwise, a new, distinct RingWithValues
+// is returned along with true. The current RingWithValues is not modified by this function.
+func UpdateWithValues[S medley.Service, V any](current *RingWithValues[S, V], attach map[S]V, services ...S) (next *RingWithValues[S, V], updated bool) {
+	ring, updated := Update(current.ring, services...)
+	var (
+		values  = make(medley.Map[S, V], len(services))
+		changed bool
+	)
+
+	for _, svc := range services {
+		if v, exists := attach[svc]; exists {
+			values[svc] = v
+			if cv, had := current.values[svc]; !had || !sameValue(v, cv) {
+				changed = true
+			}
+		} else if v, exists := current.values[svc]; exists {
+			values[svc] = v
+		}
+	}
+
+	if !updated && !changed {
+		next = current
+		return
+	}
+
+	next = &RingWithValues[S, V]{
+		ring:   ring,
+		values: values,
+	}
+
+	updated = true
+	return
+}
+
+// sameValue tests if two attached values are the same, using ==. Values whose dynamic type
+// is not comparable are never the same.
+func sameValue[V any](a, b V) bool {
+	x, y := any(a), any(b)
+	if t := reflect.TypeOf(x); t != nil && t == reflect.TypeOf(y) && !t.Comparable() {
+		return false
+	}
+
+	return x == y
+}
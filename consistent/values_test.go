@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// pool stands in for a per-service resource, such as a connection pool.
+type pool struct {
+	name  string
+	conns []int
+}
+
+type RingWithValuesSuite struct {
+	suite.Suite
+}
+
+// pools creates a pool for each of the given services.
+func (suite *RingWithValuesSuite) pools(generation int, names ...string) map[string]*pool {
+	pools := make(map[string]*pool, len(names))
+	for _, name := range names {
+		pools[name] = &pool{name: name, conns: []int{generation}}
+	}
+
+	return pools
+}
+
+func (suite *RingWithValuesSuite) TestFindWithValue() {
+	var (
+		ring  = Strings(services[:10]...).Build()
+		pools = suite.pools(1, services[:10]...)
+	)
+
+	// values for services that aren't in the ring are ignored
+	pools["nosuch"] = &pool{name: "nosuch"}
+
+	rv := AttachValues(ring, pools)
+	suite.Same(ring, rv.Ring())
+	suite.Equal(10, rv.Len())
+
+	for _, object := range hashObjects {
+		expected, err := ring.Find(object[:])
+		suite.Require().NoError(err)
+
+		svc, p, err := rv.FindWithValue(object[:])
+		suite.Require().NoError(err)
+		suite.Equal(expected, svc)
+		suite.Require().NotNil(p)
+		suite.Equal(svc, p.name)
+
+		svc, err = rv.Find(object[:])
+		suite.NoError(err)
+		suite.Equal(expected, svc)
+	}
+
+	p, exists := rv.Value(services[0])
+	suite.True(exists)
+	suite.Same(pools[services[0]], p)
+
+	_, exists = rv.Value("nosuch")
+	suite.False(exists)
+}
+
+func (suite *RingWithValuesSuite) TestMissingValues() {
+	rv := AttachValues(Strings(services[:10]...).Build(), suite.pools(1, services[:5]...))
+	for _, svc := range services[5:10] {
+		p, exists := rv.Value(svc)
+		suite.False(exists)
+		suite.Nil(p)
+	}
+}
+
+func (suite *RingWithValuesSuite) TestEmpty() {
+	rv := AttachValues[string, int](Strings[string]().Build(), nil)
+	_, v, err := rv.FindWithValue([]byte("test"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Zero(v)
+}
+
+func (suite *RingWithValuesSuite) TestUpdate() {
+	var (
+		initial = suite.pools(1, services[:10]...)
+		current = AttachValues(Strings(services[:10]...).Build(), initial)
+	)
+
+	// no change
+	next, updated := UpdateWithValues(current, nil, services[:10]...)
+	suite.False(updated)
+	suite.Same(current, next)
+
+	// retained services carry their values forward, and added services take new ones
+	added := suite.pools(2, services[10:15]...)
+	next, updated = UpdateWithValues(current, added, services[5:15]...)
+	suite.Require().True(updated)
+	suite.NotSame(current, next)
+	suite.True(Strings(services[5:15]...).Build().Equal(next.Ring()))
+
+	for _, svc := range services[5:10] {
+		p, exists := next.Value(svc)
+		suite.True(exists)
+		suite.Same(initial[svc], p)
+	}
+
+	for _, svc := range services[10:15] {
+		p, exists := next.Value(svc)
+		suite.True(exists)
+		suite.Same(added[svc], p)
+	}
+
+	for _, svc := range services[:5] {
+		_, exists := next.Value(svc)
+		suite.False(exists)
+	}
+
+	// the current ring is untouched
+	for _, svc := range services[:10] {
+		p, _ := current.Value(svc)
+		suite.Same(initial[svc], p)
+	}
+
+	// added services without values have the zero value
+	next, updated = UpdateWithValues(next, nil, services[5:16]...)
+	suite.True(updated)
+	p, exists := next.Value(services[15])
+	suite.False(exists)
+	suite.Nil(p)
+}
+
+func (suite *RingWithValuesSuite) TestUpdateValuesOnly() {
+	var (
+		current     = AttachValues(Strings(services[:10]...).Build(), suite.pools(1, services[:10]...))
+		replacement = suite.pools(2, services[0])
+	)
+
+	next, updated := UpdateWithValues(current, replacement, services[:10]...)
+	suite.Require().True(updated)
+
+	// attachments don't affect hashing, so the ring itself is reused
+	suite.Same(current.Ring(), next.Ring())
+	p, _ := next.Value(services[0])
+	suite.Same(replacement[services[0]], p)
+
+	// the same values are not an update
+	again, updated := UpdateWithValues(next, replacement, services[:10]...)
+	suite.False(updated)
+	suite.Same(next, again)
+
+	// a new value that is deeply equal to the current one is still an update
+	fresh := suite.pools(2, services[0])
+	again, updated = UpdateWithValues(next, fresh, services[:10]...)
+	suite.True(updated)
+	p, _ = again.Value(services[0])
+	suite.Same(fresh[services[0]], p)
+}
+
+func (suite *RingWithValuesSuite) TestUpdateUncomparableValues() {
+	current := AttachValues(Strings(services[:3]...).Build(), map[string][]int{services[0]: {1}})
+
+	// carried values are not an update
+	next, updated := UpdateWithValues(current, nil, services[:3]...)
+	suite.False(updated)
+	suite.Same(current, next)
+
+	// but any attached value is
+	next, updated = UpdateWithValues(current, map[string][]int{services[0]: {1}}, services[:3]...)
+	suite.True(updated)
+	suite.NotSame(current, next)
+}
+
+func (suite *RingWithValuesSuite) TestValuesDoNotAffectHashing() {
+	var (
+		ring = Strings(services[:10]...).Build()
+		a    = AttachValues(ring, suite.pools(1, services[:10]...))
+		b    = AttachValues(Strings(services[:10]...).Build(), suite.pools(2, services[:10]...))
+	)
+
+	for _, object := range hashObjects {
+		svcA, _, err := a.FindWithValue(object[:])
+		suite.Require().NoError(err)
+
+		svcB, _, err := b.FindWithValue(object[:])
+		suite.Require().NoError(err)
+		suite.Require().Equal(svcA, svcB)
+	}
+}
+
+func TestRingWithValues(t *testing.T) {
+	suite.Run(t, new(RingWithValuesSuite))
+}
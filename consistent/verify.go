@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+var (
+	// ErrUnstableServiceHash indicates that a ServiceHasher wrote different bytes for a
+	// service than it did when the service was first hashed. See Builder.VerifyStableHashing.
+	ErrUnstableServiceHash = errors.New("service hash bytes changed for an equal service")
+)
+
+// digest computes a digest of a service's hash bytes, after any truncation.
+func (h hasher[S]) digest(svc S) uint64 {
+	base, _ := h.base(svc)
+	return h.sum64(base)
+}
+
+// verifyDigest returns the digest for a service this Ring already has. If verify is set,
+// the service is sampled and rehashed, failing with ErrUnstableServiceHash if its digest
+// changed. A service without a stored digest, e.g. in a Ring that wasn't built with
+// verification, has its digest computed now.
+func (r *Ring[S]) verifyDigest(svc S, verify bool) (uint64, error) {
+	expected, ok := r.digests[svc]
+	if !ok {
+		return r.hasher.digest(svc), nil
+	}
+
+	if verify && rand.Float64()*float64(len(r.cache)) < float64(r.hasher.verifySample) {
+		if actual := r.hasher.digest(svc); actual != expected {
+			return 0, fmt.Errorf("%w: service %v: digest %016x, expected %016x", ErrUnstableServiceHash, svc, actual, expected)
+		}
+	}
+
+	return expected, nil
+}
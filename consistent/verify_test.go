@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type VerifySuite struct {
+	suite.Suite
+
+	// calls counts the invocations of the suite's ServiceHashers
+	calls int
+
+	// generation is written by unstableHasher, so changing it changes every service's bytes
+	generation int
+}
+
+func (suite *VerifySuite) SetupTest() {
+	suite.calls = 0
+	suite.generation = 0
+}
+
+// stableHasher is a ServiceHasher that counts how often services are hashed.
+func (suite *VerifySuite) stableHasher(dst io.Writer, svc string) error {
+	suite.calls++
+	return medley.HashStringTo(dst, svc)
+}
+
+// unstableHasher is like stableHasher, but includes a mutable field.
+func (suite *VerifySuite) unstableHasher(dst io.Writer, svc string) error {
+	suite.calls++
+	return medley.HashStringTo(dst, svc+"/"+strconv.Itoa(suite.generation))
+}
+
+func (suite *VerifySuite) TestUnstable() {
+	ring := Services(services[:10]...).
+		ServiceHasher(suite.unstableHasher).
+		VerifyStableHashing(10).
+		Build()
+
+	// nothing changed yet
+	next, updated, err := UpdateE(ring, services[:11]...)
+	suite.Require().NoError(err)
+	suite.True(updated)
+
+	suite.generation++
+	failed, updated, err := UpdateE(next, services[:12]...)
+	suite.ErrorIs(err, ErrUnstableServiceHash)
+	suite.Regexp(`service service-\d\.example\.net`, err.Error())
+	suite.False(updated)
+	suite.Same(next, failed)
+
+	// services whose bytes changed are reported even if the membership didn't change
+	_, updated, err = UpdateE(next, services[:11]...)
+	suite.ErrorIs(err, ErrUnstableServiceHash)
+	suite.False(updated)
+
+	// Update doesn't verify
+	_, updated = Update(next, services[:12]...)
+	suite.True(updated)
+}
+
+func (suite *VerifySuite) TestStable() {
+	ring := Services(services[:20]...).
+		ServiceHasher(suite.stableHasher).
+		VerifyStableHashing(20).
+		Build()
+
+	suite.Equal(40, suite.calls) // the nodes and the digest for each service
+	for i := range 20 {
+		var (
+			updated bool
+			err     error
+		)
+
+		ring, updated, err = UpdateE(ring, services[i:i+20]...)
+		suite.Require().NoError(err)
+		suite.Equal(i > 0, updated)
+	}
+
+	// every retained service was rehashed, and each new service was hashed twice
+	suite.Equal(40+20+19*19+2*19, suite.calls)
+	suite.Len(ring.digests, 20)
+	suite.True(ring.Equal(Strings(services[19:39]...).Build()))
+}
+
+func (suite *VerifySuite) TestSample() {
+	ring := Services(services[:50]...).
+		ServiceHasher(suite.stableHasher).
+		VerifyStableHashing(5).
+		Build()
+
+	suite.calls = 0
+	for range 20 {
+		_, _, err := UpdateE(ring, services[:50]...)
+		suite.Require().NoError(err)
+	}
+
+	// about 5 of the 50 services are rehashed each time
+	suite.Greater(suite.calls, 20)
+	suite.Less(suite.calls, 20*20)
+}
+
+func (suite *VerifySuite) TestDisabled() {
+	ring := Services(services[:10]...).ServiceHasher(suite.unstableHasher).Build()
+	suite.Equal(10, suite.calls)
+	suite.Nil(ring.digests)
+
+	// retained services are never rehashed, so their changed bytes go unnoticed
+	suite.generation++
+	next, updated, err := UpdateE(ring, services[:11]...)
+	suite.NoError(err)
+	suite.True(updated)
+	suite.Nil(next.digests)
+	suite.Equal(11, suite.calls)
+}
+
+func (suite *VerifySuite) TestWithoutDigests() {
+	// a Ring derived without digests has them computed on its first update
+	ring := Services(services[:10]...).ServiceHasher(suite.unstableHasher).VerifyStableHashing(10).Build()
+	ring.digests = nil
+
+	next, _, err := UpdateE(ring, services[:11]...)
+	suite.Require().NoError(err)
+	suite.Len(next.digests, 11)
+
+	suite.generation++
+	_, _, err = UpdateE(next, services[:11]...)
+	suite.ErrorIs(err, ErrUnstableServiceHash)
+}
+
+func TestVerify(t *testing.T) {
+	suite.Run(t, new(VerifySuite))
+}
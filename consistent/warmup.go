@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultWarmupSteps is the number of steps a WarmupController uses when none is supplied.
+	DefaultWarmupSteps = 4
+)
+
+// WarmupController gradually ramps up the share of objects owned by services that join
+// a ring, which avoids overwhelming a service with a cold cache, e.g. after a restart.
+//
+// A service that joins starts with a fraction of the ring's vnodes. On each tick of the
+// controller, every warming service moves up one step, until it reaches the ring's full
+// vnodes after the configured warmup duration. Each step publishes a new Ring to the
+// UpdatableLocator. Because a service's tokens for fewer vnodes are a subset of its tokens
+// for more vnodes, objects only ever migrate toward a warming service.
+//
+// Methods on this type are safe for concurrent usage.
+type WarmupController[S medley.Service] struct {
+	dst      *medley.UpdatableLocator[S]
+	steps    int
+	interval time.Duration
+
+	after func(time.Duration) <-chan time.Time
+
+	lock     sync.Mutex
+	current  *Ring[S]
+	services []S
+	warming  medley.Map[S, int]
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewWarmupController creates a WarmupController that publishes Rings to the given
+// UpdatableLocator. The initial Ring is published immediately, and its services are
+// considered warm. Services are ramped up over the warmup duration in the given number of
+// steps. If steps is nonpositive, DefaultWarmupSteps is used. If warmup is nonpositive,
+// services join at their full vnodes immediately. If after is nil, time.After is used.
+//
+// Start must be called for warming services to progress.
+func NewWarmupController[S medley.Service](dst *medley.UpdatableLocator[S], initial *Ring[S], warmup time.Duration, steps int, after func(time.Duration) <-chan time.Time) *WarmupController[S] {
+	switch {
+	case warmup <= 0:
+		steps = 1
+
+	case steps < 1:
+		steps = DefaultWarmupSteps
+	}
+
+	if after == nil {
+		after = time.After
+	}
+
+	wc := &WarmupController[S]{
+		dst:      dst,
+		steps:    steps,
+		interval: max(warmup/time.Duration(steps), time.Nanosecond),
+		after:    after,
+		current:  initial,
+		services: initial.Services(),
+		warming:  make(medley.Map[S, int]),
+	}
+
+	dst.Set(initial)
+	return wc
+}
+
+// Start begins ticking this controller. This method returns false if this
+// controller was already started.
+func (wc *WarmupController[S]) Start() bool {
+	defer wc.lock.Unlock()
+	wc.lock.Lock()
+
+	if wc.stop != nil {
+		return false
+	}
+
+	wc.stop = make(chan struct{})
+	wc.done = make(chan struct{})
+	go wc.run(wc.stop, wc.done)
+	return true
+}
+
+// Stop halts this controller's ticks, waiting for any tick in progress to finish.
+// Warming services remain at their current step until the controller is restarted.
+// This method returns false if this controller was not started.
+func (wc *WarmupController[S]) Stop() bool {
+	wc.lock.Lock()
+	stop, done := wc.stop, wc.done
+	wc.stop, wc.done = nil, nil
+	wc.lock.Unlock()
+
+	if stop == nil {
+		return false
+	}
+
+	// the lock can't be held here, since a tick in progress needs it
+	close(stop)
+	<-done
+	return true
+}
+
+func (wc *WarmupController[S]) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	if wc.steps < 2 {
+		// services never warm, so there is nothing to tick
+		<-stop
+		return
+	}
+
+	for {
+		select {
+		case <-wc.after(wc.interval):
+			wc.tick()
+
+		case <-stop:
+			return
+		}
+	}
+}
+
+// tick moves each warming service up one step.
+func (wc *WarmupController[S]) tick() {
+	defer wc.lock.Unlock()
+	wc.lock.Lock()
+
+	if len(wc.warming) == 0 {
+		return
+	}
+
+	for svc, step := range wc.warming {
+		if step+1 >= wc.steps {
+			delete(wc.warming, svc)
+		} else {
+			wc.warming[svc] = step + 1
+		}
+	}
+
+	wc.publish()
+}
+
+// vnodes returns the number of vnodes for a service at its current step, or zero (0)
+// for a service that is fully warm.
+func (wc *WarmupController[S]) vnodes(svc S) int {
+	step, ok := wc.warming[svc]
+	if !ok {
+		return 0
+	}
+
+	return max(1, wc.current.config().vnodes*step/wc.steps)
+}
+
+// publish computes a new Ring and sends it to the UpdatableLocator if it changed.
+// The lock must be held when calling this method.
+func (wc *WarmupController[S]) publish() {
+	if next, updated := UpdateVNodes(wc.current, wc.vnodes, wc.services...); updated {
+		wc.current = next
+		wc.dst.Set(next)
+	}
+}
+
+// Set updates the services in the ring. Services that were not already in the ring begin
+// warming at the first step. Services that are still in the ring keep their current step,
+// so repeating a Set is harmless. A service that is removed stops warming, and it starts
+// over at the first step if it rejoins.
+func (wc *WarmupController[S]) Set(services ...S) {
+	defer wc.lock.Unlock()
+	wc.lock.Lock()
+
+	next := make(medley.Map[S, int], len(wc.warming))
+	for _, svc := range services {
+		if step, ok := wc.warming[svc]; ok {
+			next[svc] = step
+		} else if wc.steps > 1 && !wc.current.Contains(svc) {
+			next[svc] = 1
+		}
+	}
+
+	wc.warming = next
+	wc.services = append(wc.services[:0], services...)
+	wc.publish()
+}
+
+// Step returns the current warmup step for a service. The step is between 1 and the
+// number of steps, exclusive. This method returns false if the service is not warming.
+func (wc *WarmupController[S]) Step(svc S) (step int, warming bool) {
+	defer wc.lock.Unlock()
+	wc.lock.Lock()
+
+	step, warming = wc.warming[svc]
+	return
+}
+
+// Ring returns the most recently published Ring.
+func (wc *WarmupController[S]) Ring() *Ring[S] {
+	defer wc.lock.Unlock()
+	wc.lock.Lock()
+
+	return wc.current
+}
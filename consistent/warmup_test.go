@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package consistent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	testWarmup      = time.Minute
+	testWarmupSteps = 4
+)
+
+type WarmupControllerSuite struct {
+	suite.Suite
+
+	// afterCalls receives the duration of each call to the fake clock
+	afterCalls chan time.Duration
+
+	// ticks is returned by the fake clock
+	ticks chan time.Time
+
+	ul *medley.UpdatableLocator[string]
+	wc *WarmupController[string]
+}
+
+func (suite *WarmupControllerSuite) SetupTest() {
+	suite.afterCalls = make(chan time.Duration, 10)
+	suite.ticks = make(chan time.Time)
+	suite.ul = new(medley.UpdatableLocator[string])
+	suite.wc = NewWarmupController(suite.ul, Strings(services[:8]...).Build(), testWarmup, testWarmupSteps, suite.after)
+	suite.Require().NotNil(suite.wc)
+}
+
+func (suite *WarmupControllerSuite) TearDownTest() {
+	suite.wc.Stop()
+}
+
+// after is the fake clock, which fires only when the test calls tick.
+func (suite *WarmupControllerSuite) after(d time.Duration) <-chan time.Time {
+	suite.afterCalls <- d
+	return suite.ticks
+}
+
+// start starts the controller and waits for it to begin waiting on the clock.
+func (suite *WarmupControllerSuite) start() {
+	suite.Require().True(suite.wc.Start())
+	suite.Equal(testWarmup/testWarmupSteps, <-suite.afterCalls)
+}
+
+// tick fires the clock and waits for the controller to process it.
+func (suite *WarmupControllerSuite) tick() {
+	suite.ticks <- time.Time{}
+	<-suite.afterCalls
+}
+
+// vnodes returns the number of vnodes a service has in the published ring.
+func (suite *WarmupControllerSuite) vnodes(svc string) int {
+	return len(suite.wc.Ring().cache[svc])
+}
+
+// owned returns the hash objects owned by a service in the currently published locator.
+func (suite *WarmupControllerSuite) owned(svc string) map[int]bool {
+	owned := make(map[int]bool)
+	for i, object := range hashObjects {
+		result, err := suite.ul.Find(object[:])
+		suite.Require().NoError(err)
+		if result == svc {
+			owned[i] = true
+		}
+	}
+
+	return owned
+}
+
+func (suite *WarmupControllerSuite) TestStartStop() {
+	suite.False(suite.wc.Stop())
+	suite.start()
+	suite.False(suite.wc.Start())
+	suite.True(suite.wc.Stop())
+	suite.False(suite.wc.Stop())
+}
+
+func (suite *WarmupControllerSuite) TestRamp() {
+	var (
+		joining = services[8]
+		ring    = suite.wc.Ring()
+	)
+
+	// the initial services are warm
+	for _, svc := range services[:8] {
+		_, warming := suite.wc.Step(svc)
+		suite.False(warming)
+	}
+
+	suite.wc.Set(services[:9]...)
+	suite.NotSame(ring, suite.wc.Ring())
+
+	step, warming := suite.wc.Step(joining)
+	suite.True(warming)
+	suite.Equal(1, step)
+	suite.Equal(DefaultVNodes/4, suite.vnodes(joining))
+
+	// existing services were not rehashed
+	for _, svc := range services[:8] {
+		suite.Same(ring.cache[svc][0], suite.wc.Ring().cache[svc][0])
+	}
+
+	previous := suite.owned(joining)
+	suite.NotEmpty(previous)
+
+	suite.start()
+	for _, expected := range []int{DefaultVNodes / 2, DefaultVNodes * 3 / 4, DefaultVNodes} {
+		suite.tick()
+		suite.Equal(expected, suite.vnodes(joining))
+
+		// objects only move toward the warming service
+		owned := suite.owned(joining)
+		suite.Greater(len(owned), len(previous))
+		for i := range previous {
+			suite.True(owned[i])
+		}
+
+		previous = owned
+	}
+
+	_, warming = suite.wc.Step(joining)
+	suite.False(warming)
+
+	// the fully warmed ring is the same as a ring built from scratch
+	suite.True(Strings(services[:9]...).Build().Equal(suite.wc.Ring()))
+
+	// nothing changes once all services are warm
+	ring = suite.wc.Ring()
+	suite.tick()
+	suite.Same(ring, suite.wc.Ring())
+}
+
+func (suite *WarmupControllerSuite) TestRemoval() {
+	joining := services[8]
+	suite.wc.Set(services[:9]...)
+	suite.start()
+	suite.tick()
+
+	step, warming := suite.wc.Step(joining)
+	suite.True(warming)
+	suite.Equal(2, step)
+
+	suite.wc.Set(services[:8]...)
+	_, warming = suite.wc.Step(joining)
+	suite.False(warming)
+	suite.False(suite.wc.Ring().Contains(joining))
+
+	// a service that flaps back starts over
+	suite.wc.Set(services[:9]...)
+	step, warming = suite.wc.Step(joining)
+	suite.True(warming)
+	suite.Equal(1, step)
+	suite.Equal(DefaultVNodes/4, suite.vnodes(joining))
+}
+
+func (suite *WarmupControllerSuite) TestRepeatedSet() {
+	joining := services[8]
+	suite.wc.Set(services[:9]...)
+	suite.start()
+	suite.tick()
+
+	ring := suite.wc.Ring()
+	suite.wc.Set(services[:9]...)
+	suite.Same(ring, suite.wc.Ring())
+
+	step, warming := suite.wc.Step(joining)
+	suite.True(warming)
+	suite.Equal(2, step)
+}
+
+func (suite *WarmupControllerSuite) TestSingleStep() {
+	wc := NewWarmupController(suite.ul, Strings(services[:8]...).Build(), testWarmup, 1, suite.after)
+	wc.Set(services[:9]...)
+
+	_, warming := wc.Step(services[8])
+	suite.False(warming)
+	suite.Len(wc.Ring().cache[services[8]], DefaultVNodes)
+}
+
+func (suite *WarmupControllerSuite) TestNoWarmup() {
+	for _, warmup := range []time.Duration{0, -time.Second} {
+		wc := NewWarmupController(suite.ul, Strings(services[:8]...).Build(), warmup, testWarmupSteps, suite.after)
+		wc.Set(services[:9]...)
+
+		_, warming := wc.Step(services[8])
+		suite.False(warming)
+		suite.Len(wc.Ring().cache[services[8]], DefaultVNodes)
+
+		// there is nothing to tick, so the clock is never consulted
+		suite.True(wc.Start())
+		suite.True(wc.Stop())
+		suite.Empty(suite.afterCalls)
+	}
+}
+
+func TestWarmupController(t *testing.T) {
+	suite.Run(t, new(WarmupControllerSuite))
+}
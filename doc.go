@@ -4,5 +4,11 @@
 /*
 Package medley implements distributed hashing aimed at microservices.
 Currently, only consistent hashing is implemented.
+
+Functions that accept strings, such as FindString and Algorithm.Sum64String, avoid copying
+the string's bytes by using package unsafe. For environments that disallow unsafe, build with
+the medley_nounsafe tag. With that tag, strings are converted with plain conversions instead,
+which allocate, and the consistent package always decodes ring storage into new slices rather
+than referring to it directly.
 */
 package medley
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley_test
+
+import (
+	"fmt"
+
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+func ExampleFindString() {
+	ring := consistent.Strings("a.example.net", "b.example.net", "c.example.net").Build()
+
+	// FindString doesn't copy the key, unlike a conversion to []byte
+	svc, err := medley.FindString[string](ring, "device-1")
+	fmt.Println(svc, err)
+
+	// Output:
+	// c.example.net <nil>
+}
+
+func ExampleUpdatableLocator() {
+	locator := medley.NewUpdatableLocator[string](nil)
+
+	// until the first update, there are no services
+	_, err := medley.FindString[string](locator, "device-1")
+	fmt.Println(err)
+
+	locator.Set(consistent.Strings("a.example.net", "b.example.net").Build())
+	svc, err := medley.FindString[string](locator, "device-1")
+	fmt.Println(svc, err)
+
+	// Output:
+	// no services defined
+	// b.example.net <nil>
+}
+
+func ExampleMultiLocator() {
+	// each datacenter has its own ring, and an object is sent to one service in each
+	var (
+		east = consistent.Strings("a.east.example.net", "b.east.example.net").Build()
+		west = consistent.Strings("a.west.example.net", "b.west.example.net").Build()
+		ml   = medley.NewMultiLocator[string](east, west)
+	)
+
+	services, err := ml.FindString("device-1")
+	fmt.Println(services, err)
+
+	// Output:
+	// [a.east.example.net b.west.example.net] <nil>
+}
+
+func ExampleHashBuilder() {
+	// the canonical encoding of a service with a host and a path
+	sum := func(host, path string) uint64 {
+		h := medley.DefaultAlgorithm().New64()
+		medley.NewHashBuilder(h).WriteDelimitedString(host).WriteDelimitedString(path)
+		return h.Sum64()
+	}
+
+	// without length prefixes, both of these services would hash the bytes "example.net/api"
+	fmt.Println(sum("example.net", "/api") == sum("example.net/", "api"))
+
+	// Output:
+	// false
+}
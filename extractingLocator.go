@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyExtraction is returned, wrapped along with the extractor's error, when
+	// a KeyExtractor fails.
+	ErrKeyExtraction = errors.New("unable to extract key")
+)
+
+// KeyExtractor produces the bytes that are hashed for an object, e.g. a single field
+// of a serialized request. The returned key may refer to the object's bytes.
+type KeyExtractor func(object []byte) (key []byte, err error)
+
+// ExtractKey applies a KeyExtractor to an object. If the extractor is nil, the object is
+// its own key. An error from the extractor is wrapped with ErrKeyExtraction.
+func ExtractKey(extract KeyExtractor, object []byte) ([]byte, error) {
+	if extract == nil {
+		return object, nil
+	}
+
+	key, err := extract(object)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyExtraction, err)
+	}
+
+	return key, nil
+}
+
+// ExtractingLocator is a Locator decorator that passes only the key extracted from
+// each object to the decorated Locator. This keeps extraction in one place, so that
+// no caller can forget it.
+//
+// An ExtractingLocator is immutable and safe for concurrent usage, provided its
+// KeyExtractor is also safe for concurrent usage.
+type ExtractingLocator[S Service] struct {
+	next    Locator[S]
+	extract KeyExtractor
+}
+
+// NewExtractingLocator decorates a Locator with a KeyExtractor. If extract is nil,
+// objects are passed to the decorated Locator as is.
+func NewExtractingLocator[S Service](next Locator[S], extract KeyExtractor) *ExtractingLocator[S] {
+	return &ExtractingLocator[S]{
+		next:    next,
+		extract: extract,
+	}
+}
+
+var _ AnnotatedLocator[string] = (*ExtractingLocator[string])(nil)
+
+// Find extracts the key from the given object and locates the key's service. If
+// extraction fails, the error wraps ErrKeyExtraction and the extractor's error.
+func (el *ExtractingLocator[S]) Find(object []byte) (svc S, err error) {
+	var key []byte
+	if key, err = ExtractKey(el.extract, object); err == nil {
+		svc, err = el.next.Find(key)
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but returns the annotation of the decorated Locator's
+// lookup of the key.
+func (el *ExtractingLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	var key []byte
+	if key, err = ExtractKey(el.extract, object); err == nil {
+		result, err = Explain(el.next, key)
+	}
+
+	return
+}
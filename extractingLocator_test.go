@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var errNoMAC = errors.New("no mac")
+
+// extractMAC is a KeyExtractor that extracts the value of a "mac=" field.
+func extractMAC(object []byte) ([]byte, error) {
+	_, after, found := bytes.Cut(object, []byte("mac="))
+	if !found {
+		return nil, errNoMAC
+	}
+
+	key, _, _ := bytes.Cut(after, []byte(";"))
+	return key, nil
+}
+
+type ExtractingLocatorSuite struct {
+	suite.Suite
+}
+
+func (suite *ExtractingLocatorSuite) TestExtractKey() {
+	key, err := ExtractKey(nil, []byte("object"))
+	suite.NoError(err)
+	suite.Equal([]byte("object"), key)
+
+	key, err = ExtractKey(extractMAC, []byte("path=/;mac=112233445566;ts=1"))
+	suite.NoError(err)
+	suite.Equal([]byte("112233445566"), key)
+
+	key, err = ExtractKey(extractMAC, []byte("path=/"))
+	suite.ErrorIs(err, ErrKeyExtraction)
+	suite.ErrorIs(err, errNoMAC)
+	suite.Nil(key)
+}
+
+func (suite *ExtractingLocatorSuite) TestFind() {
+	next := new(MockLocator[string])
+	next.ExpectFindSuccess([]byte("112233445566"), "service1").Twice()
+	next.ExpectFindSuccess([]byte("aabbccddeeff"), "service2").Once()
+
+	el := NewExtractingLocator[string](next, extractMAC)
+	for object, expected := range map[string]string{
+		"path=/a;mac=112233445566;ts=1": "service1",
+		"mac=112233445566":              "service1",
+		"path=/a;mac=aabbccddeeff;ts=1": "service2",
+	} {
+		svc, err := el.Find([]byte(object))
+		suite.NoError(err)
+		suite.Equal(expected, svc)
+	}
+
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *ExtractingLocatorSuite) TestFindString() {
+	next := new(MockLocator[string])
+	next.ExpectFindSuccess([]byte("112233445566"), "service1").Once()
+
+	var extracted []string
+	el := NewExtractingLocator[string](next, func(object []byte) ([]byte, error) {
+		extracted = append(extracted, string(object))
+		return extractMAC(object)
+	})
+
+	svc, err := FindString[string](el, "path=/;mac=112233445566")
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+	suite.Equal([]string{"path=/;mac=112233445566"}, extracted)
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *ExtractingLocatorSuite) TestFindError() {
+	next := new(MockLocator[string])
+	el := NewExtractingLocator[string](next, extractMAC)
+
+	svc, err := el.Find([]byte("path=/"))
+	suite.ErrorIs(err, ErrKeyExtraction)
+	suite.ErrorIs(err, errNoMAC)
+	suite.Empty(svc)
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *ExtractingLocatorSuite) TestNilExtractor() {
+	next := new(MockLocator[string])
+	next.ExpectFindSuccess([]byte("object"), "service").Once()
+
+	svc, err := NewExtractingLocator[string](next, nil).Find([]byte("object"))
+	suite.NoError(err)
+	suite.Equal("service", svc)
+	next.AssertExpectations(suite.T())
+}
+
+func TestExtractingLocator(t *testing.T) {
+	suite.Run(t, new(ExtractingLocatorSuite))
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"slices"
+)
+
+// FallbackLocator is a Locator decorator that routes objects to a static set of
+// fallback services when the wrapped Locator has no services, e.g. while a ring
+// is empty during startup or a discovery outage.
+//
+// A fallback service is selected with a jump consistent hash of the object, so
+// even degraded routing is sticky for each object. Errors from the wrapped Locator
+// other than ErrNoServices are returned as is.
+//
+// A FallbackLocator is immutable and safe for concurrent usage.
+type FallbackLocator[S Service] struct {
+	next     Locator[S]
+	alg      Algorithm
+	fallback []S
+}
+
+// NewFallbackLocator decorates a Locator with the given fallback services. The fallback
+// services are copied. If there are no fallback services, the returned Locator behaves
+// exactly like the wrapped Locator.
+func NewFallbackLocator[S Service](next Locator[S], fallback ...S) *FallbackLocator[S] {
+	return &FallbackLocator[S]{
+		next:     next,
+		alg:      DefaultAlgorithm(),
+		fallback: slices.Clone(fallback),
+	}
+}
+
+var _ AnnotatedLocator[string] = (*FallbackLocator[string])(nil)
+
+// Find consults the wrapped Locator, and selects a fallback service only if the
+// wrapped Locator returns ErrNoServices.
+func (fl *FallbackLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, err = fl.next.Find(object)
+	if errors.Is(err, ErrNoServices) && len(fl.fallback) > 0 {
+		svc, err = fl.fallbackFor(object), nil
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but reports SourceFallback when a fallback service was
+// selected. Otherwise, the wrapped Locator's annotation is returned.
+func (fl *FallbackLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	result, err = Explain(fl.next, object)
+	if errors.Is(err, ErrNoServices) && len(fl.fallback) > 0 {
+		result, err = FindResult[S]{Service: fl.fallbackFor(object), Source: SourceFallback}, nil
+	}
+
+	return
+}
+
+// fallbackFor selects the fallback service for an object. There must be at least
+// one fallback service.
+func (fl *FallbackLocator[S]) fallbackFor(object []byte) S {
+	return fl.fallback[jumpHash(fl.alg.Sum64Bytes(object), len(fl.fallback))]
+}
+
+// jumpHash maps a key onto one of n buckets using the jump consistent hash of
+// Lamping and Veach. When n changes, only about 1/n of the keys move.
+func jumpHash(key uint64, n int) int {
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+
+	return int(b)
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FallbackLocatorSuite struct {
+	suite.Suite
+
+	fallback []string
+	objects  [][]byte
+}
+
+func (suite *FallbackLocatorSuite) SetupSuite() {
+	suite.fallback = []string{"fallback1", "fallback2", "fallback3", "fallback4"}
+	suite.objects = make([][]byte, 1000)
+	for i := range suite.objects {
+		suite.objects[i] = []byte(fmt.Sprintf("object-%d", i))
+	}
+}
+
+func (suite *FallbackLocatorSuite) TestSuccess() {
+	next := new(MockLocator[string])
+	next.ExpectFindSuccess([]byte("test"), "primary").Once()
+
+	fl := NewFallbackLocator[string](next, suite.fallback...)
+	svc, err := fl.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("primary", svc)
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *FallbackLocatorSuite) TestError() {
+	var (
+		expectedErr = errors.New("expected")
+		next        = new(MockLocator[string])
+	)
+
+	next.ExpectFindFail([]byte("test"), expectedErr).Once()
+
+	fl := NewFallbackLocator[string](next, suite.fallback...)
+	svc, err := fl.Find([]byte("test"))
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(svc)
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *FallbackLocatorSuite) TestNoFallback() {
+	next := new(MockLocator[string])
+	next.ExpectFindNoServices([]byte("test")).Once()
+
+	fl := NewFallbackLocator[string](next)
+	svc, err := fl.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *FallbackLocatorSuite) TestSticky() {
+	var (
+		fallback = append([]string(nil), suite.fallback...)
+		fl       = NewFallbackLocator[string](NewUpdatableLocator[string](nil), fallback...)
+		selected = make(map[string]string, len(suite.objects))
+		counts   = make(map[string]int, len(suite.fallback))
+	)
+
+	// the fallback services are copied
+	fallback[0] = "modified"
+
+	for _, object := range suite.objects {
+		svc, err := fl.Find(object)
+		suite.Require().NoError(err)
+		suite.Require().Contains(suite.fallback, svc)
+		selected[string(object)] = svc
+		counts[svc]++
+	}
+
+	// every fallback is used
+	suite.Len(counts, len(suite.fallback))
+
+	for range 3 {
+		for _, object := range suite.objects {
+			svc, err := fl.Find(object)
+			suite.Require().NoError(err)
+			suite.Require().Equal(selected[string(object)], svc)
+		}
+	}
+
+	// adding a fallback service only moves objects onto that service
+	grown := NewFallbackLocator[string](NewUpdatableLocator[string](nil), append(suite.fallback, "fallback5")...)
+	for _, object := range suite.objects {
+		svc, err := grown.Find(object)
+		suite.Require().NoError(err)
+		if svc != "fallback5" {
+			suite.Require().Equal(selected[string(object)], svc)
+		}
+	}
+}
+
+func (suite *FallbackLocatorSuite) TestRecovery() {
+	var (
+		object = []byte("test")
+		ul     = NewUpdatableLocator[string](nil)
+		fl     = NewFallbackLocator[string](ul, suite.fallback...)
+	)
+
+	svc, err := fl.Find(object)
+	suite.NoError(err)
+	suite.Contains(suite.fallback, svc)
+
+	ul.Set(fixedLocator[string]{service: "primary"})
+	svc, err = fl.Find(object)
+	suite.NoError(err)
+	suite.Equal("primary", svc)
+
+	ul.Set(nil)
+	svc, err = fl.Find(object)
+	suite.NoError(err)
+	suite.Contains(suite.fallback, svc)
+}
+
+func (suite *FallbackLocatorSuite) TestJumpHash() {
+	suite.Zero(jumpHash(0, 1))
+	suite.Zero(jumpHash(12345, 1))
+
+	for key := range uint64(1000) {
+		b := jumpHash(key, 10)
+		suite.Require().GreaterOrEqual(b, 0)
+		suite.Require().Less(b, 10)
+	}
+}
+
+func TestFallbackLocator(t *testing.T) {
+	suite.Run(t, new(FallbackLocatorSuite))
+}
@@ -3,12 +3,12 @@ module github.com/xmidt-org/medley
 go 1.23
 
 require (
+	github.com/billhathaway/consistentHash v0.0.0-20140718022140-addea16d2229
 	github.com/spaolacci/murmur3 v1.1.0
 	github.com/stretchr/testify v1.10.0
 )
 
 require (
-	github.com/billhathaway/consistentHash v0.0.0-20140718022140-addea16d2229 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
@@ -4,24 +4,49 @@
 package medley
 
 import (
+	"encoding/binary"
+	"errors"
 	"io"
 	"math"
-	"unsafe"
+)
+
+var (
+	// ErrNoHashDestination indicates that a HashBuilder was written to before
+	// Use or Tee gave it a destination.
+	ErrNoHashDestination = errors.New("hash builder has no destination")
 )
 
 // HashBuilder is a Fluent Builder for hash values. Instances of this
 // type may be created via NewHashBuilder. If created directly, then
-// Use must be called before attempting any writes to the builder.
+// Use must be called before attempting any writes to the builder. Writes
+// to a zero HashBuilder fail with ErrNoHashDestination.
 //
 // This type is convenient for building hashes of complex types, such as structs.
 // The HashBasicServiceTo function in this package gives an example of this usage.
+//
+// For new ServiceHashers, the recommended canonical encoding is WriteUvarint and WriteVarint
+// for integers and WriteDelimited or WriteDelimitedString for variable-length fields. These
+// are the protobuf-style varint encodings of encoding/binary, so other languages can easily
+// produce the same bytes, and length prefixes keep adjacent fields from running together.
 type HashBuilder struct {
 	dst   io.Writer
+	dsts  []io.Writer
 	sum64 func() uint64
 	reset func()
 	err   error
 }
 
+// summer holds the behavior of anything that can compute a 64-bit hash value.
+// hash.Hash64 satisfies this interface, for example.
+type summer interface {
+	Sum64() uint64
+}
+
+// resetter defines the behavior of an io.Writer that can be reset.
+type resetter interface {
+	Reset()
+}
+
 // NewHashBuilder creates a HashBuilder that writes to this given destination.
 // Errors from the various WriteXXX methods are accumulated and available via Err().
 // When an error occurs on the underlying destination, all subsequent writes are ignored.
@@ -33,34 +58,75 @@ func NewHashBuilder(dst io.Writer) *HashBuilder {
 }
 
 // Use replaces this builder's underlying writer and resets error state. This method
-// may be used to reuse a single builder for different hashes.
+// may be used to reuse a single builder for different hashes. Any destinations
+// previously added with Tee are discarded.
 //
 // If a HashBuilder is created directly, without using NewHashBuilder, this method
-// is required to initialize the builder or writing will cause a panic.
+// is required to initialize the builder or writing will fail with ErrNoHashDestination.
 func (hb *HashBuilder) Use(dst io.Writer) *HashBuilder {
-	hb.dst = dst
+	hb.dsts = append(hb.dsts[:0], dst)
 	hb.err = nil
-
-	// summer holds the behavior of anything that can compute a 64-bit hash value.
-	// hash.Hash64 satisfies this interface, for example.
-	type summer interface {
-		Sum64() uint64
+	hb.sum64 = nil
+	if s, ok := dst.(summer); ok {
+		hb.sum64 = s.Sum64
 	}
 
-	if s, ok := hb.dst.(summer); ok {
-		hb.sum64 = s.Sum64
+	hb.useDestinations()
+	return hb
+}
+
+// Tee adds more destinations to this builder. Every subsequent write is sent to each
+// destination in order, and the first error from any destination is latched just as
+// with a single destination. This is useful for hashing the same bytes with more than
+// one algorithm in a single pass, e.g. during a migration between algorithms.
+//
+// Sum64 and CanSum64 continue to use the first destination. Use Sum64At to compute
+// the hash value of any destination. Reset resets every destination that can be reset.
+func (hb *HashBuilder) Tee(dst ...io.Writer) *HashBuilder {
+	if len(hb.dsts) == 0 && len(dst) > 0 {
+		return hb.Use(dst[0]).Tee(dst[1:]...)
 	}
 
-	// resetter defines the behavior of an io.Writer that can be reset.
-	type resetter interface {
-		Reset()
+	hb.dsts = append(hb.dsts, dst...)
+	hb.useDestinations()
+	return hb
+}
+
+// useDestinations computes the writer and reset behavior for the current destinations.
+func (hb *HashBuilder) useDestinations() {
+	var resets []func()
+	for _, dst := range hb.dsts {
+		if r, ok := dst.(resetter); ok {
+			resets = append(resets, r.Reset)
+		}
 	}
 
-	if r, ok := hb.dst.(resetter); ok {
-		hb.reset = r.Reset
+	switch len(resets) {
+	case 0:
+		hb.reset = nil
+
+	case 1:
+		hb.reset = resets[0]
+
+	default:
+		hb.reset = func() {
+			for _, r := range resets {
+				r()
+			}
+		}
 	}
 
-	return hb
+	switch len(hb.dsts) {
+	case 0:
+		hb.dst = nil
+
+	case 1:
+		hb.dst = hb.dsts[0]
+
+	default:
+		// io.MultiWriter stops at, and returns, the first error
+		hb.dst = io.MultiWriter(hb.dsts...)
+	}
 }
 
 // Err returns the first error that occurred in any Fluent Chain using this builder.
@@ -75,6 +141,20 @@ func (hb *HashBuilder) CanSum64() bool {
 	return hb.sum64 != nil
 }
 
+// Sum64At returns the current, computed hash value of the destination with the given
+// index, where the destination passed to Use or NewHashBuilder has index zero (0) and
+// destinations added with Tee follow in order. If the index is out of range or the
+// destination does not provide a Sum64() uint64 method, this method returns zero (0).
+func (hb *HashBuilder) Sum64At(i int) (v uint64) {
+	if i >= 0 && i < len(hb.dsts) {
+		if s, ok := hb.dsts[i].(summer); ok {
+			v = s.Sum64()
+		}
+	}
+
+	return
+}
+
 // Sum64 returns the current, computed hash value. If the currently wrapped io.Writer
 // does not provide a Sum64() uint64 method, this method returns zero (0).
 //
@@ -87,14 +167,14 @@ func (hb *HashBuilder) Sum64() (v uint64) {
 	return
 }
 
-// CanReset tests if Reset will actually do anything, i.e. if the currently wrapped
+// CanReset tests if Reset will actually do anything, i.e. if any currently wrapped
 // io.Writer supplies a Reset method.
 func (hb *HashBuilder) CanReset() bool {
 	return hb.reset != nil
 }
 
-// Reset resets the underlying io.Writer to its initial state. If the currently wrapped
-// io.Writer does not supply a Reset() method, this method does nothing.
+// Reset resets each underlying io.Writer that supplies a Reset() method to its initial
+// state. If no currently wrapped io.Writer supplies a Reset() method, this method does nothing.
 //
 // This method allows a HashBuilder and/or its underlying writer to be reused for multiple hashes.
 func (hb *HashBuilder) Reset() {
@@ -103,10 +183,19 @@ func (hb *HashBuilder) Reset() {
 	}
 }
 
+// write sends the given bytes to this builder's destination, latching any error.
+func (hb *HashBuilder) write(p []byte) {
+	if hb.dst != nil {
+		_, hb.err = hb.dst.Write(p)
+	} else {
+		hb.err = ErrNoHashDestination
+	}
+}
+
 // Write writes the given bytes.
 func (hb *HashBuilder) Write(v []byte) *HashBuilder {
 	if hb.err == nil {
-		_, hb.err = hb.dst.Write(v)
+		hb.write(v)
 	}
 
 	return hb
@@ -116,9 +205,7 @@ func (hb *HashBuilder) Write(v []byte) *HashBuilder {
 // additional allocations.
 func (hb *HashBuilder) WriteString(v string) *HashBuilder {
 	if hb.err == nil && len(v) > 0 {
-		_, hb.err = hb.dst.Write(
-			unsafe.Slice(unsafe.StringData(v), len(v)),
-		)
+		hb.write(stringToBytes(v))
 	}
 
 	return hb
@@ -129,7 +216,7 @@ func (hb *HashBuilder) WriteUint8(v uint8) *HashBuilder {
 	if hb.err == nil {
 		var buf [1]byte
 		buf[0] = v
-		_, hb.err = hb.dst.Write(buf[:])
+		hb.write(buf[:])
 	}
 
 	return hb
@@ -141,7 +228,7 @@ func (hb *HashBuilder) WriteUint16(v uint16) *HashBuilder {
 		var buf [2]byte
 		buf[0] = byte(v >> 8)
 		buf[1] = byte(v)
-		_, hb.err = hb.dst.Write(buf[:])
+		hb.write(buf[:])
 	}
 
 	return hb
@@ -155,7 +242,7 @@ func (hb *HashBuilder) WriteUint32(v uint32) *HashBuilder {
 		buf[1] = byte(v >> 16)
 		buf[2] = byte(v >> 8)
 		buf[3] = byte(v)
-		_, hb.err = hb.dst.Write(buf[:])
+		hb.write(buf[:])
 	}
 
 	return hb
@@ -173,7 +260,7 @@ func (hb *HashBuilder) WriteUint64(v uint64) *HashBuilder {
 		buf[5] = byte(v >> 16)
 		buf[6] = byte(v >> 8)
 		buf[7] = byte(v)
-		_, hb.err = hb.dst.Write(buf[:])
+		hb.write(buf[:])
 	}
 
 	return hb
@@ -196,3 +283,42 @@ func (hb *HashBuilder) WriteFloat64(v float64) *HashBuilder {
 
 	return hb
 }
+
+// WriteUvarint writes the given unsigned integer as a varint, using the same encoding as
+// binary.AppendUvarint. Small values take fewer bytes than the fixed-width methods.
+func (hb *HashBuilder) WriteUvarint(v uint64) *HashBuilder {
+	if hb.err == nil {
+		var buf [binary.MaxVarintLen64]byte
+		hb.write(binary.AppendUvarint(buf[:0], v))
+	}
+
+	return hb
+}
+
+// WriteVarint writes the given signed integer as a zig-zag varint, using the same encoding
+// as binary.AppendVarint. Values near zero, positive or negative, take fewer bytes.
+func (hb *HashBuilder) WriteVarint(v int64) *HashBuilder {
+	if hb.err == nil {
+		var buf [binary.MaxVarintLen64]byte
+		hb.write(binary.AppendVarint(buf[:0], v))
+	}
+
+	return hb
+}
+
+// WriteDelimited writes the length of the given bytes as a uvarint, followed by the bytes
+// themselves. Unlike Write, the result is unambiguous when several fields are written.
+func (hb *HashBuilder) WriteDelimited(v []byte) *HashBuilder {
+	hb.WriteUvarint(uint64(len(v)))
+	if hb.err == nil && len(v) > 0 {
+		hb.write(v)
+	}
+
+	return hb
+}
+
+// WriteDelimitedString is like WriteDelimited, but for a string. As with WriteString,
+// this method does not require additional allocations.
+func (hb *HashBuilder) WriteDelimitedString(v string) *HashBuilder {
+	return hb.WriteDelimited(stringToBytes(v))
+}
@@ -6,6 +6,7 @@ package medley
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"hash/fnv"
 	"io"
 	"math"
@@ -168,6 +169,149 @@ func (suite *HashBuilderSuite) TestWriteFloat64() {
 	suite.Empty(b.Bytes())
 }
 
+func (suite *HashBuilderSuite) TestWriteUvarint() {
+	testCases := []struct {
+		value    uint64
+		expected []byte
+	}{
+		{value: 0, expected: []byte{0x00}},
+		{value: 1, expected: []byte{0x01}},
+		{value: 127, expected: []byte{0x7f}},
+		{value: 128, expected: []byte{0x80, 0x01}},
+		{value: 300, expected: []byte{0xac, 0x02}},
+		{value: math.MaxUint64, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, testCase := range testCases {
+		var b bytes.Buffer
+		suite.assertWriteSuccess(suite.newHashBuilder(&b).WriteUvarint(testCase.value))
+		suite.Equal(testCase.expected, b.Bytes(), "value: %d", testCase.value)
+		suite.Equal(binary.AppendUvarint(nil, testCase.value), b.Bytes())
+	}
+}
+
+func (suite *HashBuilderSuite) TestWriteVarint() {
+	testCases := []struct {
+		value    int64
+		expected []byte
+	}{
+		{value: 0, expected: []byte{0x00}},
+		{value: -1, expected: []byte{0x01}},
+		{value: 1, expected: []byte{0x02}},
+		{value: -64, expected: []byte{0x7f}},
+		{value: 64, expected: []byte{0x80, 0x01}},
+		{value: math.MaxInt64, expected: []byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+		{value: math.MinInt64, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, testCase := range testCases {
+		var b bytes.Buffer
+		suite.assertWriteSuccess(suite.newHashBuilder(&b).WriteVarint(testCase.value))
+		suite.Equal(testCase.expected, b.Bytes(), "value: %d", testCase.value)
+		suite.Equal(binary.AppendVarint(nil, testCase.value), b.Bytes())
+	}
+}
+
+func (suite *HashBuilderSuite) TestWriteDelimited() {
+	var (
+		b    bytes.Buffer
+		hb   = suite.newHashBuilder(&b)
+		long = bytes.Repeat([]byte{'x'}, 200)
+	)
+
+	suite.assertWriteSuccess(hb.WriteDelimited(nil))
+	suite.Equal([]byte{0x00}, b.Bytes())
+
+	b.Reset()
+	suite.assertWriteSuccess(hb.WriteDelimited([]byte("abc")))
+	suite.Equal([]byte{0x03, 'a', 'b', 'c'}, b.Bytes())
+
+	b.Reset()
+	suite.assertWriteSuccess(hb.WriteDelimitedString("abc"))
+	suite.Equal([]byte{0x03, 'a', 'b', 'c'}, b.Bytes())
+
+	b.Reset()
+	suite.assertWriteSuccess(hb.WriteDelimited(long))
+	suite.Equal(append([]byte{0xc8, 0x01}, long...), b.Bytes())
+
+	// delimiting keeps adjacent fields from running together
+	var ab, a bytes.Buffer
+	NewHashBuilder(&ab).WriteDelimitedString("ab").WriteDelimitedString("")
+	NewHashBuilder(&a).WriteDelimitedString("a").WriteDelimitedString("b")
+	suite.NotEqual(ab.Bytes(), a.Bytes())
+}
+
+func (suite *HashBuilderSuite) TestVarintError() {
+	expectedErr := errors.New("expected")
+	for _, write := range []func(*HashBuilder) *HashBuilder{
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteUvarint(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteVarint(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteDelimited([]byte("test")) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteDelimitedString("test") },
+	} {
+		var b bytes.Buffer
+		hb := suite.newHashBuilder(errWriter{err: expectedErr}).Tee(&b)
+		suite.ErrorIs(write(hb).Err(), expectedErr)
+
+		// the first error is latched, so nothing more is written
+		hb.Use(&b)
+		hb.err = expectedErr
+		suite.ErrorIs(write(hb).Err(), expectedErr)
+		suite.Zero(b.Len())
+	}
+}
+
+func (suite *HashBuilderSuite) TestVarintAllocations() {
+	hb := suite.newHashBuilder(fnv.New64())
+	fixed := testing.AllocsPerRun(100, func() { hb.WriteUint64(math.MaxUint64) })
+
+	// the varint methods allocate no more than the fixed-width methods
+	suite.LessOrEqual(testing.AllocsPerRun(100, func() { hb.WriteUvarint(math.MaxUint64) }), fixed)
+	suite.LessOrEqual(testing.AllocsPerRun(100, func() { hb.WriteVarint(math.MinInt64) }), fixed)
+	if unsafeStrings {
+		suite.LessOrEqual(testing.AllocsPerRun(100, func() { hb.WriteDelimitedString("test") }), fixed)
+	}
+}
+
+func (suite *HashBuilderSuite) TestCanonicalStruct() {
+	type endpoint struct {
+		Name   string
+		Port   uint64
+		Offset int64
+		Tags   []string
+	}
+
+	var (
+		e = endpoint{
+			Name:   "svc",
+			Port:   8080,
+			Offset: -2,
+			Tags:   []string{"a", "bc"},
+		}
+
+		b  bytes.Buffer
+		hb = suite.newHashBuilder(&b)
+	)
+
+	hb.WriteDelimitedString(e.Name).WriteUvarint(e.Port).WriteVarint(e.Offset).WriteUvarint(uint64(len(e.Tags)))
+	for _, tag := range e.Tags {
+		hb.WriteDelimitedString(tag)
+	}
+
+	suite.assertWriteSuccess(hb)
+	suite.Equal(
+		[]byte{
+			0x03, 's', 'v', 'c', // Name
+			0x90, 0x3f, // Port
+			0x03,      // Offset
+			0x02,      // len(Tags)
+			0x01, 'a', // Tags[0]
+			0x02, 'b', 'c', // Tags[1]
+		},
+		b.Bytes(),
+	)
+}
+
 func (suite *HashBuilderSuite) writeHashBytes(hb *HashBuilder) int {
 	suite.Require().NotNil(hb)
 	hb.
@@ -220,6 +364,128 @@ func (suite *HashBuilderSuite) TestWithHash() {
 	suite.Equal(hb.Sum64(), hash.Sum64())
 }
 
+func (suite *HashBuilderSuite) TestTee() {
+	var (
+		first, second bytes.Buffer
+		expected      bytes.Buffer
+	)
+
+	hb := suite.newHashBuilder(&first).Tee(&second)
+	suite.True(hb.CanReset())
+	suite.False(hb.CanSum64())
+
+	suite.writeHashBytes(hb)
+	suite.writeHashBytes(NewHashBuilder(&expected))
+	suite.Equal(expected.Bytes(), first.Bytes())
+	suite.Equal(expected.Bytes(), second.Bytes())
+
+	hb.Reset()
+	suite.Zero(first.Len())
+	suite.Zero(second.Len())
+}
+
+func (suite *HashBuilderSuite) TestTeeWithoutUse() {
+	var (
+		first, second bytes.Buffer
+		hb            = new(HashBuilder).Tee(&first, &second)
+	)
+
+	suite.assertWriteSuccess(hb.WriteString("test"))
+	suite.Equal("test", first.String())
+	suite.Equal("test", second.String())
+
+	// Use discards the teed destinations
+	hb.Use(&first).WriteString("more")
+	suite.Equal("testmore", first.String())
+	suite.Equal("test", second.String())
+}
+
+func (suite *HashBuilderSuite) TestTeeError() {
+	var (
+		expectedErr = errors.New("expected")
+		first       bytes.Buffer
+		last        bytes.Buffer
+		hb          = suite.newHashBuilder(&first).Tee(errWriter{err: expectedErr}, &last)
+	)
+
+	hb.WriteString("test").WriteUint64(123)
+	suite.ErrorIs(hb.Err(), expectedErr)
+
+	// the first write reached the destinations before the failure, but nothing after it
+	suite.Equal("test", first.String())
+	suite.Zero(last.Len())
+
+	// Use clears the error
+	suite.assertWriteSuccess(hb.Use(&first).WriteString("more"))
+}
+
+func (suite *HashBuilderSuite) TestSum64At() {
+	var (
+		fnvHash     = fnv.New64()
+		murmurHash  = DefaultAlgorithm().New64()
+		buffer      bytes.Buffer
+		expectedFNV = fnv.New64()
+		expectedMur = DefaultAlgorithm().New64()
+	)
+
+	hb := suite.newHashBuilder(fnvHash).Tee(&buffer, murmurHash)
+	suite.True(hb.CanSum64())
+
+	suite.writeHashBytes(hb)
+	suite.writeHashBytes(NewHashBuilder(expectedFNV))
+	suite.writeHashBytes(NewHashBuilder(expectedMur))
+
+	suite.Equal(expectedFNV.Sum64(), hb.Sum64())
+	suite.Equal(expectedFNV.Sum64(), hb.Sum64At(0))
+	suite.Zero(hb.Sum64At(1))
+	suite.Equal(expectedMur.Sum64(), hb.Sum64At(2))
+	suite.NotEqual(hb.Sum64At(0), hb.Sum64At(2))
+
+	// out of range
+	suite.Zero(hb.Sum64At(-1))
+	suite.Zero(hb.Sum64At(3))
+	suite.Zero(new(HashBuilder).Sum64At(0))
+
+	hb.Reset()
+	suite.Equal(fnv.New64().Sum64(), hb.Sum64At(0))
+	suite.Equal(DefaultAlgorithm().New64().Sum64(), hb.Sum64At(2))
+	suite.Zero(buffer.Len())
+}
+
+func (suite *HashBuilderSuite) TestZeroValue() {
+	var hb HashBuilder
+	suite.NoError(hb.Err())
+	suite.False(hb.CanSum64())
+	suite.False(hb.CanReset())
+	suite.Zero(hb.Sum64())
+	suite.Zero(hb.Sum64At(0))
+	hb.Reset()
+
+	// every write fails without panicking, and the first error is latched
+	for _, write := range []func(*HashBuilder) *HashBuilder{
+		func(hb *HashBuilder) *HashBuilder { return hb.Write([]byte("test")) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteString("test") },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteUint8(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteUint16(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteUint32(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteUint64(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteFloat32(1) },
+		func(hb *HashBuilder) *HashBuilder { return hb.WriteFloat64(1) },
+	} {
+		var zero HashBuilder
+		suite.Same(&zero, write(&zero))
+		suite.ErrorIs(zero.Err(), ErrNoHashDestination)
+	}
+
+	// an empty Tee doesn't supply a destination
+	suite.ErrorIs(hb.Tee().WriteString("test").Err(), ErrNoHashDestination)
+
+	// Use clears the error
+	var b bytes.Buffer
+	suite.assertWriteSuccess(hb.Use(&b).WriteString("test"))
+	suite.Equal("test", b.String())
+}
+
 func TestHashBuilder(t *testing.T) {
 	suite.Run(t, new(HashBuilderSuite))
 }
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHalfLife is the half-life a LoadTracker uses when none is supplied.
+	DefaultHalfLife = 10 * time.Second
+)
+
+// decayedLoad is the exponentially decayed load for a single service.
+type decayedLoad struct {
+	lock    sync.Mutex
+	value   float64
+	updated time.Time
+}
+
+// LoadTracker tracks an exponentially decayed load for each service. Each recorded
+// weight loses half of its contribution to a service's load every half-life, so load
+// reflects recent activity rather than a running total.
+//
+// A LoadTracker's Load method can be used as a score source for score-aware locators.
+//
+// Methods on this type are safe for concurrent usage. Recording load for different
+// services does not contend on the same lock.
+type LoadTracker[S Service] struct {
+	halfLife time.Duration
+	now      func() time.Time
+
+	lock  sync.RWMutex
+	loads map[S]*decayedLoad
+}
+
+// NewLoadTracker creates a LoadTracker with the given half-life and clock. If halfLife is
+// nonpositive, DefaultHalfLife is used. If now is nil, time.Now is used.
+func NewLoadTracker[S Service](halfLife time.Duration, now func() time.Time) *LoadTracker[S] {
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+
+	if now == nil {
+		now = time.Now
+	}
+
+	return &LoadTracker[S]{
+		halfLife: halfLife,
+		now:      now,
+		loads:    make(map[S]*decayedLoad),
+	}
+}
+
+// decay computes the remaining fraction of a load after the given elapsed time.
+// A clock that moves backwards causes no decay.
+func (lt *LoadTracker[S]) decay(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 1.0
+	}
+
+	return math.Exp2(-float64(elapsed) / float64(lt.halfLife))
+}
+
+// get returns the load for a service, optionally creating it if it doesn't exist.
+func (lt *LoadTracker[S]) get(svc S, create bool) *decayedLoad {
+	lt.lock.RLock()
+	dl := lt.loads[svc]
+	lt.lock.RUnlock()
+
+	if dl == nil && create {
+		lt.lock.Lock()
+		if dl = lt.loads[svc]; dl == nil {
+			dl = new(decayedLoad)
+			lt.loads[svc] = dl
+		}
+
+		lt.lock.Unlock()
+	}
+
+	return dl
+}
+
+// Record adds the given weight to a service's load, after decaying the load
+// recorded so far.
+func (lt *LoadTracker[S]) Record(svc S, weight float64) {
+	dl := lt.get(svc, true)
+	dl.lock.Lock()
+
+	// reading the clock under the lock keeps updates for a service in time order
+	now := lt.now()
+	dl.value = dl.value*lt.decay(now.Sub(dl.updated)) + weight
+	dl.updated = now
+	dl.lock.Unlock()
+}
+
+// Load returns the current, decayed load for a service. A service with no recorded
+// load has a load of zero (0).
+func (lt *LoadTracker[S]) Load(svc S) (load float64) {
+	if dl := lt.get(svc, false); dl != nil {
+		now := lt.now()
+		dl.lock.Lock()
+		load = dl.value * lt.decay(now.Sub(dl.updated))
+		dl.lock.Unlock()
+	}
+
+	return
+}
+
+// Delete discards the load for a service, e.g. when that service is no longer in use.
+func (lt *LoadTracker[S]) Delete(svc S) {
+	lt.lock.Lock()
+	delete(lt.loads, svc)
+	lt.lock.Unlock()
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testHalfLife = 10 * time.Second
+
+type LoadTrackerSuite struct {
+	suite.Suite
+
+	clockLock sync.Mutex
+	clock     time.Time
+}
+
+func (suite *LoadTrackerSuite) SetupTest() {
+	suite.clock = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// now is the fake clock.
+func (suite *LoadTrackerSuite) now() time.Time {
+	suite.clockLock.Lock()
+	defer suite.clockLock.Unlock()
+	return suite.clock
+}
+
+func (suite *LoadTrackerSuite) advance(d time.Duration) {
+	suite.clockLock.Lock()
+	suite.clock = suite.clock.Add(d)
+	suite.clockLock.Unlock()
+}
+
+func (suite *LoadTrackerSuite) newLoadTracker() *LoadTracker[string] {
+	lt := NewLoadTracker[string](testHalfLife, suite.now)
+	suite.Require().NotNil(lt)
+	return lt
+}
+
+func (suite *LoadTrackerSuite) TestDefaults() {
+	lt := NewLoadTracker[string](0, nil)
+	suite.Require().NotNil(lt)
+	suite.Equal(DefaultHalfLife, lt.halfLife)
+
+	lt.Record("service", 1.0)
+	suite.InDelta(1.0, lt.Load("service"), 0.01)
+}
+
+func (suite *LoadTrackerSuite) TestDecay() {
+	lt := suite.newLoadTracker()
+	suite.Zero(lt.Load("service"))
+
+	lt.Record("service", 8.0)
+	suite.Equal(8.0, lt.Load("service"))
+
+	suite.advance(testHalfLife)
+	suite.InDelta(4.0, lt.Load("service"), 1e-9)
+
+	suite.advance(testHalfLife / 2)
+	suite.InDelta(4.0/1.4142135623730951, lt.Load("service"), 1e-9)
+
+	suite.advance(testHalfLife / 2)
+	suite.InDelta(2.0, lt.Load("service"), 1e-9)
+
+	// recording decays what's already there before adding the weight
+	lt.Record("service", 1.0)
+	suite.InDelta(3.0, lt.Load("service"), 1e-9)
+
+	suite.advance(2 * testHalfLife)
+	suite.InDelta(0.75, lt.Load("service"), 1e-9)
+
+	// other services are independent
+	suite.Zero(lt.Load("other"))
+}
+
+func (suite *LoadTrackerSuite) TestClockBackwards() {
+	lt := suite.newLoadTracker()
+	lt.Record("service", 4.0)
+
+	suite.advance(-testHalfLife)
+	suite.Equal(4.0, lt.Load("service"))
+
+	lt.Record("service", 1.0)
+	suite.Equal(5.0, lt.Load("service"))
+}
+
+func (suite *LoadTrackerSuite) TestDelete() {
+	lt := suite.newLoadTracker()
+	lt.Record("service", 4.0)
+	lt.Delete("service")
+	suite.Zero(lt.Load("service"))
+
+	lt.Delete("nosuch")
+	lt.Record("service", 1.0)
+	suite.Equal(1.0, lt.Load("service"))
+}
+
+func (suite *LoadTrackerSuite) TestConcurrency() {
+	const (
+		goroutines = 8
+		records    = 1000
+	)
+
+	var (
+		lt       = suite.newLoadTracker()
+		services = []string{"service1", "service2", "service3"}
+		wg       sync.WaitGroup
+	)
+
+	wg.Add(goroutines)
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			for j := range records {
+				svc := services[(i+j)%len(services)]
+				lt.Record(svc, 1.0)
+				lt.Load(svc)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// the clock never moved, so nothing decayed
+	total := 0.0
+	for _, svc := range services {
+		total += lt.Load(svc)
+	}
+
+	suite.Equal(float64(goroutines*records), total)
+}
+
+func TestLoadTracker(t *testing.T) {
+	suite.Run(t, new(LoadTrackerSuite))
+}
@@ -5,9 +5,11 @@ package medley
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
-	"unsafe"
 )
 
 var (
@@ -25,53 +27,257 @@ type Locator[S Service] interface {
 // FindString locates a service for a string key.
 func FindString[S Service](l Locator[S], v string) (S, error) {
 	return l.Find(
-		unsafe.Slice(unsafe.StringData(v), len(v)),
+		stringToBytes(v),
 	)
 }
 
+// noCopy may be added to structs which must not be copied after first use. The
+// copylocks check of go vet flags copies of any struct containing a noCopy.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527.
+type noCopy struct{}
+
+// Lock is a no-op used by go vet's copylocks check.
+func (*noCopy) Lock() {}
+
+// Unlock is a no-op used by go vet's copylocks check.
+func (*noCopy) Unlock() {}
+
+// DuplicatePolicy determines how a MultiLocator handles a Locator that it already contains.
+type DuplicatePolicy int
+
+const (
+	// DedupeDuplicates silently ignores a Locator that was already added. This is the default.
+	DedupeDuplicates DuplicatePolicy = iota
+
+	// RejectDuplicates refuses a Locator that was already added. AddE returns ErrDuplicateLocator.
+	RejectDuplicates
+
+	// AllowDuplicates adds a Locator as many times as it is given, so it is consulted once
+	// for each time it was added.
+	AllowDuplicates
+)
+
+var (
+	// ErrDuplicateLocator is returned by MultiLocator.AddE when a Locator was already added
+	// and duplicates are rejected.
+	ErrDuplicateLocator = errors.New("locator already added")
+
+	// ErrTooManyLocators is returned by MultiLocator.AddE when adding a Locator would exceed
+	// the MultiLocator's maximum size.
+	ErrTooManyLocators = errors.New("too many locators")
+)
+
 // MultiLocator represents an aggregate set of locators, each of which is
 // consulted for services. Methods on this type are safe for concurrent usage.
 // The zero value for this type is usable, but will return ErrNoServices.
 // To initialize a MultiLocator with some locators, use NewMultiLocator.
 //
-// A MultiLocator must not be copied after creation.
+// By default, a Locator that was already added is ignored, and there is no limit on the
+// number of locators. Use NewMultiLocatorWithLimits to change either.
+// Locators whose dynamic type is not comparable are never considered duplicates.
+//
+// By default, Find returns a service once for each locator that found it, so a service that
+// several locators share, e.g. overlapping regional and global rings, appears more than once.
+// Use SetDedupe to return each distinct service only once.
+//
+// A MultiLocator must not be copied after first use. Add and Remove panic if they
+// detect that a MultiLocator was copied.
 type MultiLocator[S Service] struct {
+	noCopy noCopy
+
 	lock     sync.RWMutex
 	locators []Locator[S]
+
+	// policy and maxSize are immutable after construction
+	policy  DuplicatePolicy
+	maxSize int
+
+	// dedupe indicates whether Find removes duplicate services from its results
+	dedupe bool
+
+	// self is the address of this MultiLocator, which is used to detect copies
+	self *MultiLocator[S]
 }
 
 // NewMultiLocator returns a MultiLocator initialized with the give set of Locators.
+// Duplicate Locators are silently dropped, so NewMultiLocator(l, l) consults l only once.
+// Earlier versions kept every duplicate. Use NewMultiLocatorWithLimits and Add with
+// AllowDuplicates to keep them.
 func NewMultiLocator[S Service](ls ...Locator[S]) *MultiLocator[S] {
-	return &MultiLocator[S]{
-		locators: append(
-			[]Locator[S]{},
-			ls...,
-		),
+	ml := &MultiLocator[S]{
+		locators: dedupeLocators(ls),
+	}
+
+	ml.self = ml
+	return ml
+}
+
+// NewMultiLocatorWithLimits returns an empty MultiLocator with the given policy for duplicate
+// Locators and the given maximum number of locators. A nonpositive maxSize means no limit.
+func NewMultiLocatorWithLimits[S Service](policy DuplicatePolicy, maxSize int) *MultiLocator[S] {
+	ml := &MultiLocator[S]{
+		policy:  policy,
+		maxSize: maxSize,
+	}
+
+	ml.self = ml
+	return ml
+}
+
+// sameLocator tests if two Locators are the same. Locators whose dynamic type is not
+// comparable, e.g. structs holding a func, are always treated as distinct.
+func sameLocator[S Service](a, b Locator[S]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// containsLocator tests if the given Locator is in a slice of Locators.
+func containsLocator[S Service](ls []Locator[S], l Locator[S]) bool {
+	return slices.ContainsFunc(ls, func(candidate Locator[S]) bool {
+		return sameLocator(candidate, l)
+	})
+}
+
+// dedupeLocators returns a copy of the given Locators with duplicates removed.
+func dedupeLocators[S Service](ls []Locator[S]) []Locator[S] {
+	deduped := make([]Locator[S], 0, len(ls))
+	for _, l := range ls {
+		if !containsLocator(deduped, l) {
+			deduped = append(deduped, l)
+		}
+	}
+
+	return deduped
+}
+
+// checkCopy panics if this MultiLocator was copied after first use. The first use of a
+// MultiLocator records its address. The write lock must be held when calling this method.
+func (ml *MultiLocator[S]) checkCopy() {
+	if ml.self == nil {
+		ml.self = ml
+	} else if ml.self != ml {
+		panic("medley: illegal use of a MultiLocator that was copied after first use")
 	}
 }
 
-// Add adds another locator to this MultiLocator. This method does not protect
-// against adding a Locator more than once.
+// Add adds another locator to this MultiLocator. A Locator that was already added is
+// ignored, unless duplicates are allowed, as is any Locator beyond this MultiLocator's
+// maximum size. Use TryAdd or AddE to learn whether the Locator was added.
 func (ml *MultiLocator[S]) Add(l Locator[S]) {
+	ml.add(l)
+}
+
+// TryAdd is like Add, but returns true if the Locator was added. This method returns false
+// if the Locator was already added, unless duplicates are allowed, or if this MultiLocator
+// is at its maximum size.
+func (ml *MultiLocator[S]) TryAdd(l Locator[S]) bool {
+	added, _ := ml.add(l)
+	return added
+}
+
+// AddE is like TryAdd, but reports why a Locator was not added. If this MultiLocator is at its
+// maximum size, this method returns ErrTooManyLocators. If the Locator was already added and
+// duplicates are rejected, this method returns ErrDuplicateLocator. With the default policy,
+// a duplicate Locator is ignored and this method returns nil.
+func (ml *MultiLocator[S]) AddE(l Locator[S]) error {
+	_, err := ml.add(l)
+	return err
+}
+
+// add implements Add, TryAdd, and AddE.
+func (ml *MultiLocator[S]) add(l Locator[S]) (bool, error) {
+	defer ml.lock.Unlock()
 	ml.lock.Lock()
+	ml.checkCopy()
+
+	if ml.policy != AllowDuplicates && containsLocator(ml.locators, l) {
+		if ml.policy == RejectDuplicates {
+			return false, ErrDuplicateLocator
+		}
+
+		return false, nil
+	}
+
+	if ml.maxSize > 0 && len(ml.locators) >= ml.maxSize {
+		return false, fmt.Errorf("%w: the limit is %d", ErrTooManyLocators, ml.maxSize)
+	}
+
 	ml.locators = append(ml.locators, l)
-	ml.lock.Unlock()
+	return true, nil
 }
 
 // Remove removes a locator from this MultiLocator. If the same Locator
 // was added multiple times, this method only removes the first one.
 func (ml *MultiLocator[S]) Remove(l Locator[S]) {
+	ml.TryRemove(l)
+}
+
+// TryRemove is like Remove, but returns true if a Locator was removed.
+func (ml *MultiLocator[S]) TryRemove(l Locator[S]) bool {
 	defer ml.lock.Unlock()
 	ml.lock.Lock()
+	ml.checkCopy()
 
 	for i, candidate := range ml.locators {
-		if candidate == l {
+		if sameLocator(candidate, l) {
 			last := len(ml.locators) - 1
 			ml.locators[i], ml.locators[last] = ml.locators[last], nil
 			ml.locators = ml.locators[:last]
-			return
+			return true
 		}
 	}
+
+	return false
+}
+
+// Len returns the number of locators in this MultiLocator, counting each duplicate.
+func (ml *MultiLocator[S]) Len() int {
+	defer ml.lock.RUnlock()
+	ml.lock.RLock()
+	return len(ml.locators)
+}
+
+// Contains tests if the given Locator was added to this MultiLocator.
+func (ml *MultiLocator[S]) Contains(l Locator[S]) bool {
+	defer ml.lock.RUnlock()
+	ml.lock.RLock()
+	return containsLocator(ml.locators, l)
+}
+
+// SetDedupe changes whether Find removes duplicate services from its results. When set, each
+// distinct service is returned once, in the order of its first occurrence. Services are
+// compared with ==, so services that merely refer to the same host are still distinct.
+func (ml *MultiLocator[S]) SetDedupe(dedupe bool) {
+	defer ml.lock.Unlock()
+	ml.lock.Lock()
+	ml.dedupe = dedupe
+}
+
+// dedupeServices removes duplicates from the given services in place, keeping the first
+// occurrence of each, and returns the shortened slice.
+func dedupeServices[S Service](services []S) []S {
+	if len(services) < 2 {
+		return services
+	}
+
+	var (
+		seen    = make(Map[S, bool], len(services))
+		deduped = services[:0]
+	)
+
+	for _, svc := range services {
+		if !seen[svc] {
+			seen[svc] = true
+			deduped = append(deduped, svc)
+		}
+	}
+
+	return deduped
 }
 
 // Find returns the services from each locator in this aggregate. This method
@@ -94,6 +300,8 @@ func (ml *MultiLocator[S]) Find(object []byte) ([]S, error) {
 
 	if len(services) == 0 {
 		return nil, ErrNoServices
+	} else if ml.dedupe {
+		services = dedupeServices(services)
 	}
 
 	return services, nil
@@ -102,7 +310,7 @@ func (ml *MultiLocator[S]) Find(object []byte) ([]S, error) {
 // FindString locates services based on a string key.
 func (ml *MultiLocator[S]) FindString(object string) ([]S, error) {
 	return ml.Find(
-		unsafe.Slice(unsafe.StringData(object), len(object)),
+		stringToBytes(object),
 	)
 }
 
@@ -112,29 +320,150 @@ func (ml *MultiLocator[S]) FindString(object string) ([]S, error) {
 //
 // The zero value of this type is usable, but will return ErrNoServices. Use
 // NewUpdatableLocator to return an initialized UpdatableLocator.
+//
+// During an incident, Freeze keeps the current implementation in place while Set calls, e.g.
+// from service discovery, are held back. Unfreeze installs the most recent of those.
+//
+// An UpdatableLocator must not be copied after first use. Set panics if it detects
+// that an UpdatableLocator was copied.
 type UpdatableLocator[S Service] struct {
+	noCopy noCopy
+
 	impl atomic.Pointer[Locator[S]]
+
+	notifyLock sync.Mutex
+	notify     chan struct{}
+
+	// freezeLock guards the freeze state, and is held while Set installs an implementation
+	// so that nothing is installed once Freeze returns
+	freezeLock sync.Mutex
+	frozen     bool
+	pending    Locator[S]
+	hasPending bool
+	suppressed uint64
+
+	// self is the address of this UpdatableLocator, which is used to detect copies
+	self atomic.Pointer[UpdatableLocator[S]]
 }
 
 // NewUpdatableLocator returns an UpdatableLocator initialized with the given
 // implementation.
 func NewUpdatableLocator[S Service](impl Locator[S]) *UpdatableLocator[S] {
 	ul := new(UpdatableLocator[S])
+	ul.self.Store(ul)
 	ul.Set(impl)
 	return ul
 }
 
+// checkCopy panics if this UpdatableLocator was copied after first use. The first use
+// of an UpdatableLocator records its address.
+func (ul *UpdatableLocator[S]) checkCopy() {
+	if !ul.self.CompareAndSwap(nil, ul) && ul.self.Load() != ul {
+		panic("medley: illegal use of an UpdatableLocator that was copied after first use")
+	}
+}
+
 var _ Locator[string] = &UpdatableLocator[string]{}
 
 // Set atomically changes this locator's implementation. If the implementation
 // is nil, methods of this UpdatableLocator will generally return ErrNoServices.
 // Setting an implementation to nil effectively "turns off" this locator.
+//
+// Avoid clearing this locator before setting a replacement, i.e. Set(nil) followed by
+// Set(newImpl), since lookups in between fail with ErrNoServices. Set the replacement
+// directly, or use an AtomicSwapLocator when the replacement depends on the current
+// implementation.
+//
+// While this locator is frozen, the implementation is held as pending rather than installed.
 func (ul *UpdatableLocator[S]) Set(impl Locator[S]) {
+	ul.checkCopy()
+	defer ul.freezeLock.Unlock()
+	ul.freezeLock.Lock()
+
+	if ul.frozen {
+		ul.pending, ul.hasPending = impl, true
+		ul.suppressed++
+		return
+	}
+
+	ul.install(impl)
+}
+
+// install stores an implementation and notifies any waiters. The freezeLock must be held.
+func (ul *UpdatableLocator[S]) install(impl Locator[S]) {
 	if impl != nil {
 		ul.impl.Store(&impl)
 	} else {
 		ul.impl.Store(nil)
 	}
+
+	ul.notifyLock.Lock()
+	if ul.notify != nil {
+		close(ul.notify)
+		ul.notify = nil
+	}
+
+	ul.notifyLock.Unlock()
+}
+
+// Freeze stops Set from changing this locator's implementation, e.g. to hold routing exactly
+// as it is during an incident. While frozen, each Set replaces the pending implementation and
+// counts as a suppressed update. Lookups continue to use the current implementation, so a
+// frozen locator without one still returns ErrNoServices. Freezing a frozen locator does nothing.
+func (ul *UpdatableLocator[S]) Freeze() {
+	ul.checkCopy()
+	defer ul.freezeLock.Unlock()
+	ul.freezeLock.Lock()
+	ul.frozen = true
+}
+
+// Unfreeze allows Set to change this locator's implementation again. If any Set calls were
+// suppressed while frozen, the most recent implementation is installed atomically, as if by
+// Set, and this method returns true. Otherwise, the current implementation is kept and this
+// method returns false.
+func (ul *UpdatableLocator[S]) Unfreeze() (installed bool) {
+	ul.checkCopy()
+	defer ul.freezeLock.Unlock()
+	ul.freezeLock.Lock()
+
+	ul.frozen = false
+	if ul.hasPending {
+		ul.install(ul.pending)
+		ul.pending, ul.hasPending = nil, false
+		installed = true
+	}
+
+	return
+}
+
+// IsFrozen tests if this locator is frozen.
+func (ul *UpdatableLocator[S]) IsFrozen() bool {
+	defer ul.freezeLock.Unlock()
+	ul.freezeLock.Lock()
+	return ul.frozen
+}
+
+// Suppressed returns the total number of Set calls that were not installed because this
+// locator was frozen. The count is cumulative across freezes.
+func (ul *UpdatableLocator[S]) Suppressed() uint64 {
+	defer ul.freezeLock.Unlock()
+	ul.freezeLock.Lock()
+	return ul.suppressed
+}
+
+// Updated returns a channel that is closed the next time Set is called. Each
+// call to Set closes the channel, and subsequent calls to this method return
+// a new channel. While this locator is frozen, Set doesn't close the channel,
+// but an Unfreeze that installs a pending implementation does.
+func (ul *UpdatableLocator[S]) Updated() <-chan struct{} {
+	defer ul.notifyLock.Unlock()
+	ul.notifyLock.Lock()
+
+	if ul.notify == nil {
+		ul.notify = make(chan struct{})
+	}
+
+	return ul.notify
 }
 
 // Find consults the current Locator implementation for the given object.
@@ -150,6 +479,18 @@ func (ul *UpdatableLocator[S]) Find(object []byte) (svc S, err error) {
 	return
 }
 
+// FindAnnotated is like Find, but returns the annotation of the current Locator
+// implementation.
+func (ul *UpdatableLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	if l := ul.impl.Load(); l != nil {
+		result, err = Explain(*l, object)
+	} else {
+		err = ErrNoServices
+	}
+
+	return
+}
+
 // SetLocator sets the implementation for a given locator. This function better tolerates
 // decorators than simply casting to an UpdatableLocator.
 //
@@ -5,6 +5,10 @@ package medley
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -200,6 +204,322 @@ func (suite *LocatorSuite) TestUpdatableLocator() {
 	suite.assertExpectations(l1, l2, l3)
 }
 
+func (suite *LocatorSuite) TestMultiLocatorDedupe() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[string])
+		l3 = new(MockLocator[string])
+		l4 = new(MockLocator[string])
+		ml = NewMultiLocator[string](l1, l2, l3, l4)
+	)
+
+	l1.ExpectFindSuccess(suite.object, "service1")
+	l2.ExpectFindSuccess(suite.object, "service2")
+	l3.ExpectFindSuccess(suite.object, "service1")
+	l4.ExpectFindNoServices(suite.object)
+
+	// the default keeps duplicates
+	results, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service1", "service2", "service1"}, results)
+
+	ml.SetDedupe(true)
+	results, err = ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service1", "service2"}, results)
+
+	results, err = ml.FindString(suite.objectString)
+	suite.NoError(err)
+	suite.Equal([]string{"service1", "service2"}, results)
+
+	ml.SetDedupe(false)
+	results, err = ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Len(results, 3)
+}
+
+func (suite *LocatorSuite) TestMultiLocatorDedupeFullOverlap() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[string])
+		l3 = new(MockLocator[string])
+		ml = NewMultiLocator[string](l1, l2, l3)
+	)
+
+	l1.ExpectFindSuccess(suite.object, "service1")
+	l2.ExpectFindSuccess(suite.object, "service1")
+	l3.ExpectFindSuccess(suite.object, "service1")
+
+	ml.SetDedupe(true)
+	results, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service1"}, results)
+}
+
+func (suite *LocatorSuite) TestMultiLocatorDedupeError() {
+	var (
+		expectedErr = errors.New("expected")
+
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[string])
+		l3 = new(MockLocator[string])
+		ml = NewMultiLocator[string](l1, l2, l3)
+	)
+
+	l1.ExpectFindSuccess(suite.object, "service1")
+	l2.ExpectFindSuccess(suite.object, "service1")
+	l3.ExpectFindFail(suite.object, expectedErr)
+
+	// an error still wins over any services found
+	ml.SetDedupe(true)
+	results, err := ml.Find(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(results)
+
+	// as does ErrNoServices when every locator is empty
+	empty := new(MultiLocator[string])
+	empty.SetDedupe(true)
+	results, err = empty.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+}
+
+func (suite *LocatorSuite) TestMultiLocatorDedupeDistinct() {
+	var (
+		a1 = BasicService{Scheme: "http", Host: "a.example.net", Port: 80}
+		a2 = BasicService{Scheme: "http", Host: "a.example.net", Port: 8080}
+
+		l1 = new(MockLocator[BasicService])
+		l2 = new(MockLocator[BasicService])
+		l3 = new(MockLocator[BasicService])
+		ml = NewMultiLocator[BasicService](l1, l2, l3)
+	)
+
+	l1.ExpectFindSuccess(suite.object, a1)
+	l2.ExpectFindSuccess(suite.object, a2)
+	l3.ExpectFindSuccess(suite.object, a1)
+
+	// services that share a host are still distinct services
+	ml.SetDedupe(true)
+	results, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]BasicService{a1, a2}, results)
+}
+
+func (suite *LocatorSuite) TestMultiLocatorZeroValue() {
+	var ml MultiLocator[string]
+	suite.Zero(ml.Len())
+
+	results, err := ml.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+
+	results, err = ml.FindString(suite.objectString)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+	suite.False(ml.Contains(l))
+	suite.False(ml.TryRemove(l))
+
+	// the zero value dedupes, and has no limit
+	suite.True(ml.TryAdd(l))
+	suite.False(ml.TryAdd(l))
+	suite.NoError(ml.AddE(l))
+	suite.Equal(1, ml.Len())
+	suite.True(ml.Contains(l))
+
+	results, err = ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service1"}, results)
+
+	suite.True(ml.TryRemove(l))
+	suite.Zero(ml.Len())
+	suite.assertExpectations(l)
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorZeroValue() {
+	var ul UpdatableLocator[string]
+	result, err := ul.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(result)
+
+	suite.NotNil(ul.Updated())
+
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+	suite.True(SetLocator[string](&ul, l))
+
+	result, err = ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", result)
+	suite.assertExpectations(l)
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorUpdated() {
+	ul := new(UpdatableLocator[string])
+
+	first := ul.Updated()
+	suite.Require().NotNil(first)
+	suite.Equal(first, ul.Updated())
+
+	select {
+	case <-first:
+		suite.Fail("the channel should not be closed before Set")
+	default:
+	}
+
+	ul.Set(new(MockLocator[string]))
+	select {
+	case <-first:
+	default:
+		suite.Fail("the channel should be closed after Set")
+	}
+
+	second := ul.Updated()
+	suite.Require().NotNil(second)
+	suite.NotEqual(first, second)
+
+	ul.Set(nil)
+	select {
+	case <-second:
+	default:
+		suite.Fail("the channel should be closed after Set")
+	}
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorFreeze() {
+	var (
+		ul      = NewUpdatableLocator[string](fixedLocator[string]{service: "service1"})
+		updated = ul.Updated()
+	)
+
+	suite.False(ul.IsFrozen())
+	ul.Freeze()
+	ul.Freeze()
+	suite.True(ul.IsFrozen())
+
+	// updates are suppressed, and lookups keep using the frozen implementation
+	ul.Set(fixedLocator[string]{service: "service2"})
+	ul.Set(nil)
+	ul.Set(fixedLocator[string]{service: "service3"})
+	suite.Equal(uint64(3), ul.Suppressed())
+
+	svc, err := ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	select {
+	case <-updated:
+		suite.Fail("the channel should not be closed while frozen")
+	default:
+	}
+
+	// the latest pending implementation wins
+	suite.True(ul.Unfreeze())
+	suite.False(ul.IsFrozen())
+	svc, err = ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service3", svc)
+
+	select {
+	case <-updated:
+	default:
+		suite.Fail("the channel should be closed after Unfreeze installs an implementation")
+	}
+
+	// nothing is pending, so Unfreeze keeps the current implementation
+	ul.Freeze()
+	suite.False(ul.Unfreeze())
+	svc, err = ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service3", svc)
+	suite.False(ul.Unfreeze())
+
+	// a pending nil clears the locator, as Set would
+	ul.Freeze()
+	ul.Set(nil)
+	suite.True(ul.Unfreeze())
+	_, err = ul.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+
+	// the count is cumulative, and Set works normally once unfrozen
+	suite.Equal(uint64(4), ul.Suppressed())
+	ul.Set(fixedLocator[string]{service: "service4"})
+	suite.Equal(uint64(4), ul.Suppressed())
+	svc, _ = ul.Find(suite.object)
+	suite.Equal("service4", svc)
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorFreezeZeroValue() {
+	var ul UpdatableLocator[string]
+	ul.Freeze()
+	ul.Set(fixedLocator[string]{service: "service1"})
+
+	_, err := ul.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+
+	suite.True(ul.Unfreeze())
+	svc, err := ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorFreezeConcurrent() {
+	const (
+		setters = 8
+		sets    = 200
+	)
+
+	var (
+		ul   = NewUpdatableLocator[string](fixedLocator[string]{service: "frozen"})
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	ul.Freeze()
+	for g := range setters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range sets {
+				ul.Set(fixedLocator[string]{service: fmt.Sprintf("setter-%d-%d", g, i)})
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			// routing never changes while frozen
+			if svc, err := ul.Find(suite.object); !suite.NoError(err) || !suite.Equal("frozen", svc) {
+				return
+			}
+		}
+	}()
+
+	for ul.Suppressed() < setters*sets {
+		runtime.Gosched()
+	}
+
+	close(stop)
+	wg.Wait()
+
+	suite.Equal(uint64(setters*sets), ul.Suppressed())
+	suite.True(ul.Unfreeze())
+	svc, err := ul.Find(suite.object)
+	suite.NoError(err)
+	suite.Regexp(`^setter-\d-199$`, svc)
+}
+
 func (suite *LocatorSuite) TestSetLocator() {
 	var (
 		l1 = new(MockLocator[string])
@@ -222,6 +542,278 @@ func (suite *LocatorSuite) TestSetLocator() {
 	suite.assertExpectations(l1, l2)
 }
 
+// copyOf copies a value in a way that go vet's copylocks check doesn't flag,
+// which simulates an accidental copy that vet failed to catch.
+func copyOf[T any](v *T) *T {
+	c := new(T)
+	reflect.ValueOf(c).Elem().Set(reflect.ValueOf(v).Elem())
+	return c
+}
+
+// uncomparableLocator is a Locator whose dynamic type cannot be compared with ==.
+type uncomparableLocator[S Service] struct {
+	find func([]byte) (S, error)
+}
+
+func (ul uncomparableLocator[S]) Find(object []byte) (S, error) {
+	return ul.find(object)
+}
+
+func (suite *LocatorSuite) TestMultiLocatorDuplicates() {
+	suite.Run("Dedupe", func() {
+		var (
+			l  = new(MockLocator[string])
+			ml = NewMultiLocator[string](l, l)
+		)
+
+		suite.Equal(1, ml.Len())
+		suite.False(ml.TryAdd(l))
+		suite.NoError(ml.AddE(l))
+		suite.Equal(1, ml.Len())
+
+		l.ExpectFindSuccess(suite.object, "service1").Once()
+		services, err := ml.Find(suite.object)
+		suite.NoError(err)
+		suite.Equal([]string{"service1"}, services)
+		suite.assertExpectations(l)
+	})
+
+	suite.Run("Reject", func() {
+		var (
+			l  = new(MockLocator[string])
+			ml = NewMultiLocatorWithLimits[string](RejectDuplicates, 0)
+		)
+
+		suite.True(ml.TryAdd(l))
+		suite.False(ml.TryAdd(l))
+		suite.ErrorIs(ml.AddE(l), ErrDuplicateLocator)
+		suite.Equal(1, ml.Len())
+	})
+
+	suite.Run("Allow", func() {
+		var (
+			l  = new(MockLocator[string])
+			ml = NewMultiLocatorWithLimits[string](AllowDuplicates, 0)
+		)
+
+		suite.True(ml.TryAdd(l))
+		suite.True(ml.TryAdd(l))
+		suite.NoError(ml.AddE(l))
+		suite.Equal(3, ml.Len())
+
+		// each removal only removes one
+		suite.True(ml.TryRemove(l))
+		suite.Equal(2, ml.Len())
+		suite.True(ml.Contains(l))
+	})
+
+	suite.Run("ZeroValue", func() {
+		var (
+			l  = new(MockLocator[string])
+			ml = new(MultiLocator[string])
+		)
+
+		suite.True(ml.TryAdd(l))
+		suite.False(ml.TryAdd(l))
+		suite.Equal(1, ml.Len())
+	})
+
+	suite.Run("Uncomparable", func() {
+		var (
+			l  = uncomparableLocator[string]{find: func([]byte) (string, error) { return "service1", nil }}
+			ml = NewMultiLocator[string](l, l)
+		)
+
+		// locators that cannot be compared are always distinct
+		suite.Equal(2, ml.Len())
+		suite.True(ml.TryAdd(l))
+		suite.False(ml.Contains(l))
+		suite.False(ml.TryRemove(l))
+		suite.Equal(3, ml.Len())
+	})
+}
+
+func (suite *LocatorSuite) TestMultiLocatorMaxSize() {
+	var (
+		l1, l2, l3 = new(MockLocator[string]), new(MockLocator[string]), new(MockLocator[string])
+		ml         = NewMultiLocatorWithLimits[string](DedupeDuplicates, 2)
+	)
+
+	suite.NoError(ml.AddE(l1))
+	suite.True(ml.TryAdd(l2))
+
+	err := ml.AddE(l3)
+	suite.ErrorIs(err, ErrTooManyLocators)
+	suite.False(ml.TryAdd(l3))
+	suite.False(ml.Contains(l3))
+	suite.Equal(2, ml.Len())
+
+	// a duplicate is not a violation of the limit
+	suite.NoError(ml.AddE(l1))
+
+	// removing a locator makes room
+	suite.True(ml.TryRemove(l1))
+	suite.False(ml.TryRemove(l1))
+	suite.True(ml.TryAdd(l3))
+	suite.True(ml.Contains(l3))
+}
+
+func (suite *LocatorSuite) TestMultiLocatorRemove() {
+	var (
+		l1, l2 = new(MockLocator[string]), new(MockLocator[string])
+		ml     = NewMultiLocator[string](l1)
+	)
+
+	suite.True(ml.Contains(l1))
+	suite.False(ml.Contains(l2))
+	suite.False(ml.TryRemove(l2))
+	suite.True(ml.TryRemove(l1))
+	suite.False(ml.Contains(l1))
+	suite.Zero(ml.Len())
+}
+
+func (suite *LocatorSuite) TestMultiLocatorConcurrentMembership() {
+	const goroutines = 8
+
+	var (
+		ml = NewMultiLocatorWithLimits[string](RejectDuplicates, 0)
+		ls = make([]*MockLocator[string], goroutines)
+		wg sync.WaitGroup
+	)
+
+	for i := range ls {
+		ls[i] = new(MockLocator[string])
+	}
+
+	for _, l := range ls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if ml.TryAdd(l) {
+					suite.True(ml.Contains(l))
+					suite.True(ml.TryRemove(l))
+				}
+
+				suite.LessOrEqual(ml.Len(), goroutines)
+			}
+
+			ml.Add(l)
+		}()
+	}
+
+	wg.Wait()
+	suite.Equal(goroutines, ml.Len())
+	for _, l := range ls {
+		suite.True(ml.Contains(l))
+	}
+}
+
+func (suite *LocatorSuite) TestMultiLocatorAddCompatibility() {
+	type membership interface {
+		Add(Locator[string])
+		Remove(Locator[string])
+	}
+
+	var (
+		ml = new(MultiLocator[string])
+		l  = new(MockLocator[string])
+
+		// Add and Remove keep their original signatures, so method values and
+		// interfaces written against them still compile
+		add    func(Locator[string]) = ml.Add
+		remove func(Locator[string]) = ml.Remove
+		m      membership            = ml
+	)
+
+	add(l)
+	m.Add(l)
+	suite.Equal(1, ml.Len())
+
+	remove(l)
+	suite.Zero(ml.Len())
+
+	m.Add(l)
+	m.Remove(l)
+	suite.Zero(ml.Len())
+
+	var _ membership = new(ParallelMultiLocator[string])
+}
+
+func (suite *LocatorSuite) TestNoCopy() {
+	var _ sync.Locker = (*noCopy)(nil)
+
+	for _, v := range []any{MultiLocator[string]{}, UpdatableLocator[string]{}} {
+		f, ok := reflect.TypeOf(v).FieldByName("noCopy")
+		suite.Require().True(ok)
+		suite.Equal(reflect.TypeOf(noCopy{}), f.Type)
+	}
+}
+
+func (suite *LocatorSuite) testMultiLocatorCopied(ml *MultiLocator[string]) {
+	l := new(MockLocator[string])
+	ml.Add(l)
+
+	copied := copyOf(ml)
+	suite.PanicsWithValue("medley: illegal use of a MultiLocator that was copied after first use", func() {
+		copied.Add(l)
+	})
+
+	suite.Panics(func() {
+		copied.Remove(l)
+	})
+
+	// the original is still usable
+	suite.NotPanics(func() {
+		ml.Remove(l)
+	})
+}
+
+func (suite *LocatorSuite) TestMultiLocatorCopied() {
+	suite.Run("New", func() {
+		suite.testMultiLocatorCopied(NewMultiLocator[string]())
+	})
+
+	suite.Run("ZeroValue", func() {
+		suite.testMultiLocatorCopied(new(MultiLocator[string]))
+	})
+
+	suite.Run("Parallel", func() {
+		suite.testMultiLocatorCopied(&NewParallelMultiLocator[string](0, 0).MultiLocator)
+	})
+
+	suite.Run("CopiedBeforeUse", func() {
+		copied := copyOf(new(MultiLocator[string]))
+		suite.NotPanics(func() {
+			copied.Add(new(MockLocator[string]))
+		})
+	})
+}
+
+func (suite *LocatorSuite) testUpdatableLocatorCopied(ul *UpdatableLocator[string]) {
+	ul.Set(nil)
+
+	copied := copyOf(ul)
+	suite.PanicsWithValue("medley: illegal use of an UpdatableLocator that was copied after first use", func() {
+		copied.Set(nil)
+	})
+
+	// the original is still usable
+	suite.NotPanics(func() {
+		ul.Set(new(MockLocator[string]))
+	})
+}
+
+func (suite *LocatorSuite) TestUpdatableLocatorCopied() {
+	suite.Run("New", func() {
+		suite.testUpdatableLocatorCopied(NewUpdatableLocator[string](nil))
+	})
+
+	suite.Run("ZeroValue", func() {
+		suite.testUpdatableLocatorCopied(new(UpdatableLocator[string]))
+	})
+}
+
 func TestLocator(t *testing.T) {
 	suite.Run(t, new(LocatorSuite))
 }
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+// mapLocator is the Locator returned by MapLocator.
+type mapLocator[From, To Service] struct {
+	next Locator[From]
+	f    func(From) To
+}
+
+func (ml *mapLocator[From, To]) Find(object []byte) (result To, err error) {
+	var svc From
+	if svc, err = ml.next.Find(object); err == nil {
+		result = ml.f(svc)
+	}
+
+	return
+}
+
+// MapLocator adapts a Locator of one service type into a Locator of another service type.
+// This allows locators with different service types to be combined, e.g. in a MultiLocator.
+//
+// The conversion function is only called for successful lookups. Errors from the given
+// Locator are returned as is.
+func MapLocator[From, To Service](l Locator[From], f func(From) To) Locator[To] {
+	return &mapLocator[From, To]{
+		next: l,
+		f:    f,
+	}
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// endpoint is a common service type that other service types are mapped to.
+type endpoint struct {
+	host string
+}
+
+type MapLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+
+	// conversions counts the calls to the conversion functions
+	conversions int
+}
+
+func (suite *MapLocatorSuite) SetupTest() {
+	suite.object = []byte("test value")
+	suite.conversions = 0
+}
+
+func (suite *MapLocatorSuite) SetupSubTest() {
+	suite.SetupTest()
+}
+
+func (suite *MapLocatorSuite) fromString(v string) endpoint {
+	suite.conversions++
+	return endpoint{host: v}
+}
+
+func (suite *MapLocatorSuite) fromBasicService(v BasicService) endpoint {
+	suite.conversions++
+	return endpoint{host: v.Host}
+}
+
+func (suite *MapLocatorSuite) TestSuccess() {
+	var (
+		l  = new(MockLocator[string])
+		ml = MapLocator(l, suite.fromString)
+	)
+
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	result, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal(endpoint{host: "service1"}, result)
+	suite.Equal(1, suite.conversions)
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *MapLocatorSuite) TestError() {
+	var (
+		expectedErr = errors.New("expected")
+		l           = new(MockLocator[string])
+		ml          = MapLocator(l, suite.fromString)
+	)
+
+	l.ExpectFindFail(suite.object, expectedErr).Once()
+
+	result, err := ml.Find(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Zero(result)
+	suite.Zero(suite.conversions)
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *MapLocatorSuite) testMultiLocatorAll() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[BasicService])
+		ml = NewMultiLocator(
+			MapLocator(l1, suite.fromString),
+			MapLocator(l2, suite.fromBasicService),
+		)
+	)
+
+	l1.ExpectFindSuccess(suite.object, "service1").Once()
+	l2.ExpectFindSuccess(suite.object, BasicService{Scheme: "https", Host: "service2"}).Once()
+
+	results, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.ElementsMatch([]endpoint{{host: "service1"}, {host: "service2"}}, results)
+	suite.Equal(2, suite.conversions)
+
+	mock.AssertExpectationsForObjects(suite.T(), l1, l2)
+}
+
+func (suite *MapLocatorSuite) testMultiLocatorNoServices() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[BasicService])
+		ml = NewMultiLocator(
+			MapLocator(l1, suite.fromString),
+			MapLocator(l2, suite.fromBasicService),
+		)
+	)
+
+	l1.ExpectFindNoServices(suite.object).Once()
+	l2.ExpectFindNoServices(suite.object).Once()
+
+	results, err := ml.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+	suite.Zero(suite.conversions)
+
+	mock.AssertExpectationsForObjects(suite.T(), l1, l2)
+}
+
+func (suite *MapLocatorSuite) testMultiLocatorSameType() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[string])
+		ml = NewMultiLocator(
+			MapLocator(l1, suite.fromString),
+			MapLocator(l2, suite.fromString),
+		)
+	)
+
+	suite.Equal(2, ml.Len())
+
+	l1.ExpectFindSuccess(suite.object, "service1").Once()
+	l2.ExpectFindSuccess(suite.object, "service2").Once()
+
+	results, err := ml.Find(suite.object)
+	suite.NoError(err)
+	suite.ElementsMatch([]endpoint{{host: "service1"}, {host: "service2"}}, results)
+	suite.Equal(2, suite.conversions)
+
+	mock.AssertExpectationsForObjects(suite.T(), l1, l2)
+}
+
+func (suite *MapLocatorSuite) TestMultiLocator() {
+	suite.Run("All", suite.testMultiLocatorAll)
+	suite.Run("NoServices", suite.testMultiLocatorNoServices)
+	suite.Run("SameType", suite.testMultiLocatorSameType)
+}
+
+func TestMapLocator(t *testing.T) {
+	suite.Run(t, new(MapLocatorSuite))
+}
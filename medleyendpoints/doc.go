@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package medleyendpoints adapts endpoint lists, such as those from Kubernetes EndpointSlices,
+into medley services. This package doesn't depend on any Kubernetes libraries. Callers
+convert their endpoints into this package's neutral Endpoint type.
+*/
+package medleyendpoints
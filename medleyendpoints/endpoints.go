@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleyendpoints
+
+import (
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+// Endpoint is a single network endpoint of a service, in the shape of an endpoint in a
+// Kubernetes EndpointSlice.
+type Endpoint struct {
+	// Address is the endpoint's IPv4 or IPv6 address, or its host name. IPv6 addresses
+	// are not bracketed.
+	Address string
+
+	// Port is the port the endpoint listens on.
+	Port int
+
+	// Ready indicates whether the endpoint can receive traffic.
+	Ready bool
+
+	// Zone is the topology zone of the endpoint, if known.
+	Zone string
+}
+
+// options is the configuration assembled from a set of Options.
+type options struct {
+	includeNotReady bool
+	scheme          string
+	path            string
+}
+
+// Option is a configurable option for converting endpoints into services.
+type Option func(*options)
+
+// IncludeNotReady converts endpoints regardless of readiness. By default, endpoints that
+// are not ready are skipped.
+func IncludeNotReady() Option {
+	return func(o *options) {
+		o.includeNotReady = true
+	}
+}
+
+// WithScheme sets the Scheme of each converted service, e.g. https. By default, the
+// Scheme is empty.
+func WithScheme(scheme string) Option {
+	return func(o *options) {
+		o.scheme = scheme
+	}
+}
+
+// WithPath sets the Path of each converted service. By default, the Path is empty.
+func WithPath(path string) Option {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+// newOptions applies the given Options to the defaults.
+func newOptions(opts []Option) (o options) {
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return
+}
+
+// service converts an endpoint, returning false if the endpoint is skipped.
+func (o options) service(e Endpoint) (medley.BasicService, bool) {
+	return medley.BasicService{
+		Scheme: o.scheme,
+		Host:   e.Address,
+		Port:   e.Port,
+		Path:   o.path,
+	}, e.Ready || o.includeNotReady
+}
+
+// BasicServicesFromEndpoints converts endpoints into services. Each endpoint's Address becomes
+// the service's Host, and its Port becomes the service's Port. Endpoints that are not ready are
+// skipped unless IncludeNotReady is given. Duplicate services, e.g. from endpoints that appear
+// in more than one EndpointSlice, are only returned once, in the order of their first endpoint.
+//
+// medley.BasicService has no zone, so zones are not part of the returned services. Use Zones
+// to look up the zone of each service.
+func BasicServicesFromEndpoints(endpoints []Endpoint, opts ...Option) []medley.BasicService {
+	var (
+		o        = newOptions(opts)
+		seen     = make(medley.Map[medley.BasicService, bool], len(endpoints))
+		services = make([]medley.BasicService, 0, len(endpoints))
+	)
+
+	for _, e := range endpoints {
+		if svc, ok := o.service(e); ok && !seen[svc] {
+			seen[svc] = true
+			services = append(services, svc)
+		}
+	}
+
+	return services
+}
+
+// Zones returns the zone of each service that BasicServicesFromEndpoints would return for the
+// same endpoints and options. Services whose endpoints have no zone are not in the returned map.
+// If duplicate endpoints have different zones, the first nonempty zone is used.
+func Zones(endpoints []Endpoint, opts ...Option) medley.Map[medley.BasicService, string] {
+	var (
+		o     = newOptions(opts)
+		zones = make(medley.Map[medley.BasicService, string])
+	)
+
+	for _, e := range endpoints {
+		if svc, ok := o.service(e); ok && len(e.Zone) > 0 {
+			if _, exists := zones[svc]; !exists {
+				zones[svc] = e.Zone
+			}
+		}
+	}
+
+	return zones
+}
+
+// Reconcile updates the Ring published by a GuardedUpdater to contain exactly the services for
+// the given endpoints, as converted by BasicServicesFromEndpoints. This method returns the number
+// of services that were added to and removed from the Ring. If the updater's guard rejects the
+// update, nothing changes and the error wraps consistent.ErrUpdateRejected.
+//
+// The counts are computed from the updater's Ring just before the update, so concurrent calls
+// to Reconcile for the same updater may report inexact counts.
+func Reconcile(gu *consistent.GuardedUpdater[medley.BasicService], endpoints []Endpoint, opts ...Option) (added, removed int, err error) {
+	var (
+		services = BasicServicesFromEndpoints(endpoints, opts...)
+		current  = gu.Ring()
+		next     = make(medley.Map[medley.BasicService, bool], len(services))
+	)
+
+	for _, svc := range services {
+		next[svc] = true
+		if !current.Contains(svc) {
+			added++
+		}
+	}
+
+	for svc := range current.All() {
+		if !next[svc] {
+			removed++
+		}
+	}
+
+	var updated bool
+	if updated, err = gu.Set(services...); !updated {
+		added, removed = 0, 0
+	}
+
+	return
+}
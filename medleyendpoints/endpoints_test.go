@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleyendpoints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+type EndpointsSuite struct {
+	suite.Suite
+}
+
+func (suite *EndpointsSuite) TestReadyFiltering() {
+	endpoints := []Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 8080, Ready: false},
+		{Address: "10.0.0.3", Port: 8080, Ready: true},
+	}
+
+	suite.Equal(
+		[]medley.BasicService{
+			{Host: "10.0.0.1", Port: 8080},
+			{Host: "10.0.0.3", Port: 8080},
+		},
+		BasicServicesFromEndpoints(endpoints),
+	)
+
+	suite.Equal(
+		[]medley.BasicService{
+			{Host: "10.0.0.1", Port: 8080},
+			{Host: "10.0.0.2", Port: 8080},
+			{Host: "10.0.0.3", Port: 8080},
+		},
+		BasicServicesFromEndpoints(endpoints, IncludeNotReady()),
+	)
+
+	suite.Empty(BasicServicesFromEndpoints(nil))
+}
+
+func (suite *EndpointsSuite) TestIPv6() {
+	services := BasicServicesFromEndpoints(
+		[]Endpoint{
+			{Address: "fd00::1", Port: 443, Ready: true},
+			{Address: "::1", Port: 8443, Ready: true},
+		},
+		WithScheme("https"),
+		WithPath("/api"),
+	)
+
+	suite.Equal(
+		[]medley.BasicService{
+			{Scheme: "https", Host: "fd00::1", Port: 443, Path: "/api"},
+			{Scheme: "https", Host: "::1", Port: 8443, Path: "/api"},
+		},
+		services,
+	)
+}
+
+func (suite *EndpointsSuite) TestDuplicates() {
+	// the same endpoint can appear in more than one EndpointSlice
+	services := BasicServicesFromEndpoints([]Endpoint{
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+		{Address: "10.0.0.1", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 9090, Ready: true},
+	})
+
+	suite.Equal(
+		[]medley.BasicService{
+			{Host: "10.0.0.2", Port: 8080},
+			{Host: "10.0.0.1", Port: 8080},
+			{Host: "10.0.0.2", Port: 9090},
+		},
+		services,
+	)
+}
+
+func (suite *EndpointsSuite) TestZones() {
+	endpoints := []Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Ready: true, Zone: "us-east-1a"},
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 8080, Ready: true, Zone: "us-east-1b"},
+		{Address: "10.0.0.2", Port: 8080, Ready: true, Zone: "us-east-1c"},
+		{Address: "10.0.0.3", Port: 8080, Ready: false, Zone: "us-east-1c"},
+	}
+
+	suite.Equal(
+		medley.Map[medley.BasicService, string]{
+			{Host: "10.0.0.1", Port: 8080}: "us-east-1a",
+			{Host: "10.0.0.2", Port: 8080}: "us-east-1b",
+		},
+		Zones(endpoints),
+	)
+
+	suite.Equal(
+		medley.Map[medley.BasicService, string]{
+			{Host: "10.0.0.1", Port: 8080}: "us-east-1a",
+			{Host: "10.0.0.2", Port: 8080}: "us-east-1b",
+			{Host: "10.0.0.3", Port: 8080}: "us-east-1c",
+		},
+		Zones(endpoints, IncludeNotReady()),
+	)
+}
+
+func (suite *EndpointsSuite) TestReconcile() {
+	guard, err := consistent.NewUpdateGuard(consistent.MinServices(1))
+	suite.Require().NoError(err)
+
+	var (
+		ul = medley.NewUpdatableLocator[medley.BasicService](nil)
+		gu = consistent.NewGuardedUpdater(ul, consistent.BasicServices().Build(), guard)
+	)
+
+	added, removed, err := Reconcile(gu, []Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+		{Address: "10.0.0.3", Port: 8080, Ready: false},
+	})
+
+	suite.NoError(err)
+	suite.Equal(2, added)
+	suite.Zero(removed)
+	suite.Equal(2, gu.Ring().Len())
+
+	svc, err := medley.FindString[medley.BasicService](ul, "test")
+	suite.NoError(err)
+	suite.Contains([]string{"10.0.0.1", "10.0.0.2"}, svc.Host)
+
+	// one endpoint becomes ready, another goes away
+	added, removed, err = Reconcile(gu, []Endpoint{
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+		{Address: "10.0.0.3", Port: 8080, Ready: true},
+	})
+
+	suite.NoError(err)
+	suite.Equal(1, added)
+	suite.Equal(1, removed)
+	suite.True(gu.Ring().Contains(medley.BasicService{Host: "10.0.0.3", Port: 8080}))
+	suite.False(gu.Ring().Contains(medley.BasicService{Host: "10.0.0.1", Port: 8080}))
+
+	// unchanged endpoints are not an update
+	current := gu.Ring()
+	added, removed, err = Reconcile(gu, []Endpoint{
+		{Address: "10.0.0.3", Port: 8080, Ready: true},
+		{Address: "10.0.0.2", Port: 8080, Ready: true},
+	})
+
+	suite.NoError(err)
+	suite.Zero(added)
+	suite.Zero(removed)
+	suite.Same(current, gu.Ring())
+
+	// the guard rejects losing every endpoint
+	added, removed, err = Reconcile(gu, []Endpoint{
+		{Address: "10.0.0.2", Port: 8080, Ready: false},
+		{Address: "10.0.0.3", Port: 8080, Ready: false},
+	})
+
+	suite.ErrorIs(err, consistent.ErrUpdateRejected)
+	suite.Zero(added)
+	suite.Zero(removed)
+	suite.Same(current, gu.Ring())
+}
+
+func TestEndpoints(t *testing.T) {
+	suite.Run(t, new(EndpointsSuite))
+}
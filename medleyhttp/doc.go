@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package medleyhttp provides read-only HTTP handlers for inspecting medley locators,
+such as consistent hash rings, in running services.
+*/
+package medleyhttp
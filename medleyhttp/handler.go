@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleyhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/xmidt-org/medley"
+)
+
+// Inspectable is the minimal read-only behavior required to inspect a locator.
+// *consistent.Ring implements this interface.
+type Inspectable[S medley.Service] interface {
+	medley.Locator[S]
+
+	// Len returns the number of services.
+	Len() int
+
+	// VNodes returns the number of vnodes per service.
+	VNodes() int
+
+	// Ownership returns the fraction of keys owned by each service.
+	Ownership() medley.Map[S, float64]
+}
+
+// Labeler produces the string form of a service for responses.
+type Labeler[S medley.Service] func(S) string
+
+// DefaultLabeler uses fmt.Sprint to produce a service's label.
+func DefaultLabeler[S medley.Service](svc S) string {
+	return fmt.Sprint(svc)
+}
+
+// Summary is the response for GET /.
+type Summary struct {
+	Services int `json:"services"`
+	VNodes   int `json:"vnodes"`
+}
+
+// ServiceOwnership is a single entry in the response for GET /services.
+type ServiceOwnership struct {
+	Service   string  `json:"service"`
+	Ownership float64 `json:"ownership"`
+}
+
+// FindResult is the response for GET /find.
+type FindResult struct {
+	Key     string `json:"key"`
+	Service string `json:"service"`
+}
+
+// Error is the response for any request that fails.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// handler is the http.Handler returned by NewHandler.
+type handler[S medley.Service] struct {
+	target  Inspectable[S]
+	labeler Labeler[S]
+}
+
+// NewHandler returns a read-only http.Handler that describes the given target. If
+// labeler is nil, DefaultLabeler is used. The following endpoints are served:
+//
+//   - GET / returns a Summary.
+//   - GET /services returns a ServiceOwnership for each service, sorted by label.
+//   - GET /find?key=... returns the FindResult for the key, using medley.FindString.
+//
+// To serve these endpoints under some path prefix, use http.StripPrefix.
+func NewHandler[S medley.Service](target Inspectable[S], labeler Labeler[S]) http.Handler {
+	if labeler == nil {
+		labeler = DefaultLabeler[S]
+	}
+
+	h := handler[S]{
+		target:  target,
+		labeler: labeler,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.summary)
+	mux.HandleFunc("GET /services", h.services)
+	mux.HandleFunc("GET /find", h.find)
+	return mux
+}
+
+// writeJSON streams a JSON response.
+func writeJSON(rw http.ResponseWriter, code int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	json.NewEncoder(rw).Encode(v)
+}
+
+func (h handler[S]) summary(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, Summary{
+		Services: h.target.Len(),
+		VNodes:   h.target.VNodes(),
+	})
+}
+
+func (h handler[S]) services(rw http.ResponseWriter, _ *http.Request) {
+	ownership := h.target.Ownership()
+	entries := make([]ServiceOwnership, 0, ownership.Len())
+	for svc, fraction := range ownership {
+		entries = append(entries, ServiceOwnership{
+			Service:   h.labeler(svc),
+			Ownership: fraction,
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b ServiceOwnership) int {
+		return strings.Compare(a.Service, b.Service)
+	})
+
+	writeJSON(rw, http.StatusOK, entries)
+}
+
+func (h handler[S]) find(rw http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if !query.Has("key") {
+		writeJSON(rw, http.StatusBadRequest, Error{Error: "the key parameter is required"})
+		return
+	}
+
+	key := query.Get("key")
+	svc, err := medley.FindString(h.target, key)
+	switch {
+	case errors.Is(err, medley.ErrNoServices):
+		writeJSON(rw, http.StatusNotFound, Error{Error: err.Error()})
+
+	case err != nil:
+		writeJSON(rw, http.StatusInternalServerError, Error{Error: err.Error()})
+
+	default:
+		writeJSON(rw, http.StatusOK, FindResult{
+			Key:     key,
+			Service: h.labeler(svc),
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleyhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+	"github.com/xmidt-org/medley/consistent"
+)
+
+var _ Inspectable[string] = (*consistent.Ring[string])(nil)
+
+// failing is an Inspectable whose lookups always fail.
+type failing struct {
+	err      error
+	services int
+	vnodes   int
+}
+
+func (f failing) Find([]byte) (string, error) {
+	return "", f.err
+}
+
+func (f failing) Len() int {
+	return f.services
+}
+
+func (f failing) VNodes() int {
+	return f.vnodes
+}
+
+func (f failing) Ownership() medley.Map[string, float64] {
+	return nil
+}
+
+type HandlerSuite struct {
+	suite.Suite
+
+	services []string
+	ring     *consistent.Ring[string]
+}
+
+func (suite *HandlerSuite) SetupSuite() {
+	suite.services = []string{"service3.net", "service1.net", "service2.net"}
+	suite.ring = consistent.Strings(suite.services...).Build()
+}
+
+// serve sends a GET request for the given target through the handler and decodes the JSON response.
+func (suite *HandlerSuite) serve(h http.Handler, target string, expectedCode int, v any) {
+	var (
+		response = httptest.NewRecorder()
+		request  = httptest.NewRequest(http.MethodGet, target, nil)
+	)
+
+	h.ServeHTTP(response, request)
+	suite.Require().Equal(expectedCode, response.Code)
+	if v != nil {
+		suite.Equal("application/json", response.Header().Get("Content-Type"))
+		suite.Require().NoError(json.Unmarshal(response.Body.Bytes(), v))
+	}
+}
+
+func (suite *HandlerSuite) TestSummary() {
+	var summary Summary
+	suite.serve(NewHandler(suite.ring, nil), "/", http.StatusOK, &summary)
+	suite.Equal(Summary{Services: 3, VNodes: suite.ring.VNodes()}, summary)
+	suite.Positive(summary.VNodes)
+
+	// the summary doesn't compute ownership
+	suite.serve(NewHandler[string](failing{services: 5, vnodes: 7}, nil), "/", http.StatusOK, &summary)
+	suite.Equal(Summary{Services: 5, VNodes: 7}, summary)
+}
+
+func (suite *HandlerSuite) TestServices() {
+	var entries []ServiceOwnership
+	suite.serve(NewHandler(suite.ring, nil), "/services", http.StatusOK, &entries)
+	suite.Require().Len(entries, 3)
+
+	total := 0.0
+	for i, expected := range []string{"service1.net", "service2.net", "service3.net"} {
+		suite.Equal(expected, entries[i].Service)
+		suite.Positive(entries[i].Ownership)
+		total += entries[i].Ownership
+	}
+
+	suite.InDelta(1.0, total, 1e-9)
+}
+
+func (suite *HandlerSuite) testFindSuccess() {
+	for _, key := range []string{"simple", "with spaces & symbols?/=", "ünicode"} {
+		expected, err := medley.FindString(suite.ring, key)
+		suite.Require().NoError(err)
+
+		var result FindResult
+		suite.serve(
+			NewHandler(suite.ring, nil),
+			"/find?key="+url.QueryEscape(key),
+			http.StatusOK,
+			&result,
+		)
+
+		suite.Equal(FindResult{Key: key, Service: expected}, result)
+	}
+}
+
+func (suite *HandlerSuite) testFindLabeler() {
+	var (
+		basicRing = consistent.BasicServices(medley.BasicService{Scheme: "https", Host: "service.net"}).Build()
+		labeler   = func(s medley.BasicService) string { return s.Scheme + "://" + s.Host }
+		result    FindResult
+	)
+
+	suite.serve(NewHandler(basicRing, labeler), "/find?key=test", http.StatusOK, &result)
+	suite.Equal(FindResult{Key: "test", Service: "https://service.net"}, result)
+}
+
+func (suite *HandlerSuite) testFindMissingKey() {
+	var e Error
+	suite.serve(NewHandler(suite.ring, nil), "/find", http.StatusBadRequest, &e)
+	suite.NotEmpty(e.Error)
+}
+
+func (suite *HandlerSuite) testFindNoServices() {
+	var e Error
+	empty, _ := consistent.Update(suite.ring)
+	suite.serve(NewHandler(empty, nil), "/find?key=test", http.StatusNotFound, &e)
+	suite.Equal(medley.ErrNoServices.Error(), e.Error)
+}
+
+func (suite *HandlerSuite) testFindError() {
+	var e Error
+	suite.serve(
+		NewHandler[string](failing{err: errors.New("expected")}, nil),
+		"/find?key=test",
+		http.StatusInternalServerError,
+		&e,
+	)
+
+	suite.Equal("expected", e.Error)
+}
+
+func (suite *HandlerSuite) TestFind() {
+	suite.Run("Success", suite.testFindSuccess)
+	suite.Run("Labeler", suite.testFindLabeler)
+	suite.Run("MissingKey", suite.testFindMissingKey)
+	suite.Run("NoServices", suite.testFindNoServices)
+	suite.Run("Error", suite.testFindError)
+}
+
+func (suite *HandlerSuite) TestNotFound() {
+	suite.serve(NewHandler(suite.ring, nil), "/nosuch", http.StatusNotFound, nil)
+}
+
+func (suite *HandlerSuite) TestNoMutation() {
+	var (
+		response = httptest.NewRecorder()
+		request  = httptest.NewRequest(http.MethodPost, "/services", nil)
+	)
+
+	NewHandler(suite.ring, nil).ServeHTTP(response, request)
+	suite.Equal(http.StatusMethodNotAllowed, response.Code)
+}
+
+func TestHandler(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/xmidt-org/medley"
+)
+
+// BenchmarkAlgorithms runs a sub-benchmark for each algorithm and key size, measuring both
+// Algorithm.Sum64Bytes and the path of creating a hash with New64, writing the key, and
+// calling Sum64. Allocations are reported. If keySizes is empty, medley.DefaultKeySizes
+// is used.
+//
+// Sub-benchmarks are named algorithm/size/path, so a single path can be selected with
+// the -bench flag, e.g. -bench 'Algorithms/murmur3/.*/Sum64'.
+func BenchmarkAlgorithms(b *testing.B, algs map[string]medley.Algorithm, keySizes []int) {
+	if len(keySizes) == 0 {
+		keySizes = medley.DefaultKeySizes
+	}
+
+	names := make([]string, 0, len(algs))
+	for name := range algs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	for _, name := range names {
+		alg := algs[name]
+		for _, size := range keySizes {
+			key := make([]byte, size)
+			for i := range key {
+				key[i] = byte(i)
+			}
+
+			b.Run(fmt.Sprintf("%s/%d/Sum64", name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(size))
+				for range b.N {
+					alg.Sum64Bytes(key)
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/%d/Write", name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(size))
+				for range b.N {
+					h := alg.New64()
+					h.Write(key)
+					h.Sum64()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"testing"
+
+	"github.com/xmidt-org/medley"
+)
+
+func BenchmarkBuiltinAlgorithms(b *testing.B) {
+	algs := make(map[string]medley.Algorithm)
+	for _, name := range medley.AlgorithmNames() {
+		algs[name], _ = medley.FindAlgorithm(name)
+	}
+
+	BenchmarkAlgorithms(b, algs, nil)
+}
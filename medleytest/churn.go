@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultChurnRounds is the number of rounds Churn runs when none is configured.
+	DefaultChurnRounds = 20
+
+	// DefaultChurnServices is the initial number of services Churn uses when none is configured.
+	DefaultChurnServices = 10
+)
+
+// ChurnConfig configures the Churn harness.
+type ChurnConfig struct {
+	// Seed is the random seed for membership changes. The same seed always produces
+	// the same sequence of changes. Every failure reports this seed.
+	Seed int64
+
+	// Rounds is the number of membership changes. If unset, DefaultChurnRounds is used.
+	Rounds int
+
+	// InitialServices is the number of services in the first locator. If unset,
+	// DefaultChurnServices is used.
+	InitialServices int
+
+	// Add, Remove, and Replace are the relative weights of each kind of membership change.
+	// A replace removes one service and adds another in the same round. If all
+	// weights are zero, each kind of change is equally likely.
+	Add, Remove, Replace float64
+
+	// Keys are the objects looked up after each round. This field is required.
+	Keys [][]byte
+
+	// MaxMovement is the largest fraction of Keys that may change owners when a single
+	// service is added or removed. A replace is allowed twice this fraction. If unset,
+	// movement is not bounded, although keys must still only move to added services
+	// or away from removed services.
+	MaxMovement float64
+
+	// Build creates the initial locator. This field is required.
+	Build func(services []string) medley.Locator[string]
+
+	// Update produces the locator for the next round. This field is required.
+	Update func(current medley.Locator[string], services []string) medley.Locator[string]
+
+	// Reference produces a locator that the locator from Build or Update must agree with
+	// on every key. This field is optional.
+	Reference func(services []string) medley.Locator[string]
+}
+
+// churn holds the state of a single Churn run.
+type churn struct {
+	t      testing.TB
+	cfg    ChurnConfig
+	random *rand.Rand
+	next   int
+}
+
+// newService creates a service name that has never been used in this run.
+func (c *churn) newService() string {
+	c.next++
+	return fmt.Sprintf("churn-%d.example.net", c.next)
+}
+
+// owners looks up every key, returning false if any lookup failed.
+func (c *churn) owners(round int, l medley.Locator[string]) ([]string, bool) {
+	owners := make([]string, len(c.cfg.Keys))
+	for i, key := range c.cfg.Keys {
+		var err error
+		if owners[i], err = l.Find(key); err != nil {
+			c.t.Errorf("seed %d, round %d: lookup of key %d failed: %s", c.cfg.Seed, round, i, err)
+			return nil, false
+		}
+	}
+
+	return owners, true
+}
+
+// matchesReference checks that owners agree with the reference locator, if one was configured.
+func (c *churn) matchesReference(round int, services []string, owners []string) bool {
+	if c.cfg.Reference == nil {
+		return true
+	}
+
+	reference := c.cfg.Reference(services)
+	for i, key := range c.cfg.Keys {
+		expected, err := reference.Find(key)
+		if err != nil || expected != owners[i] {
+			c.t.Errorf("seed %d, round %d: key %d mapped to %q, but the reference mapped it to %q (%v)",
+				c.cfg.Seed, round, i, owners[i], expected, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// mutate applies a random membership change, returning the new services and
+// which services were added and removed.
+func (c *churn) mutate(services []string) (next []string, added, removed string) {
+	add, remove, replace := c.cfg.Add, c.cfg.Remove, c.cfg.Replace
+	if add+remove+replace <= 0 {
+		add, remove, replace = 1, 1, 1
+	}
+
+	// never remove the last service, since there would be no owners left
+	if len(services) < 2 {
+		remove, replace = 0, 0
+		if add <= 0 {
+			add = 1
+		}
+	}
+
+	next = slices.Clone(services)
+	choice := c.random.Float64() * (add + remove + replace)
+	if choice >= add {
+		i := c.random.Intn(len(next))
+		removed = next[i]
+		next = slices.Delete(next, i, i+1)
+	}
+
+	if choice < add || choice >= add+remove {
+		added = c.newService()
+		next = append(next, added)
+	}
+
+	return
+}
+
+// checkMovement verifies that keys only moved as the membership change allows.
+func (c *churn) checkMovement(round int, before, after []string, added, removed string) bool {
+	moved := 0
+	for i := range before {
+		if before[i] == after[i] {
+			continue
+		}
+
+		moved++
+		if (len(added) == 0 || after[i] != added) && (len(removed) == 0 || before[i] != removed) {
+			c.t.Errorf("seed %d, round %d: key %d moved from %q to %q, but only %q was added and %q was removed",
+				c.cfg.Seed, round, i, before[i], after[i], added, removed)
+			return false
+		}
+	}
+
+	if c.cfg.MaxMovement > 0 {
+		limit := c.cfg.MaxMovement
+		if len(added) > 0 && len(removed) > 0 {
+			limit *= 2
+		}
+
+		if fraction := float64(moved) / float64(len(before)); fraction > limit {
+			c.t.Errorf("seed %d, round %d: %.2f%% of keys moved, exceeding the limit of %.2f%%",
+				c.cfg.Seed, round, fraction*100, limit*100)
+			return false
+		}
+	}
+
+	return true
+}
+
+// Churn runs randomized membership changes against a locator, verifying after each round
+// that keys only moved to added services or away from removed services, that the fraction of
+// moved keys stays within limits, and optionally that the locator agrees with a reference.
+//
+// The harness stops at the first failure, which is reported through t along with the seed
+// needed to reproduce it.
+func Churn(t testing.TB, cfg ChurnConfig) {
+	t.Helper()
+	if cfg.Rounds < 1 {
+		cfg.Rounds = DefaultChurnRounds
+	}
+
+	if cfg.InitialServices < 1 {
+		cfg.InitialServices = DefaultChurnServices
+	}
+
+	c := &churn{
+		t:      t,
+		cfg:    cfg,
+		random: rand.New(rand.NewSource(cfg.Seed)),
+	}
+
+	services := make([]string, 0, cfg.InitialServices)
+	for range cfg.InitialServices {
+		services = append(services, c.newService())
+	}
+
+	current := cfg.Build(slices.Clone(services))
+	before, ok := c.owners(0, current)
+	if !ok || !c.matchesReference(0, services, before) {
+		return
+	}
+
+	for round := 1; round <= cfg.Rounds; round++ {
+		var added, removed string
+		services, added, removed = c.mutate(services)
+		current = cfg.Update(current, slices.Clone(services))
+
+		after, ok := c.owners(round, current)
+		if !ok || !c.matchesReference(round, services, after) || !c.checkMovement(round, before, after, added, removed) {
+			return
+		}
+
+		before = after
+	}
+}
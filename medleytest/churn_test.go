@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// recorder is a testing.TB that records errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// moduloLocator is a deliberately poor Locator that assigns keys by modulo hashing,
+// which moves most keys whenever membership changes.
+type moduloLocator struct {
+	services []string
+}
+
+func (ml moduloLocator) Find(object []byte) (string, error) {
+	if len(ml.services) == 0 {
+		return "", medley.ErrNoServices
+	}
+
+	return ml.services[medley.DefaultAlgorithm().Sum64Bytes(object)%uint64(len(ml.services))], nil
+}
+
+func newModuloLocator(services []string) medley.Locator[string] {
+	sorted := slices.Clone(services)
+	slices.Sort(sorted)
+	return moduloLocator{services: sorted}
+}
+
+func newReferenceLocator(alg medley.Algorithm) func([]string) medley.Locator[string] {
+	return func(services []string) medley.Locator[string] {
+		return ReferenceLocator[string]{
+			Algorithm: alg,
+			Nodes:     ReferenceNodes(alg, 100, medley.HashStringTo[string], services...),
+		}
+	}
+}
+
+type ChurnSuite struct {
+	suite.Suite
+
+	keys [][]byte
+}
+
+func (suite *ChurnSuite) SetupSuite() {
+	for i := range 500 {
+		suite.keys = append(suite.keys, []byte(fmt.Sprintf("key-%d", i)))
+	}
+}
+
+// config returns a ChurnConfig that uses the given function for both Build and Update.
+func (suite *ChurnSuite) config(seed int64, build func([]string) medley.Locator[string]) ChurnConfig {
+	return ChurnConfig{
+		Seed:        seed,
+		Keys:        suite.keys,
+		MaxMovement: 0.35,
+		Build:       build,
+		Update: func(_ medley.Locator[string], services []string) medley.Locator[string] {
+			return build(services)
+		},
+	}
+}
+
+func (suite *ChurnSuite) TestSuccess() {
+	var (
+		r   = &recorder{TB: suite.T()}
+		cfg = suite.config(123, newReferenceLocator(medley.DefaultAlgorithm()))
+	)
+
+	cfg.Reference = newReferenceLocator(medley.DefaultAlgorithm())
+	Churn(r, cfg)
+	suite.Empty(r.errors)
+}
+
+func (suite *ChurnSuite) TestDeterministic() {
+	var (
+		runs = make([][][]string, 2)
+		r    = &recorder{TB: suite.T()}
+	)
+
+	for i := range runs {
+		cfg := suite.config(456, func(services []string) medley.Locator[string] {
+			runs[i] = append(runs[i], services)
+			return newReferenceLocator(medley.DefaultAlgorithm())(services)
+		})
+
+		// with so few services, a single change can move most keys
+		cfg.MaxMovement = 0
+		cfg.Rounds = 10
+		cfg.InitialServices = 3
+		cfg.Add, cfg.Remove, cfg.Replace = 1, 2, 3
+		Churn(r, cfg)
+	}
+
+	suite.Empty(r.errors)
+	suite.Len(runs[0], 11)
+	suite.Equal(runs[0], runs[1])
+	suite.Len(runs[0][0], 3)
+}
+
+func (suite *ChurnSuite) TestMovementFailure() {
+	r := &recorder{TB: suite.T()}
+	Churn(r, suite.config(789, newModuloLocator))
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "seed 789")
+	suite.Contains(r.errors[0], "moved")
+}
+
+func (suite *ChurnSuite) TestMaxMovementFailure() {
+	var (
+		r   = &recorder{TB: suite.T()}
+		cfg = suite.config(789, newReferenceLocator(medley.DefaultAlgorithm()))
+	)
+
+	cfg.MaxMovement = 0.0001
+	Churn(r, cfg)
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "seed 789")
+	suite.Contains(r.errors[0], "exceeding the limit")
+}
+
+func (suite *ChurnSuite) TestReferenceFailure() {
+	var (
+		r   = &recorder{TB: suite.T()}
+		cfg = suite.config(321, newReferenceLocator(medley.DefaultAlgorithm()))
+	)
+
+	cfg.Reference = newReferenceLocator(medley.Algorithm{New64: fnv.New64})
+	Churn(r, cfg)
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "seed 321")
+	suite.Contains(r.errors[0], "reference")
+}
+
+func (suite *ChurnSuite) TestLookupFailure() {
+	r := &recorder{TB: suite.T()}
+	Churn(r, suite.config(654, func([]string) medley.Locator[string] {
+		return moduloLocator{}
+	}))
+
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "seed 654")
+	suite.Contains(r.errors[0], medley.ErrNoServices.Error())
+}
+
+func TestChurn(t *testing.T) {
+	suite.Run(t, new(ChurnSuite))
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// updateVectors is the environment variable that causes the compatibility vectors
+// to be rewritten rather than compared.
+const updateVectors = "MEDLEY_UPDATE_VECTORS"
+
+// vectorsFile is the location of the embedded compatibility vectors.
+const vectorsFile = "../compatibility.json"
+
+// generateVectors computes the compatibility vectors with ReferenceLocator.
+func generateVectors() medley.Vectors {
+	var (
+		v = medley.Vectors{
+			Version: medley.CompatibilityVersion,
+		}
+
+		serviceSets = [][]string{
+			{"only.example.com"},
+			{"a.example.com", "b.example.com", "c.example.com"},
+			{
+				"service-0.example.net:8080", "service-1.example.net:8080", "service-2.example.net:8080",
+				"service-3.example.net:8080", "service-4.example.net:8080", "service-5.example.net:8080",
+				"service-6.example.net:8080", "service-7.example.net:8080", "service-8.example.net:8080",
+				"service-9.example.net:8080",
+			},
+		}
+
+		keys = []string{"", "a", "mac:112233445566", "device/ÄÖÜ/日本", "the quick brown fox"}
+	)
+
+	for i := range 15 {
+		keys = append(keys, fmt.Sprintf("key-%d", i))
+	}
+
+	for _, name := range medley.AlgorithmNames() {
+		alg, _ := medley.FindAlgorithm(name)
+		for _, vnodes := range []int{1, 10, 200} {
+			for _, services := range serviceSets {
+				c := medley.VectorCase{
+					Algorithm: name,
+					VNodes:    vnodes,
+					Services:  services,
+				}
+
+				rl := ReferenceLocator[string]{
+					Algorithm: alg,
+					Nodes:     ReferenceNodes(alg, vnodes, medley.HashStringTo[string], services...),
+				}
+
+				for _, key := range keys {
+					expected, _ := rl.Find([]byte(key))
+					c.Lookups = append(c.Lookups, medley.VectorLookup{Key: key, Expected: expected})
+				}
+
+				v.Cases = append(v.Cases, c)
+			}
+		}
+	}
+
+	return v
+}
+
+type CompatibilitySuite struct {
+	suite.Suite
+}
+
+func (suite *CompatibilitySuite) TestVectors() {
+	generated := generateVectors()
+	if os.Getenv(updateVectors) != "" {
+		data, err := json.MarshalIndent(generated, "", "\t")
+		suite.Require().NoError(err)
+		suite.Require().NoError(os.WriteFile(vectorsFile, append(data, '\n'), 0o644))
+		suite.T().Logf("rewrote %s", vectorsFile)
+	}
+
+	// any change to token derivation changes the generated vectors
+	suite.Equal(generated, medley.CompatibilityVectors(), "set %s=1 to regenerate the vectors", updateVectors)
+}
+
+func (suite *CompatibilitySuite) TestVerifyVectors() {
+	factory := func(algorithm string, vnodes int, services []string) (medley.Locator[string], error) {
+		alg, err := medley.FindAlgorithm(algorithm)
+		return ReferenceLocator[string]{
+			Algorithm: alg,
+			Nodes:     ReferenceNodes(alg, vnodes, medley.HashStringTo[string], services...),
+		}, err
+	}
+
+	suite.NoError(medley.VerifyVectors(medley.CompatibilityVectors(), factory))
+
+	// a tampered vector is reported
+	tampered := medley.CompatibilityVectors()
+	tampered.Cases[1].Lookups[0].Expected = "nosuch"
+	err := medley.VerifyVectors(tampered, factory)
+	suite.ErrorIs(err, medley.ErrVectorMismatch)
+	suite.ErrorContains(err, "nosuch")
+
+	// factory errors are returned as is
+	unknown := medley.Vectors{Cases: []medley.VectorCase{{Algorithm: "nosuch"}}}
+	suite.ErrorIs(medley.VerifyVectors(unknown, factory), medley.ErrUnknownAlgorithm)
+}
+
+func TestCompatibility(t *testing.T) {
+	suite.Run(t, new(CompatibilitySuite))
+}
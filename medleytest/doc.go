@@ -0,0 +1,7 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package medleytest provides testing utilities for code that uses medley.
+*/
+package medleytest
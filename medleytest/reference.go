@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/xmidt-org/medley"
+)
+
+// ReferenceNode is a single token on a hash circle.
+type ReferenceNode[S medley.Service] struct {
+	Token   uint64
+	Service S
+}
+
+// ReferenceNodes computes the tokens for a set of services in the simplest possible
+// way. The tokens are computed the same way as github.com/billhathaway/consistentHash:
+// each token is the hash of the vnode index, an '=', and the service's hash bytes.
+//
+// The returned nodes are in no particular order.
+func ReferenceNodes[S medley.Service](alg medley.Algorithm, vnodes int, sh medley.ServiceHasher[S], services ...S) (nodes []ReferenceNode[S]) {
+	for _, svc := range services {
+		var base bytes.Buffer
+		sh(&base, svc)
+
+		for i := 0; i < vnodes; i++ {
+			h := alg.New64()
+			h.Write([]byte(strconv.Itoa(i) + "="))
+			h.Write(base.Bytes())
+			nodes = append(nodes, ReferenceNode[S]{Token: h.Sum64(), Service: svc})
+		}
+	}
+
+	return
+}
+
+// ReferenceLocator is an obviously correct, but slow, consistent hashing medley.Locator.
+// It is intended to verify other Locators, such as those that use custom Algorithms
+// or ServiceHashers.
+//
+// Find hashes an object with Algorithm and then scans every node for the closest token
+// moving clockwise around the circle, i.e. the smallest token that is greater than or
+// equal to the object's hash. If there is no such token, the smallest token overall is
+// used. If several nodes have the same token, which is extremely unlikely, the first
+// such node in Nodes is used.
+type ReferenceLocator[S medley.Service] struct {
+	// Algorithm is the hash algorithm used for objects.
+	Algorithm medley.Algorithm
+
+	// Nodes holds the hash circle's tokens. The nodes need not be sorted.
+	Nodes []ReferenceNode[S]
+}
+
+var _ medley.Locator[string] = ReferenceLocator[string]{}
+
+// Find returns the service whose token is closest to the object's hash. If there
+// are no nodes, this method returns medley.ErrNoServices.
+func (rl ReferenceLocator[S]) Find(object []byte) (svc S, err error) {
+	if len(rl.Nodes) == 0 {
+		err = medley.ErrNoServices
+		return
+	}
+
+	var (
+		target = rl.Algorithm.Sum64Bytes(object)
+
+		closest, smallest *ReferenceNode[S]
+	)
+
+	for i := range rl.Nodes {
+		n := &rl.Nodes[i]
+		if n.Token >= target && (closest == nil || n.Token < closest.Token) {
+			closest = n
+		}
+
+		if smallest == nil || n.Token < smallest.Token {
+			smallest = n
+		}
+	}
+
+	if closest == nil {
+		closest = smallest
+	}
+
+	svc = closest.Service
+	return
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+// identity is an Algorithm whose hash of an 8-byte, big endian object is the object itself.
+// This makes it easy to target specific tokens.
+var identity = medley.Algorithm{
+	New64: fnv.New64,
+	Sum64: binary.BigEndian.Uint64,
+}
+
+func object(token uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, token)
+}
+
+type ReferenceSuite struct {
+	suite.Suite
+}
+
+func (suite *ReferenceSuite) TestReferenceNodes() {
+	nodes := ReferenceNodes(
+		medley.Algorithm{New64: fnv.New64},
+		3,
+		medley.HashStringTo[string],
+		"service1", "service2",
+	)
+
+	suite.Require().Len(nodes, 6)
+	for i, n := range nodes {
+		var (
+			expectedService = []string{"service1", "service2"}[i/3]
+			h               = fnv.New64()
+		)
+
+		h.Write([]byte(strconv.Itoa(i%3) + "=" + expectedService))
+		suite.Equal(expectedService, n.Service)
+		suite.Equal(h.Sum64(), n.Token)
+	}
+}
+
+func (suite *ReferenceSuite) TestFindEmpty() {
+	result, err := ReferenceLocator[string]{Algorithm: identity}.Find(object(123))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(result)
+}
+
+func (suite *ReferenceSuite) TestFind() {
+	rl := ReferenceLocator[string]{
+		Algorithm: identity,
+		Nodes: []ReferenceNode[string]{
+			{Token: 300, Service: "service3"},
+			{Token: 100, Service: "service1"},
+			{Token: 200, Service: "service2"},
+		},
+	}
+
+	testCases := []struct {
+		token    uint64
+		expected string
+	}{
+		{token: 0, expected: "service1"},
+		{token: 100, expected: "service1"},
+		{token: 101, expected: "service2"},
+		{token: 300, expected: "service3"},
+		{token: 301, expected: "service1"},
+		{token: ^uint64(0), expected: "service1"},
+	}
+
+	for _, testCase := range testCases {
+		result, err := rl.Find(object(testCase.token))
+		suite.NoError(err)
+		suite.Equal(testCase.expected, result, "token: %d", testCase.token)
+	}
+}
+
+func TestReference(t *testing.T) {
+	suite.Run(t, new(ReferenceSuite))
+}
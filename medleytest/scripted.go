@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"strconv"
+	"sync"
+
+	"github.com/xmidt-org/medley"
+)
+
+// ScriptedAlgorithm is a fake hash algorithm whose tokens are chosen by a test. Each input
+// that was scripted hashes to its scripted token. Every other input hashes to a token derived
+// from the input's bytes and a seed with an xorshift generator, so unscripted tokens are also
+// reproducible across runs and processes.
+//
+// Use Algorithm to obtain a medley.Algorithm for a ScriptedAlgorithm. Along with ScriptService,
+// this allows a test to build a consistent hash ring whose layout is known a priori, so that
+// assertions don't depend on the values of a real hash algorithm.
+//
+// Methods on this type are safe for concurrent usage, although inputs are usually scripted
+// before any hashing.
+type ScriptedAlgorithm struct {
+	lock   sync.RWMutex
+	seed   uint64
+	tokens map[string]uint64
+}
+
+// NewScriptedAlgorithm creates a ScriptedAlgorithm with no scripted inputs. The seed determines
+// the tokens of unscripted inputs.
+func NewScriptedAlgorithm(seed uint64) *ScriptedAlgorithm {
+	return &ScriptedAlgorithm{
+		seed:   seed,
+		tokens: make(map[string]uint64),
+	}
+}
+
+// NewScriptedAlgorithmFrom creates a ScriptedAlgorithm with the given inputs scripted to the
+// given tokens. The map is copied. Unscripted inputs use a seed of zero (0).
+func NewScriptedAlgorithmFrom(tokens map[string]uint64) *ScriptedAlgorithm {
+	sa := NewScriptedAlgorithm(0)
+	for input, token := range tokens {
+		sa.tokens[input] = token
+	}
+
+	return sa
+}
+
+// Script sets the token for the given input bytes, replacing any previously scripted token.
+func (sa *ScriptedAlgorithm) Script(input []byte, token uint64) *ScriptedAlgorithm {
+	return sa.ScriptString(string(input), token)
+}
+
+// ScriptString is like Script, but for an input string.
+func (sa *ScriptedAlgorithm) ScriptString(input string, token uint64) *ScriptedAlgorithm {
+	sa.lock.Lock()
+	sa.tokens[input] = token
+	sa.lock.Unlock()
+	return sa
+}
+
+// xorshift is one step of the xorshift64* generator.
+func xorshift(x uint64) uint64 {
+	x ^= x >> 12
+	x ^= x << 25
+	x ^= x >> 27
+	return x * 0x2545f4914f6cdd1d
+}
+
+// Sum64 returns the token for the given input.
+func (sa *ScriptedAlgorithm) Sum64(input []byte) uint64 {
+	sa.lock.RLock()
+	token, ok := sa.tokens[string(input)]
+	sa.lock.RUnlock()
+	if ok {
+		return token
+	}
+
+	// xorshift never leaves zero, so the state is offset by a nonzero constant
+	state := sa.seed ^ 0x9e3779b97f4a7c15
+	for _, b := range input {
+		state = xorshift(state ^ uint64(b))
+	}
+
+	return xorshift(state ^ uint64(len(input)))
+}
+
+// Algorithm returns a medley.Algorithm that uses this ScriptedAlgorithm. The Hash64 objects
+// created by New64 buffer their input, so they always agree with Sum64.
+func (sa *ScriptedAlgorithm) Algorithm() medley.Algorithm {
+	return medley.Algorithm{
+		New64: func() hash.Hash64 {
+			return &scriptedHash{sa: sa}
+		},
+		Sum64: sa.Sum64,
+	}
+}
+
+// scriptedHash is the hash.Hash64 for a ScriptedAlgorithm.
+type scriptedHash struct {
+	sa    *ScriptedAlgorithm
+	input bytes.Buffer
+}
+
+func (sh *scriptedHash) Write(p []byte) (int, error) {
+	return sh.input.Write(p)
+}
+
+func (sh *scriptedHash) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, sh.Sum64())
+}
+
+func (sh *scriptedHash) Sum64() uint64 {
+	return sh.sa.Sum64(sh.input.Bytes())
+}
+
+func (sh *scriptedHash) Reset() {
+	sh.input.Reset()
+}
+
+func (sh *scriptedHash) Size() int {
+	return 8
+}
+
+func (sh *scriptedHash) BlockSize() int {
+	return 1
+}
+
+// ScriptService scripts the tokens of a service's vnodes, so that a ring built with the
+// ScriptedAlgorithm places the service's nodes at exactly the given tokens. The ring must
+// use the same ServiceHasher and the same number of vnodes as there are tokens.
+//
+// Rings derive the input for the vnode with index i as the decimal i, an '=', and then the
+// service's hash bytes, the same as ReferenceNodes. The ith token is scripted for that input.
+// Any error from the ServiceHasher is returned, in which case nothing is scripted.
+func ScriptService[S medley.Service](sa *ScriptedAlgorithm, sh medley.ServiceHasher[S], svc S, tokens ...uint64) error {
+	var base bytes.Buffer
+	if err := sh(&base, svc); err != nil {
+		return err
+	}
+
+	for i, token := range tokens {
+		sa.ScriptString(strconv.Itoa(i)+"="+base.String(), token)
+	}
+
+	return nil
+}
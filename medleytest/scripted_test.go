@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type ScriptedSuite struct {
+	suite.Suite
+}
+
+// assertConsistent asserts that New64 and Sum64 agree for the given input.
+func (suite *ScriptedSuite) assertConsistent(alg medley.Algorithm, input []byte) uint64 {
+	h := alg.New64()
+	for i := range input {
+		// write a byte at a time, to verify that the hash buffers its input
+		h.Write(input[i : i+1])
+	}
+
+	suite.Equal(alg.Sum64(input), h.Sum64())
+	suite.Equal(alg.Sum64(input), alg.Sum64Bytes(input))
+	return h.Sum64()
+}
+
+func (suite *ScriptedSuite) TestScript() {
+	sa := NewScriptedAlgorithm(1).
+		Script([]byte("one"), 1).
+		ScriptString("two", 2)
+
+	alg := sa.Algorithm()
+	suite.Equal(uint64(1), suite.assertConsistent(alg, []byte("one")))
+	suite.Equal(uint64(2), suite.assertConsistent(alg, []byte("two")))
+	suite.Equal(uint64(2), alg.Sum64String("two"))
+
+	// scripting again replaces the token
+	sa.ScriptString("one", 100)
+	suite.Equal(uint64(100), alg.Sum64String("one"))
+
+	h := alg.New64()
+	h.Write([]byte("two"))
+	suite.Equal([]byte{0, 0, 0, 0, 0, 0, 0, 2}, h.Sum(nil))
+	suite.Equal(8, h.Size())
+	suite.Equal(1, h.BlockSize())
+
+	h.Reset()
+	h.Write([]byte("one"))
+	suite.Equal(uint64(100), h.Sum64())
+}
+
+func (suite *ScriptedSuite) TestFrom() {
+	tokens := map[string]uint64{"one": 1, "two": 2}
+	sa := NewScriptedAlgorithmFrom(tokens)
+	tokens["one"] = 123
+
+	suite.Equal(uint64(1), sa.Sum64([]byte("one")))
+	suite.Equal(uint64(2), sa.Sum64([]byte("two")))
+	suite.Equal(NewScriptedAlgorithm(0).Sum64([]byte("three")), sa.Sum64([]byte("three")))
+}
+
+func (suite *ScriptedSuite) TestSeed() {
+	var (
+		first   = NewScriptedAlgorithm(1234)
+		again   = NewScriptedAlgorithm(1234)
+		another = NewScriptedAlgorithm(5678)
+		seen    = make(map[uint64]bool)
+	)
+
+	for _, input := range []string{"", "a", "b", "ab", "ba", "\x00", "\x00\x00", "service1.example.net"} {
+		token := suite.assertConsistent(first.Algorithm(), []byte(input))
+		suite.Equal(again.Sum64([]byte(input)), token)
+		suite.NotEqual(another.Sum64([]byte(input)), token)
+
+		suite.False(seen[token], "input %q", input)
+		seen[token] = true
+	}
+
+	// the tokens are pinned, so that they are reproducible across releases
+	suite.Equal(uint64(0x98df669c1677da70), NewScriptedAlgorithm(0).Sum64([]byte("test")))
+}
+
+func (suite *ScriptedSuite) TestScriptService() {
+	sa := NewScriptedAlgorithm(0)
+	suite.Require().NoError(ScriptService(sa, medley.HashStringTo[string], "a", 100, 400))
+	suite.Require().NoError(ScriptService(sa, medley.HashStringTo[string], "b", 200, 500))
+	suite.Require().NoError(ScriptService(sa, medley.HashStringTo[string], "c", 300, 600))
+
+	nodes := ReferenceNodes(sa.Algorithm(), 2, medley.HashStringTo[string], "a", "b", "c")
+	suite.Equal(
+		[]ReferenceNode[string]{
+			{Token: 100, Service: "a"}, {Token: 400, Service: "a"},
+			{Token: 200, Service: "b"}, {Token: 500, Service: "b"},
+			{Token: 300, Service: "c"}, {Token: 600, Service: "c"},
+		},
+		nodes,
+	)
+
+	for key, expected := range map[uint64]string{50: "a", 150: "b", 250: "c", 350: "a", 450: "b", 550: "c", 650: "a"} {
+		sa.ScriptString("key", key)
+		svc, err := ReferenceLocator[string]{Algorithm: sa.Algorithm(), Nodes: nodes}.Find([]byte("key"))
+		suite.NoError(err)
+		suite.Equal(expected, svc, "key token %d", key)
+	}
+}
+
+func (suite *ScriptedSuite) TestScriptServiceError() {
+	expectedErr := errors.New("expected")
+	sa := NewScriptedAlgorithm(0)
+	err := ScriptService(sa, func(io.Writer, string) error { return expectedErr }, "a", 100)
+	suite.ErrorIs(err, expectedErr)
+
+	// nothing was scripted
+	suite.False(slices.Contains(
+		[]uint64{sa.Sum64([]byte("0=")), sa.Sum64([]byte("0=a"))},
+		100,
+	))
+}
+
+func TestScripted(t *testing.T) {
+	suite.Run(t, new(ScriptedSuite))
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/xmidt-org/medley"
+)
+
+// StaticRing is a fake ring with fixed routes, for deterministic tests of code that
+// performs lookups. It implements the same methods as consistent.RingAPI, so it can be
+// used wherever that interface is accepted.
+//
+// Each object is routed to the service for the object's exact bytes. An object without
+// a route, or any object when there are no routes, results in medley.ErrNoServices.
+type StaticRing[S medley.Service] struct {
+	routes   map[string]S
+	services []S
+}
+
+var _ medley.Locator[string] = (*StaticRing[string])(nil)
+
+// NewStaticRing creates a StaticRing from a map of objects to services. The map is
+// copied. The ring's services are the distinct services in the map, ordered by the
+// smallest object routed to each.
+func NewStaticRing[S medley.Service](routes map[string]S) *StaticRing[S] {
+	sr := &StaticRing[S]{
+		routes: make(map[string]S, len(routes)),
+	}
+
+	seen := make(medley.Map[S, bool])
+	for _, object := range slices.Sorted(maps.Keys(routes)) {
+		svc := routes[object]
+		sr.routes[object] = svc
+		if !seen[svc] {
+			seen[svc] = true
+			sr.services = append(sr.services, svc)
+		}
+	}
+
+	return sr
+}
+
+// Find returns the routed service for the given object. If the object has no route,
+// this method returns medley.ErrNoServices.
+func (sr *StaticRing[S]) Find(object []byte) (svc S, err error) {
+	var ok bool
+	if svc, ok = sr.routes[string(object)]; !ok {
+		err = medley.ErrNoServices
+	}
+
+	return
+}
+
+// Successors returns the routed service for the given object followed by every other
+// service, in the order of Services. The sequence is empty if the object has no route.
+func (sr *StaticRing[S]) Successors(object []byte) iter.Seq[S] {
+	return func(f func(S) bool) {
+		owner, ok := sr.routes[string(object)]
+		if !ok || !f(owner) {
+			return
+		}
+
+		for _, svc := range sr.services {
+			if svc != owner && !f(svc) {
+				return
+			}
+		}
+	}
+}
+
+// Contains tests if any object is routed to the given service.
+func (sr *StaticRing[S]) Contains(svc S) bool {
+	return slices.Contains(sr.services, svc)
+}
+
+// Len returns the number of distinct services.
+func (sr *StaticRing[S]) Len() int {
+	return len(sr.services)
+}
+
+// Services returns the distinct services, ordered by the smallest object routed to each.
+func (sr *StaticRing[S]) Services() []S {
+	return slices.Clone(sr.services)
+}
+
+// Ownership returns the fraction of the routes that go to each service.
+func (sr *StaticRing[S]) Ownership() medley.Map[S, float64] {
+	ownership := make(medley.Map[S, float64], len(sr.services))
+	for _, svc := range sr.routes {
+		ownership[svc] += 1.0 / float64(len(sr.routes))
+	}
+
+	return ownership
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/medley"
+)
+
+type StaticRingSuite struct {
+	suite.Suite
+}
+
+func (suite *StaticRingSuite) TestRoutes() {
+	routes := map[string]string{
+		"a": "service1",
+		"b": "service2",
+		"c": "service1",
+		"d": "service3",
+	}
+
+	sr := NewStaticRing(routes)
+
+	// the routes are copied
+	routes["e"] = "service4"
+
+	for object, expected := range map[string]string{"a": "service1", "b": "service2", "c": "service1", "d": "service3"} {
+		svc, err := sr.Find([]byte(object))
+		suite.NoError(err)
+		suite.Equal(expected, svc)
+	}
+
+	svc, err := sr.Find([]byte("e"))
+	suite.ErrorIs(err, medley.ErrNoServices)
+	suite.Empty(svc)
+
+	suite.Equal([]string{"service1", "service2", "service3"}, sr.Services())
+	suite.Equal(3, sr.Len())
+	suite.True(sr.Contains("service2"))
+	suite.False(sr.Contains("service4"))
+
+	suite.Equal([]string{"service2", "service1", "service3"}, slices.Collect(sr.Successors([]byte("b"))))
+	suite.Equal([]string{"service3", "service1", "service2"}, slices.Collect(sr.Successors([]byte("d"))))
+	suite.Empty(slices.Collect(sr.Successors([]byte("e"))))
+
+	ownership := sr.Ownership()
+	suite.InDelta(0.5, ownership["service1"], 1e-9)
+	suite.InDelta(0.25, ownership["service2"], 1e-9)
+	suite.InDelta(0.25, ownership["service3"], 1e-9)
+
+	// stopping a sequence early
+	for svc := range sr.Successors([]byte("a")) {
+		suite.Equal("service1", svc)
+		break
+	}
+}
+
+func (suite *StaticRingSuite) TestEmpty() {
+	for _, sr := range []*StaticRing[string]{NewStaticRing[string](nil), NewStaticRing(map[string]string{})} {
+		_, err := sr.Find([]byte("test"))
+		suite.ErrorIs(err, medley.ErrNoServices)
+		suite.Zero(sr.Len())
+		suite.Empty(sr.Services())
+		suite.Empty(sr.Ownership())
+		suite.Empty(slices.Collect(sr.Successors([]byte("test"))))
+		suite.False(sr.Contains("test"))
+	}
+}
+
+func TestStaticRing(t *testing.T) {
+	suite.Run(t, new(StaticRingSuite))
+}
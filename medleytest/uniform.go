@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xmidt-org/medley"
+)
+
+const (
+	// DefaultSignificance is the significance level AssertUniform uses when none is supplied.
+	// A correct distribution fails the test with this probability.
+	DefaultSignificance = 0.001
+)
+
+// uniformOptions holds the configuration for AssertUniform.
+type uniformOptions[S medley.Service] struct {
+	fractions    map[S]float64
+	significance float64
+}
+
+// UniformOption configures AssertUniform.
+type UniformOption[S medley.Service] func(*uniformOptions[S])
+
+// WithFractions sets the expected fraction of the samples for each service, e.g. the
+// Ownership of a weighted ring. The fractions are normalized, so they need not sum to 1.0.
+// By default, every service in the counts is expected to receive the same fraction.
+//
+// When fractions are supplied, every counted service must have a positive fraction.
+// Services that have a fraction but no count are treated as having a count of zero (0).
+func WithFractions[S medley.Service](fractions map[S]float64) UniformOption[S] {
+	return func(o *uniformOptions[S]) {
+		o.fractions = fractions
+	}
+}
+
+// WithSignificance sets the significance level of the test, which is the probability
+// that a correct distribution fails. By default, DefaultSignificance is used.
+func WithSignificance[S medley.Service](significance float64) UniformOption[S] {
+	return func(o *uniformOptions[S]) {
+		o.significance = significance
+	}
+}
+
+// AssertUniform runs a chi-squared goodness-of-fit test of the given counts of samples per
+// service against their expected distribution. By default, the expected distribution is
+// uniform. Use WithFractions to supply a weighted distribution.
+//
+// Unlike a fixed tolerance, this test accounts for the number of samples, so it is neither
+// too loose for large samples nor too strict for small ones. On failure, the report
+// includes the deviation of each service from its expected count.
+//
+// This function returns true if the counts fit the expected distribution.
+func AssertUniform[S medley.Service](t testing.TB, counts map[S]int, opts ...UniformOption[S]) bool {
+	t.Helper()
+	o := uniformOptions[S]{
+		significance: DefaultSignificance,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	fractions := o.fractions
+	if fractions == nil {
+		fractions = make(map[S]float64, len(counts))
+		for svc := range counts {
+			fractions[svc] = 1.0
+		}
+	}
+
+	var total, weight float64
+	for svc, count := range counts {
+		if fractions[svc] <= 0 {
+			t.Errorf("service %v was counted %d times, but it has no expected fraction", svc, count)
+			return false
+		}
+
+		total += float64(count)
+	}
+
+	for _, f := range fractions {
+		weight += f
+	}
+
+	if len(fractions) < 2 || total == 0 {
+		// there's no distribution to test
+		return true
+	}
+
+	var (
+		report    = make([]string, 0, len(fractions))
+		statistic float64
+	)
+
+	for svc, f := range fractions {
+		var (
+			observed  = float64(counts[svc])
+			expected  = total * f / weight
+			deviation = observed - expected
+		)
+
+		statistic += deviation * deviation / expected
+		report = append(report, fmt.Sprintf(
+			"%v: observed %d, expected %.1f (%+.1f%%)",
+			svc, counts[svc], expected, 100*deviation/expected,
+		))
+	}
+
+	df := len(fractions) - 1
+	p := chiSquaredSurvival(statistic, df)
+	if p < o.significance {
+		slices.Sort(report)
+		t.Errorf(
+			"distribution is not as expected: chi-squared %.2f with %d degrees of freedom, p=%.3g < %g\n\t%s",
+			statistic, df, p, o.significance, strings.Join(report, "\n\t"),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// chiSquaredSurvival returns the probability that a chi-squared random variable with
+// the given degrees of freedom is at least x.
+func chiSquaredSurvival(x float64, df int) float64 {
+	return gammaQ(float64(df)/2, x/2)
+}
+
+// gammaQ computes the regularized upper incomplete gamma function Q(a, x), using a series
+// for small x and a continued fraction otherwise.
+func gammaQ(a, x float64) float64 {
+	const (
+		epsilon    = 1e-14
+		tiny       = 1e-300
+		iterations = 1000
+	)
+
+	if x <= 0 {
+		return 1.0
+	}
+
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		var (
+			term = 1 / a
+			sum  = term
+		)
+
+		for n := 1; n < iterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+
+		return max(0, 1-sum*prefix)
+	}
+
+	// modified Lentz's method
+	var (
+		b = x + 1 - a
+		c = 1 / tiny
+		d = 1 / b
+		h = d
+	)
+
+	for i := 1; i < iterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return prefix * h
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medleytest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UniformSuite struct {
+	suite.Suite
+}
+
+// sample distributes n samples among services at random, with probabilities
+// proportional to the given weights.
+func (suite *UniformSuite) sample(seed int64, n int, weights map[string]float64) map[string]int {
+	var (
+		random   = rand.New(rand.NewSource(seed))
+		services = make([]string, 0, len(weights))
+		total    float64
+	)
+
+	for i := range len(weights) {
+		svc := fmt.Sprintf("service-%d", i)
+		services = append(services, svc)
+		total += weights[svc]
+	}
+
+	counts := make(map[string]int, len(services))
+	for range n {
+		choice := random.Float64() * total
+		for _, svc := range services {
+			if choice -= weights[svc]; choice < 0 {
+				counts[svc]++
+				break
+			}
+		}
+	}
+
+	return counts
+}
+
+// equalWeights returns the same weight for each of n services.
+func (suite *UniformSuite) equalWeights(n int) map[string]float64 {
+	weights := make(map[string]float64, n)
+	for i := range n {
+		weights[fmt.Sprintf("service-%d", i)] = 1.0
+	}
+
+	return weights
+}
+
+func (suite *UniformSuite) TestChiSquaredSurvival() {
+	// critical values from standard chi-squared tables
+	suite.InDelta(0.05, chiSquaredSurvival(3.841, 1), 1e-4)
+	suite.InDelta(0.001, chiSquaredSurvival(16.266, 3), 1e-5)
+	suite.InDelta(0.05, chiSquaredSurvival(18.307, 10), 1e-4)
+	suite.InDelta(0.5, chiSquaredSurvival(98.334, 99), 1e-3)
+	suite.Equal(1.0, chiSquaredSurvival(0, 5))
+}
+
+func (suite *UniformSuite) TestUniform() {
+	for _, n := range []int{100, 10_000, 1_000_000} {
+		r := &recorder{TB: suite.T()}
+		suite.True(AssertUniform(r, suite.sample(12345, n, suite.equalWeights(10))), n)
+		suite.Empty(r.errors, n)
+	}
+}
+
+func (suite *UniformSuite) TestSkewed() {
+	weights := suite.equalWeights(10)
+	weights["service-3"] = 1.5
+
+	r := &recorder{TB: suite.T()}
+	suite.False(AssertUniform(r, suite.sample(12345, 10_000, weights)))
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "chi-squared")
+	suite.Contains(r.errors[0], "service-3: observed")
+
+	// a small sample can't tell the difference
+	r = &recorder{TB: suite.T()}
+	suite.True(AssertUniform(r, suite.sample(12345, 100, weights)))
+}
+
+func (suite *UniformSuite) TestFractions() {
+	fractions := map[string]float64{
+		"service-0": 0.1,
+		"service-1": 0.2,
+		"service-2": 0.3,
+		"service-3": 0.4,
+	}
+
+	counts := suite.sample(67890, 10_000, fractions)
+
+	r := &recorder{TB: suite.T()}
+	suite.True(AssertUniform(r, counts, WithFractions(fractions)))
+	suite.Empty(r.errors)
+
+	// the same sample is far from uniform
+	r = &recorder{TB: suite.T()}
+	suite.False(AssertUniform(r, counts))
+	suite.Len(r.errors, 1)
+}
+
+func (suite *UniformSuite) TestMissingFraction() {
+	r := &recorder{TB: suite.T()}
+	suite.False(AssertUniform(r,
+		map[string]int{"service-0": 10, "service-1": 10},
+		WithFractions(map[string]float64{"service-0": 1.0}),
+	))
+
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "service-1")
+}
+
+func (suite *UniformSuite) TestMissingCount() {
+	// a service that was expected but never counted is a zero count
+	r := &recorder{TB: suite.T()}
+	suite.False(AssertUniform(r,
+		map[string]int{"service-0": 1000},
+		WithFractions(map[string]float64{"service-0": 1.0, "service-1": 1.0}),
+	))
+
+	suite.Require().Len(r.errors, 1)
+	suite.Contains(r.errors[0], "service-1: observed 0")
+}
+
+func (suite *UniformSuite) TestSignificance() {
+	counts := map[string]int{"service-0": 110, "service-1": 90}
+
+	r := &recorder{TB: suite.T()}
+	suite.True(AssertUniform(r, counts))
+	suite.False(AssertUniform(r, counts, WithSignificance[string](0.5)))
+	suite.Len(r.errors, 1)
+}
+
+func (suite *UniformSuite) TestTrivial() {
+	r := &recorder{TB: suite.T()}
+	suite.True(AssertUniform[string](r, nil))
+	suite.True(AssertUniform(r, map[string]int{"service-0": 100}))
+	suite.True(AssertUniform(r, map[string]int{"service-0": 0, "service-1": 0}))
+	suite.Empty(r.errors)
+}
+
+func TestUniform(t *testing.T) {
+	suite.Run(t, new(UniformSuite))
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/spaolacci/murmur3"
+)
+
+const (
+	// migrationSeed is the murmur3 seed used to select a side of a migration.
+	// A nonzero seed keeps the selection independent of any unseeded murmur3
+	// hashes that the old and new locators might use.
+	migrationSeed uint32 = 0x6d65646c
+)
+
+// MigratingLocator is a Locator that gradually moves lookups from an old Locator to a
+// new Locator. This is useful for changing the hash algorithm or configuration of a
+// Locator without a hard cutover.
+//
+// Each object passed to Find is hashed with a stable selector hash, and the object is
+// routed to the new Locator if its selector falls below the current fraction. As the
+// fraction increases, objects only ever move from the old Locator to the new Locator.
+//
+// Methods on this type are safe for concurrent usage.
+type MigratingLocator[S Service] struct {
+	old      Locator[S]
+	new      Locator[S]
+	fraction atomic.Uint64 // the bits of a float64
+}
+
+// NewMigratingLocator creates a MigratingLocator that routes the given fraction of
+// objects to the new Locator.
+func NewMigratingLocator[S Service](old, new Locator[S], fraction float64) *MigratingLocator[S] {
+	ml := &MigratingLocator[S]{
+		old: old,
+		new: new,
+	}
+
+	ml.SetFraction(fraction)
+	return ml
+}
+
+var _ AnnotatedLocator[string] = (*MigratingLocator[string])(nil)
+
+// Fraction returns the current fraction of objects routed to the new Locator.
+func (ml *MigratingLocator[S]) Fraction() float64 {
+	return math.Float64frombits(ml.fraction.Load())
+}
+
+// SetFraction atomically changes the fraction of objects routed to the new Locator.
+// The fraction is clamped to the range [0.0, 1.0], and NaN is treated as 0.0.
+func (ml *MigratingLocator[S]) SetFraction(f float64) {
+	switch {
+	case math.IsNaN(f) || f < 0.0:
+		f = 0.0
+
+	case f > 1.0:
+		f = 1.0
+	}
+
+	ml.fraction.Store(math.Float64bits(f))
+}
+
+// Find routes the object to either the old or the new Locator. When the fraction is
+// 0.0 or 1.0, no selector hash is computed.
+func (ml *MigratingLocator[S]) Find(object []byte) (S, error) {
+	l, _ := ml.selectLocator(object)
+	return l.Find(object)
+}
+
+// FindAnnotated is like Find, but reports SourceMigrating when the fraction is strictly
+// between 0.0 and 1.0, since the selector hash decided the side. Otherwise, the annotation
+// of the only side in use is returned.
+func (ml *MigratingLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	l, migrating := ml.selectLocator(object)
+	if !migrating {
+		return Explain(l, object)
+	}
+
+	result.Service, err = l.Find(object)
+	result.Source = SourceMigrating
+	return
+}
+
+// selectLocator returns the side of the migration for an object. The migrating flag
+// is true when the selector hash was consulted.
+func (ml *MigratingLocator[S]) selectLocator(object []byte) (l Locator[S], migrating bool) {
+	f := ml.Fraction()
+	switch {
+	case f <= 0.0:
+		return ml.old, false
+
+	case f >= 1.0:
+		return ml.new, false
+
+	case float64(murmur3.Sum64WithSeed(object, migrationSeed)) < f*(1<<64):
+		return ml.new, true
+
+	default:
+		return ml.old, true
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// fixedLocator is a Locator that always returns the same service.
+type fixedLocator[S Service] struct {
+	service S
+}
+
+func (fl fixedLocator[S]) Find([]byte) (S, error) {
+	return fl.service, nil
+}
+
+type MigratingLocatorSuite struct {
+	suite.Suite
+
+	objects [][]byte
+}
+
+func (suite *MigratingLocatorSuite) SetupSuite() {
+	suite.objects = make([][]byte, 1000)
+	for i := range suite.objects {
+		suite.objects[i] = []byte(fmt.Sprintf("object-%d", i))
+	}
+}
+
+func (suite *MigratingLocatorSuite) newMigratingLocator(fraction float64) *MigratingLocator[string] {
+	ml := NewMigratingLocator[string](
+		fixedLocator[string]{service: "old"},
+		fixedLocator[string]{service: "new"},
+		fraction,
+	)
+
+	suite.Require().NotNil(ml)
+	return ml
+}
+
+func (suite *MigratingLocatorSuite) TestSetFraction() {
+	testCases := []struct {
+		fraction float64
+		expected float64
+	}{
+		{fraction: 0.0, expected: 0.0},
+		{fraction: 0.25, expected: 0.25},
+		{fraction: 1.0, expected: 1.0},
+		{fraction: -1.0, expected: 0.0},
+		{fraction: 2.0, expected: 1.0},
+		{fraction: math.NaN(), expected: 0.0},
+		{fraction: math.Inf(1), expected: 1.0},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(fmt.Sprintf("%f", testCase.fraction), func() {
+			ml := suite.newMigratingLocator(0.5)
+			ml.SetFraction(testCase.fraction)
+			suite.Equal(testCase.expected, ml.Fraction())
+		})
+	}
+}
+
+func (suite *MigratingLocatorSuite) testBoundary(fraction float64, expectedSide string) func() {
+	return func() {
+		var (
+			old = new(MockLocator[string])
+			nw  = new(MockLocator[string])
+			ml  = NewMigratingLocator[string](old, nw, fraction)
+		)
+
+		for _, object := range suite.objects[:10] {
+			if expectedSide == "old" {
+				old.ExpectFindSuccess(object, "old").Once()
+			} else {
+				nw.ExpectFindSuccess(object, "new").Once()
+			}
+
+			result, err := ml.Find(object)
+			suite.NoError(err)
+			suite.Equal(expectedSide, result)
+		}
+
+		mock.AssertExpectationsForObjects(suite.T(), old, nw)
+	}
+}
+
+func (suite *MigratingLocatorSuite) TestBoundaries() {
+	suite.Run("Zero", suite.testBoundary(0.0, "old"))
+	suite.Run("One", suite.testBoundary(1.0, "new"))
+}
+
+func (suite *MigratingLocatorSuite) TestStickiness() {
+	var (
+		ml = suite.newMigratingLocator(0.0)
+
+		// migrated tracks which objects have moved to the new locator
+		migrated = make([]bool, len(suite.objects))
+	)
+
+	for step := 0; step <= 10; step++ {
+		fraction := float64(step) / 10.0
+		ml.SetFraction(fraction)
+
+		newCount := 0
+		for i, object := range suite.objects {
+			result, err := ml.Find(object)
+			suite.Require().NoError(err)
+
+			if result == "new" {
+				newCount++
+				migrated[i] = true
+			} else {
+				suite.False(migrated[i], "object %d moved back to the old locator at fraction %f", i, fraction)
+			}
+		}
+
+		expectedCount := fraction * float64(len(suite.objects))
+		suite.InDelta(expectedCount, newCount, 0.05*float64(len(suite.objects)), "fraction: %f", fraction)
+	}
+}
+
+func (suite *MigratingLocatorSuite) TestConcurrency() {
+	var (
+		ml = suite.newMigratingLocator(0.0)
+		wg sync.WaitGroup
+	)
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, object := range suite.objects {
+				result, err := ml.Find(object)
+				suite.NoError(err)
+				suite.Contains([]string{"old", "new"}, result)
+			}
+		}()
+	}
+
+	for step := 0; step <= 100; step++ {
+		ml.SetFraction(float64(step) / 100.0)
+	}
+
+	wg.Wait()
+}
+
+func TestMigratingLocator(t *testing.T) {
+	suite.Run(t, new(MigratingLocatorSuite))
+}
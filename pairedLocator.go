@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import "fmt"
+
+// PairMode determines how a PairedLocator handles a lookup that fails on one side.
+type PairMode int
+
+const (
+	// RequireBoth fails a pair lookup if either side fails. This is the default.
+	RequireBoth PairMode = iota
+
+	// BestEffort returns whichever side of a pair lookup succeeded, along with an
+	// error describing the side that failed.
+	BestEffort
+)
+
+// PartialPairError describes a pair lookup in which at least one side failed. The error
+// for a side that succeeded is nil.
+type PartialPairError struct {
+	// Primary is the error from the primary Locator.
+	Primary error
+
+	// Secondary is the error from the secondary Locator.
+	Secondary error
+}
+
+// Error describes which sides of the pair lookup failed.
+func (ppe *PartialPairError) Error() string {
+	switch {
+	case ppe.Primary != nil && ppe.Secondary != nil:
+		return fmt.Sprintf("pair lookup failed: primary: %s, secondary: %s", ppe.Primary, ppe.Secondary)
+
+	case ppe.Primary != nil:
+		return fmt.Sprintf("pair lookup failed: primary: %s", ppe.Primary)
+
+	default:
+		return fmt.Sprintf("pair lookup failed: secondary: %s", ppe.Secondary)
+	}
+}
+
+// Unwrap returns the errors from the sides that failed.
+func (ppe *PartialPairError) Unwrap() (errs []error) {
+	for _, err := range [...]error{ppe.Primary, ppe.Secondary} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return
+}
+
+// PairedLocator consults two independent Locators for each object, e.g. to dual-write
+// to the rings of an old and a new datacenter during a migration. A PairedLocator is
+// also a Locator that simply consults the primary.
+//
+// A PairedLocator is immutable and safe for concurrent usage.
+type PairedLocator[S Service] struct {
+	primary   Locator[S]
+	secondary Locator[S]
+	mode      PairMode
+}
+
+// NewPairedLocator creates a PairedLocator from a primary and a secondary Locator, using
+// the given mode for failed lookups.
+func NewPairedLocator[S Service](primary, secondary Locator[S], mode PairMode) *PairedLocator[S] {
+	return &PairedLocator[S]{
+		primary:   primary,
+		secondary: secondary,
+		mode:      mode,
+	}
+}
+
+var _ Locator[string] = (*PairedLocator[string])(nil)
+
+// Find consults only the primary Locator.
+func (pl *PairedLocator[S]) Find(object []byte) (S, error) {
+	return pl.primary.Find(object)
+}
+
+// FindPair consults both Locators for the given object. If either fails, the returned error
+// is a *PartialPairError. With RequireBoth, neither service is returned in that case. With
+// BestEffort, the service from the side that succeeded is still returned.
+func (pl *PairedLocator[S]) FindPair(object []byte) (primary S, secondary S, err error) {
+	var primaryErr, secondaryErr error
+	primary, primaryErr = pl.primary.Find(object)
+	secondary, secondaryErr = pl.secondary.Find(object)
+	if primaryErr == nil && secondaryErr == nil {
+		return
+	}
+
+	var zero S
+	if primaryErr != nil || pl.mode != BestEffort {
+		primary = zero
+	}
+
+	if secondaryErr != nil || pl.mode != BestEffort {
+		secondary = zero
+	}
+
+	err = &PartialPairError{
+		Primary:   primaryErr,
+		Secondary: secondaryErr,
+	}
+
+	return
+}
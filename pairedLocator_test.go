@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PairedLocatorSuite struct {
+	suite.Suite
+
+	object    []byte
+	primary   *MockLocator[string]
+	secondary *MockLocator[string]
+}
+
+func (suite *PairedLocatorSuite) SetupTest() {
+	suite.object = []byte("test")
+	suite.primary = new(MockLocator[string])
+	suite.secondary = new(MockLocator[string])
+}
+
+func (suite *PairedLocatorSuite) TearDownTest() {
+	mock.AssertExpectationsForObjects(suite.T(), suite.primary, suite.secondary)
+}
+
+func (suite *PairedLocatorSuite) newPairedLocator(mode PairMode) *PairedLocator[string] {
+	return NewPairedLocator[string](suite.primary, suite.secondary, mode)
+}
+
+func (suite *PairedLocatorSuite) TestFind() {
+	suite.primary.ExpectFindSuccess(suite.object, "old").Once()
+
+	svc, err := suite.newPairedLocator(RequireBoth).Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("old", svc)
+}
+
+func (suite *PairedLocatorSuite) TestFindPairSuccess() {
+	for _, mode := range []PairMode{RequireBoth, BestEffort} {
+		suite.primary.ExpectFindSuccess(suite.object, "old").Once()
+		suite.secondary.ExpectFindSuccess(suite.object, "new").Once()
+
+		primary, secondary, err := suite.newPairedLocator(mode).FindPair(suite.object)
+		suite.NoError(err)
+		suite.Equal("old", primary)
+		suite.Equal("new", secondary)
+	}
+}
+
+func (suite *PairedLocatorSuite) TestRequireBoth() {
+	expectedErr := errors.New("expected")
+	suite.primary.ExpectFindSuccess(suite.object, "old").Once()
+	suite.secondary.ExpectFindFail(suite.object, expectedErr).Once()
+
+	primary, secondary, err := suite.newPairedLocator(RequireBoth).FindPair(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(primary)
+	suite.Empty(secondary)
+
+	var ppe *PartialPairError
+	suite.Require().ErrorAs(err, &ppe)
+	suite.NoError(ppe.Primary)
+	suite.ErrorIs(ppe.Secondary, expectedErr)
+	suite.ErrorContains(err, "secondary: expected")
+}
+
+func (suite *PairedLocatorSuite) TestBestEffort() {
+	suite.primary.ExpectFindNoServices(suite.object).Once()
+	suite.secondary.ExpectFindSuccess(suite.object, "new").Once()
+
+	primary, secondary, err := suite.newPairedLocator(BestEffort).FindPair(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(primary)
+	suite.Equal("new", secondary)
+
+	var ppe *PartialPairError
+	suite.Require().ErrorAs(err, &ppe)
+	suite.ErrorIs(ppe.Primary, ErrNoServices)
+	suite.NoError(ppe.Secondary)
+	suite.ErrorContains(err, "primary: "+ErrNoServices.Error())
+}
+
+func (suite *PairedLocatorSuite) TestBothFail() {
+	expectedErr := errors.New("expected")
+	suite.primary.ExpectFindNoServices(suite.object).Once()
+	suite.secondary.ExpectFindFail(suite.object, expectedErr).Once()
+
+	primary, secondary, err := suite.newPairedLocator(BestEffort).FindPair(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(primary)
+	suite.Empty(secondary)
+
+	var ppe *PartialPairError
+	suite.Require().ErrorAs(err, &ppe)
+	suite.Error(ppe.Primary)
+	suite.Error(ppe.Secondary)
+}
+
+func TestPairedLocator(t *testing.T) {
+	suite.Run(t, new(PairedLocatorSuite))
+}
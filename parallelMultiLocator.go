@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ParallelMultiLocator is a MultiLocator that consults its locators concurrently. This
+// is useful when some locators can block, such as locators that front a remote lookup,
+// since a lookup is only as slow as the slowest locator rather than the sum of them all.
+//
+// Results are aggregated exactly as MultiLocator does, including SetDedupe. Services are
+// returned in the order that locators were added, regardless of the order in which lookups
+// complete. If more than one locator fails with an error other than ErrNoServices, the error
+// from the earliest locator is returned.
+//
+// Methods on this type are safe for concurrent usage. A ParallelMultiLocator must not be
+// copied after creation.
+type ParallelMultiLocator[S Service] struct {
+	MultiLocator[S]
+
+	maxParallel int
+	timeout     time.Duration
+}
+
+// NewParallelMultiLocator returns a ParallelMultiLocator initialized with the given set of
+// Locators, ignoring duplicates. At most maxParallel lookups run at once for each call to Find.
+// If maxParallel is nonpositive, every locator is consulted at once.
+//
+// If timeout is positive, each call to Find waits at most that long for all locators. A zero
+// timeout means no limit, although FindContext still honors its context.
+func NewParallelMultiLocator[S Service](maxParallel int, timeout time.Duration, ls ...Locator[S]) *ParallelMultiLocator[S] {
+	pl := &ParallelMultiLocator[S]{
+		MultiLocator: MultiLocator[S]{
+			locators: dedupeLocators(ls),
+		},
+		maxParallel: maxParallel,
+		timeout:     timeout,
+	}
+
+	pl.self = &pl.MultiLocator
+	return pl
+}
+
+// parallelResult is the outcome of a single locator's lookup.
+type parallelResult[S Service] struct {
+	svc S
+	err error
+}
+
+// Find returns the services from each locator in this aggregate, consulting
+// the locators concurrently.
+func (pl *ParallelMultiLocator[S]) Find(object []byte) ([]S, error) {
+	return pl.FindContext(context.Background(), object)
+}
+
+// FindString locates services based on a string key.
+func (pl *ParallelMultiLocator[S]) FindString(object string) ([]S, error) {
+	return pl.Find(
+		stringToBytes(object),
+	)
+}
+
+// FindContext is like Find, but also stops waiting when the given context is canceled.
+// In that case, the context's error is returned. Locators that are still running when
+// this method returns are left to finish in the background, and their results are discarded.
+// Those locators see a copy of object, so the caller may reuse it once this method returns.
+// Any registered TraceHooks observe the lookup.
+func (pl *ParallelMultiLocator[S]) FindContext(ctx context.Context, object []byte) (services []S, err error) {
+	ctx, end := startTrace(ctx, len(object))
+	services, err = pl.findContext(ctx, object)
+	if end != nil {
+		end(services, err)
+	}
+
+	return
+}
+
+// findContext performs the lookup for FindContext.
+func (pl *ParallelMultiLocator[S]) findContext(ctx context.Context, object []byte) ([]S, error) {
+	pl.lock.RLock()
+	locators, dedupe := slices.Clone(pl.locators), pl.dedupe
+	pl.lock.RUnlock()
+
+	if pl.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pl.timeout)
+		defer cancel()
+	}
+
+	maxParallel := pl.maxParallel
+	if maxParallel < 1 || maxParallel > len(locators) {
+		maxParallel = len(locators)
+	}
+
+	var (
+		// lookups can outlive this method, so they must not share the caller's buffer
+		key     = slices.Clone(object)
+		results = make([]parallelResult[S], len(locators))
+
+		// both channels are buffered so that lookups never block, even after this method returns
+		slots = make(chan struct{}, maxParallel)
+		done  = make(chan struct{}, len(locators))
+	)
+
+	for i, l := range locators {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		go func() {
+			defer func() {
+				<-slots
+				done <- struct{}{}
+			}()
+
+			results[i].svc, results[i].err = l.Find(key)
+		}()
+	}
+
+	for range locators {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	services := make([]S, 0, len(results))
+	for _, r := range results {
+		if r.err == nil {
+			services = append(services, r.svc)
+		} else if !errors.Is(r.err, ErrNoServices) {
+			return nil, r.err
+		}
+	}
+
+	if len(services) == 0 {
+		return nil, ErrNoServices
+	} else if dedupe {
+		services = dedupeServices(services)
+	}
+
+	return services, nil
+}
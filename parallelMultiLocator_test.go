@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// concurrency tracks the number of lookups running at once across a set of slowLocators.
+type concurrency struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (c *concurrency) enter() {
+	n := c.current.Add(1)
+	for {
+		m := c.max.Load()
+		if n <= m || c.max.CompareAndSwap(m, n) {
+			return
+		}
+	}
+}
+
+func (c *concurrency) exit() {
+	c.current.Add(-1)
+}
+
+// slowLocator is a Locator that takes a fixed amount of time for each lookup.
+type slowLocator struct {
+	delay time.Duration
+	svc   string
+	err   error
+	c     *concurrency
+}
+
+func (sl slowLocator) Find([]byte) (string, error) {
+	if sl.c != nil {
+		sl.c.enter()
+		defer sl.c.exit()
+	}
+
+	time.Sleep(sl.delay)
+	return sl.svc, sl.err
+}
+
+// gatedLocator is a Locator that blocks each lookup until its gate is closed, then
+// reports the key it was given.
+type gatedLocator struct {
+	started chan struct{}
+	gate    chan struct{}
+	keys    chan string
+}
+
+func (gl gatedLocator) Find(object []byte) (string, error) {
+	gl.started <- struct{}{}
+	<-gl.gate
+	gl.keys <- string(object)
+	return "gated", nil
+}
+
+type ParallelMultiLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+}
+
+func (suite *ParallelMultiLocatorSuite) SetupTest() {
+	suite.object = []byte("test value")
+}
+
+// slowLocators creates count slowLocators, each returning a distinct service.
+func (suite *ParallelMultiLocatorSuite) slowLocators(count int, delay time.Duration, c *concurrency) []Locator[string] {
+	ls := make([]Locator[string], 0, count)
+	for i := range count {
+		ls = append(ls, slowLocator{delay: delay, svc: fmt.Sprintf("service%d", i), c: c})
+	}
+
+	return ls
+}
+
+func (suite *ParallelMultiLocatorSuite) TestEmpty() {
+	pl := NewParallelMultiLocator[string](0, 0)
+	results, err := pl.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+
+	results, err = pl.FindString("test value")
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(results)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestSameAsSequential() {
+	var (
+		l1 = new(MockLocator[string])
+		l2 = new(MockLocator[string])
+		l3 = new(MockLocator[string])
+		l4 = new(MockLocator[string])
+	)
+
+	l1.ExpectFindSuccess(suite.object, "service1")
+	l2.ExpectFindNoServices(suite.object)
+	l3.ExpectFindSuccess(suite.object, "service3")
+	l4.ExpectFindSuccess(suite.object, "service4")
+
+	var (
+		ml = NewMultiLocator[string](l1, l2, l3)
+		pl = NewParallelMultiLocator[string](2, 0, l1, l2, l3)
+	)
+
+	ml.Add(l4)
+	pl.Add(l4)
+
+	for range 10 {
+		expected, expectedErr := ml.Find(suite.object)
+		actual, actualErr := pl.Find(suite.object)
+		suite.Equal(expectedErr, actualErr)
+		suite.Equal(expected, actual)
+		suite.Equal([]string{"service1", "service3", "service4"}, actual)
+	}
+
+	ml.Remove(l3)
+	pl.Remove(l3)
+	expected, _ := ml.FindString("test value")
+	actual, err := pl.FindString("test value")
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestDedupe() {
+	pl := NewParallelMultiLocator[string](0, 0,
+		// the delays make these locators distinct, so that they aren't deduped when added
+		slowLocator{svc: "service0"},
+		slowLocator{delay: 10 * time.Millisecond, svc: "service1"},
+		slowLocator{delay: time.Millisecond, svc: "service0"},
+		slowLocator{err: ErrNoServices},
+		slowLocator{delay: 2 * time.Millisecond, svc: "service1"},
+	)
+
+	results, err := pl.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service0", "service1", "service0", "service1"}, results)
+
+	pl.SetDedupe(true)
+	results, err = pl.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service0", "service1"}, results)
+
+	results, err = pl.FindString("test value")
+	suite.NoError(err)
+	suite.Equal([]string{"service0", "service1"}, results)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestError() {
+	var (
+		first  = errors.New("first")
+		second = errors.New("second")
+		pl     = NewParallelMultiLocator[string](0, 0,
+			slowLocator{svc: "service0"},
+			slowLocator{delay: 20 * time.Millisecond, err: first},
+			slowLocator{err: second},
+			slowLocator{err: ErrNoServices},
+		)
+	)
+
+	// the earliest locator's error wins, even though it finishes last
+	results, err := pl.Find(suite.object)
+	suite.ErrorIs(err, first)
+	suite.Empty(results)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestWallClock() {
+	const delay = 50 * time.Millisecond
+	pl := NewParallelMultiLocator(0, 0, suite.slowLocators(8, delay, nil)...)
+
+	start := time.Now()
+	results, err := pl.Find(suite.object)
+	elapsed := time.Since(start)
+
+	suite.NoError(err)
+	suite.Len(results, 8)
+	suite.Equal("service0", results[0])
+	suite.Equal("service7", results[7])
+
+	// sequential lookups would take 8 times the delay
+	suite.Less(elapsed, 4*delay)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestMaxParallel() {
+	var (
+		c  = new(concurrency)
+		pl = NewParallelMultiLocator(3, 0, suite.slowLocators(10, 5*time.Millisecond, c)...)
+	)
+
+	results, err := pl.Find(suite.object)
+	suite.NoError(err)
+	suite.Len(results, 10)
+	suite.Equal(int32(3), c.max.Load())
+	suite.Zero(c.current.Load())
+}
+
+func (suite *ParallelMultiLocatorSuite) TestTimeout() {
+	ls := suite.slowLocators(2, 0, nil)
+	ls = append(ls, slowLocator{delay: time.Second, svc: "slow"})
+	pl := NewParallelMultiLocator(0, 20*time.Millisecond, ls...)
+
+	start := time.Now()
+	results, err := pl.Find(suite.object)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Empty(results)
+	suite.Less(time.Since(start), 500*time.Millisecond)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestCanceled() {
+	var (
+		pl          = NewParallelMultiLocator(1, 0, suite.slowLocators(3, time.Second, nil)...)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+
+	cancel()
+	results, err := pl.FindContext(ctx, suite.object)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Empty(results)
+}
+
+func (suite *ParallelMultiLocatorSuite) TestCanceledReusesBuffer() {
+	var (
+		gl = gatedLocator{
+			started: make(chan struct{}, 1),
+			gate:    make(chan struct{}),
+			keys:    make(chan string, 1),
+		}
+
+		pl          = NewParallelMultiLocator[string](0, 0, gl)
+		ctx, cancel = context.WithCancel(context.Background())
+		buffer      = []byte("original key")
+		result      = make(chan error, 1)
+	)
+
+	go func() {
+		_, err := pl.FindContext(ctx, buffer)
+		result <- err
+	}()
+
+	<-gl.started
+	cancel()
+	suite.ErrorIs(<-result, context.Canceled)
+
+	// the caller reuses its buffer while the lookup is still running
+	copy(buffer, "overwritten!")
+	close(gl.gate)
+	suite.Equal("original key", <-gl.keys)
+}
+
+func TestParallelMultiLocator(t *testing.T) {
+	suite.Run(t, new(ParallelMultiLocatorSuite))
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// peerReport is the most recent fingerprint reported for a peer.
+type peerReport struct {
+	fingerprint uint64
+	at          time.Time
+}
+
+// AgreementStatus describes how well a set of peers agree on their services.
+type AgreementStatus struct {
+	// Peers is the number of peers with a current report.
+	Peers int
+
+	// Fingerprints are the distinct fingerprints currently reported. They are ordered by
+	// the number of peers that reported them, most first, and then by value.
+	Fingerprints []uint64
+
+	// Majority is the fingerprint reported by more peers than any other. This field is
+	// only meaningful if HasMajority is true.
+	Majority uint64
+
+	// HasMajority indicates whether a single fingerprint was reported by more peers than
+	// any other. This is false if there are no current reports or if the most common
+	// fingerprints are tied.
+	HasMajority bool
+
+	// Divergent are the peers, in sorted order, that did not report the Majority fingerprint.
+	// If there is no majority, every peer is divergent.
+	Divergent []string
+}
+
+// Agreed tests if every current peer reported the same fingerprint. This is true if there
+// are no current peers.
+func (as AgreementStatus) Agreed() bool {
+	return len(as.Fingerprints) < 2
+}
+
+// PeerAgreement tracks the membership fingerprints reported by peer processes, e.g. replicas
+// of a router that each build their own ring from service discovery, in order to detect when
+// peers disagree on their services.
+//
+// This type is transport-agnostic. Callers exchange fingerprints among themselves, such as
+// via gossip or a shared store, and then call Report for each peer. A peer's report expires
+// once it is older than the maximum age, so peers that stop reporting eventually drop out.
+//
+// Methods on this type are safe for concurrent usage.
+type PeerAgreement struct {
+	maxAge time.Duration
+	now    func() time.Time
+
+	lock    sync.Mutex
+	reports map[string]peerReport
+}
+
+// NewPeerAgreement creates a PeerAgreement whose reports expire after maxAge. If maxAge is
+// nonpositive, reports never expire. If now is nil, time.Now is used.
+func NewPeerAgreement(maxAge time.Duration, now func() time.Time) *PeerAgreement {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &PeerAgreement{
+		maxAge:  maxAge,
+		now:     now,
+		reports: make(map[string]peerReport),
+	}
+}
+
+// Report records the fingerprint a peer had at the given time. A report that is older than
+// the peer's current report is ignored, so reports may be delivered out of order.
+func (pa *PeerAgreement) Report(peerID string, fingerprint uint64, at time.Time) {
+	defer pa.lock.Unlock()
+	pa.lock.Lock()
+
+	if current, exists := pa.reports[peerID]; !exists || !at.Before(current.at) {
+		pa.reports[peerID] = peerReport{
+			fingerprint: fingerprint,
+			at:          at,
+		}
+	}
+}
+
+// Forget removes a peer's report, e.g. when a peer is known to have shut down.
+func (pa *PeerAgreement) Forget(peerID string) {
+	defer pa.lock.Unlock()
+	pa.lock.Lock()
+
+	delete(pa.reports, peerID)
+}
+
+// Status returns the current agreement among peers. Expired reports are discarded.
+func (pa *PeerAgreement) Status() (as AgreementStatus) {
+	defer pa.lock.Unlock()
+	pa.lock.Lock()
+
+	if pa.maxAge > 0 {
+		oldest := pa.now().Add(-pa.maxAge)
+		for peerID, r := range pa.reports {
+			if r.at.Before(oldest) {
+				delete(pa.reports, peerID)
+			}
+		}
+	}
+
+	as.Peers = len(pa.reports)
+	counts := make(map[uint64]int)
+	for _, r := range pa.reports {
+		if counts[r.fingerprint] == 0 {
+			as.Fingerprints = append(as.Fingerprints, r.fingerprint)
+		}
+
+		counts[r.fingerprint]++
+	}
+
+	slices.SortFunc(as.Fingerprints, func(a, b uint64) int {
+		return cmp.Or(
+			cmp.Compare(counts[b], counts[a]),
+			cmp.Compare(a, b),
+		)
+	})
+
+	switch {
+	case len(as.Fingerprints) == 0:
+		// no current reports
+
+	case len(as.Fingerprints) == 1 || counts[as.Fingerprints[0]] > counts[as.Fingerprints[1]]:
+		as.Majority, as.HasMajority = as.Fingerprints[0], true
+	}
+
+	for peerID, r := range pa.reports {
+		if !as.HasMajority || r.fingerprint != as.Majority {
+			as.Divergent = append(as.Divergent, peerID)
+		}
+	}
+
+	slices.Sort(as.Divergent)
+	return
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testMaxReportAge = time.Minute
+
+type PeerAgreementSuite struct {
+	suite.Suite
+
+	current time.Time
+}
+
+func (suite *PeerAgreementSuite) SetupTest() {
+	suite.current = time.Now()
+}
+
+// now is the fake clock.
+func (suite *PeerAgreementSuite) now() time.Time {
+	return suite.current
+}
+
+func (suite *PeerAgreementSuite) newPeerAgreement() *PeerAgreement {
+	pa := NewPeerAgreement(testMaxReportAge, suite.now)
+	suite.Require().NotNil(pa)
+	return pa
+}
+
+func (suite *PeerAgreementSuite) TestEmpty() {
+	as := suite.newPeerAgreement().Status()
+	suite.Zero(as.Peers)
+	suite.Empty(as.Fingerprints)
+	suite.False(as.HasMajority)
+	suite.Empty(as.Divergent)
+	suite.True(as.Agreed())
+}
+
+func (suite *PeerAgreementSuite) TestUnanimous() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 123, suite.now())
+	pa.Report("peer2", 123, suite.now())
+	pa.Report("peer3", 123, suite.now())
+
+	as := pa.Status()
+	suite.Equal(3, as.Peers)
+	suite.Equal([]uint64{123}, as.Fingerprints)
+	suite.True(as.HasMajority)
+	suite.Equal(uint64(123), as.Majority)
+	suite.Empty(as.Divergent)
+	suite.True(as.Agreed())
+}
+
+func (suite *PeerAgreementSuite) TestSingleDivergent() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 123, suite.now())
+	pa.Report("peer2", 456, suite.now())
+	pa.Report("peer3", 123, suite.now())
+
+	as := pa.Status()
+	suite.Equal(3, as.Peers)
+	suite.Equal([]uint64{123, 456}, as.Fingerprints)
+	suite.True(as.HasMajority)
+	suite.Equal(uint64(123), as.Majority)
+	suite.Equal([]string{"peer2"}, as.Divergent)
+	suite.False(as.Agreed())
+
+	// the divergent peer catches up
+	pa.Report("peer2", 123, suite.now())
+	as = pa.Status()
+	suite.Empty(as.Divergent)
+	suite.True(as.Agreed())
+}
+
+func (suite *PeerAgreementSuite) TestMajority() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 1, suite.now())
+	pa.Report("peer2", 2, suite.now())
+	pa.Report("peer3", 2, suite.now())
+	pa.Report("peer4", 3, suite.now())
+	pa.Report("peer5", 3, suite.now())
+	pa.Report("peer6", 3, suite.now())
+
+	// a plurality is enough for a majority
+	as := pa.Status()
+	suite.Equal([]uint64{3, 2, 1}, as.Fingerprints)
+	suite.True(as.HasMajority)
+	suite.Equal(uint64(3), as.Majority)
+	suite.Equal([]string{"peer1", "peer2", "peer3"}, as.Divergent)
+}
+
+func (suite *PeerAgreementSuite) TestTie() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 456, suite.now())
+	pa.Report("peer2", 123, suite.now())
+	pa.Report("peer3", 456, suite.now())
+	pa.Report("peer4", 123, suite.now())
+	pa.Report("peer5", 789, suite.now())
+
+	// a tie has no majority, so every peer diverges
+	as := pa.Status()
+	suite.Equal(5, as.Peers)
+	suite.Equal([]uint64{123, 456, 789}, as.Fingerprints)
+	suite.False(as.HasMajority)
+	suite.Zero(as.Majority)
+	suite.Equal([]string{"peer1", "peer2", "peer3", "peer4", "peer5"}, as.Divergent)
+
+	// a single report breaks the tie
+	pa.Report("peer5", 456, suite.now())
+	as = pa.Status()
+	suite.True(as.HasMajority)
+	suite.Equal(uint64(456), as.Majority)
+	suite.Equal([]string{"peer2", "peer4"}, as.Divergent)
+}
+
+func (suite *PeerAgreementSuite) TestExpiry() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 123, suite.now())
+	pa.Report("peer2", 456, suite.now().Add(-testMaxReportAge/2))
+	pa.Report("peer3", 789, suite.now().Add(-2*testMaxReportAge))
+
+	// peer3 has already expired
+	as := pa.Status()
+	suite.Equal(2, as.Peers)
+	suite.ElementsMatch([]uint64{123, 456}, as.Fingerprints)
+
+	// exactly at the maximum age, a report is still current
+	suite.current = suite.current.Add(testMaxReportAge / 2)
+	suite.Equal(2, pa.Status().Peers)
+
+	suite.current = suite.current.Add(time.Nanosecond)
+	as = pa.Status()
+	suite.Equal(1, as.Peers)
+	suite.Equal([]uint64{123}, as.Fingerprints)
+	suite.True(as.Agreed())
+
+	suite.current = suite.current.Add(testMaxReportAge)
+	suite.Zero(pa.Status().Peers)
+}
+
+func (suite *PeerAgreementSuite) TestNoExpiry() {
+	pa := NewPeerAgreement(0, nil)
+	pa.Report("peer1", 123, time.Now().Add(-24*time.Hour))
+	suite.Equal(1, pa.Status().Peers)
+}
+
+func (suite *PeerAgreementSuite) TestOutOfOrder() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 456, suite.now())
+
+	// an older report doesn't replace a newer one
+	pa.Report("peer1", 123, suite.now().Add(-time.Second))
+	suite.Equal([]uint64{456}, pa.Status().Fingerprints)
+
+	pa.Report("peer1", 789, suite.now().Add(time.Second))
+	suite.Equal([]uint64{789}, pa.Status().Fingerprints)
+}
+
+func (suite *PeerAgreementSuite) TestForget() {
+	pa := suite.newPeerAgreement()
+	pa.Report("peer1", 123, suite.now())
+	pa.Report("peer2", 456, suite.now())
+	suite.False(pa.Status().Agreed())
+
+	pa.Forget("peer2")
+	pa.Forget("nosuch")
+	as := pa.Status()
+	suite.Equal(1, as.Peers)
+	suite.True(as.Agreed())
+}
+
+func TestPeerAgreement(t *testing.T) {
+	suite.Run(t, new(PeerAgreementSuite))
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultRetryBackoff is the time a RetryingLocator waits before retrying when
+	// no backoff is supplied.
+	DefaultRetryBackoff = time.Millisecond
+)
+
+// RetryingLocator is a Locator decorator that retries a lookup exactly once when it fails
+// with ErrNoServices. This smooths over brief, transient windows with no services, such as
+// when an UpdatableLocator is cleared before its replacement is set.
+//
+// Unlike WaitingLocator, a RetryingLocator never waits for an update, so its added latency
+// is bounded by its backoff. Any other result from the wrapped Locator is returned immediately.
+type RetryingLocator[S Service] struct {
+	next    Locator[S]
+	backoff time.Duration
+
+	// after is the clock used for the backoff. Tests may replace it.
+	after func(time.Duration) <-chan time.Time
+}
+
+// NewRetryingLocator decorates a Locator so that a lookup that fails with ErrNoServices is
+// retried once after the given backoff. If backoff is nonpositive, DefaultRetryBackoff is used.
+func NewRetryingLocator[S Service](next Locator[S], backoff time.Duration) *RetryingLocator[S] {
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	return &RetryingLocator[S]{
+		next:    next,
+		backoff: backoff,
+		after:   time.After,
+	}
+}
+
+var _ AnnotatedLocator[string] = (*RetryingLocator[string])(nil)
+
+// Find locates a service for the given object, retrying once if there are no services.
+func (rl *RetryingLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, err = rl.next.Find(object)
+	if errors.Is(err, ErrNoServices) {
+		<-rl.after(rl.backoff)
+		svc, err = rl.next.Find(object)
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but returns the wrapped Locator's annotation.
+func (rl *RetryingLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	result, err = Explain(rl.next, object)
+	if errors.Is(err, ErrNoServices) {
+		<-rl.after(rl.backoff)
+		result, err = Explain(rl.next, object)
+	}
+
+	return
+}
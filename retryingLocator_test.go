@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testRetryBackoff = 5 * time.Millisecond
+
+type RetryingLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+
+	// afterCalls receives the duration of each call to the fake clock
+	afterCalls []time.Duration
+}
+
+func (suite *RetryingLocatorSuite) SetupTest() {
+	suite.object = []byte("test")
+	suite.afterCalls = nil
+}
+
+// after is a fake clock that fires immediately.
+func (suite *RetryingLocatorSuite) after(d time.Duration) <-chan time.Time {
+	suite.afterCalls = append(suite.afterCalls, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func (suite *RetryingLocatorSuite) newRetryingLocator(next Locator[string]) *RetryingLocator[string] {
+	rl := NewRetryingLocator(next, testRetryBackoff)
+	rl.after = suite.after
+	return rl
+}
+
+func (suite *RetryingLocatorSuite) TestDefaultBackoff() {
+	rl := NewRetryingLocator[string](new(MockLocator[string]), 0)
+	suite.Equal(DefaultRetryBackoff, rl.backoff)
+}
+
+func (suite *RetryingLocatorSuite) TestSuccess() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service").Once()
+
+	svc, err := suite.newRetryingLocator(l).Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service", svc)
+	suite.Empty(suite.afterCalls)
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *RetryingLocatorSuite) TestRetrySucceeds() {
+	l := new(MockLocator[string])
+	l.ExpectFindNoServices(suite.object).Once()
+	l.ExpectFindSuccess(suite.object, "service").Once()
+
+	svc, err := suite.newRetryingLocator(l).Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service", svc)
+	suite.Equal([]time.Duration{testRetryBackoff}, suite.afterCalls)
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *RetryingLocatorSuite) TestRetryFails() {
+	// only a single retry is made
+	l := new(MockLocator[string])
+	l.ExpectFindNoServices(suite.object).Twice()
+
+	_, err := suite.newRetryingLocator(l).Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Len(suite.afterCalls, 1)
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *RetryingLocatorSuite) TestOtherError() {
+	expectedErr := errors.New("expected")
+	l := new(MockLocator[string])
+	l.ExpectFindFail(suite.object, expectedErr).Once()
+
+	_, err := suite.newRetryingLocator(l).Find(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(suite.afterCalls)
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func TestRetryingLocator(t *testing.T) {
+	suite.Run(t, new(RetryingLocatorSuite))
+}
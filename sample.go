@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import "sync/atomic"
+
+// SampleEvery decorates a callback so that it is only invoked once for every n calls,
+// starting with the nth call. This is useful for trace hooks on hot paths, such as
+// lookups, where tracing every call would be too expensive.
+//
+// If n is 0 or 1, fn is returned as is. The returned function is safe for concurrent use.
+func SampleEvery[T any](n uint64, fn func(T)) func(T) {
+	if n <= 1 {
+		return fn
+	}
+
+	var counter atomic.Uint64
+	return func(v T) {
+		if counter.Add(1)%n == 0 {
+			fn(v)
+		}
+	}
+}
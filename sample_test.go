@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SampleSuite struct {
+	suite.Suite
+}
+
+func (suite *SampleSuite) testSampleEvery(n uint64, calls int, expected []int) func() {
+	return func() {
+		var (
+			actual  []int
+			sampled = SampleEvery(n, func(v int) { actual = append(actual, v) })
+		)
+
+		suite.Require().NotNil(sampled)
+		for i := 1; i <= calls; i++ {
+			sampled(i)
+		}
+
+		suite.Equal(expected, actual)
+	}
+}
+
+func (suite *SampleSuite) TestSampleEvery() {
+	testCases := []struct {
+		n        uint64
+		calls    int
+		expected []int
+	}{
+		{n: 0, calls: 3, expected: []int{1, 2, 3}},
+		{n: 1, calls: 3, expected: []int{1, 2, 3}},
+		{n: 2, calls: 5, expected: []int{2, 4}},
+		{n: 3, calls: 10, expected: []int{3, 6, 9}},
+		{n: 100, calls: 10, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(
+			strconv.FormatUint(testCase.n, 10),
+			suite.testSampleEvery(testCase.n, testCase.calls, testCase.expected),
+		)
+	}
+}
+
+func TestSample(t *testing.T) {
+	suite.Run(t, new(SampleSuite))
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultReservoirSize is the number of observations a SamplingObserver
+	// retains when no size is supplied.
+	DefaultReservoirSize = 1024
+)
+
+// Observation is a single lookup retained by a SamplingObserver.
+type Observation[S Service] struct {
+	// Hash is the hash of the object that was looked up.
+	Hash uint64
+
+	// Service is the service that the object was routed to.
+	Service S
+}
+
+// SamplingReport is a snapshot of a SamplingObserver's reservoir.
+type SamplingReport[S Service] struct {
+	// Counts is the number of retained observations for each service.
+	Counts Map[S, int]
+
+	// Sampled is the number of retained observations, which is the sum of Counts.
+	Sampled int
+
+	// Observed is the total number of observations since creation or the last Reset.
+	Observed uint64
+}
+
+// SamplingObserver keeps a fixed-size, uniformly random sample of lookups using reservoir
+// sampling, which is useful for monitoring the live distribution of production objects
+// across services.
+//
+// A SamplingObserver can decorate a Locator, in which case each successful lookup is
+// observed. Alternatively, Observe can be called directly, e.g. from a Ring's OnFind hook.
+//
+// Once the reservoir is full, most observations are not retained, and those observations
+// take no locks. Methods on this type are safe for concurrent usage.
+type SamplingObserver[S Service] struct {
+	next Locator[S]
+	alg  Algorithm
+
+	// uint64n returns a random number in [0, n). Tests may replace it.
+	uint64n func(n uint64) uint64
+
+	observed atomic.Uint64
+
+	lock      sync.Mutex
+	reservoir []Observation[S]
+	filled    []bool
+}
+
+// NewSamplingObserver creates a SamplingObserver that retains at most size observations.
+// If size is nonpositive, DefaultReservoirSize is used.
+//
+// The next Locator may be nil, in which case Find returns ErrNoServices and observations
+// can only be supplied with Observe.
+func NewSamplingObserver[S Service](next Locator[S], size int) *SamplingObserver[S] {
+	if size < 1 {
+		size = DefaultReservoirSize
+	}
+
+	return &SamplingObserver[S]{
+		next:      next,
+		alg:       DefaultAlgorithm(),
+		uint64n:   rand.Uint64N,
+		reservoir: make([]Observation[S], size),
+		filled:    make([]bool, size),
+	}
+}
+
+var _ AnnotatedLocator[string] = (*SamplingObserver[string])(nil)
+
+// Find consults the decorated Locator and observes the result of a successful lookup.
+// Objects are hashed with the default algorithm.
+func (so *SamplingObserver[S]) Find(object []byte) (svc S, err error) {
+	if so.next == nil {
+		err = ErrNoServices
+		return
+	}
+
+	svc, err = so.next.Find(object)
+	if err == nil {
+		so.Observe(so.alg.Sum64Bytes(object), svc)
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but returns the decorated Locator's annotation. Successful
+// lookups are observed in the same way as Find.
+func (so *SamplingObserver[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	if so.next == nil {
+		err = ErrNoServices
+		return
+	}
+
+	result, err = Explain(so.next, object)
+	if err == nil {
+		so.Observe(so.alg.Sum64Bytes(object), result.Service)
+	}
+
+	return
+}
+
+// Observe records a lookup of an object with the given hash that was routed to
+// the given service.
+func (so *SamplingObserver[S]) Observe(hash uint64, svc S) {
+	var (
+		n    = so.observed.Add(1)
+		size = uint64(len(so.reservoir))
+		slot uint64
+	)
+
+	if n <= size {
+		slot = n - 1
+	} else if slot = so.uint64n(n); slot >= size {
+		// the common case once the reservoir is full: this observation is not retained
+		return
+	}
+
+	so.lock.Lock()
+	so.reservoir[slot] = Observation[S]{Hash: hash, Service: svc}
+	so.filled[slot] = true
+	so.lock.Unlock()
+}
+
+// Samples returns a copy of the observations currently in the reservoir.
+func (so *SamplingObserver[S]) Samples() []Observation[S] {
+	defer so.lock.Unlock()
+	so.lock.Lock()
+
+	samples := make([]Observation[S], 0, len(so.reservoir))
+	for i, o := range so.reservoir {
+		if so.filled[i] {
+			samples = append(samples, o)
+		}
+	}
+
+	return samples
+}
+
+// Report summarizes the reservoir by service.
+func (so *SamplingObserver[S]) Report() SamplingReport[S] {
+	defer so.lock.Unlock()
+	so.lock.Lock()
+
+	report := SamplingReport[S]{
+		Counts:   make(Map[S, int]),
+		Observed: so.observed.Load(),
+	}
+
+	for i, o := range so.reservoir {
+		if so.filled[i] {
+			report.Counts[o.Service]++
+			report.Sampled++
+		}
+	}
+
+	return report
+}
+
+// Reset empties the reservoir and zeroes the number of observations. An observation
+// that is concurrent with Reset may be retained in the new reservoir.
+func (so *SamplingObserver[S]) Reset() {
+	defer so.lock.Unlock()
+	so.lock.Lock()
+
+	clear(so.reservoir)
+	clear(so.filled)
+	so.observed.Store(0)
+}
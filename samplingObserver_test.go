@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SamplingObserverSuite struct {
+	suite.Suite
+}
+
+// newSamplingObserver creates a SamplingObserver with deterministic randomness.
+func (suite *SamplingObserverSuite) newSamplingObserver(next Locator[string], size int) *SamplingObserver[string] {
+	so := NewSamplingObserver(next, size)
+	suite.Require().NotNil(so)
+	so.uint64n = rand.New(rand.NewPCG(1234, 5678)).Uint64N
+	return so
+}
+
+func (suite *SamplingObserverSuite) TestDefaultSize() {
+	so := NewSamplingObserver[string](nil, 0)
+	suite.Len(so.reservoir, DefaultReservoirSize)
+}
+
+func (suite *SamplingObserverSuite) TestSize() {
+	so := suite.newSamplingObserver(nil, 10)
+
+	report := so.Report()
+	suite.Empty(report.Counts)
+	suite.Zero(report.Sampled)
+	suite.Zero(report.Observed)
+
+	// every observation is retained until the reservoir is full
+	for i := range 10 {
+		so.Observe(uint64(i), "service")
+		suite.Equal(i+1, so.Report().Sampled)
+	}
+
+	for i := range 1000 {
+		so.Observe(uint64(i), "service")
+	}
+
+	report = so.Report()
+	suite.Equal(10, report.Sampled)
+	suite.Equal(map[string]int{"service": 10}, map[string]int(report.Counts))
+	suite.Equal(uint64(1010), report.Observed)
+	suite.Len(so.Samples(), 10)
+}
+
+func (suite *SamplingObserverSuite) TestDistribution() {
+	const (
+		size         = 1000
+		observations = 100_000
+	)
+
+	var (
+		so       = suite.newSamplingObserver(nil, size)
+		expected = map[string]float64{"a": 0.5, "b": 0.3, "c": 0.2}
+		random   = rand.New(rand.NewPCG(8, 16))
+	)
+
+	for i := range observations {
+		svc := "c"
+		switch r := random.Float64(); {
+		case r < 0.5:
+			svc = "a"
+		case r < 0.8:
+			svc = "b"
+		}
+
+		// the hash records when the observation happened
+		so.Observe(uint64(i), svc)
+	}
+
+	report := so.Report()
+	suite.Equal(size, report.Sampled)
+	suite.Equal(uint64(observations), report.Observed)
+	suite.Len(report.Counts, len(expected))
+	for svc, fraction := range expected {
+		// the binomial standard deviation is at most about 16, so this is over 5 deviations
+		suite.InDelta(fraction*size, report.Counts[svc], 80, "service %s", svc)
+	}
+
+	// every part of the stream is equally likely to be retained
+	var early int
+	for _, o := range so.Samples() {
+		if o.Hash < observations/2 {
+			early++
+		}
+	}
+
+	suite.InDelta(size/2, early, 80)
+}
+
+func (suite *SamplingObserverSuite) TestReset() {
+	so := suite.newSamplingObserver(nil, 10)
+	for i := range 100 {
+		so.Observe(uint64(i), "old")
+	}
+
+	so.Reset()
+	report := so.Report()
+	suite.Empty(report.Counts)
+	suite.Zero(report.Sampled)
+	suite.Zero(report.Observed)
+	suite.Empty(so.Samples())
+
+	for i := range 5 {
+		so.Observe(uint64(i), "new")
+	}
+
+	report = so.Report()
+	suite.Equal(map[string]int{"new": 5}, map[string]int(report.Counts))
+	suite.Equal(5, report.Sampled)
+	suite.Equal(uint64(5), report.Observed)
+}
+
+func (suite *SamplingObserverSuite) TestFind() {
+	var (
+		expectedErr = errors.New("expected")
+		next        = new(MockLocator[string])
+		so          = suite.newSamplingObserver(next, 10)
+	)
+
+	next.ExpectFindSuccess([]byte("success"), "service").Once()
+	next.ExpectFindNoServices([]byte("missing")).Once()
+	next.ExpectFindFail([]byte("fail"), expectedErr).Once()
+
+	svc, err := so.Find([]byte("success"))
+	suite.NoError(err)
+	suite.Equal("service", svc)
+
+	_, err = so.Find([]byte("missing"))
+	suite.ErrorIs(err, ErrNoServices)
+
+	_, err = so.Find([]byte("fail"))
+	suite.ErrorIs(err, expectedErr)
+
+	// only successful lookups are observed
+	suite.Equal(
+		[]Observation[string]{{Hash: DefaultAlgorithm().Sum64Bytes([]byte("success")), Service: "service"}},
+		so.Samples(),
+	)
+
+	next.AssertExpectations(suite.T())
+}
+
+func (suite *SamplingObserverSuite) TestFindNoLocator() {
+	so := suite.newSamplingObserver(nil, 10)
+	_, err := so.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Zero(so.Report().Observed)
+}
+
+func (suite *SamplingObserverSuite) TestConcurrency() {
+	const (
+		goroutines   = 8
+		observations = 10_000
+	)
+
+	var (
+		so = NewSamplingObserver[string](nil, 100)
+		wg sync.WaitGroup
+	)
+
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range observations {
+				so.Observe(uint64(i), "service")
+				if i%1000 == 0 {
+					so.Report()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	report := so.Report()
+	suite.Equal(100, report.Sampled)
+	suite.Equal(uint64(goroutines*observations), report.Observed)
+}
+
+func TestSamplingObserver(t *testing.T) {
+	suite.Run(t, new(SamplingObserverSuite))
+}
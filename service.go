@@ -7,7 +7,8 @@ import (
 	"fmt"
 	"io"
 	"iter"
-	"unsafe"
+	"maps"
+	"slices"
 )
 
 // Service represents some sort of endpoint that objects can be hashed to.
@@ -23,6 +24,9 @@ type Service interface {
 // ServiceHasher handles writing a Service's hashable bytes.  This closure
 // type is responsible for converting a Service object into a series of
 // bytes to submit to a hashing function.
+//
+// New ServiceHashers for struct services should use a HashBuilder's varint and
+// delimited methods, which are the recommended canonical encoding.
 type ServiceHasher[S Service] func(io.Writer, S) error
 
 // DefaultServiceHasher uses fmt.Fprint to write a service object's
@@ -36,6 +40,22 @@ func DefaultServiceHasher[S Service](dst io.Writer, service S) error {
 	return err
 }
 
+// SumService computes the hash of a service with the given algorithm, using sh to write
+// the service's hash bytes. The result is the same as hashing those bytes with the
+// algorithm's Sum64Bytes method. If sh is nil, DefaultServiceHasher is used.
+func SumService[S Service](alg Algorithm, sh ServiceHasher[S], svc S) (uint64, error) {
+	if sh == nil {
+		sh = DefaultServiceHasher[S]
+	}
+
+	h := alg.New64()
+	if err := sh(h, svc); err != nil {
+		return 0, err
+	}
+
+	return h.Sum64(), nil
+}
+
 // StringService is a service whose underlying type is a string. Hostnames,
 // URLs, service locator ids, etc. are usually of this type.
 type StringService interface {
@@ -48,7 +68,7 @@ type StringService interface {
 //
 // The string is written is such a way as to minimize allocations.
 func HashStringTo[SS StringService](dst io.Writer, service SS) error {
-	serviceBytes := unsafe.Slice(unsafe.StringData(string(service)), len(service))
+	serviceBytes := stringToBytes(string(service))
 	_, err := dst.Write(serviceBytes)
 	return err
 }
@@ -91,6 +111,54 @@ func (m Map[S, V]) Update(services ...S) iter.Seq[Update[S, V]] {
 	}
 }
 
+// All returns a sequence of the services in this map, in no particular order.
+//
+// As with ranging over any map, services deleted from this map during iteration are
+// not yielded if they haven't been reached yet, and services added during iteration
+// may or may not be yielded. Use AllSorted for a snapshot.
+func (m Map[S, V]) All() iter.Seq[S] {
+	return func(f func(S) bool) {
+		for svc := range m {
+			if !f(svc) {
+				return
+			}
+		}
+	}
+}
+
+// AllSorted returns a sequence of the services in this map, ordered by the given comparison
+// function. The services are copied when iteration begins, so later changes to this map don't
+// affect an iteration in progress.
+func (m Map[S, V]) AllSorted(cmp func(S, S) int) iter.Seq[S] {
+	return func(f func(S) bool) {
+		for _, svc := range slices.SortedFunc(maps.Keys(m), cmp) {
+			if !f(svc) {
+				return
+			}
+		}
+	}
+}
+
+// Added returns a sequence of the services in this map that are not in previous, in no
+// particular order. This is the set difference of this map minus previous. Changes to
+// either map during iteration behave as described for All.
+func (m Map[S, V]) Added(previous Map[S, V]) iter.Seq[S] {
+	return func(f func(S) bool) {
+		for svc := range m {
+			if _, exists := previous[svc]; !exists && !f(svc) {
+				return
+			}
+		}
+	}
+}
+
+// Removed returns a sequence of the services in previous that are not in this map, in no
+// particular order. This is the set difference of previous minus this map. Changes to
+// either map during iteration behave as described for All.
+func (m Map[S, V]) Removed(previous Map[S, V]) iter.Seq[S] {
+	return previous.Added(m)
+}
+
 // BasicService is a URI-based service object that represents the typical things an application
 // needs when describing a service. A string can hold the information in this struct,
 // but sometimes an application needs easy access to the individual parts of a URI.
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ReadServiceList reads newline-delimited service names, such as hostnames or URLs.
+// Leading and trailing whitespace is trimmed from each line, which means that both LF
+// and CRLF line endings are supported. Blank lines and lines beginning with '#' are skipped.
+//
+// The entire input is read before any services are parsed, so that the returned
+// slice can be allocated exactly once.
+func ReadServiceList[SS StringService](r io.Reader) ([]SS, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		services = make([]SS, 0, bytes.Count(data, []byte{'\n'})+1)
+
+		// a single string backs every service, so that each line doesn't need its own allocation
+		text = string(data)
+	)
+
+	for len(text) > 0 {
+		var line string
+		line, text, _ = strings.Cut(text, "\n")
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			services = append(services, SS(line))
+		}
+	}
+
+	return services, nil
+}
+
+// WriteServiceList writes services in the format read by ReadServiceList. The services
+// are written in sorted order so that the output is stable and easily compared. The
+// given slice is not modified.
+func WriteServiceList[SS StringService](w io.Writer, services []SS) error {
+	sorted := slices.Clone(services)
+	slices.Sort(sorted)
+
+	bw := bufio.NewWriter(w)
+	for _, svc := range sorted {
+		bw.WriteString(string(svc))
+		bw.WriteByte('\n')
+	}
+
+	// bufio.Writer retains the first error, so any write error is reported here
+	return bw.Flush()
+}
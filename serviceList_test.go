@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceListSuite struct {
+	suite.Suite
+}
+
+func (suite *ServiceListSuite) testReadServiceListEmpty() {
+	services, err := ReadServiceList[string](strings.NewReader(""))
+	suite.NoError(err)
+	suite.Empty(services)
+}
+
+func (suite *ServiceListSuite) testReadServiceListCommentsAndBlanks() {
+	const input = `
+# this is a comment
+service1.net
+
+   service2.net
+	# an indented comment
+service3.net`
+
+	services, err := ReadServiceList[string](strings.NewReader(input))
+	suite.NoError(err)
+	suite.Equal([]string{"service1.net", "service2.net", "service3.net"}, services)
+}
+
+func (suite *ServiceListSuite) testReadServiceListCRLF() {
+	const input = "service1.net\r\n\r\n#comment\r\nservice2.net\r\n"
+
+	services, err := ReadServiceList[string](strings.NewReader(input))
+	suite.NoError(err)
+	suite.Equal([]string{"service1.net", "service2.net"}, services)
+}
+
+func (suite *ServiceListSuite) testReadServiceListError() {
+	expectedErr := errors.New("expected")
+	services, err := ReadServiceList[string](iotest.ErrReader(expectedErr))
+	suite.ErrorIs(err, expectedErr)
+	suite.Nil(services)
+}
+
+func (suite *ServiceListSuite) TestReadServiceList() {
+	suite.Run("Empty", suite.testReadServiceListEmpty)
+	suite.Run("CommentsAndBlanks", suite.testReadServiceListCommentsAndBlanks)
+	suite.Run("CRLF", suite.testReadServiceListCRLF)
+	suite.Run("Error", suite.testReadServiceListError)
+}
+
+func (suite *ServiceListSuite) TestRoundTrip() {
+	type host string
+	original := []host{"service3.net", "service1.net", "service2.net"}
+
+	var b bytes.Buffer
+	suite.Require().NoError(WriteServiceList(&b, original))
+	suite.Equal("service1.net\nservice2.net\nservice3.net\n", b.String())
+
+	// the original slice must not be sorted in place
+	suite.Equal([]host{"service3.net", "service1.net", "service2.net"}, original)
+
+	services, err := ReadServiceList[host](&b)
+	suite.NoError(err)
+	suite.Equal([]host{"service1.net", "service2.net", "service3.net"}, services)
+}
+
+func (suite *ServiceListSuite) TestWriteServiceListError() {
+	expectedErr := errors.New("expected")
+	err := WriteServiceList(errWriter{err: expectedErr}, []string{"service1.net"})
+	suite.ErrorIs(err, expectedErr)
+}
+
+func TestServiceList(t *testing.T) {
+	suite.Run(t, new(ServiceListSuite))
+}
+
+// errWriter is an io.Writer that always fails.
+type errWriter struct {
+	err error
+}
+
+func (ew errWriter) Write([]byte) (int, error) {
+	return 0, ew.err
+}
+
+// benchmarkServiceList is a large, newline-delimited list of services.
+var benchmarkServiceList = func() string {
+	var b strings.Builder
+	for i := range 50000 {
+		fmt.Fprintf(&b, "service-%d.example.net\n", i)
+	}
+
+	return b.String()
+}()
+
+func BenchmarkReadServiceList(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		ReadServiceList[string](strings.NewReader(benchmarkServiceList))
+	}
+}
+
+func BenchmarkReadServiceListNaive(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		var (
+			services []string
+			scanner  = bufio.NewScanner(strings.NewReader(benchmarkServiceList))
+		)
+
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); len(line) > 0 {
+				services = append(services, line)
+			}
+		}
+	}
+}
@@ -5,6 +5,10 @@ package medley
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -49,6 +53,30 @@ func (suite *ServiceSuite) TestHashBasicServiceTo() {
 	suite.NotZero(b.Len())
 }
 
+func (suite *ServiceSuite) TestSumService() {
+	alg := DefaultAlgorithm()
+	actual, err := SumService(alg, HashStringTo[string], "service.com")
+	suite.NoError(err)
+	suite.Equal(alg.Sum64String("service.com"), actual)
+
+	// the default ServiceHasher is used when none is supplied
+	actual, err = SumService[string](alg, nil, "service.com")
+	suite.NoError(err)
+	suite.Equal(alg.Sum64String("service.com"), actual)
+
+	var b bytes.Buffer
+	svc := BasicService{Scheme: "http", Host: "service.com", Port: 1234}
+	suite.Require().NoError(HashBasicServiceTo(&b, svc))
+	actual, err = SumService(alg, HashBasicServiceTo, svc)
+	suite.NoError(err)
+	suite.Equal(alg.Sum64Bytes(b.Bytes()), actual)
+
+	expectedErr := errors.New("expected")
+	actual, err = SumService(alg, func(io.Writer, string) error { return expectedErr }, "service.com")
+	suite.ErrorIs(err, expectedErr)
+	suite.Zero(actual)
+}
+
 func (suite *ServiceSuite) testMapUpdateNil() {
 	var m Map[string, int] // nil Map
 	suite.Zero(m.Len())
@@ -150,7 +178,111 @@ func (suite *ServiceSuite) testMapUpdateBreak() {
 	suite.Equal([]string{"first"}, visited)
 }
 
+func (suite *ServiceSuite) testMapAll() {
+	m := Map[string, bool]{"service1": true, "service2": true, "service3": true}
+	suite.ElementsMatch([]string{"service1", "service2", "service3"}, slices.Collect(m.All()))
+	suite.Empty(slices.Collect(Map[string, bool](nil).All()))
+
+	var visited int
+	for range m.All() {
+		visited++
+		break
+	}
+
+	suite.Equal(1, visited)
+}
+
+func (suite *ServiceSuite) testMapAllMutation() {
+	m := Map[string, bool]{"service1": true, "service2": true, "service3": true}
+
+	// deleting every service on the first iteration means nothing else is yielded
+	var visited []string
+	for svc := range m.All() {
+		visited = append(visited, svc)
+		clear(m)
+	}
+
+	suite.Len(visited, 1)
+	suite.Zero(m.Len())
+}
+
+func (suite *ServiceSuite) testMapAllSorted() {
+	m := Map[string, bool]{"service3": true, "service1": true, "service2": true}
+	suite.Equal([]string{"service1", "service2", "service3"}, slices.Collect(m.AllSorted(strings.Compare)))
+
+	reversed := func(a, b string) int { return strings.Compare(b, a) }
+	suite.Equal([]string{"service3", "service2", "service1"}, slices.Collect(m.AllSorted(reversed)))
+
+	var visited []string
+	for svc := range m.AllSorted(strings.Compare) {
+		visited = append(visited, svc)
+		if len(visited) == 2 {
+			break
+		}
+	}
+
+	suite.Equal([]string{"service1", "service2"}, visited)
+
+	// iteration works on a snapshot
+	visited = nil
+	for svc := range m.AllSorted(strings.Compare) {
+		visited = append(visited, svc)
+		clear(m)
+		m["service0"] = true
+	}
+
+	suite.Equal([]string{"service1", "service2", "service3"}, visited)
+}
+
+func (suite *ServiceSuite) testMapDiff() {
+	var (
+		previous = Map[string, int]{"a": 1, "b": 2, "c": 3, "d": 4}
+		current  = Map[string, int]{"c": 3, "d": 4, "e": 5, "f": 6, "g": 7}
+	)
+
+	// compare against the set algebra
+	var added, removed []string
+	for svc := range current {
+		if _, ok := previous[svc]; !ok {
+			added = append(added, svc)
+		}
+	}
+
+	for svc := range previous {
+		if _, ok := current[svc]; !ok {
+			removed = append(removed, svc)
+		}
+	}
+
+	suite.ElementsMatch(added, slices.Collect(current.Added(previous)))
+	suite.ElementsMatch(removed, slices.Collect(current.Removed(previous)))
+	suite.ElementsMatch([]string{"e", "f", "g"}, slices.Collect(current.Added(previous)))
+	suite.ElementsMatch([]string{"a", "b"}, slices.Collect(current.Removed(previous)))
+
+	suite.Empty(slices.Collect(current.Added(current)))
+	suite.Empty(slices.Collect(current.Removed(current)))
+	suite.ElementsMatch([]string{"c", "d", "e", "f", "g"}, slices.Collect(current.Added(nil)))
+	suite.Empty(slices.Collect(current.Removed(nil)))
+
+	var visited int
+	for range current.Added(previous) {
+		visited++
+		break
+	}
+
+	for range current.Removed(previous) {
+		visited++
+		break
+	}
+
+	suite.Equal(2, visited)
+}
+
 func (suite *ServiceSuite) TestMap() {
+	suite.Run("All", suite.testMapAll)
+	suite.Run("AllMutation", suite.testMapAllMutation)
+	suite.Run("AllSorted", suite.testMapAllSorted)
+	suite.Run("Diff", suite.testMapDiff)
 	suite.Run("Update", func() {
 		suite.Run("Nil", suite.testMapUpdateNil)
 		suite.Run("AllServicesExist", suite.testMapUpdateAllServicesExist)
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ShadowResult holds the outcomes of a lookup on both the primary and the shadow
+// Locators of a ShadowLocator.
+type ShadowResult[S Service] struct {
+	// Object is the object that was looked up. It is safe to retain.
+	Object []byte
+
+	// Primary is the service returned by the primary Locator.
+	Primary S
+
+	// PrimaryErr is the error returned by the primary Locator.
+	PrimaryErr error
+
+	// Shadow is the service returned by the shadow Locator.
+	Shadow S
+
+	// ShadowErr is the error returned by the shadow Locator.
+	ShadowErr error
+}
+
+// Agree tests if the primary and shadow lookups had the same outcome. Outcomes agree if
+// both lookups returned the same service, or if both failed in the same way. A failure is
+// either ErrNoServices or any other error, and the details of other errors are not compared.
+func (sr ShadowResult[S]) Agree() bool {
+	switch {
+	case sr.PrimaryErr == nil && sr.ShadowErr == nil:
+		return sr.Primary == sr.Shadow
+
+	case sr.PrimaryErr == nil || sr.ShadowErr == nil:
+		return false
+
+	default:
+		return errors.Is(sr.PrimaryErr, ErrNoServices) == errors.Is(sr.ShadowErr, ErrNoServices)
+	}
+}
+
+// ShadowStats holds the counters of a ShadowLocator.
+type ShadowStats struct {
+	// Compared is the number of lookups whose primary and shadow results were compared.
+	Compared uint64
+
+	// Disagreed is the number of compared lookups whose results did not agree.
+	Disagreed uint64
+
+	// Dropped is the number of asynchronous shadow lookups that were skipped because
+	// the maximum number of shadow lookups were already running.
+	Dropped uint64
+}
+
+// ShadowLocator is a Locator that consults a shadow Locator alongside its primary Locator,
+// e.g. to evaluate a new hash algorithm against production traffic before switching to it.
+// Callers only ever see the primary's results. Differences are reported to a callback and
+// counted.
+//
+// Shadow lookups can be synchronous or asynchronous. Asynchronous shadow lookups never add
+// latency to Find, and at most a fixed number of them run at once. When that many are
+// already running, the shadow lookup is dropped.
+//
+// Methods on this type are safe for concurrent usage.
+type ShadowLocator[S Service] struct {
+	primary    Locator[S]
+	shadow     Locator[S]
+	onDisagree func(ShadowResult[S])
+
+	// slots limits the number of asynchronous shadow lookups. It is nil for synchronous lookups.
+	slots chan struct{}
+
+	compared  atomic.Uint64
+	disagreed atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// NewShadowLocator creates a ShadowLocator. The onDisagree callback, which may be nil, is
+// invoked with each result whose primary and shadow outcomes do not agree.
+//
+// If maxAsync is positive, shadow lookups happen in the background, with at most maxAsync
+// running at once. In that case, onDisagree may be called concurrently. Otherwise, shadow
+// lookups happen synchronously, after the primary lookup.
+func NewShadowLocator[S Service](primary, shadow Locator[S], onDisagree func(ShadowResult[S]), maxAsync int) *ShadowLocator[S] {
+	sl := &ShadowLocator[S]{
+		primary:    primary,
+		shadow:     shadow,
+		onDisagree: onDisagree,
+	}
+
+	if maxAsync > 0 {
+		sl.slots = make(chan struct{}, maxAsync)
+	}
+
+	return sl
+}
+
+var _ Locator[string] = (*ShadowLocator[string])(nil)
+
+// Find returns the primary Locator's result for the given object, and compares it
+// with the shadow Locator's result.
+func (sl *ShadowLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, err = sl.primary.Find(object)
+	if sl.slots == nil {
+		sl.compare(object, svc, err)
+		return
+	}
+
+	select {
+	case sl.slots <- struct{}{}:
+		// the caller is free to reuse its buffer once this method returns
+		object = append([]byte(nil), object...)
+		go func() {
+			defer func() {
+				<-sl.slots
+			}()
+
+			sl.compare(object, svc, err)
+		}()
+
+	default:
+		sl.dropped.Add(1)
+	}
+
+	return
+}
+
+// compare performs the shadow lookup and compares it with the primary's result.
+func (sl *ShadowLocator[S]) compare(object []byte, primary S, primaryErr error) {
+	r := ShadowResult[S]{
+		Object:     object,
+		Primary:    primary,
+		PrimaryErr: primaryErr,
+	}
+
+	r.Shadow, r.ShadowErr = sl.shadow.Find(object)
+	sl.compared.Add(1)
+	if !r.Agree() {
+		sl.disagreed.Add(1)
+		if sl.onDisagree != nil {
+			if sl.slots == nil {
+				// a synchronous caller may reuse its buffer afterward
+				r.Object = append([]byte(nil), object...)
+			}
+
+			sl.onDisagree(r)
+		}
+	}
+}
+
+// Stats returns the current counters of this locator.
+func (sl *ShadowLocator[S]) Stats() ShadowStats {
+	return ShadowStats{
+		Compared:  sl.compared.Load(),
+		Disagreed: sl.disagreed.Load(),
+		Dropped:   sl.dropped.Load(),
+	}
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// gateLocator is a Locator whose lookups block until its gate is closed.
+type gateLocator struct {
+	gate <-chan struct{}
+	svc  string
+	c    *concurrency
+}
+
+func (gl gateLocator) Find([]byte) (string, error) {
+	gl.c.enter()
+	defer gl.c.exit()
+
+	<-gl.gate
+	return gl.svc, nil
+}
+
+type ShadowLocatorSuite struct {
+	suite.Suite
+
+	lock       sync.Mutex
+	disagreed  []ShadowResult[string]
+	onDisagree func(ShadowResult[string])
+}
+
+func (suite *ShadowLocatorSuite) SetupTest() {
+	suite.disagreed = nil
+	suite.onDisagree = func(r ShadowResult[string]) {
+		suite.lock.Lock()
+		suite.disagreed = append(suite.disagreed, r)
+		suite.lock.Unlock()
+	}
+}
+
+func (suite *ShadowLocatorSuite) results() []ShadowResult[string] {
+	defer suite.lock.Unlock()
+	suite.lock.Lock()
+	return append([]ShadowResult[string](nil), suite.disagreed...)
+}
+
+func (suite *ShadowLocatorSuite) TestAgree() {
+	var (
+		otherErr   = errors.New("other")
+		anotherErr = errors.New("another")
+	)
+
+	testCases := []struct {
+		name       string
+		primary    string
+		primaryErr error
+		shadow     string
+		shadowErr  error
+		agree      bool
+	}{
+		{name: "SameService", primary: "a", shadow: "a", agree: true},
+		{name: "DifferentService", primary: "a", shadow: "b", agree: false},
+		{name: "BothNoServices", primaryErr: ErrNoServices, shadowErr: ErrNoServices, agree: true},
+		{name: "BothOtherErrors", primaryErr: otherErr, shadowErr: anotherErr, agree: true},
+		{name: "PrimaryFailed", primaryErr: ErrNoServices, shadow: "b", agree: false},
+		{name: "ShadowFailed", primary: "a", shadowErr: otherErr, agree: false},
+		{name: "DifferentErrors", primaryErr: ErrNoServices, shadowErr: otherErr, agree: false},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			r := ShadowResult[string]{
+				Primary:    testCase.primary,
+				PrimaryErr: testCase.primaryErr,
+				Shadow:     testCase.shadow,
+				ShadowErr:  testCase.shadowErr,
+			}
+
+			suite.Equal(testCase.agree, r.Agree())
+		})
+	}
+}
+
+func (suite *ShadowLocatorSuite) TestSync() {
+	var (
+		primary = new(MockLocator[string])
+		shadow  = new(MockLocator[string])
+		sl      = NewShadowLocator[string](primary, shadow, suite.onDisagree, 0)
+		object  = []byte("agree")
+	)
+
+	primary.ExpectFindSuccess([]byte("agree"), "a").Once()
+	shadow.ExpectFindSuccess([]byte("agree"), "a").Once()
+	primary.ExpectFindSuccess([]byte("disagree"), "a").Once()
+	shadow.ExpectFindSuccess([]byte("disagree"), "b").Once()
+	primary.ExpectFindNoServices([]byte("primary error")).Once()
+	shadow.ExpectFindSuccess([]byte("primary error"), "b").Once()
+	primary.ExpectFindSuccess([]byte("shadow error"), "a").Once()
+	shadow.ExpectFindNoServices([]byte("shadow error")).Once()
+
+	svc, err := sl.Find(object)
+	suite.NoError(err)
+	suite.Equal("a", svc)
+	suite.Empty(suite.results())
+
+	// the caller only sees the primary's results
+	object = []byte("disagree")
+	svc, err = sl.Find(object)
+	suite.NoError(err)
+	suite.Equal("a", svc)
+
+	_, err = sl.Find([]byte("primary error"))
+	suite.ErrorIs(err, ErrNoServices)
+
+	svc, err = sl.Find([]byte("shadow error"))
+	suite.NoError(err)
+	suite.Equal("a", svc)
+
+	// reported objects are copies
+	object[0] = 'D'
+	results := suite.results()
+	suite.Require().Len(results, 3)
+	suite.Equal([]byte("disagree"), results[0].Object)
+	suite.Equal("b", results[0].Shadow)
+	suite.ErrorIs(results[1].PrimaryErr, ErrNoServices)
+	suite.ErrorIs(results[2].ShadowErr, ErrNoServices)
+
+	suite.Equal(ShadowStats{Compared: 4, Disagreed: 3}, sl.Stats())
+	primary.AssertExpectations(suite.T())
+	shadow.AssertExpectations(suite.T())
+}
+
+func (suite *ShadowLocatorSuite) TestNilCallback() {
+	sl := NewShadowLocator[string](fixedLocator[string]{service: "a"}, fixedLocator[string]{service: "b"}, nil, 0)
+	svc, err := sl.Find([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("a", svc)
+	suite.Equal(ShadowStats{Compared: 1, Disagreed: 1}, sl.Stats())
+}
+
+func (suite *ShadowLocatorSuite) TestAsync() {
+	const maxAsync = 2
+
+	var (
+		gate = make(chan struct{})
+		c    = new(concurrency)
+		sl   = NewShadowLocator[string](
+			fixedLocator[string]{service: "a"},
+			gateLocator{gate: gate, svc: "b", c: c},
+			suite.onDisagree,
+			maxAsync,
+		)
+
+		object = []byte("test")
+	)
+
+	// lookups never wait for the shadow
+	for range 10 {
+		svc, err := sl.Find(object)
+		suite.NoError(err)
+		suite.Equal("a", svc)
+	}
+
+	suite.Eventually(
+		func() bool { return c.current.Load() == maxAsync },
+		time.Second, time.Millisecond,
+	)
+
+	suite.Equal(ShadowStats{Dropped: 8}, sl.Stats())
+
+	// the shadow lookups own their objects
+	object[0] = 'T'
+	close(gate)
+	suite.Eventually(
+		func() bool { return sl.Stats().Compared == maxAsync },
+		time.Second, time.Millisecond,
+	)
+
+	suite.Equal(int32(maxAsync), c.max.Load())
+	suite.Equal(ShadowStats{Compared: 2, Disagreed: 2, Dropped: 8}, sl.Stats())
+
+	results := suite.results()
+	suite.Require().Len(results, 2)
+	for _, r := range results {
+		suite.Equal([]byte("test"), r.Object)
+		suite.Equal("a", r.Primary)
+		suite.Equal("b", r.Shadow)
+	}
+
+	// slots are released once shadow lookups finish
+	suite.Eventually(
+		func() bool {
+			sl.Find(object)
+			return sl.Stats().Compared > maxAsync
+		},
+		time.Second, time.Millisecond,
+	)
+
+	// don't leave any shadow lookups running after this test: filling every
+	// slot waits for the running lookups to release theirs
+	for range maxAsync {
+		sl.slots <- struct{}{}
+	}
+}
+
+func TestShadowLocator(t *testing.T) {
+	suite.Run(t, new(ShadowLocatorSuite))
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrStaleMembership is returned by a StaleGuardLocator when its services haven't been
+	// refreshed within its maximum staleness.
+	ErrStaleMembership = errors.New("service membership is stale")
+)
+
+// StaleGuardLocator is a Locator decorator that bounds how long lookups may rely on a set of
+// services that hasn't been refreshed, e.g. while a service discovery backend is failing.
+// The wrapped Locator is typically an UpdatableLocator fed by a discovery loop, which calls
+// MarkFresh after every successful sync.
+//
+// While the time since the last MarkFresh is within the maximum staleness, Find passes
+// through to the wrapped Locator. Beyond that, Find fails with ErrStaleMembership rather than
+// routing on old membership, until MarkFresh is called again.
+//
+// Methods on this type are safe for concurrent usage.
+type StaleGuardLocator[S Service] struct {
+	next           Locator[S]
+	maxStaleness   time.Duration
+	wrapNoServices bool
+	now            func() time.Time
+
+	// fresh is the time of the last MarkFresh
+	fresh atomic.Pointer[time.Time]
+}
+
+// NewStaleGuardLocator decorates a Locator so that lookups fail once its services are older
+// than maxStaleness. The returned StaleGuardLocator is fresh as of its creation. If maxStaleness
+// is nonpositive, lookups never fail due to staleness. If now is nil, time.Now is used.
+//
+// If wrapNoServices is set, the errors for stale lookups also wrap ErrNoServices, so that
+// callers which only check for ErrNoServices, such as a FallbackLocator, treat stale
+// membership the same as no services.
+func NewStaleGuardLocator[S Service](next Locator[S], maxStaleness time.Duration, wrapNoServices bool, now func() time.Time) *StaleGuardLocator[S] {
+	if now == nil {
+		now = time.Now
+	}
+
+	sg := &StaleGuardLocator[S]{
+		next:           next,
+		maxStaleness:   maxStaleness,
+		wrapNoServices: wrapNoServices,
+		now:            now,
+	}
+
+	sg.MarkFresh()
+	return sg
+}
+
+var _ AnnotatedLocator[string] = (*StaleGuardLocator[string])(nil)
+
+// MarkFresh records that the wrapped Locator's services were just refreshed.
+func (sg *StaleGuardLocator[S]) MarkFresh() {
+	fresh := sg.now()
+	sg.fresh.Store(&fresh)
+}
+
+// Age returns the time since the last MarkFresh, which is useful as a metric.
+func (sg *StaleGuardLocator[S]) Age() time.Duration {
+	return sg.now().Sub(*sg.fresh.Load())
+}
+
+// Find passes through to the wrapped Locator if the services are within the maximum staleness.
+// Otherwise, this method returns an error that wraps ErrStaleMembership and, if configured,
+// ErrNoServices. A lookup exactly at the maximum staleness still passes through.
+func (sg *StaleGuardLocator[S]) Find(object []byte) (svc S, err error) {
+	if err = sg.check(); err == nil {
+		svc, err = sg.next.Find(object)
+	}
+
+	return
+}
+
+// check returns the error for a lookup if the services are stale.
+func (sg *StaleGuardLocator[S]) check() error {
+	age := sg.Age()
+	switch {
+	case sg.maxStaleness <= 0 || age <= sg.maxStaleness:
+		return nil
+
+	case sg.wrapNoServices:
+		return fmt.Errorf("%w: %w: age %s exceeds %s", ErrStaleMembership, ErrNoServices, age, sg.maxStaleness)
+
+	default:
+		return fmt.Errorf("%w: age %s exceeds %s", ErrStaleMembership, age, sg.maxStaleness)
+	}
+}
+
+// FindAnnotated is like Find, but returns the wrapped Locator's annotation.
+func (sg *StaleGuardLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	if err = sg.check(); err == nil {
+		result, err = Explain(sg.next, object)
+	}
+
+	return
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testMaxStaleness = time.Minute
+
+type StaleGuardLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+	start  time.Time
+
+	// elapsed is the fake clock's time since start, which is safe to change concurrently
+	elapsed atomic.Int64
+}
+
+func (suite *StaleGuardLocatorSuite) SetupTest() {
+	suite.object = []byte("test value")
+	suite.start = time.Now()
+	suite.elapsed.Store(0)
+}
+
+// now is the fake clock.
+func (suite *StaleGuardLocatorSuite) now() time.Time {
+	return suite.start.Add(time.Duration(suite.elapsed.Load()))
+}
+
+func (suite *StaleGuardLocatorSuite) advance(d time.Duration) {
+	suite.elapsed.Add(int64(d))
+}
+
+func (suite *StaleGuardLocatorSuite) newStaleGuardLocator(next Locator[string], wrapNoServices bool) *StaleGuardLocator[string] {
+	sg := NewStaleGuardLocator(next, testMaxStaleness, wrapNoServices, suite.now)
+	suite.Require().NotNil(sg)
+	return sg
+}
+
+func (suite *StaleGuardLocatorSuite) TestFresh() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Twice()
+
+	sg := suite.newStaleGuardLocator(l, false)
+	suite.Zero(sg.Age())
+
+	svc, err := sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	suite.advance(testMaxStaleness / 2)
+	suite.Equal(testMaxStaleness/2, sg.Age())
+	svc, err = sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *StaleGuardLocatorSuite) TestBoundary() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+	sg := suite.newStaleGuardLocator(l, false)
+
+	// exactly at the budget still passes through
+	suite.advance(testMaxStaleness)
+	svc, err := sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	// beyond it fails, without consulting the wrapped locator
+	suite.advance(time.Nanosecond)
+	svc, err = sg.Find(suite.object)
+	suite.ErrorIs(err, ErrStaleMembership)
+	suite.NotErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+	suite.Equal(testMaxStaleness+time.Nanosecond, sg.Age())
+
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *StaleGuardLocatorSuite) TestWrapNoServices() {
+	sg := suite.newStaleGuardLocator(new(MockLocator[string]), true)
+	suite.advance(2 * testMaxStaleness)
+
+	svc, err := sg.Find(suite.object)
+	suite.ErrorIs(err, ErrStaleMembership)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+}
+
+func (suite *StaleGuardLocatorSuite) TestRecovery() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+	sg := suite.newStaleGuardLocator(l, false)
+
+	suite.advance(2 * testMaxStaleness)
+	_, err := sg.Find(suite.object)
+	suite.ErrorIs(err, ErrStaleMembership)
+
+	sg.MarkFresh()
+	suite.Zero(sg.Age())
+	svc, err := sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *StaleGuardLocatorSuite) TestNoBudget() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	sg := NewStaleGuardLocator[string](l, 0, true, suite.now)
+	suite.advance(24 * time.Hour)
+	svc, err := sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *StaleGuardLocatorSuite) TestDefaultClock() {
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	sg := NewStaleGuardLocator[string](l, time.Hour, false, nil)
+	svc, err := sg.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+	suite.Less(sg.Age(), time.Hour)
+}
+
+func (suite *StaleGuardLocatorSuite) TestConcurrent() {
+	const goroutines = 8
+
+	var (
+		l  = new(MockLocator[string])
+		ul = NewUpdatableLocator[string](l)
+		sg = suite.newStaleGuardLocator(ul, true)
+		wg sync.WaitGroup
+	)
+
+	l.ExpectFindSuccess(suite.object, "service1")
+
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				if g%2 == 0 {
+					// the discovery loop falls behind, then catches up
+					suite.advance(testMaxStaleness / 4)
+					sg.MarkFresh()
+				} else if svc, err := sg.Find(suite.object); err == nil {
+					suite.Equal("service1", svc)
+				} else {
+					suite.ErrorIs(err, ErrStaleMembership)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestStaleGuardLocator(t *testing.T) {
+	suite.Run(t, new(StaleGuardLocatorSuite))
+}
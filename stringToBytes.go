@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !medley_nounsafe
+
+package medley
+
+import "unsafe"
+
+// unsafeStrings indicates whether stringToBytes avoids copying.
+const unsafeStrings = true
+
+// stringToBytes returns the bytes of a string without copying them. The returned
+// slice must never be modified.
+//
+// Builds with the medley_nounsafe tag use a plain conversion instead.
+func stringToBytes(v string) []byte {
+	return unsafe.Slice(unsafe.StringData(v), len(v))
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build medley_nounsafe
+
+package medley
+
+// unsafeStrings indicates whether stringToBytes avoids copying.
+const unsafeStrings = false
+
+// stringToBytes returns a copy of the bytes of a string. This is the fallback for
+// builds that disallow package unsafe, and it allocates for nonempty strings.
+func stringToBytes(v string) []byte {
+	return []byte(v)
+}
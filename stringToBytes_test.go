@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"bytes"
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StringToBytesSuite struct {
+	suite.Suite
+}
+
+func (suite *StringToBytesSuite) TestConversion() {
+	for _, v := range []string{"", "a", "test", "service.example.net:8080", "\x00\xff"} {
+		suite.Run(v, func() {
+			actual := stringToBytes(v)
+			suite.Len(actual, len(v))
+			suite.Equal(v, string(actual))
+		})
+	}
+}
+
+func (suite *StringToBytesSuite) TestHelpers() {
+	// the helpers agree with plain conversions, regardless of build tags
+	const v = "service.example.net:8080"
+	alg := DefaultAlgorithm()
+	suite.Equal(alg.Sum64Bytes([]byte(v)), alg.Sum64String(v))
+
+	var b bytes.Buffer
+	suite.Require().NoError(HashStringTo(&b, v))
+	suite.Equal(v, b.String())
+
+	h := fnv.New64a()
+	suite.Require().NoError(NewHashBuilder(h).WriteString(v).Err())
+	suite.Equal(fnv64a([]byte(v)), h.Sum64())
+
+	l := new(MockLocator[string])
+	l.ExpectFindSuccess([]byte(v), "service1").Once()
+	svc, err := FindString[string](l, v)
+	suite.NoError(err)
+	suite.Equal("service1", svc)
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *StringToBytesSuite) TestAllocations() {
+	allocs := testing.AllocsPerRun(100, func() {
+		stringToBytes("service.example.net:8080")
+	})
+
+	if unsafeStrings {
+		suite.Zero(allocs)
+	} else {
+		suite.LessOrEqual(allocs, 1.0)
+	}
+}
+
+func TestStringToBytes(t *testing.T) {
+	suite.Run(t, new(StringToBytesSuite))
+}
+
+// fnv64a computes the FNV-1a hash of some bytes.
+func fnv64a(v []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(v)
+	return h.Sum64()
+}
+
+var benchmarkString = "service.example.net:8080"
+
+func BenchmarkSum64String(b *testing.B) {
+	alg := DefaultAlgorithm()
+	b.ReportAllocs()
+	for range b.N {
+		alg.Sum64String(benchmarkString)
+	}
+}
+
+func BenchmarkHashStringTo(b *testing.B) {
+	h := fnv.New64a()
+	b.ReportAllocs()
+	for range b.N {
+		HashStringTo(h, benchmarkString)
+	}
+}
+
+func BenchmarkFindString(b *testing.B) {
+	l := NewUpdatableLocator[string](nil)
+	b.ReportAllocs()
+	for range b.N {
+		FindString[string](l, benchmarkString)
+	}
+}
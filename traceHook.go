@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// TraceHook observes lookups made through locators that accept a context, such as
+// WaitingLocator.FindContext and ParallelMultiLocator.FindContext. This allows an integration,
+// e.g. with OpenTelemetry, to start a span for each lookup without this package depending on it.
+type TraceHook interface {
+	// BeforeFind is called before a lookup for an object of the given length. The returned
+	// context is used for the rest of the lookup, so a hook can attach a span to it. The returned
+	// function, if not nil, is called once the lookup completes with its result: a single service
+	// or a slice of services, depending on the locator, along with any error.
+	BeforeFind(ctx context.Context, objectLen int) (context.Context, func(service any, err error))
+}
+
+// TraceHookFunc is a function type that implements TraceHook.
+type TraceHookFunc func(context.Context, int) (context.Context, func(any, error))
+
+// BeforeFind invokes this function.
+func (f TraceHookFunc) BeforeFind(ctx context.Context, objectLen int) (context.Context, func(any, error)) {
+	return f(ctx, objectLen)
+}
+
+// traceHookEntry is a registered TraceHook. Each registration gets its own entry, so the same
+// TraceHook may be registered more than once and hooks need not be comparable.
+type traceHookEntry struct {
+	hook TraceHook
+}
+
+var (
+	// traceHooksLock serializes changes to traceHooks.
+	traceHooksLock sync.Mutex
+
+	// traceHooks is the immutable list of registered hooks, which is nil if there are none.
+	traceHooks atomic.Pointer[[]*traceHookEntry]
+)
+
+// RegisterTraceHook adds a TraceHook for all lookups that accept a context. Hooks are called
+// in the order they were registered. The functions they return are called in the reverse order,
+// so that spans started by later hooks end first.
+//
+// The returned function removes the hook. It is safe to call more than once. When no hooks
+// are registered, lookups pay only the cost of a single atomic load.
+func RegisterTraceHook(h TraceHook) (remove func()) {
+	entry := &traceHookEntry{hook: h}
+
+	traceHooksLock.Lock()
+	var current []*traceHookEntry
+	if p := traceHooks.Load(); p != nil {
+		current = *p
+	}
+
+	updated := append(slices.Clip(current), entry)
+	traceHooks.Store(&updated)
+	traceHooksLock.Unlock()
+
+	return func() {
+		defer traceHooksLock.Unlock()
+		traceHooksLock.Lock()
+
+		p := traceHooks.Load()
+		if p == nil {
+			return
+		}
+
+		updated := slices.DeleteFunc(slices.Clone(*p), func(e *traceHookEntry) bool {
+			return e == entry
+		})
+
+		if len(updated) == 0 {
+			traceHooks.Store(nil)
+		} else {
+			traceHooks.Store(&updated)
+		}
+	}
+}
+
+// startTrace calls the registered hooks before a lookup. The returned function, which is nil
+// if no hook needs to observe the result, must be called once the lookup completes. Callers
+// check for nil before converting their result to an interface, so that lookups don't
+// allocate when there are no hooks.
+func startTrace(ctx context.Context, objectLen int) (context.Context, func(any, error)) {
+	p := traceHooks.Load()
+	if p == nil {
+		return ctx, nil
+	}
+
+	var ends []func(any, error)
+	for _, e := range *p {
+		var end func(any, error)
+		if ctx, end = e.hook.BeforeFind(ctx, objectLen); end != nil {
+			ends = append(ends, end)
+		}
+	}
+
+	switch len(ends) {
+	case 0:
+		return ctx, nil
+
+	case 1:
+		return ctx, ends[0]
+
+	default:
+		return ctx, func(service any, err error) {
+			for _, end := range slices.Backward(ends) {
+				end(service, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// traceKey is the context key that traceLog hooks use to mark contexts.
+type traceKey string
+
+// traceEvent is a single call observed by a traceLog hook.
+type traceEvent struct {
+	name      string
+	objectLen int
+	service   any
+	err       error
+	end       bool
+}
+
+// traceLog collects the events from several hooks in the order they happened.
+type traceLog struct {
+	lock   sync.Mutex
+	events []traceEvent
+}
+
+func (tl *traceLog) add(e traceEvent) {
+	tl.lock.Lock()
+	tl.events = append(tl.events, e)
+	tl.lock.Unlock()
+}
+
+// hook returns a TraceHook with the given name that records its calls in this log. The hook
+// marks the context with its name, and verifies that the contexts it receives were marked by
+// every earlier hook.
+func (tl *traceLog) hook(name string, earlier ...string) TraceHook {
+	return TraceHookFunc(func(ctx context.Context, objectLen int) (context.Context, func(any, error)) {
+		for _, e := range earlier {
+			if ctx.Value(traceKey(e)) == nil {
+				panic(fmt.Sprintf("hook %s did not receive the context from hook %s", name, e))
+			}
+		}
+
+		tl.add(traceEvent{name: name, objectLen: objectLen})
+		return context.WithValue(ctx, traceKey(name), true), func(service any, err error) {
+			tl.add(traceEvent{name: name, service: service, err: err, end: true})
+		}
+	})
+}
+
+type TraceHookSuite struct {
+	suite.Suite
+
+	object []byte
+	log    *traceLog
+}
+
+func (suite *TraceHookSuite) SetupTest() {
+	suite.object = []byte("test value")
+	suite.log = new(traceLog)
+}
+
+func (suite *TraceHookSuite) register(h TraceHook) {
+	suite.T().Cleanup(RegisterTraceHook(h))
+}
+
+func (suite *TraceHookSuite) TestPairing() {
+	suite.register(suite.log.hook("hook"))
+	wl := NewWaitingLocator[string](fixedLocator[string]{service: "service1"}, 0, 0)
+
+	for range 3 {
+		svc, err := wl.FindContext(context.Background(), suite.object)
+		suite.NoError(err)
+		suite.Equal("service1", svc)
+	}
+
+	suite.Require().Len(suite.log.events, 6)
+	for i := 0; i < len(suite.log.events); i += 2 {
+		suite.Equal(traceEvent{name: "hook", objectLen: len(suite.object)}, suite.log.events[i])
+		suite.Equal(traceEvent{name: "hook", service: "service1", end: true}, suite.log.events[i+1])
+	}
+}
+
+func (suite *TraceHookSuite) TestError() {
+	var (
+		expectedErr = errors.New("expected")
+		l           = new(MockLocator[string])
+		wl          = NewWaitingLocator[string](l, 0, 0)
+	)
+
+	suite.register(suite.log.hook("hook"))
+	l.ExpectFindFail(suite.object, expectedErr).Once()
+
+	_, err := wl.FindContext(context.Background(), suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Require().Len(suite.log.events, 2)
+	suite.ErrorIs(suite.log.events[1].err, expectedErr)
+	suite.Equal("", suite.log.events[1].service)
+
+	l.AssertExpectations(suite.T())
+}
+
+func (suite *TraceHookSuite) TestCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var (
+		l  = new(MockLocator[string])
+		wl = NewWaitingLocator[string](l, time.Hour, 0)
+	)
+
+	suite.register(suite.log.hook("hook"))
+	l.ExpectFindNoServices(suite.object)
+
+	_, err := wl.FindContext(ctx, suite.object)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Require().Len(suite.log.events, 2)
+	suite.ErrorIs(suite.log.events[1].err, context.Canceled)
+}
+
+func (suite *TraceHookSuite) TestOrdering() {
+	suite.register(suite.log.hook("first"))
+	suite.register(suite.log.hook("second", "first"))
+	suite.register(TraceHookFunc(func(ctx context.Context, _ int) (context.Context, func(any, error)) {
+		// a hook needn't observe results
+		return ctx, nil
+	}))
+
+	suite.register(suite.log.hook("third", "first", "second"))
+
+	pl := NewParallelMultiLocator[string](0, 0, fixedLocator[string]{service: "service1"})
+	services, err := pl.FindContext(context.Background(), suite.object)
+	suite.NoError(err)
+	suite.Equal([]string{"service1"}, services)
+
+	var names []string
+	for _, e := range suite.log.events {
+		names = append(names, fmt.Sprintf("%s/%t", e.name, e.end))
+	}
+
+	suite.Equal(
+		[]string{"first/false", "second/false", "third/false", "third/true", "second/true", "first/true"},
+		names,
+	)
+
+	suite.Equal([]string{"service1"}, suite.log.events[5].service)
+}
+
+func (suite *TraceHookSuite) TestRemove() {
+	var (
+		removeFirst  = RegisterTraceHook(suite.log.hook("first"))
+		removeSecond = RegisterTraceHook(suite.log.hook("second"))
+		wl           = NewWaitingLocator[string](fixedLocator[string]{service: "service1"}, 0, 0)
+	)
+
+	defer removeSecond()
+
+	removeFirst()
+	removeFirst() // idempotent
+	wl.FindContext(context.Background(), suite.object)
+	suite.Require().Len(suite.log.events, 2)
+	suite.Equal("second", suite.log.events[0].name)
+
+	removeSecond()
+	suite.Nil(traceHooks.Load())
+	wl.FindContext(context.Background(), suite.object)
+	suite.Len(suite.log.events, 2)
+}
+
+func (suite *TraceHookSuite) TestNoHookAllocations() {
+	suite.Require().Nil(traceHooks.Load())
+	wl := NewWaitingLocator[string](fixedLocator[string]{service: "service1"}, 0, 0)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		wl.FindContext(context.Background(), suite.object)
+	})
+
+	suite.Zero(allocs)
+}
+
+func TestTraceHook(t *testing.T) {
+	suite.Run(t, new(TraceHookSuite))
+}
+
+func BenchmarkFindContext(b *testing.B) {
+	var (
+		ctx    = context.Background()
+		object = []byte("test value")
+		wl     = NewWaitingLocator[string](fixedLocator[string]{service: "service1"}, 0, 0)
+	)
+
+	b.Run("NoHooks", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			wl.FindContext(ctx, object)
+		}
+	})
+
+	b.Run("Hook", func(b *testing.B) {
+		defer RegisterTraceHook(TraceHookFunc(func(ctx context.Context, _ int) (context.Context, func(any, error)) {
+			return ctx, func(any, error) {}
+		}))()
+
+		b.ReportAllocs()
+		for range b.N {
+			wl.FindContext(ctx, object)
+		}
+	})
+}
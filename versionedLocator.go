@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// versioned is a Locator implementation along with the version at which it was set.
+// Publishing both in a single struct means a lookup can never pair a result from one
+// implementation with the version of another.
+type versioned[S Service] struct {
+	impl    Locator[S]
+	version uint64
+}
+
+// VersionedLocator is an UpdatableLocator that stamps each implementation with an
+// incrementing version. Callers that resolve a service with FindVersioned can later
+// use IsCurrent to determine whether that resolution is stale, e.g. before committing
+// an expensive operation.
+//
+// The zero value of this type is usable, and is at version zero (0) with no
+// implementation. A VersionedLocator must not be copied after first use.
+type VersionedLocator[S Service] struct {
+	ul UpdatableLocator[S]
+
+	// setLock serializes Set, so that versions are published in order
+	setLock sync.Mutex
+	current atomic.Pointer[versioned[S]]
+}
+
+var _ AnnotatedLocator[string] = (*VersionedLocator[string])(nil)
+
+// NewVersionedLocator returns a VersionedLocator initialized with the given implementation
+// at version one (1).
+func NewVersionedLocator[S Service](impl Locator[S]) *VersionedLocator[S] {
+	vl := new(VersionedLocator[S])
+	vl.Set(impl)
+	return vl
+}
+
+// Set atomically changes this locator's implementation and increments its version.
+// As with UpdatableLocator, a nil implementation turns off this locator, and setting
+// one still increments the version.
+func (vl *VersionedLocator[S]) Set(impl Locator[S]) {
+	defer vl.setLock.Unlock()
+	vl.setLock.Lock()
+
+	next := &versioned[S]{impl: impl, version: 1}
+	if current := vl.current.Load(); current != nil {
+		next.version = current.version + 1
+	}
+
+	vl.current.Store(next)
+
+	// the embedded UpdatableLocator only supplies update notifications
+	vl.ul.Set(impl)
+}
+
+// Version returns the version of the current implementation.
+func (vl *VersionedLocator[S]) Version() uint64 {
+	if current := vl.current.Load(); current != nil {
+		return current.version
+	}
+
+	return 0
+}
+
+// IsCurrent tests if the given version, as returned by FindVersioned or Version,
+// is still this locator's version.
+func (vl *VersionedLocator[S]) IsCurrent(version uint64) bool {
+	return vl.Version() == version
+}
+
+// Updated returns a channel that is closed the next time Set is called, in the
+// same manner as UpdatableLocator.Updated.
+func (vl *VersionedLocator[S]) Updated() <-chan struct{} {
+	return vl.ul.Updated()
+}
+
+// Find consults the current implementation for the given object. This method
+// returns ErrNoServices if there is no implementation.
+func (vl *VersionedLocator[S]) Find(object []byte) (svc S, err error) {
+	svc, _, err = vl.FindVersioned(object)
+	return
+}
+
+// FindVersioned is like Find, but also returns the version of the implementation
+// that produced the result. The version is returned even when there is an error.
+func (vl *VersionedLocator[S]) FindVersioned(object []byte) (svc S, version uint64, err error) {
+	current := vl.current.Load()
+	if current == nil {
+		err = ErrNoServices
+		return
+	}
+
+	version = current.version
+	if current.impl != nil {
+		svc, err = current.impl.Find(object)
+	} else {
+		err = ErrNoServices
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but returns the annotation of the current implementation.
+func (vl *VersionedLocator[S]) FindAnnotated(object []byte) (result FindResult[S], err error) {
+	if current := vl.current.Load(); current != nil && current.impl != nil {
+		result, err = Explain(current.impl, object)
+	} else {
+		err = ErrNoServices
+	}
+
+	return
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VersionedLocatorSuite struct {
+	suite.Suite
+}
+
+func (suite *VersionedLocatorSuite) TestZeroValue() {
+	var vl VersionedLocator[string]
+	suite.Zero(vl.Version())
+	suite.True(vl.IsCurrent(0))
+
+	svc, version, err := vl.FindVersioned([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+	suite.Zero(version)
+}
+
+func (suite *VersionedLocatorSuite) TestMonotonic() {
+	vl := NewVersionedLocator[string](fixedLocator[string]{service: "first"})
+	suite.Equal(uint64(1), vl.Version())
+
+	svc, version, err := vl.FindVersioned([]byte("test"))
+	suite.NoError(err)
+	suite.Equal("first", svc)
+	suite.Equal(uint64(1), version)
+
+	updated := vl.Updated()
+	vl.Set(fixedLocator[string]{service: "second"})
+	suite.Equal(uint64(2), vl.Version())
+	select {
+	case <-updated:
+	default:
+		suite.Fail("Set did not close the updated channel")
+	}
+
+	// turning off the locator is still a new version
+	vl.Set(nil)
+	svc, version, err = vl.FindVersioned([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+	suite.Equal(uint64(3), version)
+
+	svc, err = vl.Find([]byte("test"))
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(svc)
+}
+
+func (suite *VersionedLocatorSuite) TestIsCurrent() {
+	vl := NewVersionedLocator[string](fixedLocator[string]{service: "first"})
+	_, version, err := vl.FindVersioned([]byte("test"))
+	suite.Require().NoError(err)
+	suite.True(vl.IsCurrent(version))
+
+	// setting even the same implementation makes prior resolutions stale
+	vl.Set(fixedLocator[string]{service: "first"})
+	suite.False(vl.IsCurrent(version))
+	suite.True(vl.IsCurrent(version + 1))
+}
+
+func (suite *VersionedLocatorSuite) TestConcurrentSet() {
+	const (
+		readers = 8
+		sets    = 500
+	)
+
+	var (
+		vl   = NewVersionedLocator[string](fixedLocator[string]{service: "1"})
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var previous uint64
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// each implementation returns its own version, so the result must match the version
+				svc, version, err := vl.FindVersioned([]byte("test"))
+				if !suite.NoError(err) ||
+					!suite.Equal(strconv.FormatUint(version, 10), svc) ||
+					!suite.GreaterOrEqual(version, previous) {
+					return
+				}
+
+				previous = version
+			}
+		}()
+	}
+
+	for i := 2; i <= sets; i++ {
+		vl.Set(fixedLocator[string]{service: strconv.Itoa(i)})
+	}
+
+	close(stop)
+	wg.Wait()
+	suite.Equal(uint64(sets), vl.Version())
+}
+
+func TestVersionedLocator(t *testing.T) {
+	suite.Run(t, new(VersionedLocatorSuite))
+}
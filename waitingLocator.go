@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is the interval at which a WaitingLocator polls a Locator
+	// that cannot notify it of updates.
+	DefaultPollInterval = 10 * time.Millisecond
+)
+
+// updateNotifier is implemented by Locators that can signal when their set of
+// services may have changed. UpdatableLocator implements this interface.
+type updateNotifier interface {
+	Updated() <-chan struct{}
+}
+
+// WaitingLocator is a Locator decorator that waits for services to become available.
+// This is useful for Locators that are fed by asynchronous service discovery, which
+// can be empty for a short time after startup.
+//
+// When the wrapped Locator returns ErrNoServices, a WaitingLocator blocks for up to
+// a maximum wait for services to appear. If the wrapped Locator supplies an
+// 'Updated() <-chan struct{}' method, as UpdatableLocator does, that channel is used
+// to detect changes. Otherwise, the wrapped Locator is polled.
+//
+// Any other result from the wrapped Locator, including other errors, is returned
+// immediately.
+type WaitingLocator[S Service] struct {
+	next         Locator[S]
+	maxWait      time.Duration
+	pollInterval time.Duration
+
+	// after is the clock used for deadlines and polling. Tests may replace it.
+	after func(time.Duration) <-chan time.Time
+}
+
+// NewWaitingLocator decorates a Locator so that lookups wait up to maxWait for services
+// to become available. If pollInterval is nonpositive, DefaultPollInterval is used.
+// The pollInterval is ignored if next can notify the decorator of updates.
+//
+// If maxWait is nonpositive, the returned WaitingLocator never waits.
+func NewWaitingLocator[S Service](next Locator[S], maxWait, pollInterval time.Duration) *WaitingLocator[S] {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &WaitingLocator[S]{
+		next:         next,
+		maxWait:      maxWait,
+		pollInterval: pollInterval,
+		after:        time.After,
+	}
+}
+
+var _ AnnotatedLocator[string] = (*WaitingLocator[string])(nil)
+
+// Find locates a service for the given object, waiting up to the maximum wait
+// for services to become available.
+func (wl *WaitingLocator[S]) Find(object []byte) (S, error) {
+	return wl.FindContext(context.Background(), object)
+}
+
+// FindContext is like Find, but also stops waiting when the given context is canceled.
+// In that case, the context's error is returned. Any registered TraceHooks observe the lookup.
+func (wl *WaitingLocator[S]) FindContext(ctx context.Context, object []byte) (svc S, err error) {
+	ctx, end := startTrace(ctx, len(object))
+
+	var result FindResult[S]
+	result, err = wl.findContext(ctx, object, false)
+	svc = result.Service
+	if end != nil {
+		end(svc, err)
+	}
+
+	return
+}
+
+// FindAnnotated is like Find, but returns the wrapped Locator's annotation. Lookups made
+// with this method are not traced.
+func (wl *WaitingLocator[S]) FindAnnotated(object []byte) (FindResult[S], error) {
+	return wl.findContext(context.Background(), object, true)
+}
+
+// findContext performs the lookup for FindContext and FindAnnotated. If explain is set,
+// the wrapped Locator is consulted with Explain.
+func (wl *WaitingLocator[S]) findContext(ctx context.Context, object []byte, explain bool) (result FindResult[S], err error) {
+	result, err = wl.find(object, explain)
+	if !errors.Is(err, ErrNoServices) || wl.maxWait <= 0 {
+		return
+	}
+
+	deadline := wl.after(wl.maxWait)
+	for {
+		// subscribe before looking again, so that an update between the
+		// lookup and the select isn't missed
+		updated, poll := wl.wait()
+		result, err = wl.find(object, explain)
+		if !errors.Is(err, ErrNoServices) {
+			return
+		}
+
+		select {
+		case <-updated:
+		case <-poll:
+		case <-deadline:
+			return
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+}
+
+// find consults the wrapped Locator once.
+func (wl *WaitingLocator[S]) find(object []byte, explain bool) (result FindResult[S], err error) {
+	if explain {
+		return Explain(wl.next, object)
+	}
+
+	result.Service, err = wl.next.Find(object)
+	return
+}
+
+// wait returns the channels that signal when the wrapped Locator should be consulted again.
+// Exactly one of the returned channels is non-nil.
+func (wl *WaitingLocator[S]) wait() (<-chan struct{}, <-chan time.Time) {
+	if n, ok := wl.next.(updateNotifier); ok {
+		return n.Updated(), nil
+	}
+
+	return nil, wl.after(wl.pollInterval)
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package medley
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testMaxWait      = time.Minute
+	testPollInterval = time.Second
+)
+
+type WaitingLocatorSuite struct {
+	suite.Suite
+
+	object []byte
+
+	// afterCalls receives the duration of each call to the fake clock
+	afterCalls chan time.Duration
+
+	// deadline is returned by the fake clock for testMaxWait
+	deadline chan time.Time
+}
+
+func (suite *WaitingLocatorSuite) SetupTest() {
+	suite.object = []byte("test value")
+	suite.afterCalls = make(chan time.Duration, 100)
+	suite.deadline = make(chan time.Time)
+}
+
+func (suite *WaitingLocatorSuite) SetupSubTest() {
+	suite.SetupTest()
+}
+
+// after is the fake clock. Polls fire immediately, while the deadline
+// fires only when the test closes it.
+func (suite *WaitingLocatorSuite) after(d time.Duration) <-chan time.Time {
+	suite.afterCalls <- d
+	if d == testMaxWait {
+		return suite.deadline
+	}
+
+	poll := make(chan time.Time, 1)
+	poll <- time.Time{}
+	return poll
+}
+
+func (suite *WaitingLocatorSuite) newWaitingLocator(next Locator[string]) *WaitingLocator[string] {
+	wl := NewWaitingLocator(next, testMaxWait, testPollInterval)
+	suite.Require().NotNil(wl)
+	wl.after = suite.after
+	return wl
+}
+
+func (suite *WaitingLocatorSuite) TestDefaults() {
+	wl := NewWaitingLocator[string](new(MockLocator[string]), testMaxWait, 0)
+	suite.Require().NotNil(wl)
+	suite.Equal(DefaultPollInterval, wl.pollInterval)
+}
+
+func (suite *WaitingLocatorSuite) TestImmediateSuccess() {
+	var (
+		l  = new(MockLocator[string])
+		wl = suite.newWaitingLocator(l)
+	)
+
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	result, err := wl.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", result)
+	suite.Empty(suite.afterCalls, "no timers should have been created")
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *WaitingLocatorSuite) TestImmediateError() {
+	var (
+		expectedErr = errors.New("expected")
+		l           = new(MockLocator[string])
+		wl          = suite.newWaitingLocator(l)
+	)
+
+	l.ExpectFindFail(suite.object, expectedErr).Once()
+
+	result, err := wl.Find(suite.object)
+	suite.ErrorIs(err, expectedErr)
+	suite.Empty(result)
+	suite.Empty(suite.afterCalls, "no timers should have been created")
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *WaitingLocatorSuite) TestNoWait() {
+	var (
+		l  = new(MockLocator[string])
+		wl = NewWaitingLocator[string](l, 0, 0)
+	)
+
+	l.ExpectFindNoServices(suite.object).Once()
+
+	result, err := wl.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(result)
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *WaitingLocatorSuite) testNotifiedSuccess() {
+	var (
+		l  = new(MockLocator[string])
+		ul = NewUpdatableLocator[string](nil)
+		wl = suite.newWaitingLocator(ul)
+
+		done = make(chan struct{})
+	)
+
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	go func() {
+		defer close(done)
+		result, err := wl.Find(suite.object)
+		suite.NoError(err)
+		suite.Equal("service1", result)
+	}()
+
+	suite.Equal(testMaxWait, <-suite.afterCalls)
+	ul.Set(l)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.Fail("Find did not return after the locator was updated")
+	}
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *WaitingLocatorSuite) testNotifiedDeadline() {
+	var (
+		ul = NewUpdatableLocator[string](nil)
+		wl = suite.newWaitingLocator(ul)
+	)
+
+	close(suite.deadline)
+	result, err := wl.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(result)
+}
+
+func (suite *WaitingLocatorSuite) testNotifiedCanceled() {
+	var (
+		ul = NewUpdatableLocator[string](nil)
+		wl = suite.newWaitingLocator(ul)
+
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+
+	cancel()
+	result, err := wl.FindContext(ctx, suite.object)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Empty(result)
+}
+
+func (suite *WaitingLocatorSuite) TestNotified() {
+	suite.Run("Success", suite.testNotifiedSuccess)
+	suite.Run("Deadline", suite.testNotifiedDeadline)
+	suite.Run("Canceled", suite.testNotifiedCanceled)
+}
+
+func (suite *WaitingLocatorSuite) testPolledSuccess() {
+	var (
+		l  = new(MockLocator[string])
+		wl = suite.newWaitingLocator(l)
+	)
+
+	l.ExpectFindNoServices(suite.object).Times(3)
+	l.ExpectFindSuccess(suite.object, "service1").Once()
+
+	result, err := wl.Find(suite.object)
+	suite.NoError(err)
+	suite.Equal("service1", result)
+
+	suite.Equal(testMaxWait, <-suite.afterCalls)
+	suite.Equal(testPollInterval, <-suite.afterCalls)
+	suite.Equal(testPollInterval, <-suite.afterCalls)
+
+	mock.AssertExpectationsForObjects(suite.T(), l)
+}
+
+func (suite *WaitingLocatorSuite) testPolledDeadline() {
+	var (
+		l  = new(MockLocator[string])
+		wl = NewWaitingLocator[string](l, testMaxWait, testPollInterval)
+	)
+
+	// the poll never fires, so only the deadline can end the wait
+	wl.after = func(d time.Duration) <-chan time.Time {
+		if d == testMaxWait {
+			return suite.deadline
+		}
+
+		return nil
+	}
+
+	l.ExpectFindNoServices(suite.object)
+
+	close(suite.deadline)
+	result, err := wl.Find(suite.object)
+	suite.ErrorIs(err, ErrNoServices)
+	suite.Empty(result)
+}
+
+func (suite *WaitingLocatorSuite) TestPolled() {
+	suite.Run("Success", suite.testPolledSuccess)
+	suite.Run("Deadline", suite.testPolledDeadline)
+}
+
+func TestWaitingLocator(t *testing.T) {
+	suite.Run(t, new(WaitingLocatorSuite))
+}